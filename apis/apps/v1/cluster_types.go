@@ -198,6 +198,18 @@ type ClusterSpec struct {
 	//
 	// +optional
 	Backup *ClusterBackup `json:"backup,omitempty"`
+
+	// Specifies the maintenance windows of the Cluster.
+	//
+	// Disruptive operations, such as "Restart", "VerticalScaling" and "Upgrade" OpsRequests, or Rollouts with
+	// the "Replace" strategy, are only started within one of the windows, unless they explicitly bypass the windows.
+	// Operations that have already started are not interrupted when the window ends.
+	//
+	// If not specified, disruptive operations can be started at any time.
+	//
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
 }
 
 // ClusterStatus defines the observed state of the Cluster.
@@ -541,6 +553,16 @@ type ClusterComponentSpec struct {
 	//
 	// +optional
 	EnableInstanceAPI *bool `json:"enableInstanceAPI,omitempty"`
+
+	// Specifies the policy of the automated failover performed by KubeBlocks.
+	//
+	// It's intended for the engines that don't elect a new primary by themselves. Once enabled, the replica
+	// holding the role is fenced and the role is switched over to the most up-to-date replica if it's
+	// unhealthy for a period.
+	// It requires the `roleProbe`, `switchover` and `replicationLag` lifecycle actions defined in the ComponentDefinition.
+	//
+	// +optional
+	FailoverPolicy *FailoverPolicy `json:"failoverPolicy,omitempty"`
 }
 
 type ClusterComponentService struct {
@@ -843,6 +865,45 @@ type ClusterBackup struct {
 	IncrementalCronExpression string `json:"incrementalCronExpression,omitempty"`
 }
 
+// MaintenanceWindow defines a weekly recurring time window in which disruptive operations can be started.
+type MaintenanceWindow struct {
+	// Specifies the days of the week on which the window starts.
+	// If not specified, the window starts every day.
+	//
+	// +listType=set
+	// +optional
+	DaysOfWeek []Weekday `json:"daysOfWeek,omitempty"`
+
+	// Specifies the start time of the window in 24-hour "HH:MM" format. The timezone is in UTC.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern:=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	StartTime string `json:"startTime"`
+
+	// Specifies the duration of the window in minutes, at most one week.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10080
+	DurationMinutes int32 `json:"durationMinutes"`
+}
+
+// Weekday defines a day of the week.
+//
+// +enum
+// +kubebuilder:validation:Enum={Sunday,Monday,Tuesday,Wednesday,Thursday,Friday,Saturday}
+type Weekday string
+
+const (
+	Sunday    Weekday = "Sunday"
+	Monday    Weekday = "Monday"
+	Tuesday   Weekday = "Tuesday"
+	Wednesday Weekday = "Wednesday"
+	Thursday  Weekday = "Thursday"
+	Friday    Weekday = "Friday"
+	Saturday  Weekday = "Saturday"
+)
+
 // ClusterPhase defines the phase of the Cluster within the .status.phase field.
 //
 // +enum
@@ -900,4 +961,88 @@ type ClusterComponentStatus struct {
 	//
 	// +optional
 	UpToDate bool `json:"upToDate,omitempty"`
+
+	// Records the progress of the data rebalancing when the number of shards changes.
+	// It is only available for shardings whose ShardingDefinition defines the `rebalance` action.
+	//
+	// +optional
+	Resharding *ReshardingStatus `json:"resharding,omitempty"`
+}
+
+// ReshardingPhase defines the phase of the resharding or the data rebalancing of a shard.
+//
+// +enum
+// +kubebuilder:validation:Enum={Pending,Running,Succeeded,Failed}
+type ReshardingPhase string
+
+const (
+	// ReshardingPending indicates the data rebalancing of the shard is waiting for the added shards to be ready.
+	ReshardingPending ReshardingPhase = "Pending"
+
+	// ReshardingRunning indicates the data is being rebalanced.
+	ReshardingRunning ReshardingPhase = "Running"
+
+	// ReshardingSucceeded indicates the data has been rebalanced.
+	ReshardingSucceeded ReshardingPhase = "Succeeded"
+
+	// ReshardingFailed indicates the last attempt to rebalance the data of the shard failed, it will be retried.
+	ReshardingFailed ReshardingPhase = "Failed"
+)
+
+// ReshardingStatus records the progress of a resharding.
+type ReshardingStatus struct {
+	// The phase of the resharding, either "Running" or "Succeeded".
+	//
+	// +optional
+	Phase ReshardingPhase `json:"phase,omitempty"`
+
+	// The names of the shard components added by the resharding.
+	//
+	// +optional
+	AddedShards []string `json:"addedShards,omitempty"`
+
+	// The names of the shard components removed by the resharding.
+	//
+	// +optional
+	RemovedShards []string `json:"removedShards,omitempty"`
+
+	// The time when the resharding started.
+	//
+	// +optional
+	StartTime metav1.Time `json:"startTime,omitempty"`
+
+	// The time when the resharding completed.
+	//
+	// +optional
+	CompletionTime metav1.Time `json:"completionTime,omitempty"`
+
+	// The progress of the data rebalancing of each shard that exists before the resharding.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Shards []ShardRebalanceStatus `json:"shards,omitempty"`
+}
+
+// ShardRebalanceStatus records the progress of the data rebalancing of a shard.
+type ShardRebalanceStatus struct {
+	// The name of the shard component.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// The phase of the data rebalancing of the shard.
+	//
+	// +optional
+	Phase ReshardingPhase `json:"phase,omitempty"`
+
+	// A human-readable message about the data rebalancing, such as the error of the last attempt.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// The time when the phase of the shard last changed.
+	//
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 }
@@ -21,6 +21,7 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -338,6 +339,16 @@ type ComponentSpec struct {
 	//
 	// +optional
 	EnableInstanceAPI *bool `json:"enableInstanceAPI,omitempty"`
+
+	// Specifies the policy of the automated failover performed by KubeBlocks.
+	//
+	// It's intended for the engines that don't elect a new primary by themselves. Once enabled, the replica
+	// holding the role is fenced and the role is switched over to the most up-to-date replica if it's
+	// unhealthy for a period.
+	// It requires the `roleProbe`, `switchover` and `replicationLag` lifecycle actions defined in the ComponentDefinition.
+	//
+	// +optional
+	FailoverPolicy *FailoverPolicy `json:"failoverPolicy,omitempty"`
 }
 
 // ComponentStatus represents the observed state of a Component within the Cluster.
@@ -377,6 +388,186 @@ type ComponentStatus struct {
 	//
 	// +optional
 	Message map[string]string `json:"message,omitempty"`
+
+	// Records the automatic expansions of the volumes with autoscaling enabled.
+	//
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=name
+	VolumeAutoscaling []VolumeAutoscalingStatus `json:"volumeAutoscaling,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+
+	// Records the replicas that have been switched into the read-only state by the `readonly` lifecycle action.
+	//
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=name
+	ReadonlyReplicas []ReadonlyReplicaStatus `json:"readonlyReplicas,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+
+	// Records the TLS certificate used by the Component, if the TLS is enabled.
+	//
+	// +optional
+	TLS *ComponentTLSStatus `json:"tls,omitempty"`
+
+	// Records the password rotations of the system accounts that have a rotation policy.
+	//
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=name
+	SystemAccounts []ComponentSystemAccountStatus `json:"systemAccounts,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+
+	// Records the recent automated failovers of the Component, the latest one comes last.
+	//
+	// +optional
+	FailoverHistory []FailoverRecord `json:"failoverHistory,omitempty"`
+}
+
+// VolumeAutoscalingStatus records the automatic expansion of a volumeClaimTemplate.
+type VolumeAutoscalingStatus struct {
+	// The name of the volumeClaimTemplate.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// The highest usage among the volumes, in percentage of their capacity, that triggered the last expansion.
+	//
+	// +optional
+	UsagePercent *int32 `json:"usagePercent,omitempty"`
+
+	// The storage size requested by the last expansion.
+	//
+	// +optional
+	Storage *resource.Quantity `json:"storage,omitempty"`
+
+	// The name of the "VolumeExpansion" OpsRequest created for the last expansion.
+	//
+	// +optional
+	OpsRequestName string `json:"opsRequestName,omitempty"`
+
+	// The time of the last expansion.
+	//
+	// +optional
+	LastExpansionTime metav1.Time `json:"lastExpansionTime,omitempty"`
+
+	// A human-readable message about the autoscaling, such as the reason why the volume can't be expanded.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ComponentTLSStatus records the TLS certificate used by the Component.
+type ComponentTLSStatus struct {
+	// The issuer of the certificate.
+	//
+	// +optional
+	Issuer IssuerName `json:"issuer,omitempty"`
+
+	// The time before which the certificate is not valid.
+	//
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// The time after which the certificate is expired.
+	//
+	// Certificates issued by KubeBlocks are renewed ahead of the expiry,
+	// and warning events are emitted if user-provided certificates are close to expiring.
+	//
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// The time when the certificate was rotated last time.
+	//
+	// The new certificate is loaded by the `reconfigure` lifecycle action if it's defined,
+	// otherwise the replicas are restarted in order.
+	//
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+}
+
+// ComponentSystemAccountStatus records the password rotation of a system account.
+type ComponentSystemAccountStatus struct {
+	// The name of the system account.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// The time when the password was rotated last time.
+	//
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+
+	// The time when the grace period of the last rotation ends, and the previous password will be discarded.
+	//
+	// It's cleared once the previous password has been discarded.
+	//
+	// +optional
+	GracePeriodEndTime *metav1.Time `json:"gracePeriodEndTime,omitempty"`
+}
+
+// FailoverRecord records an automated failover of the Component.
+type FailoverRecord struct {
+	// The role that has been failed over.
+	//
+	// +kubebuilder:validation:Required
+	Role string `json:"role"`
+
+	// The name of the replica (Pod) that held the role and has been fenced.
+	//
+	// +kubebuilder:validation:Required
+	From string `json:"from"`
+
+	// The name of the replica (Pod) that the role has been switched over to.
+	//
+	// +kubebuilder:validation:Required
+	To string `json:"to"`
+
+	// The replication lag of the new replica reported by the `replicationLag` lifecycle action.
+	//
+	// +optional
+	Lag *int64 `json:"lag,omitempty"`
+
+	// The time when the failover is done.
+	//
+	// +optional
+	Time metav1.Time `json:"time,omitempty"`
+
+	// A human-readable message about the failover, such as why the previous replica was considered unhealthy.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ReadonlyReplicaStatus records a replica that has been switched into the read-only state.
+type ReadonlyReplicaStatus struct {
+	// The name of the replica (Pod).
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// The reason why the replica is switched into the read-only state.
+	//
+	// - VolumeHighWatermark: The usage of one of its volumes exceeds the `highWatermark` of the volume.
+	// The replica is switched back to the read-write state once the usage falls below the watermark.
+	// - OpsRequest: The replica is switched by a "ReadonlySwitch" OpsRequest.
+	// It stays read-only until it's switched back by another OpsRequest.
+	//
+	// +kubebuilder:validation:Required
+	Reason ReadonlyReason `json:"reason"`
+
+	// The time when the replica is switched into the read-only state.
+	//
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// A human-readable message about the switch, such as the usage of the volume.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 type Sidecar struct {
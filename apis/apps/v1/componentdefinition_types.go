@@ -516,11 +516,13 @@ type ComponentDefinitionSpec struct {
 	//     such as before planned maintenance or upgrades on the current leader node.
 	//   - `memberJoin`: Defines the procedure to add a new replica to the replication group.
 	//   - `memberLeave`: Defines the method to remove a replica from the replication group.
+	//   - `replicationLag`: Defines the procedure to report how far a replica lags behind the primary.
 	//   - `readOnly`: Defines the procedure to switch a replica into the read-only state.
 	//   - `readWrite`: transition a replica from the read-only state back to the read-write state.
 	//   - `dataDump`: Defines the procedure to export the data from a replica.
 	//   - `dataLoad`: Defines the procedure to import data into a replica.
 	//   - `reconfigure`: Defines the procedure that update a replica with new configuration file.
+	//   - `parameterQuery`: Defines the procedure to query the effective parameters of a replica.
 	//   - `accountProvision`: Defines the procedure to generate a new database account.
 	//
 	// This field is immutable.
@@ -1003,6 +1005,7 @@ type MultipleClusterObjectValueFormatFlatten struct {
 	KeyValueDelimiter string `json:"keyValueDelimiter"`
 }
 
+// +kubebuilder:validation:XValidation:rule="!has(self.lowWatermark) || (has(self.highWatermark) && self.lowWatermark < self.highWatermark)",message="the low watermark should be less than the high watermark"
 type ComponentVolume struct {
 	// Specifies the name of the volume.
 	// It must be a DNS_LABEL and unique within the pod.
@@ -1025,7 +1028,7 @@ type ComponentVolume struct {
 	// Exceeding this percentage triggers the system to switch the volume to read-only mode as specified in
 	// `componentDefinition.spec.lifecycleActions.readOnly`.
 	// This precaution helps prevent space depletion while maintaining read-only access.
-	// If the space utilization later falls below this threshold, the system reverts the volume to read-write mode
+	// If the space utilization later falls below the `lowWatermark`, the system reverts the volume to read-write mode
 	// as defined in `componentDefinition.spec.lifecycleActions.readWrite`, restoring full functionality.
 	//
 	// Note: This field cannot be updated.
@@ -1035,6 +1038,20 @@ type ComponentVolume struct {
 	// +kubebuilder:default=0
 	// +optional
 	HighWatermark int `json:"highWatermark,omitempty"`
+
+	// Sets the threshold for volume space utilization as a percentage (0-100), below which the volume switched
+	// into read-only mode by the `highWatermark` is reverted to read-write mode.
+	//
+	// The gap between the two watermarks prevents the volume from flapping between read-only and read-write modes
+	// when the space utilization hovers around the `highWatermark`.
+	// If not specified, it defaults to 10 percentage points below the `highWatermark`, but no lower than half of it.
+	//
+	// Note: This field cannot be updated.
+	//
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	LowWatermark int `json:"lowWatermark,omitempty"`
 }
 
 type HostNetwork struct {
@@ -1191,6 +1208,24 @@ type SystemAccountStatement struct {
 	//
 	// +optional
 	Update string `json:"update,omitempty"`
+
+	// The statement to update the password of an existing account while retaining the current password as
+	// a secondary one, e.g. `ALTER USER ... IDENTIFIED BY ... RETAIN CURRENT PASSWORD` in MySQL.
+	//
+	// Both passwords are accepted until the secondary one is discarded by the `discardSecondaryPassword` statement.
+	// It's used to rotate the password with a grace period, if the engine supports dual passwords.
+	//
+	// This field is immutable once set.
+	//
+	// +optional
+	DualPasswordUpdate string `json:"dualPasswordUpdate,omitempty"`
+
+	// The statement to discard the secondary password retained by the `dualPasswordUpdate` statement.
+	//
+	// This field is immutable once set.
+	//
+	// +optional
+	DiscardSecondaryPassword string `json:"discardSecondaryPassword,omitempty"`
 }
 
 type TLS struct {
@@ -1237,6 +1272,15 @@ type TLS struct {
 	//
 	// +optional
 	KeyFile *string `json:"keyFile,omitempty"`
+
+	// Specifies whether the rotated certificates can be reloaded by the `reconfigure` lifecycle action.
+	//
+	// If set to true, the rotated certificates are rolled out by calling the `reconfigure` action with the changed files,
+	// and the action must be able to reload them without restarting.
+	// Otherwise, the replicas are restarted one by one, following the update strategy of the component.
+	//
+	// +optional
+	ReloadOnRotation *bool `json:"reloadOnRotation,omitempty"`
 }
 
 // ReplicasLimit defines the valid range of number of replicas supported.
@@ -1298,6 +1342,19 @@ type ComponentAvailableWithProbe struct {
 	//
 	// +optional
 	Description string `json:"description,omitempty"`
+
+	// Specifies a built-in synthetic probe that kbagent runs as the available probe of each replica,
+	// in place of the `availableProbe` lifecycle action.
+	//
+	// The synthetic probe periodically connects to the Services of the Component from inside the Pod network,
+	// checking the read and write paths that apply to the current role of the replica.
+	// The latency and result of each check are exposed through the metrics endpoint of kbagent,
+	// and the overall result is reported as the event of the available probe to evaluate the `condition`.
+	//
+	// This field is immutable once set.
+	//
+	// +optional
+	Synthetic *ComponentAvailableSyntheticProbe `json:"synthetic,omitempty"`
 }
 
 type ComponentAvailableCondition struct {
@@ -1387,6 +1444,106 @@ type ComponentAvailableProbeAssertion struct {
 	Strict *bool `json:"strict,omitempty"`
 }
 
+// ComponentAvailableSyntheticProbe defines the built-in synthetic probe to check the end-to-end availability of the component.
+type ComponentAvailableSyntheticProbe struct {
+	// Specifies the endpoints to check.
+	//
+	// The probe succeeds only if all the targets that apply to the current role of the replica are reachable.
+	//
+	// This field is immutable once set.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=name
+	Targets []SyntheticProbeTarget `json:"targets"`
+
+	// Specifies the number of seconds after which the check of each target times out.
+	// Default to 5 seconds. Minimum value is 1.
+	//
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// Specifies the frequency at which the probe is conducted. This value is expressed in seconds.
+	// Default to 60 seconds. Minimum value is 1.
+	//
+	// +optional
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+
+	// Minimum consecutive successes for the probe to be considered successful after having failed.
+	// Defaults to 1. Minimum value is 1.
+	//
+	// +optional
+	SuccessThreshold int32 `json:"successThreshold,omitempty"`
+
+	// Minimum consecutive failures for the probe to be considered failed after having succeeded.
+	// Defaults to 3. Minimum value is 1.
+	//
+	// +optional
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+}
+
+// SyntheticProbeTarget defines an endpoint of the component to check by the synthetic probe.
+type SyntheticProbeTarget struct {
+	// The name of the target, which must be unique within the probe.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// The name of the Service defined in `componentDefinition.spec.services` to connect to.
+	//
+	// +kubebuilder:validation:Required
+	Service string `json:"service"`
+
+	// The port of the Service to connect to.
+	// It may be a numeric string (e.g., "3306") or the name of a port defined in the Service.
+	//
+	// +kubebuilder:validation:Required
+	Port string `json:"port"`
+
+	// Specifies whether the target is the read or the write path of the component.
+	//
+	// +kubebuilder:default=Read
+	// +optional
+	Path SyntheticProbePath `json:"path,omitempty"`
+
+	// Specifies the roles of the replicas that check the target.
+	//
+	// If not specified, the target is checked by all replicas.
+	//
+	// +optional
+	Roles []string `json:"roles,omitempty"`
+	// Specifies the action to check the read or write path of the target, e.g., running a query or writing
+	// a heartbeat record, instead of just establishing a TCP connection to it.
+	//
+	// The action is executed by the replicas that check the target, with the following environment variables:
+	//
+	// - KB_SYNTHETIC_TARGET_NAME: The name of the target.
+	// - KB_SYNTHETIC_TARGET_HOST: The host of the Service to connect to.
+	// - KB_SYNTHETIC_TARGET_PORT: The port of the Service to connect to.
+	// - KB_SYNTHETIC_TARGET_PATH: The path of the target, Read or Write.
+	// - KB_SYNTHETIC_REPLICA_ROLE: The current role of the replica that runs the action.
+	//
+	// The target is reachable only if the action succeeds.
+	// If not specified, the target is reachable if a TCP connection can be established to it.
+	//
+	// This field is immutable once set.
+	//
+	// +optional
+	Action *Action `json:"action,omitempty"`
+}
+
+// SyntheticProbePath defines the access path of the component that a synthetic probe target represents.
+//
+// +enum
+// +kubebuilder:validation:Enum={Read,Write}
+type SyntheticProbePath string
+
+const (
+	SyntheticProbeReadPath  SyntheticProbePath = "Read"
+	SyntheticProbeWritePath SyntheticProbePath = "Write"
+)
+
 // ReplicaRole represents a role that can be assigned to a component instance, defining its behavior and responsibilities.
 type ReplicaRole struct {
 	// Name defines the role's unique identifier. This value is used to set the "apps.kubeblocks.io/role" label
@@ -1617,6 +1774,23 @@ type ComponentLifecycleActions struct {
 	// +optional
 	MemberLeave *Action `json:"memberLeave,omitempty"`
 
+	// Defines the procedure to report how far a replica lags behind the primary.
+	//
+	// Use Case:
+	// This action is invoked on the candidates when KubeBlocks fails over the primary automatically,
+	// as configured by the `failoverPolicy` of the Component, to pick the most up-to-date replica as the new primary.
+	//
+	// Expected action output:
+	// - On Success: A non-negative integer that indicates the replication lag of the replica, e.g., in bytes or seconds.
+	//   The smaller the value, the more up-to-date the replica is. The unit is up to the addon implementation.
+	// - On Failure: An error message, if applicable, indicating why the action failed.
+	//   The replica is not considered as a candidate if the action fails.
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	ReplicationLag *Action `json:"replicationLag,omitempty"`
+
 	// Defines the procedure to switch a replica into the read-only state.
 	//
 	// Use Case:
@@ -1695,6 +1869,27 @@ type ComponentLifecycleActions struct {
 	// +optional
 	Reconfigure *Action `json:"reconfigure,omitempty"`
 
+	// Defines the procedure to query the effective parameters of a replica.
+	//
+	// Use Case:
+	// This action is invoked regularly to detect whether the effective parameters of a replica drift from
+	// the desired configuration, e.g., a configuration file is edited inside the Pod by hand,
+	// or a parameter is changed at runtime without going through KubeBlocks.
+	//
+	// The container executing this action has access to following variables:
+	//
+	// - KB_QUERY_PARAMETERS: A JSON object that maps each configuration file name to the names of the parameters to be queried.
+	//
+	// Expected action output:
+	// - On Success: A JSON object that maps each configuration file name to the effective values of the parameters,
+	//   e.g., `{"my.cnf": {"max_connections": "1000"}}`.
+	// - On Failure: An error message, if applicable, indicating why the action failed.
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	ParameterQuery *Action `json:"parameterQuery,omitempty"`
+
 	// Defines the procedure to generate a new database account.
 	//
 	// Use Case:
@@ -1728,11 +1923,13 @@ type ComponentLifecycleActions struct {
 //   - `switchover`: Defines the procedure for a controlled transition of a role to a new replica.
 //   - `memberJoin`: Defines the procedure to add a new replica to the replication group.
 //   - `memberLeave`: Defines the method to remove a replica from the replication group.
+//   - `replicationLag`: Defines the procedure to report how far a replica lags behind the primary.
 //   - `readOnly`: Defines the procedure to switch a replica into the read-only state.
 //   - `readWrite`: Defines the procedure to transition a replica from the read-only state back to the read-write state.
 //   - `dataDump`: Defines the procedure to export the data from a replica.
 //   - `dataLoad`: Defines the procedure to import data into a replica.
 //   - `reconfigure`: Defines the procedure that update a replica with new configuration.
+//   - `parameterQuery`: Defines the procedure to query the effective parameters of a replica.
 //   - `accountProvision`: Defines the procedure to generate a new database account.
 //
 // Actions can be executed in different ways:
@@ -199,6 +199,26 @@ type ShardingLifecycleActions struct {
 	//
 	// +optional
 	ShardRemove *Action `json:"shardRemove,omitempty"`
+
+	// Specifies the hook to rebalance the data between shards when the number of shards changes.
+	//
+	// When shards are added or removed, the action is executed on each shard that exists before the change,
+	// after all the added shards are ready.
+	// A shard to be removed is deleted only after the action has succeeded on it, that is, its data has been drained.
+	//
+	// The action is called in a non-blocking manner and it is retried until it succeeds,
+	// so it should be idempotent and able to resume an interrupted data migration.
+	//
+	// The following variables are passed to the action:
+	//
+	// - KB_RESHARDING_SHARDS: The names of all the shard components after the change, separated by commas.
+	// - KB_RESHARDING_ADDED_SHARDS: The names of the added shard components, separated by commas.
+	// - KB_RESHARDING_REMOVED_SHARDS: The names of the shard components to be removed, separated by commas.
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	Rebalance *Action `json:"rebalance,omitempty"`
 }
 
 type ShardingSystemAccount struct {
@@ -18,6 +18,8 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -47,6 +49,21 @@ const (
 	ConditionTypeApplyResources      = "ApplyResources"      // ConditionTypeApplyResources the operator start to apply resources to create or change the cluster
 	ConditionTypeReady               = "Ready"               // ConditionTypeReady all components and shardings are running
 	ConditionTypeAvailable           = "Available"           // ConditionTypeAvailable indicates whether the target object is available for serving.
+	ConditionTypeReadonly            = "Readonly"            // ConditionTypeReadonly indicates whether some replicas of the component are switched into the read-only state.
+)
+
+// ReadonlyReason defines the reason why a replica is switched into the read-only state.
+//
+// +enum
+// +kubebuilder:validation:Enum={VolumeHighWatermark,OpsRequest}
+type ReadonlyReason string
+
+const (
+	// ReadonlyReasonVolumeHighWatermark indicates that the usage of a volume exceeds its high watermark.
+	ReadonlyReasonVolumeHighWatermark ReadonlyReason = "VolumeHighWatermark"
+
+	// ReadonlyReasonOpsRequest indicates that the replica is switched by an OpsRequest.
+	ReadonlyReasonOpsRequest ReadonlyReason = "OpsRequest"
 )
 
 type ServiceRef struct {
@@ -196,6 +213,48 @@ type PersistentVolumeClaimTemplate struct {
 	//
 	// +optional
 	Spec corev1.PersistentVolumeClaimSpec `json:"spec,omitempty"`
+
+	// Specifies the policy to expand the volume automatically when its usage exceeds a threshold.
+	//
+	// The usage of the volumes is read from the volume stats of the kubelet, and the volumes are expanded by
+	// "VolumeExpansion" OpsRequests created on behalf of the user.
+	// The StorageClass of the volume should allow volume expansion.
+	//
+	// It only takes effect on the volumeClaimTemplates of the Component, not of the instance templates.
+	//
+	// +optional
+	Autoscaling *PersistentVolumeClaimAutoscaling `json:"autoscaling,omitempty"`
+}
+
+// PersistentVolumeClaimAutoscaling defines the policy to expand a volume automatically.
+type PersistentVolumeClaimAutoscaling struct {
+	// Specifies the usage of the volume, in percentage of its capacity, above which the volume is expanded.
+	// The highest usage among the volumes of all replicas is considered.
+	//
+	// +kubebuilder:default=80
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	// +optional
+	ThresholdPercent *int32 `json:"thresholdPercent,omitempty"`
+
+	// Specifies the size to add at each expansion, in percentage of the current size.
+	//
+	// +kubebuilder:default=20
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000
+	// +optional
+	StepPercent *int32 `json:"stepPercent,omitempty"`
+
+	// Specifies the minimum size to add at each expansion.
+	// It takes effect when the size calculated by the `stepPercent` is smaller.
+	//
+	// +optional
+	MinStep *resource.Quantity `json:"minStep,omitempty"`
+
+	// Specifies the maximum size the volume can be expanded to.
+	//
+	// +kubebuilder:validation:Required
+	MaxSize resource.Quantity `json:"maxSize"`
 }
 
 // PersistentVolumeClaimRetentionPolicy describes the policy used for PVCs created from the VolumeClaimTemplates.
@@ -395,6 +454,68 @@ type ComponentSystemAccount struct {
 	//
 	// +optional
 	SecretRef *ProvisionSecretRef `json:"secretRef,omitempty"`
+
+	// Specifies the policy for rotating the account's password periodically.
+	//
+	// The password is rotated through the `accountProvision` lifecycle action, and it's not applied to the accounts
+	// whose passwords are referenced from the `secretRef`.
+	//
+	// The rotation requires the engine to support dual passwords, that is, both the `dualPasswordUpdate` and
+	// `discardSecondaryPassword` statements are defined for the account in the ComponentDefinition. The replicas are
+	// restarted to pick up the new password, and the previous one is retained until all of them are restarted.
+	//
+	// +optional
+	RotationPolicy *PasswordRotationPolicy `json:"rotationPolicy,omitempty"`
+}
+
+// PasswordRotationPolicy defines how the password of a system account is rotated periodically.
+type PasswordRotationPolicy struct {
+	// The interval between two rotations, e.g. "2160h" to rotate the password every 90 days.
+	//
+	// +kubebuilder:validation:Required
+	Interval metav1.Duration `json:"interval"`
+
+	// The period after a rotation during which the previous password is still accepted.
+	//
+	// The previous password is discarded once the grace period ends and all the replicas have been restarted.
+	// Apps other than the replicas that consume the password need to pick up the new one within the grace period.
+	//
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+// FailoverPolicy defines how a role is failed over automatically when the replica holding it is unhealthy.
+//
+// The replica holding the role is fenced before the failover, that is, the role label is removed from it and it's
+// removed from the endpoints of the services selecting the role. It can't take the role back until it comes back
+// in another role. The `switchover` action is executed on the candidate during the failover, since the replica
+// holding the role is unavailable.
+type FailoverPolicy struct {
+	// The role to fail over, e.g. "primary".
+	// Defaults to the role with the highest update priority defined in the ComponentDefinition.
+	//
+	// +optional
+	Role string `json:"role,omitempty"`
+
+	// The number of consecutive role probe periods for which the replica holding the role must be unhealthy
+	// before it's failed over. Defaults to 3. Minimum value is 1.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	UnhealthyThreshold int32 `json:"unhealthyThreshold,omitempty"`
+
+	// The maximum replication lag of a candidate, in the unit reported by the `replicationLag` lifecycle action.
+	// The replicas lagging behind more than this are never promoted. There is no limit if not specified.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxLag *int64 `json:"maxLag,omitempty"`
+
+	// The minimum interval between two failovers, to prevent the role from flapping between replicas.
+	// Defaults to 10m.
+	//
+	// +optional
+	MinInterval *metav1.Duration `json:"minInterval,omitempty"`
 }
 
 // PasswordConfig helps provide to customize complexity of password generation pattern.
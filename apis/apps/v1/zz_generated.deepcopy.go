@@ -408,6 +408,11 @@ func (in *ClusterComponentSpec) DeepCopyInto(out *ClusterComponentSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.FailoverPolicy != nil {
+		in, out := &in.FailoverPolicy, &out.FailoverPolicy
+		*out = new(FailoverPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterComponentSpec.
@@ -430,6 +435,11 @@ func (in *ClusterComponentStatus) DeepCopyInto(out *ClusterComponentStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.Resharding != nil {
+		in, out := &in.Resharding, &out.Resharding
+		*out = new(ReshardingStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterComponentStatus.
@@ -678,6 +688,13 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(ClusterBackup)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
@@ -1036,6 +1053,28 @@ func (in *ComponentAvailableProbeAssertion) DeepCopy() *ComponentAvailableProbeA
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentAvailableSyntheticProbe) DeepCopyInto(out *ComponentAvailableSyntheticProbe) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]SyntheticProbeTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentAvailableSyntheticProbe.
+func (in *ComponentAvailableSyntheticProbe) DeepCopy() *ComponentAvailableSyntheticProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentAvailableSyntheticProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentAvailableWithProbe) DeepCopyInto(out *ComponentAvailableWithProbe) {
 	*out = *in
@@ -1049,6 +1088,11 @@ func (in *ComponentAvailableWithProbe) DeepCopyInto(out *ComponentAvailableWithP
 		*out = new(ComponentAvailableCondition)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Synthetic != nil {
+		in, out := &in.Synthetic, &out.Synthetic
+		*out = new(ComponentAvailableSyntheticProbe)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentAvailableWithProbe.
@@ -1347,6 +1391,11 @@ func (in *ComponentLifecycleActions) DeepCopyInto(out *ComponentLifecycleActions
 		*out = new(Action)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ReplicationLag != nil {
+		in, out := &in.ReplicationLag, &out.ReplicationLag
+		*out = new(Action)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Readonly != nil {
 		in, out := &in.Readonly, &out.Readonly
 		*out = new(Action)
@@ -1372,6 +1421,11 @@ func (in *ComponentLifecycleActions) DeepCopyInto(out *ComponentLifecycleActions
 		*out = new(Action)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ParameterQuery != nil {
+		in, out := &in.ParameterQuery, &out.ParameterQuery
+		*out = new(Action)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AccountProvision != nil {
 		in, out := &in.AccountProvision, &out.AccountProvision
 		*out = new(Action)
@@ -1623,6 +1677,11 @@ func (in *ComponentSpec) DeepCopyInto(out *ComponentSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.FailoverPolicy != nil {
+		in, out := &in.FailoverPolicy, &out.FailoverPolicy
+		*out = new(FailoverPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentSpec.
@@ -1652,6 +1711,39 @@ func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.VolumeAutoscaling != nil {
+		in, out := &in.VolumeAutoscaling, &out.VolumeAutoscaling
+		*out = make([]VolumeAutoscalingStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ReadonlyReplicas != nil {
+		in, out := &in.ReadonlyReplicas, &out.ReadonlyReplicas
+		*out = make([]ReadonlyReplicaStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ComponentTLSStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SystemAccounts != nil {
+		in, out := &in.SystemAccounts, &out.SystemAccounts
+		*out = make([]ComponentSystemAccountStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FailoverHistory != nil {
+		in, out := &in.FailoverHistory, &out.FailoverHistory
+		*out = make([]FailoverRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
@@ -1682,6 +1774,11 @@ func (in *ComponentSystemAccount) DeepCopyInto(out *ComponentSystemAccount) {
 		*out = new(ProvisionSecretRef)
 		**out = **in
 	}
+	if in.RotationPolicy != nil {
+		in, out := &in.RotationPolicy, &out.RotationPolicy
+		*out = new(PasswordRotationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentSystemAccount.
@@ -1694,6 +1791,56 @@ func (in *ComponentSystemAccount) DeepCopy() *ComponentSystemAccount {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentSystemAccountStatus) DeepCopyInto(out *ComponentSystemAccountStatus) {
+	*out = *in
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.GracePeriodEndTime != nil {
+		in, out := &in.GracePeriodEndTime, &out.GracePeriodEndTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentSystemAccountStatus.
+func (in *ComponentSystemAccountStatus) DeepCopy() *ComponentSystemAccountStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentSystemAccountStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentTLSStatus) DeepCopyInto(out *ComponentTLSStatus) {
+	*out = *in
+	if in.NotBefore != nil {
+		in, out := &in.NotBefore, &out.NotBefore
+		*out = (*in).DeepCopy()
+	}
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentTLSStatus.
+func (in *ComponentTLSStatus) DeepCopy() *ComponentTLSStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentTLSStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentVarSelector) DeepCopyInto(out *ComponentVarSelector) {
 	*out = *in
@@ -2110,6 +2257,52 @@ func (in *Exporter) DeepCopy() *Exporter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailoverPolicy) DeepCopyInto(out *FailoverPolicy) {
+	*out = *in
+	if in.MaxLag != nil {
+		in, out := &in.MaxLag, &out.MaxLag
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MinInterval != nil {
+		in, out := &in.MinInterval, &out.MinInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverPolicy.
+func (in *FailoverPolicy) DeepCopy() *FailoverPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FailoverPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailoverRecord) DeepCopyInto(out *FailoverRecord) {
+	*out = *in
+	if in.Lag != nil {
+		in, out := &in.Lag, &out.Lag
+		*out = new(int64)
+		**out = **in
+	}
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverRecord.
+func (in *FailoverRecord) DeepCopy() *FailoverRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(FailoverRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GRPCAction) DeepCopyInto(out *GRPCAction) {
 	*out = *in
@@ -2402,6 +2595,26 @@ func (in *LogConfig) DeepCopy() *LogConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.DaysOfWeek != nil {
+		in, out := &in.DaysOfWeek, &out.DaysOfWeek
+		*out = make([]Weekday, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MultipleClusterObjectCombinedOption) DeepCopyInto(out *MultipleClusterObjectCombinedOption) {
 	*out = *in
@@ -2527,6 +2740,58 @@ func (in *PasswordConfig) DeepCopy() *PasswordConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordRotationPolicy) DeepCopyInto(out *PasswordRotationPolicy) {
+	*out = *in
+	out.Interval = in.Interval
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordRotationPolicy.
+func (in *PasswordRotationPolicy) DeepCopy() *PasswordRotationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordRotationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistentVolumeClaimAutoscaling) DeepCopyInto(out *PersistentVolumeClaimAutoscaling) {
+	*out = *in
+	if in.ThresholdPercent != nil {
+		in, out := &in.ThresholdPercent, &out.ThresholdPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.StepPercent != nil {
+		in, out := &in.StepPercent, &out.StepPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinStep != nil {
+		in, out := &in.MinStep, &out.MinStep
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	out.MaxSize = in.MaxSize.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistentVolumeClaimAutoscaling.
+func (in *PersistentVolumeClaimAutoscaling) DeepCopy() *PersistentVolumeClaimAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistentVolumeClaimAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PersistentVolumeClaimRetentionPolicy) DeepCopyInto(out *PersistentVolumeClaimRetentionPolicy) {
 	*out = *in
@@ -2565,6 +2830,11 @@ func (in *PersistentVolumeClaimTemplate) DeepCopyInto(out *PersistentVolumeClaim
 		}
 	}
 	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(PersistentVolumeClaimAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistentVolumeClaimTemplate.
@@ -2623,6 +2893,22 @@ func (in *Range) DeepCopy() *Range {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadonlyReplicaStatus) DeepCopyInto(out *ReadonlyReplicaStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadonlyReplicaStatus.
+func (in *ReadonlyReplicaStatus) DeepCopy() *ReadonlyReplicaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadonlyReplicaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReplicaRole) DeepCopyInto(out *ReplicaRole) {
 	*out = *in
@@ -2653,6 +2939,40 @@ func (in *ReplicasLimit) DeepCopy() *ReplicasLimit {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReshardingStatus) DeepCopyInto(out *ReshardingStatus) {
+	*out = *in
+	if in.AddedShards != nil {
+		in, out := &in.AddedShards, &out.AddedShards
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemovedShards != nil {
+		in, out := &in.RemovedShards, &out.RemovedShards
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+	if in.Shards != nil {
+		in, out := &in.Shards, &out.Shards
+		*out = make([]ShardRebalanceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReshardingStatus.
+func (in *ReshardingStatus) DeepCopy() *ReshardingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReshardingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceVarSelector) DeepCopyInto(out *ResourceVarSelector) {
 	*out = *in
@@ -3193,6 +3513,22 @@ func (in *ServiceVars) DeepCopy() *ServiceVars {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardRebalanceStatus) DeepCopyInto(out *ShardRebalanceStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardRebalanceStatus.
+func (in *ShardRebalanceStatus) DeepCopy() *ShardRebalanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardRebalanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ShardTemplate) DeepCopyInto(out *ShardTemplate) {
 	*out = *in
@@ -3433,6 +3769,11 @@ func (in *ShardingLifecycleActions) DeepCopyInto(out *ShardingLifecycleActions)
 		*out = new(Action)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Rebalance != nil {
+		in, out := &in.Rebalance, &out.Rebalance
+		*out = new(Action)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingLifecycleActions.
@@ -3652,6 +3993,31 @@ func (in *SidecarDefinitionStatus) DeepCopy() *SidecarDefinitionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyntheticProbeTarget) DeepCopyInto(out *SyntheticProbeTarget) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Action != nil {
+		in, out := &in.Action, &out.Action
+		*out = new(Action)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyntheticProbeTarget.
+func (in *SyntheticProbeTarget) DeepCopy() *SyntheticProbeTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(SyntheticProbeTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SystemAccount) DeepCopyInto(out *SystemAccount) {
 	*out = *in
@@ -3711,6 +4077,11 @@ func (in *TLS) DeepCopyInto(out *TLS) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ReloadOnRotation != nil {
+		in, out := &in.ReloadOnRotation, &out.ReloadOnRotation
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLS.
@@ -3859,3 +4230,29 @@ func (in *VarSource) DeepCopy() *VarSource {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeAutoscalingStatus) DeepCopyInto(out *VolumeAutoscalingStatus) {
+	*out = *in
+	if in.UsagePercent != nil {
+		in, out := &in.UsagePercent, &out.UsagePercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	in.LastExpansionTime.DeepCopyInto(&out.LastExpansionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeAutoscalingStatus.
+func (in *VolumeAutoscalingStatus) DeepCopy() *VolumeAutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeAutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -82,9 +82,42 @@ type RolloutSpec struct {
 	// +optional
 	Shardings []RolloutSharding `json:"shardings,omitempty"`
 
+	// Indicates whether the Rollout can be started outside the maintenance windows of the Cluster.
+	//
+	// By default, a Rollout that uses the "Replace" strategy stays in the "Pending" state until the Cluster enters
+	// one of its maintenance windows. Set this to true for emergency rollouts that must be started immediately.
+	//
+	// +optional
+	BypassMaintenanceWindow bool `json:"bypassMaintenanceWindow,omitempty"`
+
+	// Specifies the policy to roll back the Rollout automatically when it fails or times out.
+	//
+	// When a target component or sharding fails during the rollout, the ServiceVersion and ComponentDefinition
+	// of the components and shardings are reverted to the ones recorded before the rollout, the new instances
+	// are removed and the scaled-down instances are restored.
+	//
+	// If not specified, the Rollout is not rolled back.
+	//
+	// +optional
+	RollbackPolicy *RolloutRollbackPolicy `json:"rollbackPolicy,omitempty"`
+
 	// TODO: auto-reclaim the successful rollouts.
 }
 
+// RolloutRollbackPolicy defines the policy to roll back the Rollout automatically.
+type RolloutRollbackPolicy struct {
+	// Specifies the maximum duration in seconds for the Rollout to succeed since it starts rolling.
+	// The Rollout is rolled back if it doesn't succeed within the duration, and the rollback is also limited
+	// to the same duration.
+	//
+	// If not specified or set to 0, the Rollout is only rolled back when a target component or sharding fails,
+	// or the analysis of the new instances fails.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
 // RolloutStatus defines the observed state of Rollout
 type RolloutStatus struct {
 	// The most recent generation number of the Rollout object that has been observed by the controller.
@@ -102,6 +135,16 @@ type RolloutStatus struct {
 	// +optional
 	Message string `json:"message,omitempty"`
 
+	// Records the time when the Rollout starts rolling.
+	//
+	// +optional
+	StartTimestamp metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Records the automatic rollback of the Rollout, it is set once the rollback is triggered.
+	//
+	// +optional
+	Rollback *RolloutRollbackStatus `json:"rollback,omitempty"`
+
 	// Represents a list of detailed status of the Rollout object.
 	//
 	// +optional
@@ -232,6 +275,16 @@ type RolloutStrategyReplace struct {
 	// +optional
 	ScaleDownDelaySeconds *int32 `json:"scaleDownDelaySeconds,omitempty"`
 
+	// The analysis to run against the new instances before scaling down the old instances.
+	//
+	// If specified, the analysis starts after the first new instance becomes ready, and no old instance
+	// will be scaled down until the analysis succeeds.
+	// If the analysis fails, the rollout stops and its state becomes Error, or it is rolled back if the
+	// rollback policy is specified.
+	//
+	// +optional
+	Analysis *RolloutAnalysis `json:"analysis,omitempty"`
+
 	// TODO: policy to scale-down the old instances and retain the PVCs.
 }
 
@@ -278,6 +331,84 @@ type RolloutPromotion struct {
 	// TODO: policy to retain the PVCs of old instances.
 }
 
+type RolloutAnalysis struct {
+	// The interval in seconds between two measurements.
+	//
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	IntervalSeconds *int32 `json:"intervalSeconds,omitempty"`
+
+	// The number of successful measurements required to pass the analysis.
+	//
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SuccessfulMeasurements *int32 `json:"successfulMeasurements,omitempty"`
+
+	// The number of failed measurements tolerated, the analysis fails once the failed measurements exceed it.
+	//
+	// +kubebuilder:default=0
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FailureLimit *int32 `json:"failureLimit,omitempty"`
+
+	// The metrics to measure, a measurement succeeds only when all the metrics meet their thresholds.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=map
+	// +listMapKey=name
+	Metrics []RolloutAnalysisMetric `json:"metrics"`
+}
+
+type RolloutAnalysisMetric struct {
+	// The name of the metric.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Queries the metric from a Prometheus-compatible endpoint.
+	//
+	// +kubebuilder:validation:Required
+	Prometheus RolloutPrometheusMetric `json:"prometheus"`
+
+	// The maximum value allowed for the new instances, in decimal format.
+	//
+	// +kubebuilder:validation:Pattern:=`^-?[0-9]+(\.[0-9]+)?$`
+	// +optional
+	Max *string `json:"max,omitempty"`
+
+	// The minimum value allowed for the new instances, in decimal format.
+	//
+	// +kubebuilder:validation:Pattern:=`^-?[0-9]+(\.[0-9]+)?$`
+	// +optional
+	Min *string `json:"min,omitempty"`
+
+	// The maximum deviation in percent allowed between the values of the new instances and the old instances,
+	// which is calculated as |new - old| / |old| * 100.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxDeviationPercent *int32 `json:"maxDeviationPercent,omitempty"`
+}
+
+type RolloutPrometheusMetric struct {
+	// The address of the Prometheus-compatible endpoint, e.g. http://prometheus.monitoring:9090.
+	//
+	// +kubebuilder:validation:Required
+	Address string `json:"address"`
+
+	// The PromQL query to evaluate, it should return a scalar or a single-element vector.
+	//
+	// The placeholder `$instances` in the query is replaced with a regular expression matching the names
+	// of the new instances or the old instances, e.g. `sum(rate(errors_total{pod=~"$instances"}[1m]))`.
+	//
+	// +kubebuilder:validation:Required
+	Query string `json:"query"`
+}
+
 type RolloutPromoteCondition struct {
 	// The condition before promoting the new instances.
 	//
@@ -317,16 +448,36 @@ type InstanceMeta struct {
 // RolloutState defines the state of the Rollout within the .status.state field.
 //
 // +enum
-// +kubebuilder:validation:Enum={Pending,Rolling,Succeed,Error}
+// +kubebuilder:validation:Enum={Pending,Rolling,Succeed,Error,RollingBack,RolledBack}
 type RolloutState string
 
 const (
-	PendingRolloutState RolloutState = "Pending"
-	RollingRolloutState RolloutState = "Rolling"
-	SucceedRolloutState RolloutState = "Succeed"
-	ErrorRolloutState   RolloutState = "Error"
+	PendingRolloutState     RolloutState = "Pending"
+	RollingRolloutState     RolloutState = "Rolling"
+	SucceedRolloutState     RolloutState = "Succeed"
+	ErrorRolloutState       RolloutState = "Error"
+	RollingBackRolloutState RolloutState = "RollingBack"
+	RolledBackRolloutState  RolloutState = "RolledBack"
 )
 
+// RolloutRollbackStatus records the automatic rollback of the Rollout.
+type RolloutRollbackStatus struct {
+	// The time when the rollback was triggered.
+	//
+	// +optional
+	StartTimestamp metav1.Time `json:"startTimestamp,omitempty"`
+
+	// The time when the rollback was completed.
+	//
+	// +optional
+	CompletionTimestamp metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// Provides a human-readable message about why the rollback was triggered.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 type RolloutComponentStatus struct {
 	// The name of the component.
 	//
@@ -348,6 +499,11 @@ type RolloutComponentStatus struct {
 	// +kubebuilder:validation:Required
 	Replicas int32 `json:"replicas"`
 
+	// The replicas of the instance templates the component has before the rollout.
+	//
+	// +optional
+	InstanceReplicas map[string]int32 `json:"instanceReplicas,omitempty"`
+
 	// The new replicas the component has been created successfully.
 	//
 	// +optional
@@ -377,8 +533,84 @@ type RolloutComponentStatus struct {
 	//
 	// +optional
 	LastScaleDownTimestamp metav1.Time `json:"lastScaleDownTimestamp,omitempty"`
+
+	// The status of the analysis of the new instances.
+	//
+	// +optional
+	Analysis *RolloutAnalysisStatus `json:"analysis,omitempty"`
 }
 
+type RolloutAnalysisStatus struct {
+	// The phase of the analysis.
+	//
+	// +optional
+	Phase RolloutAnalysisPhase `json:"phase,omitempty"`
+
+	// The number of successful measurements.
+	//
+	// +optional
+	SuccessfulMeasurements int32 `json:"successfulMeasurements,omitempty"`
+
+	// The number of failed measurements.
+	//
+	// +optional
+	FailedMeasurements int32 `json:"failedMeasurements,omitempty"`
+
+	// The last time the metrics were measured.
+	//
+	// +optional
+	LastMeasurementTimestamp metav1.Time `json:"lastMeasurementTimestamp,omitempty"`
+
+	// The results of the last measurement of each metric.
+	//
+	// +optional
+	Metrics []RolloutAnalysisMetricStatus `json:"metrics,omitempty"`
+
+	// Provides additional information about the analysis.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+type RolloutAnalysisMetricStatus struct {
+	// The name of the metric.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// The value measured from the new instances.
+	//
+	// +optional
+	CanaryValue string `json:"canaryValue,omitempty"`
+
+	// The value measured from the old instances.
+	//
+	// +optional
+	StableValue string `json:"stableValue,omitempty"`
+
+	// Whether the metric met its thresholds in the last measurement.
+	//
+	// +optional
+	Passed bool `json:"passed"`
+
+	// Provides additional information about the measurement.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// RolloutAnalysisPhase defines the phase of the analysis.
+//
+// +enum
+// +kubebuilder:validation:Enum={Running,Succeed,Failed}
+type RolloutAnalysisPhase string
+
+const (
+	RunningRolloutAnalysisPhase RolloutAnalysisPhase = "Running"
+	SucceedRolloutAnalysisPhase RolloutAnalysisPhase = "Succeed"
+	FailedRolloutAnalysisPhase  RolloutAnalysisPhase = "Failed"
+)
+
 type RolloutShardingStatus struct {
 	// The name of the sharding.
 	//
@@ -405,6 +637,11 @@ type RolloutShardingStatus struct {
 	// +kubebuilder:validation:Required
 	Replicas int32 `json:"replicas"`
 
+	// The replicas of the instance templates the sharding has before the rollout.
+	//
+	// +optional
+	InstanceReplicas map[string]int32 `json:"instanceReplicas,omitempty"`
+
 	// The new replicas the sharding has been created successfully.
 	//
 	// +optional
@@ -434,4 +671,9 @@ type RolloutShardingStatus struct {
 	//
 	// +optional
 	LastScaleDownTimestamp metav1.Time `json:"lastScaleDownTimestamp,omitempty"`
+
+	// The status of the analysis of the new instances.
+	//
+	// +optional
+	Analysis *RolloutAnalysisStatus `json:"analysis,omitempty"`
 }
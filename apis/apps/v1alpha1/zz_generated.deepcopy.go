@@ -3631,6 +3631,110 @@ func (in *Rollout) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutAnalysis) DeepCopyInto(out *RolloutAnalysis) {
+	*out = *in
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SuccessfulMeasurements != nil {
+		in, out := &in.SuccessfulMeasurements, &out.SuccessfulMeasurements
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureLimit != nil {
+		in, out := &in.FailureLimit, &out.FailureLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]RolloutAnalysisMetric, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutAnalysis.
+func (in *RolloutAnalysis) DeepCopy() *RolloutAnalysis {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutAnalysis)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutAnalysisMetric) DeepCopyInto(out *RolloutAnalysisMetric) {
+	*out = *in
+	out.Prometheus = in.Prometheus
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(string)
+		**out = **in
+	}
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxDeviationPercent != nil {
+		in, out := &in.MaxDeviationPercent, &out.MaxDeviationPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutAnalysisMetric.
+func (in *RolloutAnalysisMetric) DeepCopy() *RolloutAnalysisMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutAnalysisMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutAnalysisMetricStatus) DeepCopyInto(out *RolloutAnalysisMetricStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutAnalysisMetricStatus.
+func (in *RolloutAnalysisMetricStatus) DeepCopy() *RolloutAnalysisMetricStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutAnalysisMetricStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutAnalysisStatus) DeepCopyInto(out *RolloutAnalysisStatus) {
+	*out = *in
+	in.LastMeasurementTimestamp.DeepCopyInto(&out.LastMeasurementTimestamp)
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]RolloutAnalysisMetricStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutAnalysisStatus.
+func (in *RolloutAnalysisStatus) DeepCopy() *RolloutAnalysisStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutAnalysisStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RolloutComponent) DeepCopyInto(out *RolloutComponent) {
 	*out = *in
@@ -3670,6 +3774,13 @@ func (in *RolloutComponent) DeepCopy() *RolloutComponent {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RolloutComponentStatus) DeepCopyInto(out *RolloutComponentStatus) {
 	*out = *in
+	if in.InstanceReplicas != nil {
+		in, out := &in.InstanceReplicas, &out.InstanceReplicas
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.ScaleDownInstances != nil {
 		in, out := &in.ScaleDownInstances, &out.ScaleDownInstances
 		*out = make([]string, len(*in))
@@ -3677,6 +3788,11 @@ func (in *RolloutComponentStatus) DeepCopyInto(out *RolloutComponentStatus) {
 	}
 	in.LastScaleUpTimestamp.DeepCopyInto(&out.LastScaleUpTimestamp)
 	in.LastScaleDownTimestamp.DeepCopyInto(&out.LastScaleDownTimestamp)
+	if in.Analysis != nil {
+		in, out := &in.Analysis, &out.Analysis
+		*out = new(RolloutAnalysisStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutComponentStatus.
@@ -3741,6 +3857,21 @@ func (in *RolloutList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutPrometheusMetric) DeepCopyInto(out *RolloutPrometheusMetric) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutPrometheusMetric.
+func (in *RolloutPrometheusMetric) DeepCopy() *RolloutPrometheusMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutPrometheusMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RolloutPromoteCondition) DeepCopyInto(out *RolloutPromoteCondition) {
 	*out = *in
@@ -3801,6 +3932,43 @@ func (in *RolloutPromotion) DeepCopy() *RolloutPromotion {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutRollbackPolicy) DeepCopyInto(out *RolloutRollbackPolicy) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutRollbackPolicy.
+func (in *RolloutRollbackPolicy) DeepCopy() *RolloutRollbackPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutRollbackPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutRollbackStatus) DeepCopyInto(out *RolloutRollbackStatus) {
+	*out = *in
+	in.StartTimestamp.DeepCopyInto(&out.StartTimestamp)
+	in.CompletionTimestamp.DeepCopyInto(&out.CompletionTimestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutRollbackStatus.
+func (in *RolloutRollbackStatus) DeepCopy() *RolloutRollbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutRollbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RolloutSharding) DeepCopyInto(out *RolloutSharding) {
 	*out = *in
@@ -3840,6 +4008,13 @@ func (in *RolloutSharding) DeepCopy() *RolloutSharding {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RolloutShardingStatus) DeepCopyInto(out *RolloutShardingStatus) {
 	*out = *in
+	if in.InstanceReplicas != nil {
+		in, out := &in.InstanceReplicas, &out.InstanceReplicas
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.ScaleDownInstances != nil {
 		in, out := &in.ScaleDownInstances, &out.ScaleDownInstances
 		*out = make([]string, len(*in))
@@ -3847,6 +4022,11 @@ func (in *RolloutShardingStatus) DeepCopyInto(out *RolloutShardingStatus) {
 	}
 	in.LastScaleUpTimestamp.DeepCopyInto(&out.LastScaleUpTimestamp)
 	in.LastScaleDownTimestamp.DeepCopyInto(&out.LastScaleDownTimestamp)
+	if in.Analysis != nil {
+		in, out := &in.Analysis, &out.Analysis
+		*out = new(RolloutAnalysisStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutShardingStatus.
@@ -3876,6 +4056,11 @@ func (in *RolloutSpec) DeepCopyInto(out *RolloutSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RollbackPolicy != nil {
+		in, out := &in.RollbackPolicy, &out.RollbackPolicy
+		*out = new(RolloutRollbackPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutSpec.
@@ -3891,6 +4076,12 @@ func (in *RolloutSpec) DeepCopy() *RolloutSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
 	*out = *in
+	in.StartTimestamp.DeepCopyInto(&out.StartTimestamp)
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(RolloutRollbackStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -4017,6 +4208,11 @@ func (in *RolloutStrategyReplace) DeepCopyInto(out *RolloutStrategyReplace) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Analysis != nil {
+		in, out := &in.Analysis, &out.Analysis
+		*out = new(RolloutAnalysis)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStrategyReplace.
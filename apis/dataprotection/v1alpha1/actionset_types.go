@@ -129,6 +129,23 @@ type BackupActionSpec struct {
 	//
 	// +optional
 	WithParameters []string `json:"withParameters,omitempty"`
+
+	// Represents the action to merge a base full backup and a chain of incremental
+	// backups into a synthetic full backup.
+	//
+	// It is only used by full backup ActionSets. The output must be in the same format
+	// as the backup data produced by `backupData`, so that the synthetic full backup can
+	// be restored and used as the base of subsequent incremental backups.
+	//
+	// The following environment variables are provided to the action:
+	//
+	// - `DP_BASE_BACKUP_NAME`: the name of the base full backup.
+	// - `DP_ANCESTOR_INCREMENTAL_BACKUP_NAMES`: the names of the incremental backups to be merged,
+	//   separated by commas, in the order they were taken.
+	// - `DP_BACKUP_ROOT_PATH` and `DP_TARGET_RELATIVE_PATH`: used to locate the data of the backups above.
+	//
+	// +optional
+	ConsolidateData *JobActionSpec `json:"consolidateData,omitempty"`
 }
 
 // BackupDataActionSpec defines how to back up data.
@@ -306,6 +323,13 @@ func (r *ActionSet) HasPrepareDataStage() bool {
 	return r.Spec.Restore.PrepareData != nil
 }
 
+func (r *ActionSet) HasConsolidateDataAction() bool {
+	if r == nil || r.Spec.Backup == nil {
+		return false
+	}
+	return r.Spec.Backup.ConsolidateData != nil
+}
+
 func (r *ActionSet) HasPostReadyStage() bool {
 	if r == nil || r.Spec.Restore == nil {
 		return false
@@ -71,11 +71,27 @@ type BackupSpec struct {
 	RetentionPeriod RetentionPeriod `json:"retentionPeriod,omitempty"`
 
 	// Determines the parent backup name for incremental or differential backup.
+	// The field is immutable, refer to `status.parentBackupName` for the parent backup
+	// the backup is actually based on.
 	//
 	// +optional
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.parentBackupName"
 	ParentBackupName string `json:"parentBackupName,omitempty"`
 
+	// Specifies the name of an incremental backup to be consolidated.
+	//
+	// When set, the backup does not back up the target. Instead, it merges the base full backup and
+	// all incremental backups up to and including the specified one into a synthetic full backup,
+	// using the `backup.consolidateData` action of the ActionSet. The backup method must be the
+	// compatible full backup method of the incremental backup method.
+	//
+	// Once the synthetic full backup is completed, the incremental backups taken after the specified one
+	// are re-parented onto it, and the consolidated chain will be deleted by the normal retention rules.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.consolidateBackupName"
+	ConsolidateBackupName string `json:"consolidateBackupName,omitempty"`
+
 	// Specifies a list of name-value pairs representing parameters and their corresponding values.
 	// Parameters match the schema specified in the `actionset.spec.parametersSchema`
 	//
@@ -196,22 +212,103 @@ type BackupStatus struct {
 
 	// Records the parent backup name for incremental or differential backup.
 	// When the parent backup is deleted, the backup will also be deleted.
+	// It may differ from `spec.parentBackupName` after the parent backup is consolidated
+	// into a synthetic full backup, which the backup is re-parented onto.
 	//
 	// +optional
 	ParentBackupName string `json:"parentBackupName,omitempty"`
 
 	// Records the base full backup name for incremental backup or differential backup.
 	// When the base backup is deleted, the backup will also be deleted.
+	// It is updated to the synthetic full backup when the backup is re-parented.
 	//
 	// +optional
 	BaseBackupName string `json:"baseBackupName,omitempty"`
 
+	// Records the names of the backups consolidated into this synthetic full backup,
+	// starting with the base full backup, followed by the incremental backups in order.
+	//
+	// +optional
+	ConsolidatedBackupNames []string `json:"consolidatedBackupNames,omitempty"`
+
 	// Records any additional information for the backup.
 	//
 	// +optional
 	Extras []map[string]string `json:"extras,omitempty"`
+
+	// Describes the current state of the backup, such as the result of the integrity verification.
+	//
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Records the status of the copies of the backup data in the secondary backup repositories.
+	//
+	// +optional
+	Replicas []BackupReplicaStatus `json:"replicas,omitempty"`
+}
+
+// BackupReplicaStatus records the status of a copy of the backup data in a secondary backup repository.
+type BackupReplicaStatus struct {
+	// The name of the secondary backup repository.
+	BackupRepoName string `json:"backupRepoName"`
+
+	// The current phase of the copy.
+	//
+	// +optional
+	Phase BackupReplicaPhase `json:"phase,omitempty"`
+
+	// The directory within the secondary backup repository where the backup data is copied to.
+	//
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Records the time the copy was started.
+	//
+	// +optional
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Records the time the copy was completed.
+	//
+	// +optional
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// An error that caused the copy to fail.
+	//
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
+
+	// The number of times the copy has been retried after the replication job failed.
+	//
+	// +optional
+	Retries int32 `json:"retries,omitempty"`
 }
 
+// BackupReplicaPhase describes the lifecycle phase of a copy of the backup data.
+// +enum
+// +kubebuilder:validation:Enum={Copying,Completed,Failed}
+type BackupReplicaPhase string
+
+const (
+	// BackupReplicaPhaseCopying means the backup data is being copied.
+	BackupReplicaPhaseCopying BackupReplicaPhase = "Copying"
+
+	// BackupReplicaPhaseCompleted means the backup data has been copied successfully.
+	BackupReplicaPhaseCompleted BackupReplicaPhase = "Completed"
+
+	// BackupReplicaPhaseFailed means the backup data failed to be copied.
+	BackupReplicaPhaseFailed BackupReplicaPhase = "Failed"
+)
+
+const (
+	// ConditionTypeVerified is the name of the condition that indicates whether
+	// the backup data has passed the integrity verification.
+	ConditionTypeVerified = "Verified"
+
+	// ConditionTypeCorrupted is the name of the condition that indicates whether
+	// the backup data is found corrupted by the integrity verification.
+	ConditionTypeCorrupted = "Corrupted"
+)
+
 // BackupTimeRange records the time range of backed up data, for PITR, this is the
 // time range of recoverable data.
 type BackupTimeRange struct {
@@ -327,6 +424,13 @@ type ActionStatus struct {
 	//
 	// +optional
 	VolumeSnapshots []VolumeSnapshotStatus `json:"volumeSnapshots,omitempty"`
+
+	// Records the checksum of the backed up data, in the format of "sha256:<hex digest>".
+	// It is the digest of the checksum manifest that the action writes into the backup repository,
+	// which lists the SHA-256 checksum of each backup file.
+	//
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
 }
 
 type BackupStatusTarget struct {
@@ -84,6 +84,27 @@ type BackupPolicySpec struct {
 	//
 	// +optional
 	RetentionPolicy BackupPolicyRetentionPolicy `json:"retentionPolicy,omitempty"`
+
+	// Specifies the policy for replicating the completed backups to a secondary backup repository,
+	// for example, an off-site backup repository for disaster recovery.
+	//
+	// +optional
+	Replication *BackupReplicationPolicy `json:"replication,omitempty"`
+}
+
+// BackupReplicationPolicy defines how the completed backups are replicated to a secondary backup repository.
+type BackupReplicationPolicy struct {
+	// Specifies the name of the secondary BackupRepo which the backup data is copied to.
+	// The backup data is copied after the backup is completed, and the copy is recorded
+	// in `backup.status.replicas`. When the primary BackupRepo of a backup is unavailable,
+	// the restore falls back to the copy in the secondary BackupRepo.
+	//
+	// Backups taken by volume snapshots, continuous backups and backups stored in
+	// a Kopia repository are not replicated.
+	//
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([a-z0-9\.\-]*[a-z0-9])?$`
+	// +kubebuilder:validation:Required
+	BackupRepoName string `json:"backupRepoName"`
 }
 
 type BackupTarget struct {
@@ -36,6 +36,8 @@ const (
 )
 
 // BackupRepoSpec defines the desired state of `BackupRepo`.
+//
+// +kubebuilder:validation:XValidation:rule="!has(self.credentialStorePath) || (has(self.accessMethod) && self.accessMethod == 'Tool')",message="credentialStorePath is only supported by the Tool access method"
 type BackupRepoSpec struct {
 	// Specifies the name of the `StorageProvider` used by this backup repository.
 	//
@@ -71,6 +73,19 @@ type BackupRepoSpec struct {
 	// +optional
 	Credential *corev1.SecretReference `json:"credential,omitempty"`
 
+	// Specifies the path of the secret in the external secret store that holds the credentials for the `StorageProvider`.
+	//
+	// It keeps the credentials out of the Secret referenced by the `credential`, and requires the external secret store
+	// to be configured for KubeBlocks. The values from the secret store take precedence over those from the `credential`.
+	//
+	// The credentials are never rendered into the Secrets generated for the datasafed tool, these Secrets only hold
+	// the references to the secret store, which are resolved into an in-memory volume when the pods using the tool start.
+	// It's only supported by the `Tool` access method, since the CSI driver of the `Mount` access method can only read
+	// the credentials from Kubernetes Secrets.
+	//
+	// +optional
+	CredentialStorePath string `json:"credentialStorePath,omitempty"`
+
 	// Specifies the prefix of the path for storing backup data.
 	//
 	// +kubebuilder:validation:Pattern=`^([a-zA-Z0-9-_]+/?)*$`
@@ -83,6 +83,36 @@ type SchedulePolicy struct {
 	// +kubebuilder:default="7d"
 	RetentionPeriod RetentionPeriod `json:"retentionPeriod,omitempty"`
 
+	// Specifies the maximum number of incremental backups in a chain for an incremental backup method.
+	// When an incremental backup created by this schedule completes and its chain reaches this length,
+	// a synthetic full backup consolidating the chain is created automatically.
+	//
+	// It requires the ActionSet of the compatible full backup method to define `backup.consolidateData`.
+	// If not set, incremental backups are never consolidated.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ConsolidationThreshold *int32 `json:"consolidationThreshold,omitempty"`
+
+	// Specifies whether to verify the integrity of the backups created by this schedule.
+	// If set to true, the backup data is re-read from the backup repository and checked against
+	// the checksums recorded during the backup once the backup is completed.
+	// The result is reported by the `Verified` and `Corrupted` conditions of the backup.
+	// If the backup has no checksums recorded, only the readability of the backup files is checked,
+	// and the `Verified` condition is left unknown with the reason `BackupUnverifiable`.
+	//
+	// +optional
+	VerifyBackup *bool `json:"verifyBackup,omitempty"`
+
+	// Specifies the tiered retention policy, also known as grandfather-father-son (GFS) retention,
+	// for the backups created by this schedule.
+	// When set, the completed backups are retained by their positions among all the completed backups
+	// of this schedule instead of their own expiration, and `retentionPeriod` only applies to
+	// the backups that are not completed.
+	//
+	// +optional
+	GFSRetention *GFSRetentionPolicy `json:"gfsRetention,omitempty"`
+
 	// Specifies a list of name-value pairs representing parameters and their corresponding values.
 	// Parameters match the schema specified in the `actionset.spec.parametersSchema`
 	//
@@ -95,6 +125,37 @@ type SchedulePolicy struct {
 	Parameters []ParameterPair `json:"parameters,omitempty"`
 }
 
+// GFSRetentionPolicy defines a grandfather-father-son retention policy. The completed backups are
+// grouped by the day, the ISO week and the month of their completion time in UTC, and the latest
+// backup of each of the most recent groups is retained. A backup is retained if any of the rules
+// retains it.
+type GFSRetentionPolicy struct {
+	// Specifies the number of the most recent days, for each of which the latest backup is retained.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Daily int32 `json:"daily,omitempty"`
+
+	// Specifies the number of the most recent weeks, for each of which the latest backup is retained.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Weekly int32 `json:"weekly,omitempty"`
+
+	// Specifies the number of the most recent months, for each of which the latest backup is retained.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Monthly int32 `json:"monthly,omitempty"`
+
+	// Specifies the minimum number of the latest completed backups to retain,
+	// regardless of the daily, weekly and monthly rules.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinCount int32 `json:"minCount,omitempty"`
+}
+
 // BackupScheduleStatus defines the observed state of BackupSchedule.
 type BackupScheduleStatus struct {
 	// Describes the phase of the BackupSchedule.
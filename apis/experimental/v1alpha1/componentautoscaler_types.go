@@ -0,0 +1,357 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ComponentAutoscalerSpec defines the desired state of ComponentAutoscaler
+//
+// +kubebuilder:validation:XValidation:rule="has(self.horizontal) || has(self.vertical)",message="at least one of horizontal and vertical should be specified"
+type ComponentAutoscalerSpec struct {
+	// Specifies the target Cluster name this autoscaler applies to.
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="targetClusterName is immutable"
+	TargetClusterName string `json:"targetClusterName"`
+
+	// Specifies the target Component or Sharding name within the Cluster this autoscaler applies to.
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="targetComponentName is immutable"
+	TargetComponentName string `json:"targetComponentName"`
+
+	// Indicates whether the targetComponentName refers to a Sharding.
+	// If true, the scaling applies to all shards of the Sharding.
+	//
+	// +kubebuilder:default=false
+	// +optional
+	Sharding bool `json:"sharding,omitempty"`
+
+	// Specifies the metrics used to calculate the desired scale.
+	// The metric that proposes the largest scale wins.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	// +listType=map
+	// +listMapKey=name
+	Metrics []AutoscalerMetric `json:"metrics"`
+
+	// Specifies the bounds of the horizontal scaling.
+	// Horizontal scaling is disabled if not specified.
+	//
+	// +optional
+	Horizontal *HorizontalAutoscalingPolicy `json:"horizontal,omitempty"`
+
+	// Specifies the bounds of the vertical scaling.
+	// Vertical scaling only takes resource metrics into account, and is performed when horizontal scaling is
+	// disabled or has reached its bounds.
+	//
+	// +optional
+	Vertical *VerticalAutoscalingPolicy `json:"vertical,omitempty"`
+
+	// Specifies the tolerance in percentage of the deviation between the current and the target metric values,
+	// within which no scaling is performed.
+	//
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	TolerancePercent *int32 `json:"tolerancePercent,omitempty"`
+
+	// Specifies the minimum interval in seconds between two scalings.
+	//
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	CooldownSeconds *int32 `json:"cooldownSeconds,omitempty"`
+
+	// Specifies the window in seconds during which the recommendations are considered when scaling down.
+	// The highest recommendation within the window is used, preventing flapping caused by fluctuating metrics.
+	//
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	StabilizationWindowSeconds *int32 `json:"stabilizationWindowSeconds,omitempty"`
+}
+
+// AutoscalerMetric defines a metric used by the ComponentAutoscaler.
+// Exactly one of the metric sources should be specified.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.resource) != has(self.probe)",message="exactly one of resource and probe should be specified"
+type AutoscalerMetric struct {
+	// Specifies the unique name of the metric.
+	Name string `json:"name"`
+
+	// Specifies a resource metric read from the metrics API, scaled by the utilization of the resource requests.
+	//
+	// +optional
+	Resource *ResourceMetricSource `json:"resource,omitempty"`
+
+	// Specifies a metric read from the output of a kbagent probe, scaled by the average value of all replicas.
+	//
+	// +optional
+	Probe *ProbeMetricSource `json:"probe,omitempty"`
+}
+
+// ResourceMetricSource defines a resource metric read from the metrics API.
+type ResourceMetricSource struct {
+	// Specifies the name of the resource.
+	//
+	// +kubebuilder:validation:Enum={cpu,memory}
+	Name corev1.ResourceName `json:"name"`
+
+	// Specifies the target average utilization of the resource, as a percentage of the requested resource.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	TargetUtilization int32 `json:"targetUtilization"`
+}
+
+// ProbeMetricSource defines a metric read from the output of a kbagent probe.
+type ProbeMetricSource struct {
+	// Specifies the name of the probe defined in the ComponentDefinition.
+	// The output of the probe should be a decimal number.
+	Probe string `json:"probe"`
+
+	// Specifies the target average value of the probe output across all replicas.
+	//
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	TargetValue string `json:"targetValue"`
+}
+
+// HorizontalAutoscalingPolicy defines the bounds of the horizontal scaling.
+type HorizontalAutoscalingPolicy struct {
+	// Specifies the minimum number of replicas.
+	// It is further bounded by the replicas limit defined in the ComponentDefinition.
+	//
+	// +kubebuilder:validation:Minimum=0
+	MinReplicas int32 `json:"minReplicas"`
+
+	// Specifies the maximum number of replicas.
+	// It is further bounded by the replicas limit defined in the ComponentDefinition.
+	//
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// Specifies the maximum number of replicas to add or remove in one scaling.
+	//
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxStep *int32 `json:"maxStep,omitempty"`
+}
+
+// VerticalAutoscalingPolicy defines the bounds of the vertical scaling.
+type VerticalAutoscalingPolicy struct {
+	// Specifies the minimum resource requests allowed.
+	//
+	// +optional
+	MinAllowed corev1.ResourceList `json:"minAllowed,omitempty"`
+
+	// Specifies the maximum resource requests allowed.
+	//
+	// +optional
+	MaxAllowed corev1.ResourceList `json:"maxAllowed,omitempty"`
+
+	// Specifies the maximum change of the resource requests in one scaling, as a percentage of the current requests.
+	// The resource limits are changed in proportion to the requests.
+	//
+	// +kubebuilder:default=50
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxStepPercent *int32 `json:"maxStepPercent,omitempty"`
+}
+
+// ComponentAutoscalerStatus defines the observed state of ComponentAutoscaler
+type ComponentAutoscalerStatus struct {
+	// The most recent generation observed by the autoscaler.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// The current number of replicas of the target.
+	//
+	// +optional
+	CurrentReplicas int32 `json:"currentReplicas,omitempty"`
+
+	// The desired number of replicas of the target, as last calculated by the autoscaler.
+	//
+	// +optional
+	DesiredReplicas int32 `json:"desiredReplicas,omitempty"`
+
+	// Records the latest values of the metrics.
+	//
+	// +optional
+	CurrentMetrics []AutoscalerMetricStatus `json:"currentMetrics,omitempty"`
+
+	// Records the recommendations made within the stabilization window.
+	//
+	// +optional
+	Recommendations []AutoscalerRecommendation `json:"recommendations,omitempty"`
+
+	// The name of the OpsRequest being applied for the latest scaling.
+	//
+	// +optional
+	OpsRequestName string `json:"opsRequestName,omitempty"`
+
+	// LastScaleTime is the last time the ComponentAutoscaler scaled the target.
+	//
+	// +optional
+	LastScaleTime metav1.Time `json:"lastScaleTime,omitempty"`
+
+	// Records the most recent scalings, the latest one last.
+	//
+	// +optional
+	History []AutoscalerScalingRecord `json:"history,omitempty"`
+
+	// Represents the latest available observations of a componentautoscaler's current state.
+	// Known .status.conditions.type are: "ScalingActive".
+	// ScalingActive - The autoscaler is able to read the metrics and scale the target.
+	//
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// AutoscalerMetricStatus records the latest value of a metric.
+type AutoscalerMetricStatus struct {
+	// Specifies the name of the metric.
+	Name string `json:"name"`
+
+	// The current value of the metric.
+	// For resource metrics, it is the average utilization in percentage.
+	//
+	// +optional
+	CurrentValue string `json:"currentValue,omitempty"`
+
+	// The target value of the metric.
+	//
+	// +optional
+	TargetValue string `json:"targetValue,omitempty"`
+
+	// The reason why the metric is unavailable.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// AutoscalerRecommendation records a scale recommended by the autoscaler.
+type AutoscalerRecommendation struct {
+	// The time when the recommendation is made.
+	Timestamp metav1.Time `json:"timestamp"`
+
+	// The recommended number of replicas.
+	//
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// The recommended resource requests.
+	//
+	// +optional
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+}
+
+// AutoscalerScalingRecord records a scaling performed by the autoscaler.
+type AutoscalerScalingRecord struct {
+	// The time when the scaling is performed.
+	Timestamp metav1.Time `json:"timestamp"`
+
+	// The name of the OpsRequest that applies the scaling.
+	OpsRequestName string `json:"opsRequestName"`
+
+	// The number of replicas before the scaling.
+	//
+	// +optional
+	FromReplicas *int32 `json:"fromReplicas,omitempty"`
+
+	// The number of replicas after the scaling.
+	//
+	// +optional
+	ToReplicas *int32 `json:"toReplicas,omitempty"`
+
+	// The resource requests before the scaling.
+	//
+	// +optional
+	FromRequests corev1.ResourceList `json:"fromRequests,omitempty"`
+
+	// The resource requests after the scaling.
+	//
+	// +optional
+	ToRequests corev1.ResourceList `json:"toRequests,omitempty"`
+
+	// The reason of the scaling.
+	//
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+const (
+	// ScalingActive is added to a componentautoscaler when it is able to read the metrics and scale the target.
+	ScalingActive ConditionType = "ScalingActive"
+)
+
+const (
+	// ReasonMetricsAvailable is a reason for condition ScalingActive.
+	ReasonMetricsAvailable = "MetricsAvailable"
+
+	// ReasonMetricsUnavailable is a reason for condition ScalingActive.
+	ReasonMetricsUnavailable = "MetricsUnavailable"
+
+	// ReasonTargetNotFound is a reason for condition ScalingActive.
+	ReasonTargetNotFound = "TargetNotFound"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kubeblocks},shortName=cas
+// +kubebuilder:printcolumn:name="TARGET-CLUSTER-NAME",type="string",JSONPath=".spec.targetClusterName",description="target cluster name."
+// +kubebuilder:printcolumn:name="TARGET-COMPONENT-NAME",type="string",JSONPath=".spec.targetComponentName",description="target component name."
+// +kubebuilder:printcolumn:name="CURRENT-REPLICAS",type="integer",JSONPath=".status.currentReplicas",description="current replicas."
+// +kubebuilder:printcolumn:name="DESIRED-REPLICAS",type="integer",JSONPath=".status.desiredReplicas",description="desired replicas."
+// +kubebuilder:printcolumn:name="ACTIVE",type="string",JSONPath=".status.conditions[?(@.type==\"ScalingActive\")].status",description="scaling active."
+// +kubebuilder:printcolumn:name="LAST-SCALE-TIME",type="date",JSONPath=".status.lastScaleTime"
+
+// ComponentAutoscaler is the Schema for the componentautoscalers API
+type ComponentAutoscaler struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ComponentAutoscalerSpec   `json:"spec,omitempty"`
+	Status ComponentAutoscalerStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ComponentAutoscalerList contains a list of ComponentAutoscaler
+type ComponentAutoscalerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ComponentAutoscaler `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ComponentAutoscaler{}, &ComponentAutoscalerList{})
+}
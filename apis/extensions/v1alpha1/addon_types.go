@@ -76,6 +76,21 @@ type AddonSpec struct {
 	// +optional
 	Installable *InstallableSpec `json:"installable,omitempty"`
 
+	// Specifies the other add-ons that this add-on depends on.
+	//
+	// The add-on is installed or upgraded only after all of its dependencies are enabled with a matched version,
+	// and an enabled add-on can't be disabled or deleted while other enabled add-ons depend on it.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Dependencies []AddonDependency `json:"dependencies,omitempty"`
+
+	// Specifies the requirements checked before upgrading the add-on to this version.
+	//
+	// +optional
+	Upgrade *AddonUpgradeSpec `json:"upgrade,omitempty"`
+
 	// Specifies the CLI plugin installation specifications.
 	//
 	// +optional
@@ -95,6 +110,25 @@ type AddonStatus struct {
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
+	// Records the dependencies of the add-on, as resolved against the installed add-ons
+	// when the add-on was last installed or upgraded.
+	//
+	// +optional
+	Dependencies []AddonDependencyStatus `json:"dependencies,omitempty"`
+
+	// Records the history of the versions and values applied to the add-on, with the latest one at the end.
+	// At most 10 revisions are kept.
+	//
+	// The add-on can be rolled back to the last revision succeeded before the latest one by annotating it
+	// with "extensions.kubeblocks.io/rollback=true".
+	// The rollback restores the version and values of the revision to the spec of the add-on and applies them
+	// by upgrading the Helm release, instead of `helm rollback`. So the chart of the revision should still be
+	// available, and the rollback is recorded as a new revision.
+	// A failed installation is recorded once, until the version or values are changed.
+	//
+	// +optional
+	History []AddonRevision `json:"history,omitempty"`
+
 	// Represents the most recent generation observed for this add-on. It corresponds
 	// to the add-on's generation, which is updated on mutation by the API Server.
 	//
@@ -102,6 +136,121 @@ type AddonStatus struct {
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
+// AddonDependency defines an add-on that another add-on depends on.
+type AddonDependency struct {
+	// Specifies the name of the add-on depended on.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the semver constraint of the version of the add-on depended on, i.e., ">= 1.0".
+	// If not specified, any version is accepted.
+	//
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
+// AddonDependencyStatus defines the resolved state of an add-on dependency.
+type AddonDependencyStatus struct {
+	// The name of the add-on depended on.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// The semver constraint of the version of the add-on depended on.
+	//
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// The version of the add-on depended on that is installed.
+	//
+	// +optional
+	ResolvedVersion string `json:"resolvedVersion,omitempty"`
+
+	// The phase of the add-on depended on.
+	//
+	// +optional
+	Phase AddonPhase `json:"phase,omitempty"`
+
+	// Indicates whether the dependency is satisfied, that is, the add-on depended on is enabled
+	// and its version matches the constraint.
+	Satisfied bool `json:"satisfied"`
+
+	// A human-readable message explaining why the dependency is not satisfied.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// AddonUpgradeSpec defines the requirements checked before upgrading an add-on to a higher version.
+type AddonUpgradeSpec struct {
+	// Specifies the semver constraint of the installed versions that can be upgraded to this version directly, i.e., ">= 0.9.0".
+	// If not specified, upgrading from any version is allowed.
+	//
+	// +optional
+	FromVersion string `json:"fromVersion,omitempty"`
+
+	// Specifies the service versions supported by this version of the add-on.
+	//
+	// The upgrade is refused if any Component in use runs a service version not listed here,
+	// where the Components are those referencing the ComponentDefinitions provided by the add-on,
+	// either directly or through the compatibility rules of the ComponentVersions provided by the add-on.
+	//
+	// If not specified, the service versions are not checked.
+	//
+	// +optional
+	ServiceVersions []string `json:"serviceVersions,omitempty"`
+}
+
+// AddonRevisionPhase defines the result of applying an add-on revision.
+//
+// +enum
+// +kubebuilder:validation:Enum={Succeeded,Failed}
+type AddonRevisionPhase string
+
+const (
+	AddonRevisionSucceeded AddonRevisionPhase = "Succeeded"
+	AddonRevisionFailed    AddonRevisionPhase = "Failed"
+)
+
+// AddonRevision records a version and the values applied to an add-on.
+type AddonRevision struct {
+	// The sequence number of the revision, starting from 1.
+	//
+	// +kubebuilder:validation:Required
+	Revision int64 `json:"revision"`
+
+	// The version of the add-on applied.
+	//
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// The Helm installation specifications applied.
+	//
+	// +optional
+	Helm *HelmTypeInstallSpec `json:"helm,omitempty"`
+
+	// The installation parameters applied.
+	//
+	// +optional
+	InstallSpec *AddonInstallSpec `json:"install,omitempty"`
+
+	// The result of applying the revision.
+	//
+	// +kubebuilder:validation:Required
+	Phase AddonRevisionPhase `json:"phase"`
+
+	// The time when the revision was applied.
+	//
+	// +optional
+	AppliedTime metav1.Time `json:"appliedTime,omitempty"`
+
+	// A human-readable message about the revision, such as the reason of the failure.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 type InstallableSpec struct {
 	// Specifies the selectors for add-on installation. If multiple selectors are provided,
 	// they must all evaluate to true for the add-on to be installed.
@@ -75,6 +75,8 @@ const (
 	ConditionTypeChecked     = "InstallableChecked"
 	ConditionTypeSucceed     = "Succeed"
 	ConditionTypeFailed      = "Failed"
+
+	ConditionTypeDependenciesSatisfied = "DependenciesSatisfied"
 )
 
 // SetKubeServerVersion provides "_KUBE_SERVER_INFO" viper settings helper function.
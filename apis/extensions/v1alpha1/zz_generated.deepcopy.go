@@ -79,6 +79,36 @@ func (in *AddonDefaultInstallSpecItem) DeepCopy() *AddonDefaultInstallSpecItem {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonDependency) DeepCopyInto(out *AddonDependency) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonDependency.
+func (in *AddonDependency) DeepCopy() *AddonDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonDependencyStatus) DeepCopyInto(out *AddonDependencyStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonDependencyStatus.
+func (in *AddonDependencyStatus) DeepCopy() *AddonDependencyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonDependencyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddonInstallExtraItem) DeepCopyInto(out *AddonInstallExtraItem) {
 	*out = *in
@@ -176,6 +206,32 @@ func (in *AddonList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonRevision) DeepCopyInto(out *AddonRevision) {
+	*out = *in
+	if in.Helm != nil {
+		in, out := &in.Helm, &out.Helm
+		*out = new(HelmTypeInstallSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InstallSpec != nil {
+		in, out := &in.InstallSpec, &out.InstallSpec
+		*out = new(AddonInstallSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.AppliedTime.DeepCopyInto(&out.AppliedTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonRevision.
+func (in *AddonRevision) DeepCopy() *AddonRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddonSpec) DeepCopyInto(out *AddonSpec) {
 	*out = *in
@@ -201,6 +257,16 @@ func (in *AddonSpec) DeepCopyInto(out *AddonSpec) {
 		*out = new(InstallableSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make([]AddonDependency, len(*in))
+		copy(*out, *in)
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(AddonUpgradeSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CliPlugins != nil {
 		in, out := &in.CliPlugins, &out.CliPlugins
 		*out = make([]CliPlugin, len(*in))
@@ -228,6 +294,18 @@ func (in *AddonStatus) DeepCopyInto(out *AddonStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make([]AddonDependencyStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]AddonRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonStatus.
@@ -240,6 +318,26 @@ func (in *AddonStatus) DeepCopy() *AddonStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonUpgradeSpec) DeepCopyInto(out *AddonUpgradeSpec) {
+	*out = *in
+	if in.ServiceVersions != nil {
+		in, out := &in.ServiceVersions, &out.ServiceVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonUpgradeSpec.
+func (in *AddonUpgradeSpec) DeepCopy() *AddonUpgradeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonUpgradeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CliPlugin) DeepCopyInto(out *CliPlugin) {
 	*out = *in
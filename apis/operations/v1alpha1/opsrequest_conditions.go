@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -47,17 +48,25 @@ const (
 	ConditionTypeBackup             = "Backup"
 	ConditionTypeInstanceRebuilding = "InstancesRebuilding"
 	ConditionTypeCustomOperation    = "CustomOperation"
+	ConditionTypePipeline           = "Pipeline"
+	ConditionTypeReadonlySwitch     = "ReadonlySwitch"
+	ConditionTypeMaintenanceWindow  = "WaitForMaintenanceWindow"
+	ConditionTypeRollingBack        = "RollingBack"
 
 	// condition and event reasons
-	ReasonClusterPhaseMismatch  = "ClusterPhaseMismatch"
-	ReasonOpsTypeNotSupported   = "OpsTypeNotSupported"
-	ReasonValidateFailed        = "ValidateFailed"
-	ReasonClusterNotFound       = "ClusterNotFound"
-	ReasonOpsRequestFailed      = "OpsRequestFailed"
-	ReasonOpsCanceling          = "Canceling"
-	ReasonOpsCancelFailed       = "CancelFailed"
-	ReasonOpsCancelSucceed      = "CancelSucceed"
-	ReasonOpsCancelByController = "CancelByController"
+	ReasonClusterPhaseMismatch   = "ClusterPhaseMismatch"
+	ReasonOpsTypeNotSupported    = "OpsTypeNotSupported"
+	ReasonValidateFailed         = "ValidateFailed"
+	ReasonClusterNotFound        = "ClusterNotFound"
+	ReasonOpsRequestFailed       = "OpsRequestFailed"
+	ReasonOpsCanceling           = "Canceling"
+	ReasonOpsCancelFailed        = "CancelFailed"
+	ReasonOpsCancelSucceed       = "CancelSucceed"
+	ReasonOpsCancelByController  = "CancelByController"
+	ReasonOutOfMaintenanceWindow = "OutOfMaintenanceWindow"
+	ReasonInMaintenanceWindow    = "InMaintenanceWindow"
+	ReasonRollbackSucceed        = "RollbackSucceed"
+	ReasonRollbackFailed         = "RollbackFailed"
 )
 
 func (r *OpsRequest) SetStatusCondition(condition metav1.Condition) {
@@ -76,6 +85,61 @@ func NewWaitForProcessingCondition(ops *OpsRequest) *metav1.Condition {
 	}
 }
 
+// NewWaitForMaintenanceWindowCondition creates a condition that the OpsRequest is waiting for the maintenance window of the Cluster.
+func NewWaitForMaintenanceWindowCondition(ops *OpsRequest, nextWindowStart *metav1.Time) *metav1.Condition {
+	message := fmt.Sprintf(`wait for the maintenance window of Cluster: "%s" to start the OpsRequest: "%s"`,
+		ops.Spec.GetClusterName(), ops.Name)
+	if nextWindowStart != nil {
+		message = fmt.Sprintf("%s, the next window starts at %s", message, nextWindowStart.UTC().Format(time.RFC3339))
+	}
+	return &metav1.Condition{
+		Type:               ConditionTypeMaintenanceWindow,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonOutOfMaintenanceWindow,
+		LastTransitionTime: metav1.Now(),
+		Message:            message,
+	}
+}
+
+// NewInMaintenanceWindowCondition creates a condition that the maintenance window of the Cluster has started.
+func NewInMaintenanceWindowCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeMaintenanceWindow,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonInMaintenanceWindow,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf(`the maintenance window of Cluster: "%s" has started`,
+			ops.Spec.GetClusterName()),
+	}
+}
+
+// NewRollingBackCondition creates a condition that the OpsRequest is rolling back automatically.
+func NewRollingBackCondition(ops *OpsRequest, message string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeRollingBack,
+		Status:             metav1.ConditionTrue,
+		Reason:             ConditionTypeRollingBack,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf(`Start to roll back the OpsRequest "%s" in Cluster: "%s": %s`,
+			ops.Name, ops.Spec.GetClusterName(), message),
+	}
+}
+
+// NewRollbackCompletedCondition creates a condition that the automatic rollback of the OpsRequest is completed.
+func NewRollbackCompletedCondition(phase RollbackPhase, message string) *metav1.Condition {
+	reason := ReasonRollbackSucceed
+	if phase == RollbackFailedPhase {
+		reason = ReasonRollbackFailed
+	}
+	return &metav1.Condition{
+		Type:               ConditionTypeRollingBack,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		LastTransitionTime: metav1.Now(),
+		Message:            message,
+	}
+}
+
 // NewCancelingCondition the controller is canceling the OpsRequest
 func NewCancelingCondition(ops *OpsRequest) *metav1.Condition {
 	return &metav1.Condition{
@@ -365,3 +429,25 @@ func NewRestoreCondition(ops *OpsRequest) *metav1.Condition {
 		Message:            fmt.Sprintf("Start to restore the Cluster: %s", ops.Spec.GetClusterName()),
 	}
 }
+
+// NewPipelineCondition creates a condition that the OpsRequest starts the pipeline.
+func NewPipelineCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypePipeline,
+		Status:             metav1.ConditionTrue,
+		Reason:             "PipelineStarted",
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("Start to run the pipeline on the Cluster: %s", ops.Spec.GetClusterName()),
+	}
+}
+
+// NewReadonlySwitchCondition creates a condition that the OpsRequest starts to switch the read-only state of replicas.
+func NewReadonlySwitchCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeReadonlySwitch,
+		Status:             metav1.ConditionTrue,
+		Reason:             "ReadonlySwitchStarted",
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("Start to switch the read-only state of replicas in Cluster: %s", ops.Spec.GetClusterName()),
+	}
+}
@@ -28,7 +28,7 @@ import (
 
 // OpsRequestSpec defines the desired state of OpsRequest
 //
-// +kubebuilder:validation:XValidation:rule="has(self.cancel) && self.cancel ? (self.type in ['VerticalScaling', 'HorizontalScaling']) : true",message="forbidden to cancel the opsRequest which type not in ['VerticalScaling','HorizontalScaling']"
+// +kubebuilder:validation:XValidation:rule="has(self.cancel) && self.cancel ? (self.type in ['VerticalScaling', 'HorizontalScaling', 'Pipeline']) : true",message="forbidden to cancel the opsRequest which type not in ['VerticalScaling','HorizontalScaling','Pipeline']"
 type OpsRequestSpec struct {
 	// Specifies the name of the Cluster resource that this operation is targeting.
 	//
@@ -38,7 +38,7 @@ type OpsRequestSpec struct {
 	// Indicates whether the current operation should be canceled and terminated gracefully if it's in the
 	// "Pending", "Creating", or "Running" state.
 	//
-	// This field applies only to "VerticalScaling" and "HorizontalScaling" opsRequests.
+	// This field applies only to "VerticalScaling", "HorizontalScaling" and "Pipeline" opsRequests.
 	//
 	// Note: Setting `cancel` to true is irreversible; further modifications to this field are ineffective.
 	//
@@ -64,9 +64,18 @@ type OpsRequestSpec struct {
 	// +optional
 	EnqueueOnForce bool `json:"enqueueOnForce,omitempty"`
 
+	// Indicates whether the opsRequest can be started outside the maintenance windows of the Cluster.
+	//
+	// By default, disruptive operations, such as 'Restart', 'VerticalScaling' and 'Upgrade', stay in the 'Pending' phase
+	// until the Cluster enters one of its maintenance windows. Set this to true for emergency operations that must be
+	// started immediately.
+	//
+	// +optional
+	BypassMaintenanceWindow bool `json:"bypassMaintenanceWindow,omitempty"`
+
 	// Specifies the type of this operation. Supported types include "Start", "Stop", "Restart", "Switchover",
 	// "VerticalScaling", "HorizontalScaling", "VolumeExpansion", "Reconfiguring", "Upgrade", "Backup", "Restore",
-	// "Expose", "RebuildInstance", "Custom".
+	// "Expose", "RebuildInstance", "Custom", "Pipeline", "ReadonlySwitch".
 	//
 	// Note: This field is immutable once set.
 	//
@@ -226,10 +235,28 @@ type SpecificOpsRequest struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.rebuildFrom"
 	RebuildFrom []RebuildInstance `json:"rebuildFrom,omitempty"  patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
 
+	// Lists ReadonlySwitch objects, each specifying a Component whose replicas are switched into the read-only state,
+	// or back to the read-write state.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.readonlySwitch"
+	// +kubebuilder:validation:MaxItems=1024
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	ReadonlySwitchList []ReadonlySwitch `json:"readonlySwitch,omitempty"  patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
 	// Specifies a custom operation defined by OpsDefinition.
 	//
 	// +optional
 	CustomOps *CustomOps `json:"custom,omitempty"`
+
+	// Specifies a set of operations to be executed in order as a pipeline.
+	// Each step of the pipeline is executed by a child OpsRequest owned by this OpsRequest.
+	//
+	// +optional
+	Pipeline *Pipeline `json:"pipeline,omitempty"`
 }
 
 // ComponentOps specifies the Component to be operated on.
@@ -321,6 +348,30 @@ type Switchover struct {
 	CandidateName string `json:"candidateName,omitempty"`
 }
 
+// ReadonlySwitch defines the parameters to switch the replicas of a Component into the read-only state,
+// or back to the read-write state, by the `readonly` and `readwrite` lifecycle actions.
+type ReadonlySwitch struct {
+	// Specifies the name of the Component.
+	ComponentOps `json:",inline"`
+
+	// Specifies whether to switch the replicas into the read-only state.
+	// If false, the replicas are switched back to the read-write state.
+	//
+	// Replicas switched into the read-only state by this operation stay read-only until they are switched back
+	// by another "ReadonlySwitch" OpsRequest.
+	// Replicas switched back to the read-write state may be switched into the read-only state again
+	// if the usage of their volumes still exceeds the high watermark.
+	//
+	// +kubebuilder:validation:Required
+	Readonly bool `json:"readonly"`
+
+	// Specifies the names of the instances (Pods) to switch.
+	// If not specified, all replicas of the Component are switched.
+	//
+	// +optional
+	InstanceNames []string `json:"instanceNames,omitempty"`
+}
+
 // Upgrade defines the parameters for an upgrade operation.
 type Upgrade struct {
 	// Lists components to be upgrade based on desired ComponentDefinition and ServiceVersion.
@@ -336,6 +387,16 @@ type Upgrade struct {
 	// +kubebuilder:validation:MaxItems=1024
 	// +optional
 	Components []UpgradeComponent `json:"components,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
+	// Specifies the policy to roll back the upgrade when it fails or times out.
+	//
+	// - `None`: the upgrade is not rolled back, the components may be left with mixed service versions.
+	// - `Auto`: the ComponentDefinition and ServiceVersion of the components are reverted to `status.lastConfiguration`,
+	// and the instances are driven back to them. The outcome is recorded in `status.rollback`.
+	//
+	// +kubebuilder:default=None
+	// +optional
+	RollbackPolicy RollbackPolicy `json:"rollbackPolicy,omitempty"`
 }
 
 // +kubebuilder:validation:XValidation:rule="has(self.componentDefinitionName) || has(self.serviceVersion)",message="at least one componentDefinitionName or serviceVersion"
@@ -926,6 +987,105 @@ type Restore struct {
 	Parameters []dpv1alpha1.ParameterPair `json:"parameters,omitempty"`
 }
 
+// Pipeline defines a directed acyclic graph of operations to be performed on the Cluster.
+type Pipeline struct {
+	// Specifies the steps of the pipeline.
+	//
+	// Steps without dependencies start immediately, and other steps start once all the steps
+	// they depend on have finished.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	// +listType=map
+	// +listMapKey=name
+	Steps []PipelineStep `json:"steps"`
+}
+
+type PipelineStep struct {
+	// Specifies the name of the step, which must be unique within the pipeline.
+	// It is also used as the suffix of the name of the child OpsRequest.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([a-z0-9\-]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Specifies the names of the steps that must be finished before this step starts.
+	//
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// Specifies the conditions that must be met before this step starts.
+	//
+	// +optional
+	Precondition *PipelineStepPrecondition `json:"precondition,omitempty"`
+
+	// Specifies how the pipeline handles the failure of this step. Supported values:
+	//
+	// - `Abort`: no new steps are started, the steps that have not been started are skipped,
+	//   and the pipeline fails once the running steps have finished.
+	// - `Continue`: the failure is ignored, and the steps that depend on this step are started as usual.
+	// - `Rollback`: same as `Abort`, and the succeeded steps are then rolled back in reverse order of completion
+	//   by running their `rollback` operations.
+	//
+	// +kubebuilder:default=Abort
+	// +optional
+	FailurePolicy PipelineStepFailurePolicy `json:"failurePolicy,omitempty"`
+
+	// Specifies the maximum duration in seconds that this step is allowed to run.
+	// It is set as the `timeoutSeconds` of the child OpsRequest.
+	// If not set or set to 0, the step will run indefinitely.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// Specifies the operation performed by this step.
+	PipelineStepOps `json:",inline"`
+
+	// Specifies the operation performed to roll back this step when the pipeline is rolled back.
+	// If not set, this step will be left as it is during rollback.
+	//
+	// +optional
+	Rollback *PipelineStepOps `json:"rollback,omitempty"`
+}
+
+// PipelineStepOps defines the operation performed by a pipeline step.
+type PipelineStepOps struct {
+	// Specifies the type of the operation.
+	// The "Restore" and "Pipeline" operations are not supported in a pipeline.
+	//
+	// +kubebuilder:validation:Required
+	Type OpsType `json:"type"`
+
+	// Specifies the type-specific parameters of the operation, such as `restart` or `upgrade`,
+	// which share the same schema with the corresponding fields of the OpsRequest spec.
+	// They are validated in the same way as the child OpsRequest of the step before the pipeline starts.
+	//
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Type=object
+	// +optional
+	Ops *SpecificOpsRequest `json:"ops,omitempty"`
+}
+
+// PipelineStepPrecondition defines the conditions that must be met before a pipeline step starts.
+type PipelineStepPrecondition struct {
+	// Specifies the phases that the Cluster must be in before the step starts.
+	//
+	// +optional
+	ClusterPhases []appsv1.ClusterPhase `json:"clusterPhases,omitempty"`
+
+	// Specifies the maximum time in seconds that the step waits for its precondition to be met.
+	// If the precondition is still not met after this period, the step fails.
+	// If not set or set to 0, the step waits indefinitely.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	DeadlineSeconds *int32 `json:"deadlineSeconds,omitempty"`
+}
+
 // OpsRequestStatus represents the observed state of an OpsRequest.
 type OpsRequestStatus struct {
 	// Records the cluster generation after the OpsRequest action has been handled.
@@ -949,6 +1109,14 @@ type OpsRequestStatus struct {
 	// +optional
 	Components map[string]OpsRequestComponentStatus `json:"components,omitempty"`
 
+	// Records the status of each step of the pipeline when `spec.type` is "Pipeline".
+	// +optional
+	PipelineSteps []PipelineStepStatus `json:"pipelineSteps,omitempty"`
+
+	// Records the automatic rollback of the OpsRequest, it is set once the rollback is triggered.
+	// +optional
+	Rollback *OpsRollbackStatus `json:"rollback,omitempty"`
+
 	// A collection of additional key-value pairs that provide supplementary information for the OpsRequest.
 	Extras []map[string]string `json:"extras,omitempty"`
 
@@ -967,7 +1135,7 @@ type OpsRequestStatus struct {
 	// Describes the detailed status of the OpsRequest.
 	// Possible condition types include "Cancelled", "WaitForProgressing", "Validated", "Succeed", "Failed", "Restarting",
 	// "VerticalScaling", "HorizontalScaling", "VolumeExpanding", "Reconfigure", "Switchover", "Stopping", "Starting",
-	// "VersionUpgrading", "Exposing", "Backup", "InstancesRebuilding", "CustomOperation".
+	// "VersionUpgrading", "Exposing", "Backup", "InstancesRebuilding", "CustomOperation", "Pipeline", "ReadonlySwitch".
 	// +optional
 	// +patchMergeKey=type
 	// +patchStrategy=merge
@@ -976,6 +1144,61 @@ type OpsRequestStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+type PipelineStepStatus struct {
+	// Specifies the name of the step.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Represents the phase of the step, including "Pending", "Running", "Succeed", "Failed", "Skipped",
+	// "RollingBack" and "RolledBack".
+	// +kubebuilder:validation:Required
+	Phase PipelineStepPhase `json:"phase"`
+
+	// Records the name of the child OpsRequest that performs the step.
+	// +optional
+	OpsRequestName string `json:"opsRequestName,omitempty"`
+
+	// Records the name of the child OpsRequest that rolls back the step.
+	// +optional
+	RollbackOpsRequestName string `json:"rollbackOpsRequestName,omitempty"`
+
+	// Records the time when the step became ready to start, including the time spent waiting for its precondition.
+	// +optional
+	StartTimestamp metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Records the time when the step was completed.
+	// +optional
+	CompletionTimestamp metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// Provides a human-readable message about the step.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// OpsRollbackStatus records the automatic rollback of an OpsRequest.
+type OpsRollbackStatus struct {
+	// Records the phase that the OpsRequest would have entered without the rollback, "Failed" or "Aborted".
+	// The OpsRequest enters this phase after the rollback is completed.
+	// +kubebuilder:validation:Required
+	TriggeredPhase OpsPhase `json:"triggeredPhase"`
+
+	// Represents the phase of the rollback, including "Running", "Succeed" and "Failed".
+	// +kubebuilder:validation:Required
+	Phase RollbackPhase `json:"phase"`
+
+	// Records the time when the rollback was triggered.
+	// +optional
+	StartTimestamp metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Records the time when the rollback was completed.
+	// +optional
+	CompletionTimestamp metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// Provides a human-readable message about why the rollback was triggered and its outcome.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 // +kubebuilder:validation:XValidation:rule="has(self.objectKey) || has(self.actionName)", message="at least one objectKey or actionName."
 
 type ProgressStatusDetail struct {
@@ -1203,6 +1426,14 @@ func (r OpsRequestSpec) GetRestore() *Restore {
 	return r.Restore
 }
 
+// GetRollbackPolicy returns the rollback policy of the operation, only "Upgrade" supports the rollback policy now.
+func (r OpsRequestSpec) GetRollbackPolicy() RollbackPolicy {
+	if r.Type == UpgradeType && r.Upgrade != nil {
+		return r.Upgrade.RollbackPolicy
+	}
+	return NoneRollbackPolicy
+}
+
 func (p *ProgressStatusDetail) SetStatusAndMessage(status ProgressStatus, message string) {
 	p.Message = message
 	p.Status = status
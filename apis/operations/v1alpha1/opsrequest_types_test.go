@@ -17,7 +17,10 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
 	"testing"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 )
 
 var componentName = "mysql"
@@ -52,3 +55,60 @@ func TestSetStatusAndMessage(t *testing.T) {
 		t.Error("set progressDetail status and message failed")
 	}
 }
+
+func TestValidatePipeline(t *testing.T) {
+	cluster := &appsv1.Cluster{
+		Spec: appsv1.ClusterSpec{
+			ComponentSpecs: []appsv1.ClusterComponentSpec{{Name: componentName}},
+		},
+	}
+	newPipelineOps := func(steps ...PipelineStep) *OpsRequest {
+		ops := &OpsRequest{}
+		ops.Spec.Type = PipelineType
+		ops.Spec.Pipeline = &Pipeline{Steps: steps}
+		return ops
+	}
+	// stepSpecs are the valid type-specific specs of the steps
+	stepSpecs := map[OpsType]*SpecificOpsRequest{
+		UpgradeType:       {Upgrade: &Upgrade{Components: []UpgradeComponent{{ComponentOps: ComponentOps{ComponentName: componentName}}}}},
+		ReconfiguringType: {Reconfigures: []Reconfigure{{ComponentOps: ComponentOps{ComponentName: componentName}}}},
+		RestartType:       {RestartList: []ComponentOps{{ComponentName: componentName}}},
+	}
+	stepWithSpec := func(name string, opsType OpsType, spec *SpecificOpsRequest, dependsOn ...string) PipelineStep {
+		return PipelineStep{Name: name, DependsOn: dependsOn, PipelineStepOps: PipelineStepOps{Type: opsType, Ops: spec}}
+	}
+	step := func(name string, opsType OpsType, dependsOn ...string) PipelineStep {
+		return stepWithSpec(name, opsType, stepSpecs[opsType], dependsOn...)
+	}
+	withRollback := func(step PipelineStep, rollback PipelineStepOps) PipelineStep {
+		step.Rollback = &rollback
+		return step
+	}
+	tests := []struct {
+		name    string
+		ops     *OpsRequest
+		wantErr bool
+	}{
+		{"empty steps", newPipelineOps(), true},
+		{"valid dag", newPipelineOps(step("upgrade", UpgradeType), step("reconfigure", ReconfiguringType, "upgrade"),
+			step("restart", RestartType, "upgrade", "reconfigure")), false},
+		{"duplicated step", newPipelineOps(step("restart", RestartType), step("restart", RestartType)), true},
+		{"unsupported type", newPipelineOps(step("restore", RestoreType)), true},
+		{"nonexistent dependency", newPipelineOps(step("restart", RestartType, "upgrade")), true},
+		{"cycle", newPipelineOps(step("a", RestartType, "c"), step("b", RestartType, "a"), step("c", RestartType, "b")), true},
+		{"missing spec", newPipelineOps(stepWithSpec("upgrade", UpgradeType, nil)), true},
+		{"missing reconfigures", newPipelineOps(stepWithSpec("reconfigure", ReconfiguringType, nil)), true},
+		{"spec of another type", newPipelineOps(stepWithSpec("upgrade", UpgradeType, stepSpecs[RestartType])), true},
+		{"malformed spec", newPipelineOps(stepWithSpec("upgrade", UpgradeType, &SpecificOpsRequest{Upgrade: &Upgrade{}})), true},
+		{"nonexistent component", newPipelineOps(stepWithSpec("restart", RestartType,
+			&SpecificOpsRequest{RestartList: []ComponentOps{{ComponentName: "unknown"}}})), true},
+		{"invalid rollback", newPipelineOps(withRollback(step("restart", RestartType), PipelineStepOps{Type: UpgradeType})), true},
+		{"valid rollback", newPipelineOps(withRollback(step("restart", RestartType),
+			PipelineStepOps{Type: RestartType, Ops: stepSpecs[RestartType]})), false},
+	}
+	for _, tt := range tests {
+		if err := tt.ops.validatePipeline(context.Background(), nil, cluster); (err != nil) != tt.wantErr {
+			t.Errorf("%s: validatePipeline() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
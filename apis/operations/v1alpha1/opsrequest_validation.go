@@ -145,6 +145,10 @@ func (r *OpsRequest) ValidateOps(ctx context.Context,
 		return r.validateExpose(ctx, cluster)
 	case RebuildInstanceType:
 		return r.validateRebuildInstance(cluster)
+	case PipelineType:
+		return r.validatePipeline(ctx, k8sClient, cluster)
+	case ReadonlySwitchType:
+		return r.validateReadonlySwitch(cluster)
 	}
 	return nil
 }
@@ -191,6 +195,95 @@ func (r *OpsRequest) validateRebuildInstance(cluster *appsv1.Cluster) error {
 	return r.checkComponentExistence(cluster, compOpsList)
 }
 
+// validatePipeline validates spec.pipeline, the steps must form a directed acyclic graph,
+// and the operation of each step is validated as the child OpsRequest that runs it.
+func (r *OpsRequest) validatePipeline(ctx context.Context, k8sClient client.Client, cluster *appsv1.Cluster) error {
+	pipeline := r.Spec.Pipeline
+	if pipeline == nil || len(pipeline.Steps) == 0 {
+		return notEmptyError("spec.pipeline.steps")
+	}
+	steps := map[string]PipelineStep{}
+	for _, step := range pipeline.Steps {
+		if _, ok := steps[step.Name]; ok {
+			return fmt.Errorf(`duplicated pipeline step "%s"`, step.Name)
+		}
+		steps[step.Name] = step
+		if err := r.validatePipelineStepOps(ctx, k8sClient, cluster, step.PipelineStepOps); err != nil {
+			return fmt.Errorf(`invalid pipeline step "%s": %w`, step.Name, err)
+		}
+		if step.Rollback != nil {
+			if err := r.validatePipelineStepOps(ctx, k8sClient, cluster, *step.Rollback); err != nil {
+				return fmt.Errorf(`invalid rollback of pipeline step "%s": %w`, step.Name, err)
+			}
+		}
+	}
+	// check the dependencies and detect cycles by topological sorting.
+	inDegrees := map[string]int{}
+	dependents := map[string][]string{}
+	for _, step := range pipeline.Steps {
+		for _, dep := range step.DependsOn {
+			if _, ok := steps[dep]; !ok {
+				return fmt.Errorf(`pipeline step "%s" depends on a nonexistent step "%s"`, step.Name, dep)
+			}
+			inDegrees[step.Name]++
+			dependents[dep] = append(dependents[dep], step.Name)
+		}
+	}
+	var queue []string
+	for _, step := range pipeline.Steps {
+		if inDegrees[step.Name] == 0 {
+			queue = append(queue, step.Name)
+		}
+	}
+	sortedCount := 0
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		sortedCount++
+		for _, dependent := range dependents[name] {
+			inDegrees[dependent]--
+			if inDegrees[dependent] == 0 {
+				queue = append(queue, dependent)
+			}
+		}
+	}
+	if sortedCount != len(pipeline.Steps) {
+		return fmt.Errorf("the dependencies of the pipeline steps contain a cycle")
+	}
+	return nil
+}
+
+// validatePipelineStepOps validates the operation of a pipeline step by a transient OpsRequest,
+// which is the same as the child OpsRequest that runs the step.
+func (r *OpsRequest) validatePipelineStepOps(ctx context.Context, k8sClient client.Client,
+	cluster *appsv1.Cluster, stepOps PipelineStepOps) error {
+	if stepOps.Type == PipelineType || stepOps.Type == RestoreType {
+		return fmt.Errorf(`the type "%s" is not supported in a pipeline`, stepOps.Type)
+	}
+	stepRequest := &OpsRequest{
+		ObjectMeta: *r.ObjectMeta.DeepCopy(),
+		Spec: OpsRequestSpec{
+			ClusterName: r.Spec.GetClusterName(),
+			Type:        stepOps.Type,
+		},
+	}
+	if stepOps.Ops != nil {
+		stepRequest.Spec.SpecificOpsRequest = *stepOps.Ops.DeepCopy()
+	}
+	// the types below are not validated by ValidateOps, check their specs are present at least.
+	switch stepOps.Type {
+	case ReconfiguringType:
+		if len(stepRequest.Spec.Reconfigures) == 0 {
+			return notEmptyError("ops.reconfigures")
+		}
+	case CustomType:
+		if stepRequest.Spec.CustomOps == nil {
+			return notEmptyError("ops.custom")
+		}
+	}
+	return stepRequest.ValidateOps(ctx, k8sClient, cluster)
+}
+
 // validateUpgrade validates spec.restart
 func (r *OpsRequest) validateRestart(cluster *appsv1.Cluster) error {
 	restartList := r.Spec.RestartList
@@ -559,6 +652,19 @@ func (r *OpsRequest) validateSwitchover(cluster *appsv1.Cluster) error {
 	return nil
 }
 
+// validateReadonlySwitch validates spec.readonlySwitch
+func (r *OpsRequest) validateReadonlySwitch(cluster *appsv1.Cluster) error {
+	readonlySwitchList := r.Spec.ReadonlySwitchList
+	if len(readonlySwitchList) == 0 {
+		return notEmptyError("spec.readonlySwitch")
+	}
+	compOpsList := make([]ComponentOps, 0, len(readonlySwitchList))
+	for _, v := range readonlySwitchList {
+		compOpsList = append(compOpsList, v.ComponentOps)
+	}
+	return r.checkComponentExistence(cluster, compOpsList)
+}
+
 func (r *OpsRequest) checkInstanceTemplate(cluster *appsv1.Cluster, componentOps ComponentOps, inputInstances []string) error {
 	instanceNameMap := make(map[string]sets.Empty)
 	setInstanceMap := func(instances []appsv1.InstanceTemplate) {
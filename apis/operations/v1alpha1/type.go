@@ -72,7 +72,7 @@ const (
 
 // OpsType defines operation types.
 // +enum
-// +kubebuilder:validation:Enum={Upgrade,VerticalScaling,VolumeExpansion,HorizontalScaling,Restart,Reconfiguring,Start,Stop,Expose,Switchover,Backup,Restore,RebuildInstance,Custom}
+// +kubebuilder:validation:Enum={Upgrade,VerticalScaling,VolumeExpansion,HorizontalScaling,Restart,Reconfiguring,Start,Stop,Expose,Switchover,Backup,Restore,RebuildInstance,Custom,Pipeline,ReadonlySwitch}
 type OpsType string
 
 const (
@@ -90,6 +90,8 @@ const (
 	RestoreType           OpsType = "Restore"
 	RebuildInstanceType   OpsType = "RebuildInstance" // RebuildInstance rebuilding an instance is very useful when a node is offline or an instance is unrecoverable.
 	CustomType            OpsType = "Custom"          // use opsDefinition
+	PipelineType          OpsType = "Pipeline"        // PipelineType runs a DAG of operations, each step is executed by a child OpsRequest.
+	ReadonlySwitchType    OpsType = "ReadonlySwitch"  // ReadonlySwitchType switches the replicas into the read-only state, or back to the read-write state.
 )
 
 // ProgressStatus defines the status of the opsRequest progress.
@@ -115,6 +117,61 @@ const (
 	SucceedActionTaskStatus    ActionTaskStatus = "Succeed"
 )
 
+// PipelineStepFailurePolicy defines how the pipeline handles the failure of a step.
+// +enum
+// +kubebuilder:validation:Enum={Abort,Continue,Rollback}
+type PipelineStepFailurePolicy string
+
+const (
+	// PipelineStepFailurePolicyAbort stops starting new steps and marks the pipeline as failed.
+	PipelineStepFailurePolicyAbort PipelineStepFailurePolicy = "Abort"
+
+	// PipelineStepFailurePolicyContinue ignores the failure and continues to run the remaining steps.
+	PipelineStepFailurePolicyContinue PipelineStepFailurePolicy = "Continue"
+
+	// PipelineStepFailurePolicyRollback stops starting new steps and rolls back the succeeded steps.
+	PipelineStepFailurePolicyRollback PipelineStepFailurePolicy = "Rollback"
+)
+
+// PipelineStepPhase defines the phase of a pipeline step.
+// +enum
+// +kubebuilder:validation:Enum={Pending,Running,Succeed,Failed,Skipped,RollingBack,RolledBack}
+type PipelineStepPhase string
+
+const (
+	PipelineStepPendingPhase     PipelineStepPhase = "Pending"
+	PipelineStepRunningPhase     PipelineStepPhase = "Running"
+	PipelineStepSucceedPhase     PipelineStepPhase = "Succeed"
+	PipelineStepFailedPhase      PipelineStepPhase = "Failed"
+	PipelineStepSkippedPhase     PipelineStepPhase = "Skipped"
+	PipelineStepRollingBackPhase PipelineStepPhase = "RollingBack"
+	PipelineStepRolledBackPhase  PipelineStepPhase = "RolledBack"
+)
+
+// RollbackPolicy defines the policy to roll back an operation when it fails or times out.
+// +enum
+// +kubebuilder:validation:Enum={None,Auto}
+type RollbackPolicy string
+
+const (
+	// NoneRollbackPolicy does not roll back the operation.
+	NoneRollbackPolicy RollbackPolicy = "None"
+
+	// AutoRollbackPolicy rolls back the operation automatically.
+	AutoRollbackPolicy RollbackPolicy = "Auto"
+)
+
+// RollbackPhase defines the phase of an automatic rollback.
+// +enum
+// +kubebuilder:validation:Enum={Running,Succeed,Failed}
+type RollbackPhase string
+
+const (
+	RollbackRunningPhase RollbackPhase = "Running"
+	RollbackSucceedPhase RollbackPhase = "Succeed"
+	RollbackFailedPhase  RollbackPhase = "Failed"
+)
+
 type OpsRequestBehaviour struct {
 	FromClusterPhases []appsv1.ClusterPhase
 	ToClusterPhase    appsv1.ClusterPhase
@@ -820,6 +820,18 @@ func (in *OpsRequestStatus) DeepCopyInto(out *OpsRequestStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.PipelineSteps != nil {
+		in, out := &in.PipelineSteps, &out.PipelineSteps
+		*out = make([]PipelineStepStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(OpsRollbackStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Extras != nil {
 		in, out := &in.Extras, &out.Extras
 		*out = make([]map[string]string, len(*in))
@@ -893,6 +905,23 @@ func (in *OpsResourceModifierAction) DeepCopy() *OpsResourceModifierAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsRollbackStatus) DeepCopyInto(out *OpsRollbackStatus) {
+	*out = *in
+	in.StartTimestamp.DeepCopyInto(&out.StartTimestamp)
+	in.CompletionTimestamp.DeepCopyInto(&out.CompletionTimestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRollbackStatus.
+func (in *OpsRollbackStatus) DeepCopy() *OpsRollbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsRollbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsService) DeepCopyInto(out *OpsService) {
 	*out = *in
@@ -1064,6 +1093,126 @@ func (in *ParametersSchema) DeepCopy() *ParametersSchema {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Pipeline) DeepCopyInto(out *Pipeline) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]PipelineStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pipeline.
+func (in *Pipeline) DeepCopy() *Pipeline {
+	if in == nil {
+		return nil
+	}
+	out := new(Pipeline)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineStep) DeepCopyInto(out *PipelineStep) {
+	*out = *in
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Precondition != nil {
+		in, out := &in.Precondition, &out.Precondition
+		*out = new(PipelineStepPrecondition)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	in.PipelineStepOps.DeepCopyInto(&out.PipelineStepOps)
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(PipelineStepOps)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineStep.
+func (in *PipelineStep) DeepCopy() *PipelineStep {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineStepOps) DeepCopyInto(out *PipelineStepOps) {
+	*out = *in
+	if in.Ops != nil {
+		in, out := &in.Ops, &out.Ops
+		*out = new(SpecificOpsRequest)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineStepOps.
+func (in *PipelineStepOps) DeepCopy() *PipelineStepOps {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineStepOps)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineStepPrecondition) DeepCopyInto(out *PipelineStepPrecondition) {
+	*out = *in
+	if in.ClusterPhases != nil {
+		in, out := &in.ClusterPhases, &out.ClusterPhases
+		*out = make([]appsv1.ClusterPhase, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeadlineSeconds != nil {
+		in, out := &in.DeadlineSeconds, &out.DeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineStepPrecondition.
+func (in *PipelineStepPrecondition) DeepCopy() *PipelineStepPrecondition {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineStepPrecondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineStepStatus) DeepCopyInto(out *PipelineStepStatus) {
+	*out = *in
+	in.StartTimestamp.DeepCopyInto(&out.StartTimestamp)
+	in.CompletionTimestamp.DeepCopyInto(&out.CompletionTimestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineStepStatus.
+func (in *PipelineStepStatus) DeepCopy() *PipelineStepStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineStepStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodInfoExtractor) DeepCopyInto(out *PodInfoExtractor) {
 	*out = *in
@@ -1166,6 +1315,27 @@ func (in *ProgressStatusDetail) DeepCopy() *ProgressStatusDetail {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadonlySwitch) DeepCopyInto(out *ReadonlySwitch) {
+	*out = *in
+	out.ComponentOps = in.ComponentOps
+	if in.InstanceNames != nil {
+		in, out := &in.InstanceNames, &out.InstanceNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadonlySwitch.
+func (in *ReadonlySwitch) DeepCopy() *ReadonlySwitch {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadonlySwitch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RebuildInstance) DeepCopyInto(out *RebuildInstance) {
 	*out = *in
@@ -1418,11 +1588,23 @@ func (in *SpecificOpsRequest) DeepCopyInto(out *SpecificOpsRequest) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ReadonlySwitchList != nil {
+		in, out := &in.ReadonlySwitchList, &out.ReadonlySwitchList
+		*out = make([]ReadonlySwitch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.CustomOps != nil {
 		in, out := &in.CustomOps, &out.CustomOps
 		*out = new(CustomOps)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Pipeline != nil {
+		in, out := &in.Pipeline, &out.Pipeline
+		*out = new(Pipeline)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpecificOpsRequest.
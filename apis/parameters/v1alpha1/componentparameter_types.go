@@ -148,6 +148,33 @@ type ComponentParameterSpec struct {
 	// +listType=map
 	// +listMapKey=name
 	ConfigItemDetails []ConfigTemplateItemDetail `json:"configItemDetails,omitempty"`
+
+	// Specifies how to detect the drift of the effective parameters of the instances from the desired configuration.
+	//
+	// When specified, the effective parameters of each instance are queried periodically through
+	// the `parameterQuery` lifecycle action of the Component, and compared with the parameters in `configItemDetails`.
+	// The result is recorded in `status.instanceDrifts` and the `ParametersDrifted` condition.
+	//
+	// +optional
+	DriftDetection *ParameterDriftDetection `json:"driftDetection,omitempty"`
+}
+
+// ParameterDriftDetection defines how to detect and repair the parameter drift of the instances.
+type ParameterDriftDetection struct {
+	// Specifies the interval in seconds between two detections.
+	//
+	// +kubebuilder:validation:Minimum=30
+	// +kubebuilder:default=300
+	// +optional
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+
+	// Specifies whether to re-apply the desired values to the drifted instances.
+	//
+	// Only the dynamic parameters that can be reloaded synchronously are re-applied,
+	// the drift of the other parameters is reported only.
+	//
+	// +optional
+	AutoRepair bool `json:"autoRepair,omitempty"`
 }
 
 type ReconcileDetail struct {
@@ -264,4 +291,71 @@ type ComponentParameterStatus struct {
 	// +listType=map
 	// +listMapKey=name
 	ConfigurationItemStatus []ConfigTemplateItemDetailStatus `json:"configurationStatus"`
+
+	// Records the instances whose effective parameters drift from the desired configuration,
+	// or whose effective parameters cannot be queried.
+	//
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=name
+	InstanceDrifts []InstanceParameterDrift `json:"instanceDrifts,omitempty"`
+}
+
+const (
+	// ParametersDriftedConditionType indicates whether the effective parameters of any instance
+	// drift from the desired configuration.
+	ParametersDriftedConditionType = "ParametersDrifted"
+)
+
+// InstanceParameterDrift records the parameter drift of an instance.
+type InstanceParameterDrift struct {
+	// The name of the instance (Pod).
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Lists the parameters whose effective values differ from the desired ones.
+	//
+	// +optional
+	Parameters []ParameterDrift `json:"parameters,omitempty"`
+
+	// Provides the reason if the effective parameters of the instance cannot be queried.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// The last time the effective parameters of the instance were queried.
+	//
+	// +optional
+	LastDetectTime metav1.Time `json:"lastDetectTime,omitempty"`
+}
+
+// ParameterDrift describes a parameter whose effective value differs from the desired one.
+type ParameterDrift struct {
+	// The name of the configuration template.
+	//
+	// +kubebuilder:validation:Required
+	TemplateName string `json:"templateName"`
+
+	// The name of the configuration file.
+	//
+	// +kubebuilder:validation:Required
+	FileName string `json:"fileName"`
+
+	// The name of the parameter.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// The desired value of the parameter.
+	//
+	// +optional
+	Expected *string `json:"expected,omitempty"`
+
+	// The effective value of the parameter on the instance, absent if the instance does not report the parameter.
+	//
+	// +optional
+	Actual *string `json:"actual,omitempty"`
 }
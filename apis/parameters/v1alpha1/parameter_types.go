@@ -62,6 +62,16 @@ type ParameterSpec struct {
 	//
 	// +kubebuilder:validation:Required
 	ComponentParameters []ComponentParametersSpec `json:"componentParameters"`
+
+	// Specifies whether to preview the impact of the changes without applying them.
+	//
+	// When set, the parameters are validated and merged with the current configuration,
+	// and the resulting diff, the reload policies that would be taken and the affected Pods are recorded in
+	// `status.componentReconfiguringStatus[*].parameterStatus[*].dryRun`. Nothing is applied to the Component.
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.dryRun"
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 // ParameterStatus defines the observed state of Parameter
@@ -148,4 +158,58 @@ type ReconfiguringStatus struct {
 	//
 	// +optional
 	CustomTemplate *ConfigTemplateExtension `json:"userConfigTemplates,omitempty"`
+
+	// Records the impact of the changes previewed by a dry-run, if `spec.dryRun` is set.
+	//
+	// +optional
+	DryRun *ReconfiguringDryRunResult `json:"dryRun,omitempty"`
+}
+
+// ReconfiguringDryRunResult describes the impact of the changes on a configuration template,
+// which is previewed without applying the changes.
+type ReconfiguringDryRunResult struct {
+	// Lists the changes to the configuration files.
+	//
+	// +optional
+	Diff []ConfigFileDiff `json:"diff,omitempty"`
+
+	// Lists the reload policies that would be taken to make the changes effective.
+	//
+	// +optional
+	Policies []ReloadPolicy `json:"policies,omitempty"`
+
+	// Lists the Pods that would be affected by the changes, in the order in which they would be updated.
+	//
+	// +optional
+	AffectedPods []string `json:"affectedPods,omitempty"`
 }
+
+// ConfigFileDiff describes the change to a configuration file.
+type ConfigFileDiff struct {
+	// The name of the configuration file.
+	//
+	// +kubebuilder:validation:Required
+	FileName string `json:"fileName"`
+
+	// The type of the change.
+	//
+	// +kubebuilder:validation:Required
+	Type ConfigFileDiffType `json:"type"`
+
+	// The JSON merge patch of the parameters if the file is updated.
+	//
+	// +optional
+	Patch string `json:"patch,omitempty"`
+}
+
+// ConfigFileDiffType defines the type of the change to a configuration file.
+//
+// +enum
+// +kubebuilder:validation:Enum={Add,Delete,Update}
+type ConfigFileDiffType string
+
+const (
+	ConfigFileAdded   ConfigFileDiffType = "Add"
+	ConfigFileDeleted ConfigFileDiffType = "Delete"
+	ConfigFileUpdated ConfigFileDiffType = "Update"
+)
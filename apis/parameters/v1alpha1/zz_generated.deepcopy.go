@@ -140,6 +140,11 @@ func (in *ComponentParameterSpec) DeepCopyInto(out *ComponentParameterSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DriftDetection != nil {
+		in, out := &in.DriftDetection, &out.DriftDetection
+		*out = new(ParameterDriftDetection)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentParameterSpec.
@@ -169,6 +174,13 @@ func (in *ComponentParameterStatus) DeepCopyInto(out *ComponentParameterStatus)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.InstanceDrifts != nil {
+		in, out := &in.InstanceDrifts, &out.InstanceDrifts
+		*out = make([]InstanceParameterDrift, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentParameterStatus.
@@ -271,6 +283,21 @@ func (in *ComponentReconfiguringStatus) DeepCopy() *ComponentReconfiguringStatus
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigFileDiff) DeepCopyInto(out *ConfigFileDiff) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigFileDiff.
+func (in *ConfigFileDiff) DeepCopy() *ConfigFileDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigFileDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigTemplateExtension) DeepCopyInto(out *ConfigTemplateExtension) {
 	*out = *in
@@ -442,6 +469,29 @@ func (in *IniConfig) DeepCopy() *IniConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceParameterDrift) DeepCopyInto(out *InstanceParameterDrift) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]ParameterDrift, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.LastDetectTime.DeepCopyInto(&out.LastDetectTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceParameterDrift.
+func (in *InstanceParameterDrift) DeepCopy() *InstanceParameterDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceParameterDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ParamConfigRenderer) DeepCopyInto(out *ParamConfigRenderer) {
 	*out = *in
@@ -590,6 +640,46 @@ func (in *ParameterDeletedPolicy) DeepCopy() *ParameterDeletedPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParameterDrift) DeepCopyInto(out *ParameterDrift) {
+	*out = *in
+	if in.Expected != nil {
+		in, out := &in.Expected, &out.Expected
+		*out = new(string)
+		**out = **in
+	}
+	if in.Actual != nil {
+		in, out := &in.Actual, &out.Actual
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterDrift.
+func (in *ParameterDrift) DeepCopy() *ParameterDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(ParameterDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParameterDriftDetection) DeepCopyInto(out *ParameterDriftDetection) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterDriftDetection.
+func (in *ParameterDriftDetection) DeepCopy() *ParameterDriftDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(ParameterDriftDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ParameterList) DeepCopyInto(out *ParameterList) {
 	*out = *in
@@ -909,6 +999,36 @@ func (in *ReconcileDetail) DeepCopy() *ReconcileDetail {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconfiguringDryRunResult) DeepCopyInto(out *ReconfiguringDryRunResult) {
+	*out = *in
+	if in.Diff != nil {
+		in, out := &in.Diff, &out.Diff
+		*out = make([]ConfigFileDiff, len(*in))
+		copy(*out, *in)
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]ReloadPolicy, len(*in))
+		copy(*out, *in)
+	}
+	if in.AffectedPods != nil {
+		in, out := &in.AffectedPods, &out.AffectedPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconfiguringDryRunResult.
+func (in *ReconfiguringDryRunResult) DeepCopy() *ReconfiguringDryRunResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconfiguringDryRunResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReconfiguringStatus) DeepCopyInto(out *ReconfiguringStatus) {
 	*out = *in
@@ -925,6 +1045,11 @@ func (in *ReconfiguringStatus) DeepCopyInto(out *ReconfiguringStatus) {
 		*out = new(ConfigTemplateExtension)
 		**out = **in
 	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(ReconfiguringDryRunResult)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconfiguringStatus.
@@ -20,9 +20,11 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	"os/exec"
 	"os/signal"
 	"strings"
 	"syscall"
@@ -37,6 +39,7 @@ import (
 
 	kbagent "github.com/apecloud/kubeblocks/pkg/kbagent"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/server"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
@@ -57,9 +60,28 @@ func init() {
 	pflag.IntVar(&serverConfig.Concurrency, "max-concurrency", defaultMaxConcurrency,
 		fmt.Sprintf("The maximum number of concurrent connections the Server may serve, use the default value %d if <=0.", defaultMaxConcurrency))
 	pflag.BoolVar(&serverConfig.Logging, "api-logging", true, "Enable api logging for kb-agent request.")
+	pflag.StringVar(&serverConfig.TLSCertFile, "tls-cert-file", "", "The certificate file used to serve kb-agent over TLS.")
+	pflag.StringVar(&serverConfig.TLSKeyFile, "tls-key-file", "", "The private key file used to serve kb-agent over TLS.")
+	pflag.StringVar(&serverConfig.TLSClientCAFile, "tls-client-ca-file", "", "The CA file used to verify the client certificates, the client certificate is required if it is set.")
+	pflag.StringVar(&serverConfig.AuthTokenFile, "auth-token-file", "", "The file contains the bearer token required to call the kb-agent HTTP and streaming services.")
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == secretstore.EnvCommand {
+		if err := execWithSecretEnv(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", secretstore.EnvCommand, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == secretstore.FileCommand {
+		if err := resolveSecretFiles(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", secretstore.FileCommand, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Set GOMAXPROCS
 	_, _ = maxprocs.Set()
 
@@ -94,3 +116,49 @@ func main() {
 		<-stop
 	}
 }
+
+// execWithSecretEnv resolves the references to the secret store in the env vars and the args expanded from them,
+// and then replaces the current process with the command given after "--", so the resolved values are only visible to the command.
+func execWithSecretEnv(args []string) error {
+	if len(args) > 0 && args[0] == "--" {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("the command to execute is required")
+	}
+	provider, err := secretstore.Default()
+	if err != nil {
+		return err
+	}
+	if provider == nil {
+		return fmt.Errorf("the secret store is not configured")
+	}
+	environ, err := secretstore.Resolve(context.Background(), provider, os.Environ())
+	if err != nil {
+		return err
+	}
+	if args, err = secretstore.Resolve(context.Background(), provider, args); err != nil {
+		return err
+	}
+	path, err := exec.LookPath(args[0])
+	if err != nil {
+		return err
+	}
+	return syscall.Exec(path, args, environ)
+}
+
+// resolveSecretFiles resolves the references to the secret store in the files of the source directory,
+// and writes the resolved files to the target directory.
+func resolveSecretFiles(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("the source and target directories are required")
+	}
+	provider, err := secretstore.Default()
+	if err != nil {
+		return err
+	}
+	if provider == nil {
+		return fmt.Errorf("the secret store is not configured")
+	}
+	return secretstore.ResolveFiles(context.Background(), provider, args[0], args[1])
+}
@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -78,6 +79,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/metrics"
+	"github.com/apecloud/kubeblocks/pkg/tracing"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
@@ -164,6 +166,11 @@ func init() {
 	viper.SetDefault(constant.CfgCacheSyncTimeout, 300)
 	viper.SetDefault(constant.CfgClientQPS, 128)
 	viper.SetDefault(constant.CfgClientBurst, 256)
+	viper.SetDefault(constant.CfgKeyTracingEnabled, false)
+	viper.SetDefault(constant.CfgKeyTracingInsecure, true)
+	viper.SetDefault(constant.CfgKeyTracingSampleRatio, 1.0)
+	viper.SetDefault(constant.CfgKeyTLSCertValidityDays, 36500)
+	viper.SetDefault(constant.CfgKeyTLSCertRenewBeforeDays, 30)
 }
 
 type flagName string
@@ -487,6 +494,33 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = (&component.VolumeAutoscalingReconciler{
+			Client:   client,
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("volume-autoscaling-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "VolumeAutoscaling")
+			os.Exit(1)
+		}
+
+		if err = (&component.VolumeProtectionReconciler{
+			Client:   client,
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("volume-protection-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "VolumeProtection")
+			os.Exit(1)
+		}
+
+		if err = (&component.FailoverReconciler{
+			Client:   client,
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("failover-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Failover")
+			os.Exit(1)
+		}
+
 		if err = (&appscontrollers.ServiceDescriptorReconciler{
 			Client:   mgr.GetClient(),
 			Scheme:   mgr.GetScheme(),
@@ -513,6 +547,11 @@ func main() {
 			setupLog.Error(err, "unable to create controller", "controller", "Rollout")
 			os.Exit(1)
 		}
+
+		if err = metrics.RegisterCollector(metrics.NewPhaseCollector(mgr.GetClient())); err != nil {
+			setupLog.Error(err, "unable to register metrics collector", "collector", "Phase")
+			os.Exit(1)
+		}
 	}
 
 	if viper.GetBool(workloadsFlagKey.viperName()) {
@@ -594,6 +633,14 @@ func main() {
 			setupLog.Error(err, "unable to create controller", "controller", "NodeCountScaler")
 			os.Exit(1)
 		}
+		if err = (&experimentalcontrollers.ComponentAutoscalerReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("component-autoscaler-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ComponentAutoscaler")
+			os.Exit(1)
+		}
 	}
 
 	if viper.GetBool(traceFlagKey.viperName()) {
@@ -676,6 +723,14 @@ func main() {
 			setupLog.Error(err, "unable to create controller", "controller", "ComponentParameter")
 			os.Exit(1)
 		}
+		if err = (&parameterscontrollers.ParameterDriftReconciler{
+			Client:   client,
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("parameter-drift-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ParameterDrift")
+			os.Exit(1)
+		}
 		if err = (&parameterscontrollers.ReconfigureReconciler{
 			Client:   client,
 			Scheme:   mgr.GetScheme(),
@@ -725,6 +780,12 @@ func main() {
 	}
 	viper.SetDefault(constant.CfgKeyServerInfo, *ver)
 
+	shutdownTracing, err := tracing.Setup(context.Background())
+	if err != nil {
+		setupLog.Error(err, "unable to setup reconciliation tracing")
+		os.Exit(1)
+	}
+
 	setupLog.Info("starting manager")
 	if multiClusterMgr != nil {
 		if err := multiClusterMgr.Bind(mgr); err != nil {
@@ -736,4 +797,7 @@ func main() {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+	if err := shutdownTracing(context.Background()); err != nil {
+		setupLog.Error(err, "unable to flush reconciliation traces")
+	}
 }
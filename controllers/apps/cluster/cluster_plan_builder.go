@@ -40,6 +40,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/tracing"
 )
 
 // clusterTransformContext a graph.TransformContext implementation for Cluster reconciliation
@@ -65,6 +66,9 @@ type clusterTransformContext struct {
 
 	// TODO: remove this, annotations to be added to components for sharding, mapping with @allComps.
 	annotations map[string]map[string]string
+
+	// the delayed requeue to check the progress of the resharding
+	reshardingErr error
 }
 
 // clusterPlanBuilder a graph.PlanBuilder implementation for Cluster reconciliation
@@ -167,6 +171,7 @@ func (c *clusterPlanBuilder) Init() error {
 	if err := c.cli.Get(c.transCtx.Context, c.req.NamespacedName, cluster); err != nil {
 		return err
 	}
+	tracing.SetObject(c.transCtx.Context, cluster)
 	c.AddTransformer(&clusterInitTransformer{cluster: cluster})
 	return nil
 }
@@ -254,6 +259,7 @@ func (c *clusterPlanBuilder) defaultWalkFuncWithLogging(vertex graph.Vertex) err
 	err := c.defaultWalkFunc(vertex)
 	switch {
 	case err == nil:
+		tracing.RecordChange(c.transCtx.Context, string(*node.Action), node.Obj)
 		return err
 	case !ok:
 		c.transCtx.Logger.Error(err, "")
@@ -0,0 +1,235 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	ictrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	reshardingShardsVar        = "KB_RESHARDING_SHARDS"
+	reshardingAddedShardsVar   = "KB_RESHARDING_ADDED_SHARDS"
+	reshardingRemovedShardsVar = "KB_RESHARDING_REMOVED_SHARDS"
+
+	reshardingRequeueAfter = 10 * time.Second
+)
+
+// shardingRebalanceAction returns the rebalance action of the sharding, if it is defined by the sharding definition.
+func shardingRebalanceAction(transCtx *clusterTransformContext, shardingName string) *appsv1.Action {
+	for _, sharding := range transCtx.shardings {
+		if sharding.Name != shardingName {
+			continue
+		}
+		shardingDef, ok := transCtx.shardingDefs[sharding.ShardingDef]
+		if ok && shardingDef.Spec.LifecycleActions != nil && shardingDef.Spec.LifecycleActions.Rebalance != nil {
+			return shardingDef.Spec.LifecycleActions.Rebalance
+		}
+	}
+	return nil
+}
+
+func reshardingInProgress(cluster *appsv1.Cluster) bool {
+	for _, status := range cluster.Status.Shardings {
+		if status.Resharding != nil && status.Resharding.Phase != appsv1.ReshardingSucceeded {
+			return true
+		}
+	}
+	return false
+}
+
+// reshard adds and removes the shards of the sharding, with the data rebalanced between shards:
+//  1. creates the added shards;
+//  2. waits for the added shards to be ready;
+//  3. calls the rebalance action on each shard that exists before the resharding, until it succeeds;
+//  4. deletes the removed shards after their data have been drained.
+//
+// The progress is recorded in the cluster status, so it can be resumed after the controller restarts.
+func (h *clusterShardingHandler) reshard(transCtx *clusterTransformContext, dag *graph.DAG, name string, action *appsv1.Action,
+	runningComps, protoComps map[string]*appsv1.Component, toCreate, toDelete sets.Set[string]) error {
+	holding := sets.New[string]()
+	for compName, comp := range runningComps {
+		if !model.IsObjectDeleting(comp) {
+			holding.Insert(compName)
+		}
+	}
+	target := sets.KeySet(protoComps)
+
+	resharding := transCtx.Cluster.Status.Shardings[name].Resharding
+	switch {
+	case resharding == nil || resharding.Phase == appsv1.ReshardingSucceeded:
+		if len(toCreate) == 0 && len(toDelete) == 0 {
+			return nil
+		}
+		resharding = newResharding(holding, target)
+	case !reshardingTarget(resharding).Equal(target):
+		// the shards are changed again before the resharding completes, start over with the new shards.
+		resharding = newResharding(holding, target)
+	default:
+		resharding = resharding.DeepCopy()
+	}
+	defer h.setReshardingStatus(transCtx, name, resharding)
+
+	h.createComps(transCtx, dag, protoComps, toCreate)
+
+	for _, shardName := range resharding.AddedShards {
+		comp, ok := runningComps[shardName]
+		if !ok || comp.Status.Phase != appsv1.RunningComponentPhase {
+			return ictrlutil.NewDelayedRequeueError(reshardingRequeueAfter,
+				fmt.Sprintf("wait for the added shard %s to be ready before rebalancing the data", shardName))
+		}
+	}
+
+	for i := range resharding.Shards {
+		shard := &resharding.Shards[i]
+		if shard.Phase == appsv1.ReshardingSucceeded {
+			continue
+		}
+		comp, ok := runningComps[shard.Name]
+		if !ok {
+			setShardRebalancePhase(shard, appsv1.ReshardingSucceeded, "the shard has been deleted")
+			continue
+		}
+		err := h.rebalance(transCtx, comp, action, resharding)
+		switch {
+		case err == nil:
+			setShardRebalancePhase(shard, appsv1.ReshardingSucceeded, "")
+		case errors.Is(err, lifecycle.ErrActionInProgress):
+			setShardRebalancePhase(shard, appsv1.ReshardingRunning, "")
+		default:
+			setShardRebalancePhase(shard, appsv1.ReshardingFailed, err.Error())
+		}
+	}
+
+	completed := true
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	for _, shard := range resharding.Shards {
+		if shard.Phase != appsv1.ReshardingSucceeded {
+			completed = false
+			continue
+		}
+		// the data of the removed shard has been drained, it's safe to delete it now.
+		if comp, ok := runningComps[shard.Name]; ok && slices.Contains(resharding.RemovedShards, shard.Name) {
+			h.deleteComp(transCtx, graphCli, dag, comp, ptr.To(true))
+			completed = false
+		}
+	}
+	if !completed {
+		return ictrlutil.NewDelayedRequeueError(reshardingRequeueAfter,
+			fmt.Sprintf("wait for the data of sharding %s to be rebalanced", name))
+	}
+
+	resharding.Phase = appsv1.ReshardingSucceeded
+	resharding.CompletionTime = metav1.Now()
+	return nil
+}
+
+func (h *clusterShardingHandler) rebalance(transCtx *clusterTransformContext,
+	comp *appsv1.Component, action *appsv1.Action, resharding *appsv1.ReshardingStatus) error {
+	cluster := transCtx.Cluster
+	compName, err := component.ShortName(cluster.Name, comp.Name)
+	if err != nil {
+		return err
+	}
+	pods, err := component.ListOwnedPods(transCtx.Context, transCtx.Client, cluster.Namespace, cluster.Name, compName)
+	if err != nil {
+		return err
+	}
+	if len(pods) == 0 {
+		return fmt.Errorf("has no pods to execute the rebalance action")
+	}
+	lfa, err := lifecycle.New(cluster.Namespace, cluster.Name, compName, nil, nil, nil, pods...)
+	if err != nil {
+		return err
+	}
+	opts := &lifecycle.Options{
+		NonBlocking: ptr.To(true),
+	}
+	return lfa.UserDefined(transCtx.Context, transCtx.Client, opts, component.UDFRebalanceActionName, action, reshardingParameters(resharding))
+}
+
+func (h *clusterShardingHandler) setReshardingStatus(transCtx *clusterTransformContext, name string, resharding *appsv1.ReshardingStatus) {
+	cluster := transCtx.Cluster
+	if cluster.Status.Shardings == nil {
+		cluster.Status.Shardings = make(map[string]appsv1.ClusterComponentStatus)
+	}
+	status := cluster.Status.Shardings[name]
+	status.Resharding = resharding
+	cluster.Status.Shardings[name] = status
+}
+
+func newResharding(holding, target sets.Set[string]) *appsv1.ReshardingStatus {
+	now := metav1.Now()
+	resharding := &appsv1.ReshardingStatus{
+		Phase:         appsv1.ReshardingRunning,
+		AddedShards:   sets.List(target.Difference(holding)),
+		RemovedShards: sets.List(holding.Difference(target)),
+		StartTime:     now,
+	}
+	for _, name := range sets.List(holding) {
+		resharding.Shards = append(resharding.Shards, appsv1.ShardRebalanceStatus{
+			Name:               name,
+			Phase:              appsv1.ReshardingPending,
+			LastTransitionTime: now,
+		})
+	}
+	return resharding
+}
+
+// reshardingTarget returns the shards of the sharding after the resharding.
+func reshardingTarget(resharding *appsv1.ReshardingStatus) sets.Set[string] {
+	target := sets.New[string](resharding.AddedShards...)
+	for _, shard := range resharding.Shards {
+		if !slices.Contains(resharding.RemovedShards, shard.Name) {
+			target.Insert(shard.Name)
+		}
+	}
+	return target
+}
+
+func reshardingParameters(resharding *appsv1.ReshardingStatus) map[string]string {
+	return map[string]string{
+		reshardingShardsVar:        strings.Join(sets.List(reshardingTarget(resharding)), ","),
+		reshardingAddedShardsVar:   strings.Join(resharding.AddedShards, ","),
+		reshardingRemovedShardsVar: strings.Join(resharding.RemovedShards, ","),
+	}
+}
+
+func setShardRebalancePhase(shard *appsv1.ShardRebalanceStatus, phase appsv1.ReshardingPhase, message string) {
+	if shard.Phase != phase {
+		shard.Phase = phase
+		shard.LastTransitionTime = metav1.Now()
+	}
+	shard.Message = message
+}
@@ -0,0 +1,290 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cluster
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsutil "github.com/apecloud/kubeblocks/controllers/apps/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	ictrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+var _ = Describe("cluster sharding resharding", func() {
+	const (
+		clusterName  = "test-cluster"
+		shardingName = "sharding"
+	)
+
+	var (
+		action = &appsv1.Action{
+			Exec: &appsv1.ExecAction{
+				Command: []string{"rebalance"},
+			},
+		}
+	)
+
+	newTransCtx := func() (*clusterTransformContext, model.GraphClient, *graph.DAG) {
+		cluster := &appsv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      clusterName,
+			},
+		}
+		graphCli := model.NewGraphClient(&appsutil.MockReader{})
+		transCtx := &clusterTransformContext{
+			Context:     ctx,
+			Client:      graphCli,
+			Logger:      logger,
+			Cluster:     cluster,
+			OrigCluster: cluster.DeepCopy(),
+		}
+		dag := graph.NewDAG()
+		graphCli.Root(dag, transCtx.OrigCluster, transCtx.Cluster, model.ActionStatusPtr())
+		return transCtx, graphCli, dag
+	}
+
+	newComps := func(phase appsv1.ComponentPhase, shards ...string) map[string]*appsv1.Component {
+		comps := make(map[string]*appsv1.Component)
+		for _, shard := range shards {
+			comps[shard] = &appsv1.Component{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      shard,
+				},
+				Status: appsv1.ComponentStatus{
+					Phase: phase,
+				},
+			}
+		}
+		return comps
+	}
+
+	reshard := func(transCtx *clusterTransformContext, dag *graph.DAG, running, proto map[string]*appsv1.Component) error {
+		toCreate, toDelete, _ := mapDiff(running, proto)
+		h := &clusterShardingHandler{}
+		return h.reshard(transCtx, dag, shardingName, action, running, proto, toCreate, toDelete)
+	}
+
+	Context("resharding status", func() {
+		It("new resharding", func() {
+			resharding := newResharding(sets.New("s1", "s2", "s3"), sets.New("s2", "s3", "s4"))
+			Expect(resharding.Phase).Should(Equal(appsv1.ReshardingRunning))
+			Expect(resharding.AddedShards).Should(Equal([]string{"s4"}))
+			Expect(resharding.RemovedShards).Should(Equal([]string{"s1"}))
+			Expect(resharding.Shards).Should(HaveLen(3))
+			for i, name := range []string{"s1", "s2", "s3"} {
+				Expect(resharding.Shards[i].Name).Should(Equal(name))
+				Expect(resharding.Shards[i].Phase).Should(Equal(appsv1.ReshardingPending))
+			}
+			Expect(sets.List(reshardingTarget(resharding))).Should(Equal([]string{"s2", "s3", "s4"}))
+		})
+
+		It("parameters", func() {
+			resharding := newResharding(sets.New("s1", "s2"), sets.New("s2", "s3", "s4"))
+			Expect(reshardingParameters(resharding)).Should(Equal(map[string]string{
+				reshardingShardsVar:        "s2,s3,s4",
+				reshardingAddedShardsVar:   "s3,s4",
+				reshardingRemovedShardsVar: "s1",
+			}))
+		})
+
+		It("shard phase transition", func() {
+			shard := &appsv1.ShardRebalanceStatus{Name: "s1", Phase: appsv1.ReshardingPending}
+			setShardRebalancePhase(shard, appsv1.ReshardingFailed, "failed")
+			Expect(shard.Phase).Should(Equal(appsv1.ReshardingFailed))
+			Expect(shard.Message).Should(Equal("failed"))
+			Expect(shard.LastTransitionTime.IsZero()).Should(BeFalse())
+
+			setShardRebalancePhase(shard, appsv1.ReshardingSucceeded, "")
+			Expect(shard.Phase).Should(Equal(appsv1.ReshardingSucceeded))
+			Expect(shard.Message).Should(BeEmpty())
+		})
+	})
+
+	Context("reshard", func() {
+		It("nothing to reshard", func() {
+			transCtx, _, dag := newTransCtx()
+			comps := newComps(appsv1.RunningComponentPhase, "s1", "s2")
+			Expect(reshard(transCtx, dag, comps, comps)).Should(Succeed())
+			Expect(transCtx.Cluster.Status.Shardings).Should(BeEmpty())
+		})
+
+		It("wait for the added shards to be ready", func() {
+			transCtx, graphCli, dag := newTransCtx()
+			running := newComps(appsv1.RunningComponentPhase, "s1", "s2")
+			proto := newComps("", "s1", "s2", "s3")
+
+			err := reshard(transCtx, dag, running, proto)
+			Expect(ictrlutil.IsDelayedRequeueError(err)).Should(BeTrue())
+
+			objs := graphCli.FindAll(dag, &appsv1.Component{})
+			Expect(objs).Should(HaveLen(1))
+			Expect(objs[0].GetName()).Should(Equal("s3"))
+			Expect(graphCli.IsAction(dag, objs[0], model.ActionCreatePtr())).Should(BeTrue())
+
+			resharding := transCtx.Cluster.Status.Shardings[shardingName].Resharding
+			Expect(resharding).ShouldNot(BeNil())
+			Expect(resharding.Phase).Should(Equal(appsv1.ReshardingRunning))
+			Expect(resharding.AddedShards).Should(Equal([]string{"s3"}))
+			Expect(resharding.RemovedShards).Should(BeEmpty())
+			for _, shard := range resharding.Shards {
+				Expect(shard.Phase).Should(Equal(appsv1.ReshardingPending))
+			}
+		})
+
+		It("delete the removed shard after its data are drained", func() {
+			transCtx, graphCli, dag := newTransCtx()
+			running := newComps(appsv1.RunningComponentPhase, "s1", "s2")
+			proto := newComps("", "s1")
+
+			resharding := newResharding(sets.KeySet(running), sets.KeySet(proto))
+			for i := range resharding.Shards {
+				resharding.Shards[i].Phase = appsv1.ReshardingSucceeded
+			}
+			transCtx.Cluster.Status.Shardings = map[string]appsv1.ClusterComponentStatus{
+				shardingName: {Resharding: resharding},
+			}
+
+			err := reshard(transCtx, dag, running, proto)
+			Expect(ictrlutil.IsDelayedRequeueError(err)).Should(BeTrue())
+
+			objs := graphCli.FindAll(dag, &appsv1.Component{})
+			Expect(objs).ShouldNot(BeEmpty())
+			for _, obj := range objs {
+				Expect(obj.GetName()).Should(Equal("s2"))
+			}
+			Expect(running["s2"].Annotations).Should(HaveKeyWithValue(constant.ComponentScaleInAnnotationKey, "true"))
+			Expect(transCtx.Cluster.Status.Shardings[shardingName].Resharding.Phase).Should(Equal(appsv1.ReshardingRunning))
+
+			// the removed shard has been deleted
+			transCtx, _, dag = newTransCtx()
+			transCtx.Cluster.Status.Shardings = map[string]appsv1.ClusterComponentStatus{
+				shardingName: {Resharding: resharding},
+			}
+			delete(running, "s2")
+			Expect(reshard(transCtx, dag, running, proto)).Should(Succeed())
+			resharding = transCtx.Cluster.Status.Shardings[shardingName].Resharding
+			Expect(resharding.Phase).Should(Equal(appsv1.ReshardingSucceeded))
+			Expect(resharding.CompletionTime.IsZero()).Should(BeFalse())
+		})
+
+		It("the removed shard is kept if the rebalance fails", func() {
+			transCtx, graphCli, dag := newTransCtx()
+			running := newComps(appsv1.RunningComponentPhase, clusterName+"-s1", clusterName+"-s2")
+			proto := newComps("", clusterName+"-s1")
+
+			err := reshard(transCtx, dag, running, proto)
+			Expect(ictrlutil.IsDelayedRequeueError(err)).Should(BeTrue())
+			Expect(graphCli.FindAll(dag, &appsv1.Component{})).Should(BeEmpty())
+
+			resharding := transCtx.Cluster.Status.Shardings[shardingName].Resharding
+			Expect(resharding.RemovedShards).Should(Equal([]string{clusterName + "-s2"}))
+			for _, shard := range resharding.Shards {
+				// there are no pods to execute the action
+				Expect(shard.Phase).Should(Equal(appsv1.ReshardingFailed))
+				Expect(shard.Message).ShouldNot(BeEmpty())
+			}
+		})
+	})
+
+	Context("update", func() {
+		It("the resharding doesn't block the update", func() {
+			const shardingDefName = "test-shardingdef"
+			reader := &appsutil.MockReader{}
+			for _, shard := range []string{"s1", "s2"} {
+				reader.Objects = append(reader.Objects, &appsv1.Component{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      constant.GenerateClusterComponentName(clusterName, fmt.Sprintf("%s-%s", shardingName, shard)),
+						Labels: constant.GetClusterLabels(clusterName, map[string]string{
+							constant.KBAppShardingNameLabelKey: shardingName,
+						}),
+					},
+					Status: appsv1.ComponentStatus{
+						Phase: appsv1.RunningComponentPhase,
+					},
+				})
+			}
+			cluster := &appsv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      clusterName,
+				},
+			}
+			graphCli := model.NewGraphClient(reader)
+			transCtx := &clusterTransformContext{
+				Context:     ctx,
+				Client:      graphCli,
+				Logger:      logger,
+				Cluster:     cluster,
+				OrigCluster: cluster.DeepCopy(),
+				shardingDefs: map[string]*appsv1.ShardingDefinition{
+					shardingDefName: {
+						Spec: appsv1.ShardingDefinitionSpec{
+							LifecycleActions: &appsv1.ShardingLifecycleActions{
+								Rebalance: action,
+							},
+						},
+					},
+				},
+				shardings: []*appsv1.ClusterSharding{
+					{
+						Name:        shardingName,
+						ShardingDef: shardingDefName,
+						Shards:      3,
+						Template: appsv1.ClusterComponentSpec{
+							ComponentDef: "test-compdef",
+							Replicas:     1,
+						},
+					},
+				},
+			}
+			var err error
+			_, transCtx.shardingCompsWithTpl, err = (&clusterNormalizationTransformer{}).buildShardingComps(transCtx)
+			Expect(err).Should(BeNil())
+			dag := graph.NewDAG()
+			graphCli.Root(dag, transCtx.OrigCluster, transCtx.Cluster, model.ActionStatusPtr())
+
+			By("waiting for the added shard to be ready")
+			Expect((&clusterShardingHandler{}).update(transCtx, dag, shardingName)).Should(Succeed())
+			Expect(ictrlutil.IsDelayedRequeueError(transCtx.reshardingErr)).Should(BeTrue())
+			created := 0
+			for _, obj := range graphCli.FindAll(dag, &appsv1.Component{}) {
+				if graphCli.IsAction(dag, obj, model.ActionCreatePtr()) {
+					created++
+				}
+			}
+			Expect(created).Should(Equal(1))
+			resharding := transCtx.Cluster.Status.Shardings[shardingName].Resharding
+			Expect(resharding).ShouldNot(BeNil())
+			Expect(resharding.AddedShards).Should(HaveLen(1))
+		})
+	})
+})
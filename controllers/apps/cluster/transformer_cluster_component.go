@@ -63,8 +63,9 @@ func (t *clusterComponentTransformer) Transform(ctx graph.TransformContext, dag
 		return err
 	}
 
-	// if the cluster is not updating and all components are up-to-date, skip the reconciliation
-	if !transCtx.OrigCluster.IsUpdating() && updateToDate {
+	// if the cluster is not updating and all components are up-to-date, skip the reconciliation,
+	// unless there is a resharding in progress.
+	if !transCtx.OrigCluster.IsUpdating() && updateToDate && !reshardingInProgress(transCtx.OrigCluster) {
 		return nil
 	}
 
@@ -96,6 +97,9 @@ func (t *clusterComponentTransformer) transform(transCtx *clusterTransformContex
 		return err
 	}
 
+	if delayedErr == nil {
+		delayedErr = transCtx.reshardingErr
+	}
 	return delayedErr
 }
 
@@ -287,6 +291,7 @@ func copyAndMergeComponent(oldCompObj, newCompObj *appsv1.Component) *appsv1.Com
 	compObjCopy.Spec.Sidecars = compProto.Spec.Sidecars
 	compObjCopy.Spec.Resources = compProto.Spec.Resources
 	compObjCopy.Spec.EnableInstanceAPI = compProto.Spec.EnableInstanceAPI
+	compObjCopy.Spec.FailoverPolicy = compProto.Spec.FailoverPolicy
 
 	metadataChanged := !reflect.DeepEqual(oldCompObj.Annotations, compObjCopy.Annotations) ||
 		!reflect.DeepEqual(oldCompObj.Labels, compObjCopy.Labels)
@@ -889,8 +894,18 @@ func (h *clusterShardingHandler) update(transCtx *clusterTransformContext, dag *
 	toCreate, toDelete, toUpdate := mapDiff(runningCompsMap, protoCompsMap)
 
 	// TODO: update strategy
-	h.deleteComps(transCtx, dag, runningCompsMap, toDelete)
 	h.updateComps(transCtx, dag, runningCompsMap, protoCompsMap, toUpdate)
+	if action := shardingRebalanceAction(transCtx, name); action != nil {
+		// the resharding goes on across reconciliations, don't block the components and shardings after it
+		if err := h.reshard(transCtx, dag, name, action, runningCompsMap, protoCompsMap, toCreate, toDelete); err != nil {
+			if !ictrlutil.IsDelayedRequeueError(err) {
+				return err
+			}
+			transCtx.reshardingErr = err
+		}
+		return nil
+	}
+	h.deleteComps(transCtx, dag, runningCompsMap, toDelete)
 	h.createComps(transCtx, dag, protoCompsMap, toCreate)
 
 	return nil
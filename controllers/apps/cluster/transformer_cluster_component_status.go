@@ -219,6 +219,7 @@ func (t *clusterComponentStatusTransformer) transformShardingStatus(transCtx *cl
 	createSet, deleteSet, updateSet := setDiff(runningSet, protoSet)
 
 	// reset the status
+	prevStatus := cluster.Status.Shardings
 	cluster.Status.Shardings = make(map[string]appsv1.ClusterComponentStatus)
 	for name := range createSet {
 		cluster.Status.Shardings[name] = appsv1.ClusterComponentStatus{
@@ -241,15 +242,17 @@ func (t *clusterComponentStatusTransformer) transformShardingStatus(transCtx *cl
 		}
 	}
 	for name := range updateSet {
-		cluster.Status.Shardings[name] = t.buildClusterShardingStatus(transCtx, name, shardingComps[name])
+		status := t.buildClusterShardingStatus(transCtx, name, prevStatus[name], shardingComps[name])
+		// the resharding progress is maintained by the component transformer, keep it as is.
+		status.Resharding = prevStatus[name].Resharding
+		cluster.Status.Shardings[name] = status
 	}
 }
 
 func (t *clusterComponentStatusTransformer) buildClusterShardingStatus(transCtx *clusterTransformContext,
-	shardingName string, comps []*appsv1.Component) appsv1.ClusterComponentStatus {
+	shardingName string, status appsv1.ClusterComponentStatus, comps []*appsv1.Component) appsv1.ClusterComponentStatus {
 	var (
 		cluster = transCtx.Cluster
-		status  = cluster.Status.Shardings[shardingName]
 	)
 
 	phase := status.Phase
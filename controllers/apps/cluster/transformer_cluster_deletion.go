@@ -38,6 +38,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
 )
 
 // clusterDeletionTransformer handles cluster deletion
@@ -133,6 +134,12 @@ func (t *clusterDeletionTransformer) Transform(ctx graph.TransformContext, dag *
 		if isOwnedByComp(o) || appsutil.IsOwnedByInstanceSet(o) {
 			continue
 		}
+		if secret, ok := o.(*corev1.Secret); ok {
+			// the shared sharding accounts may keep their passwords in the secret store
+			if err = secretstore.DeleteSecretData(transCtx.Context, secret); err != nil {
+				return err
+			}
+		}
 		graphCli.Delete(dag, o)
 	}
 
@@ -34,6 +34,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
 )
 
 // clusterShardingAccountTransformer handles shared system accounts for sharding.
@@ -75,16 +76,23 @@ func (t *clusterShardingAccountTransformer) reconcileShardingAccounts(transCtx *
 
 func (t *clusterShardingAccountTransformer) reconcileShardingAccount(transCtx *clusterTransformContext,
 	graphCli model.GraphClient, dag *graph.DAG, sharding *appsv1.ClusterSharding, accountName string) error {
-	exist, err := t.checkSystemAccountSecret(transCtx, sharding, accountName)
+	secret, err := t.getSystemAccountSecret(transCtx, sharding, accountName)
 	if err != nil {
 		return err
 	}
-	if !exist {
+	switch {
+	case secret == nil:
 		obj, err := t.newSystemAccountSecret(transCtx, sharding, accountName)
 		if err != nil {
 			return err
 		}
 		graphCli.Create(dag, obj)
+	case secretstore.Enabled() && !secretstore.IsReferred(secret):
+		// move the password to the secret store, the secret is immutable, so delete it and recreate it in the next reconciliation
+		if err = t.movePasswordToSecretStore(transCtx, secret); err != nil {
+			return err
+		}
+		graphCli.Delete(dag, secret)
 	}
 
 	// TODO: update
@@ -94,8 +102,8 @@ func (t *clusterShardingAccountTransformer) reconcileShardingAccount(transCtx *c
 	return nil
 }
 
-func (t *clusterShardingAccountTransformer) checkSystemAccountSecret(transCtx *clusterTransformContext,
-	sharding *appsv1.ClusterSharding, accountName string) (bool, error) {
+func (t *clusterShardingAccountTransformer) getSystemAccountSecret(transCtx *clusterTransformContext,
+	sharding *appsv1.ClusterSharding, accountName string) (*corev1.Secret, error) {
 	var (
 		cluster = transCtx.Cluster
 	)
@@ -104,11 +112,25 @@ func (t *clusterShardingAccountTransformer) checkSystemAccountSecret(transCtx *c
 		Name:      shardingAccountSecretName(cluster.Name, sharding.Name, accountName),
 	}
 	secret := &corev1.Secret{}
-	err := transCtx.GetClient().Get(transCtx.GetContext(), secretKey, secret)
-	if err != nil && !apierrors.IsNotFound(err) {
-		return false, err
+	if err := transCtx.GetClient().Get(transCtx.GetContext(), secretKey, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return secret, nil
+}
+
+func (t *clusterShardingAccountTransformer) movePasswordToSecretStore(transCtx *clusterTransformContext, secret *corev1.Secret) error {
+	store, err := secretstore.Default()
+	if err != nil {
+		return err
 	}
-	return !apierrors.IsNotFound(err), nil
+	data := map[string]string{}
+	for k, v := range secret.Data {
+		data[k] = string(v)
+	}
+	return store.Put(transCtx.Context, secretstore.SecretPath(secret.Namespace, secret.Name), data)
 }
 
 func (t *clusterShardingAccountTransformer) newSystemAccountSecret(transCtx *clusterTransformContext,
@@ -117,11 +139,28 @@ func (t *clusterShardingAccountTransformer) newSystemAccountSecret(transCtx *clu
 	if err != nil {
 		return nil, err
 	}
-	password, err := t.buildPassword(transCtx, account, sharding.Name)
+	store, err := secretstore.Default()
+	if err != nil {
+		return nil, err
+	}
+	path := secretstore.SecretPath(transCtx.Cluster.Namespace, shardingAccountSecretName(transCtx.Cluster.Name, sharding.Name, accountName))
+	password, err := t.buildPassword(transCtx, store, path, account, sharding.Name)
 	if err != nil {
 		return nil, err
 	}
-	return t.newAccountSecretWithPassword(transCtx, sharding, accountName, password)
+	secret, err := t.newAccountSecretWithPassword(transCtx, sharding, accountName, password)
+	if err != nil || store == nil {
+		return secret, err
+	}
+	// keep the password in the secret store, and refer to it in the secret
+	if err = store.Put(transCtx.Context, path, map[string]string{
+		constant.AccountNameForSecret:   accountName,
+		constant.AccountPasswdForSecret: string(password),
+	}); err != nil {
+		return nil, err
+	}
+	secretstore.ReferTo(secret, path, constant.AccountPasswdForSecret)
+	return secret, nil
 }
 
 func (t *clusterShardingAccountTransformer) definedSystemAccount(transCtx *clusterTransformContext,
@@ -156,7 +195,18 @@ func (t *clusterShardingAccountTransformer) definedSystemAccount(transCtx *clust
 	return appsv1.SystemAccount{}, fmt.Errorf("system account %s not found in component definition %s", accountName, compDef.Name)
 }
 
-func (t *clusterShardingAccountTransformer) buildPassword(transCtx *clusterTransformContext, account appsv1.SystemAccount, shardingName string) ([]byte, error) {
+func (t *clusterShardingAccountTransformer) buildPassword(transCtx *clusterTransformContext,
+	store secretstore.Provider, path string, account appsv1.SystemAccount, shardingName string) ([]byte, error) {
+	if store != nil {
+		// the password may have been moved to the secret store already
+		data, err := store.Get(transCtx.Context, path)
+		if err != nil && !secretstore.IsNotFound(err) {
+			return nil, err
+		}
+		if len(data[constant.AccountPasswdForSecret]) > 0 {
+			return []byte(data[constant.AccountPasswdForSecret]), nil
+		}
+	}
 	password, err := appsutil.GetRestoreSystemAccountPassword(transCtx.Context, transCtx.Client, transCtx.Cluster.Annotations, shardingName, account.Name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to restore password for system account %s of shard %s from annotation, err: %w", account.Name, shardingName, err)
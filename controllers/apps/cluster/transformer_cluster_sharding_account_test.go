@@ -0,0 +1,172 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cluster
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsutil "github.com/apecloud/kubeblocks/controllers/apps/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
+	testsecretstore "github.com/apecloud/kubeblocks/pkg/testutil/secretstore"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+var _ = Describe("clusterShardingAccountTransformer", func() {
+	const (
+		clusterName  = "test-cluster"
+		shardingName = "shard"
+		compDefName  = "test-compdef"
+		accountName  = "root"
+	)
+
+	var (
+		reader     *appsutil.MockReader
+		transCtx   *clusterTransformContext
+		dag        *graph.DAG
+		vault      *testsecretstore.FakeVault
+		secretName string
+		storePath  string
+	)
+
+	BeforeEach(func() {
+		vault = testsecretstore.NewFakeVault()
+		viper.Set(constant.CfgKeySecretStoreProvider, secretstore.VaultProvider)
+		viper.Set(constant.CfgKeySecretStoreAddress, vault.URL)
+		viper.Set(constant.CfgKeySecretStoreToken, testsecretstore.FakeVaultToken)
+
+		cluster := &appsv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      clusterName,
+			},
+		}
+		sharding := &appsv1.ClusterSharding{
+			Name:        shardingName,
+			ShardingDef: "test-shardingdef",
+			Template: appsv1.ClusterComponentSpec{
+				ComponentDef: compDefName,
+			},
+		}
+		reader = &appsutil.MockReader{}
+		transCtx = &clusterTransformContext{
+			Context:     ctx,
+			Client:      model.NewGraphClient(reader),
+			Logger:      logger,
+			Cluster:     cluster,
+			OrigCluster: cluster.DeepCopy(),
+			shardingDefs: map[string]*appsv1.ShardingDefinition{
+				sharding.ShardingDef: {
+					Spec: appsv1.ShardingDefinitionSpec{
+						SystemAccounts: []appsv1.ShardingSystemAccount{{Name: accountName, Shared: ptr.To(true)}},
+					},
+				},
+			},
+			componentDefs: map[string]*appsv1.ComponentDefinition{
+				compDefName: {
+					ObjectMeta: metav1.ObjectMeta{Name: compDefName},
+					Spec: appsv1.ComponentDefinitionSpec{
+						SystemAccounts: []appsv1.SystemAccount{
+							{
+								Name: accountName,
+								PasswordGenerationPolicy: appsv1.PasswordConfig{
+									Length: 16,
+								},
+							},
+						},
+					},
+				},
+			},
+			shardings:     []*appsv1.ClusterSharding{sharding},
+			shardingComps: map[string][]*appsv1.ClusterComponentSpec{},
+		}
+		dag = graph.NewDAG()
+		transCtx.Client.(model.GraphClient).Root(dag, transCtx.OrigCluster, transCtx.Cluster, model.ActionStatusPtr())
+
+		secretName = shardingAccountSecretName(clusterName, shardingName, accountName)
+		storePath = secretstore.SecretPath(testCtx.DefaultNamespace, secretName)
+	})
+
+	AfterEach(func() {
+		viper.Set(constant.CfgKeySecretStoreProvider, "")
+		vault.Close()
+	})
+
+	secretInDAG := func() (*corev1.Secret, *model.Action) {
+		graphCli := transCtx.Client.(model.GraphClient)
+		objs := graphCli.FindAll(dag, &corev1.Secret{})
+		Expect(objs).Should(HaveLen(1))
+		v := graphCli.FindMatchedVertex(dag, objs[0]).(*model.ObjectVertex)
+		return objs[0].(*corev1.Secret), v.Action
+	}
+
+	It("keeps the password of the shared account in the secret store", func() {
+		transformer := &clusterShardingAccountTransformer{}
+		Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+
+		secret, action := secretInDAG()
+		Expect(*action).Should(Equal(model.CREATE))
+		Expect(secret.Annotations).Should(HaveKeyWithValue(constant.SecretStorePathAnnotationKey, storePath))
+		Expect(secret.Data).Should(HaveKeyWithValue(constant.AccountPasswdForSecret, []byte(secretstore.EnvRef(storePath, constant.AccountPasswdForSecret))))
+		Expect(vault.Secret(storePath)[constant.AccountPasswdForSecret]).Should(HaveLen(16))
+		Expect(transCtx.shardings[0].Template.SystemAccounts).Should(HaveLen(1))
+		Expect(transCtx.shardings[0].Template.SystemAccounts[0].SecretRef.Name).Should(Equal(secretName))
+	})
+
+	It("moves the password kept in the secret to the secret store", func() {
+		reader.Objects = []client.Object{&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      secretName,
+			},
+			Data: map[string][]byte{
+				constant.AccountNameForSecret:   []byte(accountName),
+				constant.AccountPasswdForSecret: []byte("password"),
+			},
+		}}
+
+		transformer := &clusterShardingAccountTransformer{}
+		Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+
+		_, action := secretInDAG()
+		Expect(*action).Should(Equal(model.DELETE))
+		Expect(vault.Secret(storePath)).Should(HaveKeyWithValue(constant.AccountPasswdForSecret, "password"))
+
+		By("recreate the secret with the password kept in the secret store")
+		reader.Objects = nil
+		dag = graph.NewDAG()
+		transCtx.Client.(model.GraphClient).Root(dag, transCtx.OrigCluster, transCtx.Cluster, model.ActionStatusPtr())
+		Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+
+		secret, action := secretInDAG()
+		Expect(*action).Should(Equal(model.CREATE))
+		Expect(secret.Data).Should(HaveKeyWithValue(constant.AccountPasswdForSecret, []byte(secretstore.EnvRef(storePath, constant.AccountPasswdForSecret))))
+		Expect(vault.Secret(storePath)).Should(HaveKeyWithValue(constant.AccountPasswdForSecret, "password"))
+	})
+})
@@ -22,6 +22,7 @@ package cluster
 import (
 	"fmt"
 	"reflect"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -102,6 +103,14 @@ func (t *clusterShardingTLSTransformer) reconcileShardingTLS(transCtx *clusterTr
 		secretCopy := secret.DeepCopy()
 		secretCopy.Labels = proto.Labels
 		secretCopy.Annotations = proto.Annotations
+		// renew the certs ahead of the expiry, the shards will roll them out as the user-provided certs
+		if t.tlsCertExpiring(compDef, secret) {
+			renewed, err1 := t.renewTLSSecret(transCtx, sharding, compDef, secret)
+			if err1 != nil {
+				return err1
+			}
+			secretCopy.Data = renewed.Data
+		}
 		if !reflect.DeepEqual(secret, secretCopy) {
 			graphCli.Update(dag, secret, secretCopy)
 		}
@@ -129,6 +138,17 @@ func (t *clusterShardingTLSTransformer) checkTLSSecret(
 	return secret, nil
 }
 
+func (t *clusterShardingTLSTransformer) tlsCertExpiring(compDef *appsv1.ComponentDefinition, secret *corev1.Secret) bool {
+	if compDef.Spec.TLS == nil || compDef.Spec.TLS.CertFile == nil {
+		return false
+	}
+	cert, err := plan.ParseTLSCert(secret.Data[*compDef.Spec.TLS.CertFile])
+	if err != nil {
+		return false
+	}
+	return plan.IsTLSCertExpiring(cert, time.Now())
+}
+
 func (t *clusterShardingTLSTransformer) buildTLSSecret(transCtx *clusterTransformContext,
 	sharding *appsv1.ClusterSharding, compDef *appsv1.ComponentDefinition) (*corev1.Secret, error) {
 	synthesizedComp := component.SynthesizedComponent{
@@ -140,6 +160,17 @@ func (t *clusterShardingTLSTransformer) buildTLSSecret(transCtx *clusterTransfor
 	return plan.ComposeTLSCertsWithSecret(compDef, synthesizedComp, secret)
 }
 
+func (t *clusterShardingTLSTransformer) renewTLSSecret(transCtx *clusterTransformContext,
+	sharding *appsv1.ClusterSharding, compDef *appsv1.ComponentDefinition, running *corev1.Secret) (*corev1.Secret, error) {
+	synthesizedComp := component.SynthesizedComponent{
+		Namespace:   transCtx.Cluster.Namespace,
+		ClusterName: transCtx.Cluster.Name,
+		Name:        sharding.Name,
+	}
+	secret := t.newTLSSecret(transCtx, sharding, compDef)
+	return plan.RenewTLSCertsWithSecret(compDef, synthesizedComp, running, secret)
+}
+
 func (t *clusterShardingTLSTransformer) newTLSSecret(transCtx *clusterTransformContext,
 	sharding *appsv1.ClusterSharding, compDef *appsv1.ComponentDefinition) *corev1.Secret {
 	var (
@@ -36,6 +36,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/tracing"
 )
 
 // componentTransformContext a graph.TransformContext implementation for Component reconciliation
@@ -92,6 +93,7 @@ func (c *componentPlanBuilder) Init() error {
 	if err := c.cli.Get(c.transCtx.Context, c.req.NamespacedName, comp); err != nil {
 		return err
 	}
+	tracing.SetObject(c.transCtx.Context, comp)
 
 	c.transCtx.Component = comp
 	c.transCtx.ComponentOrig = comp.DeepCopy()
@@ -148,6 +150,7 @@ func (c *componentPlanBuilder) defaultWalkFuncWithLogging(vertex graph.Vertex) e
 	err := c.defaultWalkFunc(vertex)
 	switch {
 	case err == nil:
+		tracing.RecordChange(c.transCtx.Context, string(*node.Action), node.Obj)
 		c.transCtx.Logger.Info(fmt.Sprintf("reconcile object %T with action %s OK", node.Obj, *node.Action))
 		return err
 	case !ok:
@@ -0,0 +1,440 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	defaultRoleProbePeriodSeconds     = 60
+	defaultFailoverUnhealthyThreshold = 3
+	defaultFailoverMinInterval        = 10 * time.Minute
+	maxFailoverHistory                = 10
+)
+
+// FailoverReconciler fails over a role of the Component automatically, for the engines that don't elect a new primary
+// by themselves. Once the replica holding the role has been unhealthy for a number of role probe periods, it's fenced
+// and the role is switched over to the most up-to-date replica reported by the `replicationLag` lifecycle action.
+//
+// To avoid split brain, the fenced replica can't take the role back from its role probe, and the failover is
+// suspended if more than one replica holds the role. To avoid flapping, the failovers are rate-limited by the
+// `minInterval` of the policy, and suspended while the Component is being updated.
+type FailoverReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=components,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=components/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=componentdefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=workloads.kubeblocks.io,resources=instancesets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.14.4/pkg/reconcile
+func (r *FailoverReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("component", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	comp := &appsv1.Component{}
+	if err := r.Client.Get(ctx, req.NamespacedName, comp); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if !comp.GetDeletionTimestamp().IsZero() || len(comp.Spec.CompDef) == 0 || comp.Spec.FailoverPolicy == nil {
+		return intctrlutil.Reconciled()
+	}
+
+	compDef, err := component.GetCompDefByName(ctx, r.Client, comp.Spec.CompDef)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	role := failoverRole(comp.Spec.FailoverPolicy, compDef)
+	if len(role) == 0 || !failoverSupported(compDef) {
+		return intctrlutil.Reconciled()
+	}
+
+	if err = r.failover(reqCtx, comp, compDef, role); err != nil {
+		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.RequeueAfter(roleProbePeriod(compDef.Spec.LifecycleActions), reqCtx.Log, "")
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *FailoverReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewControllerManagedBy(mgr).
+		Named("failover").
+		For(&appsv1.Component{}).
+		Complete(r)
+}
+
+func (r *FailoverReconciler) failover(reqCtx intctrlutil.RequestCtx,
+	comp *appsv1.Component, compDef *appsv1.ComponentDefinition, role string) error {
+	if ptr.Deref(comp.Spec.Stop, false) || !failoverAllowedPhase(comp.Status.Phase) {
+		return nil
+	}
+	updating, err := r.isWorkloadUpdating(reqCtx.Ctx, comp)
+	if err != nil || updating {
+		return err
+	}
+
+	synthesizedComp, err := component.BuildSynthesizedComponent(reqCtx.Ctx, r.Client, compDef, comp)
+	if err != nil {
+		return err
+	}
+	synthesizedComp.TemplateVars, _, err = component.ResolveTemplateNEnvVars(reqCtx.Ctx, r.Client, synthesizedComp, compDef.Spec.Vars)
+	if err != nil {
+		return err
+	}
+	pods, err := component.ListOwnedPods(reqCtx.Ctx, r.Client, comp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name)
+	if err != nil {
+		return err
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+
+	var holders, fenced []*corev1.Pod
+	for _, pod := range pods {
+		switch {
+		case pod.Labels[constant.RoleLabelKey] == role:
+			holders = append(holders, pod)
+		case pod.Labels[constant.FencedRoleLabelKey] == role:
+			fenced = append(fenced, pod)
+		}
+	}
+
+	switch {
+	case len(holders) > 1:
+		r.Recorder.Eventf(comp, corev1.EventTypeWarning, "FailoverSuspended",
+			"the role %s is held by more than one replica: %s", role, strings.Join(podNames(holders), ","))
+		return nil
+	case len(holders) == 1:
+		return r.failoverUnhealthy(reqCtx, comp, synthesizedComp, role, holders[0], pods)
+	case len(fenced) > 0:
+		return r.resumeFailover(reqCtx, comp, synthesizedComp, role, fenced[0], pods)
+	default:
+		return nil
+	}
+}
+
+// failoverUnhealthy fails over the role if the replica holding it has been unhealthy for long enough.
+func (r *FailoverReconciler) failoverUnhealthy(reqCtx intctrlutil.RequestCtx, comp *appsv1.Component,
+	synthesizedComp *component.SynthesizedComponent, role string, holder *corev1.Pod, pods []*corev1.Pod) error {
+	since, unhealthy := unhealthySince(holder)
+	if !unhealthy {
+		return nil
+	}
+	policy := comp.Spec.FailoverPolicy
+	threshold := policy.UnhealthyThreshold
+	if threshold <= 0 {
+		threshold = defaultFailoverUnhealthyThreshold
+	}
+	duration := time.Since(since)
+	if duration < time.Duration(threshold)*roleProbePeriod(synthesizedComp.LifecycleActions) {
+		return nil
+	}
+	if last := lastFailover(comp); last != nil {
+		minInterval := defaultFailoverMinInterval
+		if policy.MinInterval != nil {
+			minInterval = policy.MinInterval.Duration
+		}
+		if time.Since(last.Time.Time) < minInterval {
+			r.Recorder.Eventf(comp, corev1.EventTypeWarning, "FailoverSuspended",
+				"the replica %s is unhealthy, but the last failover was done at %s, within the min interval %s",
+				holder.Name, last.Time.Format(time.RFC3339), minInterval)
+			return nil
+		}
+	}
+	message := fmt.Sprintf("the replica %s holding the role %s has been unhealthy for %s",
+		holder.Name, role, duration.Round(time.Second))
+	return r.doFailover(reqCtx, comp, synthesizedComp, role, holder, pods, message)
+}
+
+// resumeFailover handles the fenced replica when no replica holds the role.
+func (r *FailoverReconciler) resumeFailover(reqCtx intctrlutil.RequestCtx, comp *appsv1.Component,
+	synthesizedComp *component.SynthesizedComponent, role string, fenced *corev1.Pod, pods []*corev1.Pod) error {
+	if last := lastFailover(comp); last != nil && last.Role == role && last.From == fenced.Name {
+		// the failover has been done, waiting for the new replica to take the role.
+		return nil
+	}
+	if _, unhealthy := unhealthySince(fenced); !unhealthy {
+		// the replica recovers before the role is switched over to another one, give the role back to it.
+		if err := r.unfence(reqCtx.Ctx, fenced, role); err != nil {
+			return err
+		}
+		r.Recorder.Eventf(comp, corev1.EventTypeNormal, "ReplicaUnfenced",
+			"the replica %s recovers before the failover is done, and the role %s is given back", fenced.Name, role)
+		return nil
+	}
+	message := fmt.Sprintf("resume the failover of the fenced replica %s holding the role %s", fenced.Name, role)
+	return r.doFailover(reqCtx, comp, synthesizedComp, role, fenced, pods, message)
+}
+
+func (r *FailoverReconciler) doFailover(reqCtx intctrlutil.RequestCtx, comp *appsv1.Component,
+	synthesizedComp *component.SynthesizedComponent, role string, holder *corev1.Pod, pods []*corev1.Pod, message string) error {
+	candidate, lag := r.pickCandidate(reqCtx, synthesizedComp, comp.Spec.FailoverPolicy, holder, pods)
+	if candidate == nil {
+		r.Recorder.Eventf(comp, corev1.EventTypeWarning, "FailoverFailed",
+			"%s, but there is no available candidate to take the role", message)
+		return nil
+	}
+
+	if _, ok := holder.Labels[constant.FencedRoleLabelKey]; !ok {
+		if err := r.fence(reqCtx.Ctx, holder, role); err != nil {
+			return err
+		}
+		r.Recorder.Eventf(comp, corev1.EventTypeWarning, "ReplicaFenced",
+			"%s, the replica is fenced", message)
+	}
+
+	if err := r.switchover(reqCtx.Ctx, synthesizedComp, role, holder, candidate); err != nil {
+		r.Recorder.Eventf(comp, corev1.EventTypeWarning, "FailoverFailed",
+			"failed to switch the role %s over to the replica %s: %s", role, candidate.Name, err.Error())
+		return err
+	}
+
+	compCopy := comp.DeepCopy()
+	comp.Status.FailoverHistory = append(comp.Status.FailoverHistory, appsv1.FailoverRecord{
+		Role:    role,
+		From:    holder.Name,
+		To:      candidate.Name,
+		Lag:     ptr.To(lag),
+		Time:    metav1.Now(),
+		Message: message,
+	})
+	if len(comp.Status.FailoverHistory) > maxFailoverHistory {
+		comp.Status.FailoverHistory = comp.Status.FailoverHistory[len(comp.Status.FailoverHistory)-maxFailoverHistory:]
+	}
+	if err := r.Client.Status().Patch(reqCtx.Ctx, comp, client.MergeFrom(compCopy)); err != nil {
+		return err
+	}
+	r.Recorder.Eventf(comp, corev1.EventTypeNormal, "FailoverSucceeded",
+		"the role %s is switched over from the replica %s to %s, with the replication lag %d", role, holder.Name, candidate.Name, lag)
+	return nil
+}
+
+// pickCandidate picks the most up-to-date replica among the healthy ones, by the `replicationLag` lifecycle action.
+func (r *FailoverReconciler) pickCandidate(reqCtx intctrlutil.RequestCtx, synthesizedComp *component.SynthesizedComponent,
+	policy *appsv1.FailoverPolicy, holder *corev1.Pod, pods []*corev1.Pod) (*corev1.Pod, int64) {
+	var (
+		candidate *corev1.Pod
+		minLag    int64
+	)
+	for _, pod := range pods {
+		if pod.Name == holder.Name || !intctrlutil.IsPodReady(pod) {
+			continue
+		}
+		if _, ok := pod.Labels[constant.FencedRoleLabelKey]; ok {
+			continue
+		}
+		lag, err := r.replicationLag(reqCtx.Ctx, synthesizedComp, pod)
+		if err != nil {
+			reqCtx.Log.Info("failed to query the replication lag of the replica", "pod", pod.Name, "error", err.Error())
+			continue
+		}
+		if policy.MaxLag != nil && lag > *policy.MaxLag {
+			reqCtx.Log.Info("the replication lag of the replica exceeds the max lag", "pod", pod.Name, "lag", lag)
+			continue
+		}
+		if candidate == nil || lag < minLag {
+			candidate, minLag = pod, lag
+		}
+	}
+	return candidate, minLag
+}
+
+func (r *FailoverReconciler) replicationLag(ctx context.Context,
+	synthesizedComp *component.SynthesizedComponent, pod *corev1.Pod) (int64, error) {
+	lfa, err := lifecycle.New(synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name,
+		synthesizedComp.LifecycleActions, synthesizedComp.TemplateVars, pod, pod)
+	if err != nil {
+		return 0, err
+	}
+	output, err := lfa.ReplicationLag(ctx, r.Client, nil)
+	if err != nil {
+		return 0, err
+	}
+	lag, err := strconv.ParseInt(strings.TrimSpace(string(output)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid replication lag %q: %s", string(output), err.Error())
+	}
+	if lag < 0 {
+		return 0, fmt.Errorf("invalid replication lag %d", lag)
+	}
+	return lag, nil
+}
+
+// switchover switches the role over to the candidate. The `switchover` action is executed on the candidate,
+// since the replica holding the role is unavailable.
+func (r *FailoverReconciler) switchover(ctx context.Context,
+	synthesizedComp *component.SynthesizedComponent, role string, holder, candidate *corev1.Pod) error {
+	actions := synthesizedComp.LifecycleActions.DeepCopy()
+	actions.Switchover.TargetPodSelector = appsv1.AnyReplica
+	current := holder.DeepCopy()
+	current.Labels[constant.RoleLabelKey] = role
+	lfa, err := lifecycle.New(synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name,
+		actions, synthesizedComp.TemplateVars, current, candidate)
+	if err != nil {
+		return err
+	}
+	return lfa.Switchover(ctx, r.Client, nil, candidate.Name)
+}
+
+// fence takes the role away from the replica, which removes it from the endpoints of the services selecting the role.
+func (r *FailoverReconciler) fence(ctx context.Context, pod *corev1.Pod, role string) error {
+	podCopy := pod.DeepCopy()
+	delete(pod.Labels, constant.RoleLabelKey)
+	pod.Labels[constant.FencedRoleLabelKey] = role
+	return r.Client.Patch(ctx, pod, client.MergeFrom(podCopy))
+}
+
+func (r *FailoverReconciler) unfence(ctx context.Context, pod *corev1.Pod, role string) error {
+	podCopy := pod.DeepCopy()
+	delete(pod.Labels, constant.FencedRoleLabelKey)
+	pod.Labels[constant.RoleLabelKey] = role
+	return r.Client.Patch(ctx, pod, client.MergeFrom(podCopy))
+}
+
+// isWorkloadUpdating checks whether the replicas are being updated, during which they may be restarted as expected.
+func (r *FailoverReconciler) isWorkloadUpdating(ctx context.Context, comp *appsv1.Component) (bool, error) {
+	clusterName, err := component.GetClusterName(comp)
+	if err != nil {
+		return false, err
+	}
+	compName, err := component.ShortName(clusterName, comp.Name)
+	if err != nil {
+		return false, err
+	}
+	its := &workloads.InstanceSet{}
+	itsKey := types.NamespacedName{
+		Namespace: comp.Namespace,
+		Name:      constant.GenerateWorkloadNamePattern(clusterName, compName),
+	}
+	if err = r.Client.Get(ctx, itsKey, its); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	return its.Status.ObservedGeneration != its.Generation ||
+		its.Spec.Replicas == nil || its.Status.UpdatedReplicas != *its.Spec.Replicas, nil
+}
+
+func failoverSupported(compDef *appsv1.ComponentDefinition) bool {
+	actions := compDef.Spec.LifecycleActions
+	return actions != nil && actions.RoleProbe != nil && actions.Switchover != nil && actions.ReplicationLag != nil
+}
+
+func failoverAllowedPhase(phase appsv1.ComponentPhase) bool {
+	return slices.Contains([]appsv1.ComponentPhase{
+		appsv1.RunningComponentPhase, appsv1.UpdatingComponentPhase, appsv1.FailedComponentPhase,
+	}, phase)
+}
+
+// failoverRole returns the role to fail over, which defaults to the role with the highest update priority.
+func failoverRole(policy *appsv1.FailoverPolicy, compDef *appsv1.ComponentDefinition) string {
+	var role *appsv1.ReplicaRole
+	for i := range compDef.Spec.Roles {
+		r := &compDef.Spec.Roles[i]
+		if len(policy.Role) > 0 {
+			if r.Name == policy.Role {
+				return r.Name
+			}
+			continue
+		}
+		if role == nil || r.UpdatePriority > role.UpdatePriority {
+			role = r
+		}
+	}
+	if role == nil {
+		return ""
+	}
+	return role.Name
+}
+
+func roleProbePeriod(actions *appsv1.ComponentLifecycleActions) time.Duration {
+	periodSeconds := actions.RoleProbe.PeriodSeconds
+	if periodSeconds <= 0 {
+		periodSeconds = defaultRoleProbePeriodSeconds
+	}
+	return time.Duration(periodSeconds) * time.Second
+}
+
+// unhealthySince returns the time since when the replica is unhealthy, that is, not ready or being deleted.
+func unhealthySince(pod *corev1.Pod) (time.Time, bool) {
+	if intctrlutil.IsPodReady(pod) {
+		return time.Time{}, false
+	}
+	if cond := intctrlutil.GetPodCondition(&pod.Status, corev1.PodReady); cond != nil && cond.Status != corev1.ConditionTrue {
+		return cond.LastTransitionTime.Time, true
+	}
+	if pod.DeletionTimestamp != nil {
+		return pod.DeletionTimestamp.Time, true
+	}
+	return pod.CreationTimestamp.Time, true
+}
+
+func lastFailover(comp *appsv1.Component) *appsv1.FailoverRecord {
+	if len(comp.Status.FailoverHistory) == 0 {
+		return nil
+	}
+	return &comp.Status.FailoverHistory[len(comp.Status.FailoverHistory)-1]
+}
+
+func podNames(pods []*corev1.Pod) []string {
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	return names
+}
@@ -0,0 +1,291 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	kbacli "github.com/apecloud/kubeblocks/pkg/kbagent/client"
+	kbagentproto "github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("failover test", func() {
+	const (
+		namespace   = "default"
+		clusterName = "test-cluster"
+		compName    = "comp"
+		compDefName = "test-compdef"
+		leader      = "leader"
+		follower    = "follower"
+	)
+
+	var (
+		compKey    = types.NamespacedName{Namespace: namespace, Name: constant.GenerateClusterComponentName(clusterName, compName)}
+		compDef    *appsv1.ComponentDefinition
+		comp       *appsv1.Component
+		pods       []*corev1.Pod
+		reconciler *FailoverReconciler
+		actions    []string
+		lags       []string
+		switchover map[string]string
+	)
+
+	podName := func(ordinal int) string {
+		return fmt.Sprintf("%s-%d", compKey.Name, ordinal)
+	}
+
+	newPod := func(ordinal int, role string, ready bool) *corev1.Pod {
+		status := corev1.ConditionTrue
+		if !ready {
+			status = corev1.ConditionFalse
+		}
+		labels := constant.GetCompLabels(clusterName, compName)
+		if len(role) > 0 {
+			labels[constant.RoleLabelKey] = role
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      podName(ordinal),
+				Labels:    labels,
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{
+					{
+						Type:               corev1.PodReady,
+						Status:             status,
+						LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+					},
+				},
+			},
+		}
+	}
+
+	newReconciler := func() {
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).Should(Succeed())
+		Expect(appsv1.AddToScheme(scheme)).Should(Succeed())
+		Expect(workloads.AddToScheme(scheme)).Should(Succeed())
+		objs := []client.Object{compDef, comp, &workloads.InstanceSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      constant.GenerateWorkloadNamePattern(clusterName, compName),
+			},
+			Spec: workloads.InstanceSetSpec{
+				Replicas: ptr.To[int32](3),
+			},
+			Status: workloads.InstanceSetStatus{
+				UpdatedReplicas: 3,
+			},
+		}}
+		for _, pod := range pods {
+			objs = append(objs, pod)
+		}
+		cli := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objs...).
+			WithStatusSubresource(&appsv1.Component{}).
+			Build()
+		reconciler = &FailoverReconciler{
+			Client:   cli,
+			Scheme:   scheme,
+			Recorder: record.NewFakeRecorder(10),
+		}
+	}
+
+	reconcile := func() {
+		_, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: compKey})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(reconciler.Client.Get(context.Background(), compKey, comp)).Should(Succeed())
+	}
+
+	getPod := func(ordinal int) *corev1.Pod {
+		pod := &corev1.Pod{}
+		Expect(reconciler.Client.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: podName(ordinal)}, pod)).Should(Succeed())
+		return pod
+	}
+
+	BeforeEach(func() {
+		compDef = testapps.NewComponentDefinitionFactory(compDefName).
+			SetDefaultSpec().
+			GetObject().DeepCopy()
+		compDef.Spec.LifecycleActions.Switchover = testapps.NewLifecycleAction("switchover")
+		compDef.Spec.LifecycleActions.ReplicationLag = testapps.NewLifecycleAction("replication-lag")
+		comp = &appsv1.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      compKey.Name,
+				Labels:    constant.GetCompLabels(clusterName, compName),
+				Annotations: map[string]string{
+					constant.KBAppClusterUIDKey: "uid",
+				},
+			},
+			Spec: appsv1.ComponentSpec{
+				CompDef:        compDefName,
+				Replicas:       3,
+				FailoverPolicy: &appsv1.FailoverPolicy{},
+			},
+			Status: appsv1.ComponentStatus{
+				Phase: appsv1.UpdatingComponentPhase,
+			},
+		}
+		pods = []*corev1.Pod{
+			newPod(0, leader, false),
+			newPod(1, follower, true),
+			newPod(2, follower, true),
+		}
+
+		actions = nil
+		lags = []string{"100", "10"}
+		switchover = nil
+		testapps.MockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+			recorder.Action(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req kbagentproto.ActionRequest) (kbagentproto.ActionResponse, error) {
+				actions = append(actions, req.Action)
+				switch req.Action {
+				case "replicationLag":
+					lag := lags[0]
+					lags = lags[1:]
+					return kbagentproto.ActionResponse{Output: []byte(lag)}, nil
+				case "switchover":
+					switchover = req.Parameters
+				}
+				return kbagentproto.ActionResponse{}, nil
+			}).AnyTimes()
+		})
+	})
+
+	AfterEach(func() {
+		kbacli.UnsetMockClient()
+	})
+
+	Context("failover role", func() {
+		It("defaults to the role with the highest update priority", func() {
+			Expect(failoverRole(&appsv1.FailoverPolicy{}, compDef)).Should(Equal(leader))
+			Expect(failoverRole(&appsv1.FailoverPolicy{Role: follower}, compDef)).Should(Equal(follower))
+			Expect(failoverRole(&appsv1.FailoverPolicy{Role: "unknown"}, compDef)).Should(BeEmpty())
+		})
+	})
+
+	Context("reconcile", func() {
+		It("does nothing if the replica holding the role is healthy", func() {
+			pods[0] = newPod(0, leader, true)
+			newReconciler()
+			reconcile()
+			Expect(actions).Should(BeEmpty())
+			Expect(comp.Status.FailoverHistory).Should(BeEmpty())
+		})
+
+		It("does nothing if the replicationLag action is not defined", func() {
+			compDef.Spec.LifecycleActions.ReplicationLag = nil
+			newReconciler()
+			reconcile()
+			Expect(actions).Should(BeEmpty())
+			Expect(getPod(0).Labels).Should(HaveKeyWithValue(constant.RoleLabelKey, leader))
+		})
+
+		It("fails over to the most up-to-date replica", func() {
+			newReconciler()
+			reconcile()
+			Expect(actions).Should(Equal([]string{"replicationLag", "replicationLag", "switchover"}))
+			Expect(switchover).Should(HaveKeyWithValue("KB_SWITCHOVER_CURRENT_NAME", podName(0)))
+			Expect(switchover).Should(HaveKeyWithValue("KB_SWITCHOVER_CANDIDATE_NAME", podName(2)))
+			Expect(switchover).Should(HaveKeyWithValue("KB_SWITCHOVER_ROLE", leader))
+
+			pod := getPod(0)
+			Expect(pod.Labels).ShouldNot(HaveKey(constant.RoleLabelKey))
+			Expect(pod.Labels).Should(HaveKeyWithValue(constant.FencedRoleLabelKey, leader))
+
+			Expect(comp.Status.FailoverHistory).Should(HaveLen(1))
+			record := comp.Status.FailoverHistory[0]
+			Expect(record.Role).Should(Equal(leader))
+			Expect(record.From).Should(Equal(podName(0)))
+			Expect(record.To).Should(Equal(podName(2)))
+			Expect(record.Lag).Should(Equal(ptr.To[int64](10)))
+
+			By("waiting for the new replica to take the role")
+			reconcile()
+			Expect(actions).Should(HaveLen(3))
+			Expect(comp.Status.FailoverHistory).Should(HaveLen(1))
+		})
+
+		It("skips the candidates lagging behind too much", func() {
+			comp.Spec.FailoverPolicy.MaxLag = ptr.To[int64](5)
+			newReconciler()
+			reconcile()
+			Expect(actions).Should(Equal([]string{"replicationLag", "replicationLag"}))
+			Expect(getPod(0).Labels).Should(HaveKeyWithValue(constant.RoleLabelKey, leader))
+			Expect(comp.Status.FailoverHistory).Should(BeEmpty())
+		})
+
+		It("suspends the failover within the min interval", func() {
+			comp.Status.FailoverHistory = []appsv1.FailoverRecord{
+				{
+					Role: leader,
+					From: podName(1),
+					To:   podName(0),
+					Time: metav1.NewTime(time.Now().Add(-time.Minute)),
+				},
+			}
+			newReconciler()
+			reconcile()
+			Expect(actions).Should(BeEmpty())
+			Expect(getPod(0).Labels).Should(HaveKeyWithValue(constant.RoleLabelKey, leader))
+		})
+
+		It("suspends the failover if more than one replica holds the role", func() {
+			pods[1] = newPod(1, leader, true)
+			newReconciler()
+			reconcile()
+			Expect(actions).Should(BeEmpty())
+			Expect(getPod(0).Labels).Should(HaveKeyWithValue(constant.RoleLabelKey, leader))
+		})
+
+		It("gives the role back to the fenced replica that recovers before the failover is done", func() {
+			pods[0] = newPod(0, "", true)
+			pods[0].Labels[constant.FencedRoleLabelKey] = leader
+			newReconciler()
+			reconcile()
+			Expect(actions).Should(BeEmpty())
+			pod := getPod(0)
+			Expect(pod.Labels).Should(HaveKeyWithValue(constant.RoleLabelKey, leader))
+			Expect(pod.Labels).ShouldNot(HaveKey(constant.FencedRoleLabelKey))
+		})
+	})
+})
@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package component
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 
@@ -41,6 +42,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
 )
 
 const (
@@ -95,7 +97,9 @@ func (t *componentAccountTransformer) Transform(ctx graph.TransformContext, dag
 	}
 
 	for _, name := range sets.List(deleteSet) {
-		t.deleteAccount(transCtx, dag, graphCli, secrets[name])
+		if err := t.deleteAccount(transCtx, dag, graphCli, secrets[name]); err != nil {
+			return err
+		}
 	}
 
 	for _, name := range sets.List(updateSet) {
@@ -119,37 +123,47 @@ func (t *componentAccountTransformer) Transform(ctx graph.TransformContext, dag
 
 func (t *componentAccountTransformer) createAccount(transCtx *componentTransformContext,
 	dag *graph.DAG, graphCli model.GraphClient, account synthesizedSystemAccount) error {
-	secret, err := t.buildAccountSecret(transCtx, transCtx.SynthesizeComponent, account)
+	secret, err := t.buildAccountSecret(transCtx, transCtx.SynthesizeComponent, account, nil)
 	if err != nil {
 		return err
 	}
 	if err = t.buildAccountHash(account, nil, secret); err != nil {
 		return err
 	}
+	referToSecretStore(secret)
 	graphCli.Create(dag, secret)
 	return nil
 }
 
 func (t *componentAccountTransformer) deleteAccount(transCtx *componentTransformContext,
-	dag *graph.DAG, graphCli model.GraphClient, secret *corev1.Secret) {
+	dag *graph.DAG, graphCli model.GraphClient, secret *corev1.Secret) error {
+	if err := deleteAccountFromSecretStore(transCtx.Context, secret); err != nil {
+		return err
+	}
 	graphCli.Delete(dag, secret)
+	return nil
 }
 
 func (t *componentAccountTransformer) updateAccount(transCtx *componentTransformContext,
 	dag *graph.DAG, graphCli model.GraphClient, account synthesizedSystemAccount, running *corev1.Secret) error {
-	secret, err := t.buildAccountSecret(transCtx, transCtx.SynthesizeComponent, account)
+	secret, err := t.buildAccountSecret(transCtx, transCtx.SynthesizeComponent, account, running)
 	if err != nil {
 		return err
 	}
 	if err = t.buildAccountHash(account, running, secret); err != nil {
 		return err
 	}
+	referToSecretStore(secret)
 
 	runningCopy := running.DeepCopy()
-	if account.SecretRef != nil {
-		// sync password from the external secret
+	if account.SecretRef != nil || secretstore.IsReferred(secret) {
+		// sync password from the external secret, or replace it with the reference to the secret store
 		runningCopy.Data[constant.AccountPasswdForSecret] = secret.Data[constant.AccountPasswdForSecret]
 	}
+	if secretstore.IsReferred(secret) {
+		// the pending password has been moved to the secret store
+		delete(runningCopy.Data, systemAccountPendingPasswordKey)
+	}
 	ctrlutil.MergeMetadataMapInplace(secret.Labels, &runningCopy.Labels)
 	ctrlutil.MergeMetadataMapInplace(secret.Annotations, &runningCopy.Annotations)
 	if !reflect.DeepEqual(running, runningCopy) {
@@ -164,7 +178,7 @@ func (t *componentAccountTransformer) buildAccountHash(account synthesizedSystem
 	}
 	if running != nil {
 		hashedPassword := running.Annotations[systemAccountHashAnnotation]
-		if verifySystemAccountPassword(secret, []byte(hashedPassword)) {
+		if verifySystemAccountPassword(secret.Data[constant.AccountPasswdForSecret], []byte(hashedPassword)) {
 			if secret.Annotations == nil {
 				secret.Annotations = map[string]string{}
 			}
@@ -176,14 +190,29 @@ func (t *componentAccountTransformer) buildAccountHash(account synthesizedSystem
 }
 
 func (t *componentAccountTransformer) buildAccountSecret(ctx *componentTransformContext,
-	synthesizeComp *component.SynthesizedComponent, account synthesizedSystemAccount) (*corev1.Secret, error) {
+	synthesizeComp *component.SynthesizedComponent, account synthesizedSystemAccount, running *corev1.Secret) (*corev1.Secret, error) {
+	store, err := secretstore.Default()
+	if err != nil {
+		return nil, err
+	}
+
 	var password []byte
-	var err error
+	storePath := secretstore.SecretPath(synthesizeComp.Namespace,
+		constant.GenerateAccountSecretName(synthesizeComp.ClusterName, synthesizeComp.Name, account.Name))
 	switch {
 	case account.SecretRef != nil:
 		if password, err = t.getPasswordFromSecret(ctx, account); err != nil {
 			return nil, err
 		}
+		if store != nil {
+			if err = t.syncPasswordToSecretStore(ctx, store, storePath, account, password); err != nil {
+				return nil, err
+			}
+		}
+	case store != nil:
+		if password, err = t.buildPasswordInSecretStore(ctx, store, storePath, account, running); err != nil {
+			return nil, err
+		}
 	default:
 		password, err = t.buildPassword(ctx, account)
 		if err != nil {
@@ -196,6 +225,43 @@ func (t *componentAccountTransformer) buildAccountSecret(ctx *componentTransform
 	return t.buildAccountSecretWithPassword(ctx, synthesizeComp, account, password)
 }
 
+// buildPasswordInSecretStore returns the password kept in the secret store, the password will be generated
+// and written back to the store if it's not there yet.
+func (t *componentAccountTransformer) buildPasswordInSecretStore(ctx *componentTransformContext,
+	store secretstore.Provider, path string, account synthesizedSystemAccount, running *corev1.Secret) ([]byte, error) {
+	data, err := store.Get(ctx.Context, path)
+	if err != nil && !secretstore.IsNotFound(err) {
+		return nil, err
+	}
+	if len(data[constant.AccountPasswdForSecret]) > 0 {
+		return []byte(data[constant.AccountPasswdForSecret]), nil
+	}
+
+	var password []byte
+	data = map[string]string{
+		constant.AccountNameForSecret: account.Name,
+	}
+	switch {
+	case running != nil && secretstore.IsReferred(running):
+		return nil, fmt.Errorf("the password of system account %s is missing in the secret store: %s", account.Name, path)
+	case running != nil && len(running.Data[constant.AccountPasswdForSecret]) > 0:
+		// move the password kept in the secret to the store, along with the pending one of the rotation
+		password = running.Data[constant.AccountPasswdForSecret]
+		if pending := running.Data[systemAccountPendingPasswordKey]; len(pending) > 0 {
+			data[systemAccountPendingPasswordKey] = string(pending)
+		}
+	default:
+		if password, err = t.buildPassword(ctx, account); err != nil {
+			return nil, err
+		}
+	}
+	data[constant.AccountPasswdForSecret] = string(password)
+	if err = store.Put(ctx.Context, path, data); err != nil {
+		return nil, err
+	}
+	return password, nil
+}
+
 func (t *componentAccountTransformer) getPasswordFromSecret(ctx graph.TransformContext, account synthesizedSystemAccount) ([]byte, error) {
 	secretKey := types.NamespacedName{
 		Namespace: account.SecretRef.Namespace,
@@ -210,10 +276,33 @@ func (t *componentAccountTransformer) getPasswordFromSecret(ctx graph.TransformC
 	if len(account.SecretRef.Password) > 0 {
 		passwordKey = account.SecretRef.Password
 	}
-	if len(secret.Data) == 0 || len(secret.Data[passwordKey]) == 0 {
+	// the referenced secret may keep its data in the secret store too
+	data, err := secretstore.ResolveSecretData(ctx.GetContext(), secret)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 || len(data[passwordKey]) == 0 {
 		return nil, fmt.Errorf("referenced account secret has no required credential field: %s", passwordKey)
 	}
-	return secret.Data[passwordKey], nil
+	return data[passwordKey], nil
+}
+
+// syncPasswordToSecretStore keeps the password referenced from the external secret in the secret store.
+func (t *componentAccountTransformer) syncPasswordToSecretStore(ctx *componentTransformContext,
+	store secretstore.Provider, path string, account synthesizedSystemAccount, password []byte) error {
+	data, err := store.Get(ctx.Context, path)
+	if err != nil && !secretstore.IsNotFound(err) {
+		return err
+	}
+	if data[constant.AccountPasswdForSecret] == string(password) {
+		return nil
+	}
+	if data == nil {
+		data = map[string]string{}
+	}
+	data[constant.AccountNameForSecret] = account.Name
+	data[constant.AccountPasswdForSecret] = string(password)
+	return store.Put(ctx.Context, path, data)
 }
 
 func (t *componentAccountTransformer) buildPassword(ctx *componentTransformContext, account synthesizedSystemAccount) ([]byte, error) {
@@ -256,6 +345,45 @@ func (t *componentAccountTransformer) buildAccountSecretWithPassword(ctx *compon
 	return secret, nil
 }
 
+// referToSecretStore replaces the password in the account secret with the reference to the secret store if it's configured,
+// the password has been kept in the secret store at the path of the secret.
+func referToSecretStore(secret *corev1.Secret) {
+	if secretstore.Enabled() {
+		secretstore.ReferTo(secret, secretstore.SecretPath(secret.Namespace, secret.Name), constant.AccountPasswdForSecret)
+	}
+}
+
+// readAccountData returns the credential data of the account, which is kept in the account secret,
+// or in the secret store if the secret refers to it.
+func readAccountData(ctx context.Context, secret *corev1.Secret) (map[string][]byte, error) {
+	return secretstore.ResolveSecretData(ctx, secret)
+}
+
+// writeAccountData writes the credential data of the account back to the secret store if the secret refers to it,
+// the data kept in the account secret is updated along with the secret itself.
+func writeAccountData(ctx context.Context, secret *corev1.Secret, data map[string][]byte) error {
+	path, ok := secret.Annotations[constant.SecretStorePathAnnotationKey]
+	if !ok {
+		return nil
+	}
+	store, err := secretstore.Default()
+	if err != nil {
+		return err
+	}
+	if store == nil {
+		return fmt.Errorf("the account secret %s refers to the secret store, but the secret store is not configured", secret.Name)
+	}
+	m := make(map[string]string)
+	for k, v := range data {
+		m[k] = string(v)
+	}
+	return store.Put(ctx, path, m)
+}
+
+func deleteAccountFromSecretStore(ctx context.Context, secret *corev1.Secret) error {
+	return secretstore.DeleteSecretData(ctx, secret)
+}
+
 func listSystemAccountObjects(ctx graph.TransformContext,
 	synthesizedComp *component.SynthesizedComponent) (map[string]*corev1.Secret, error) {
 	opts := []client.ListOption{
@@ -289,16 +417,16 @@ func signatureSystemAccountPassword(secret *corev1.Secret) error {
 	return nil
 }
 
-func verifySystemAccountPassword(secret *corev1.Secret, hashedPassword []byte) bool {
-	password := secret.Data[constant.AccountPasswdForSecret]
+func verifySystemAccountPassword(password, hashedPassword []byte) bool {
 	err := bcrypt.CompareHashAndPassword(hashedPassword, password)
 	return err == nil
 }
 
 type synthesizedSystemAccount struct {
 	appsv1.SystemAccount
-	Disabled  *bool
-	SecretRef *appsv1.ProvisionSecretRef
+	Disabled       *bool
+	SecretRef      *appsv1.ProvisionSecretRef
+	RotationPolicy *appsv1.PasswordRotationPolicy
 }
 
 func synthesizeSystemAccounts(compDefAccounts []appsv1.SystemAccount,
@@ -316,6 +444,7 @@ func synthesizeSystemAccounts(compDefAccounts []appsv1.SystemAccount,
 		}
 		account.Disabled = compAccount.Disabled
 		account.SecretRef = compAccount.SecretRef
+		account.RotationPolicy = compAccount.RotationPolicy
 		return account
 	}
 
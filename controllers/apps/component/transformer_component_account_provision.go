@@ -33,11 +33,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
 	"github.com/apecloud/kubeblocks/pkg/common"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
@@ -45,6 +47,10 @@ const (
 	accountProvisionConditionType             = "SystemAccountProvision"
 	accountProvisionConditionReasonInProgress = "InProgress"
 	accountProvisionConditionReasonDone       = "AllProvisioned"
+
+	systemAccountRotatedAtAnnotation      = "apps.kubeblocks.io/system-account-rotated-at"
+	systemAccountGracePeriodEndAnnotation = "apps.kubeblocks.io/system-account-grace-period-end"
+	systemAccountPendingPasswordKey       = "pendingPassword"
 )
 
 // componentAccountProvisionTransformer provisions component system accounts.
@@ -124,11 +130,23 @@ func (t *componentAccountProvisionTransformer) Transform(ctx graph.TransformCont
 		}
 	}
 
+	for _, name := range sets.List(updateSet) {
+		if err := t.rotateAccount(transCtx, dag, lfa, accounts[name], secrets[name]); err != nil {
+			if err3 == nil {
+				err3 = err
+			}
+		}
+	}
+
 	t.provisionCondDone(transCtx, condCopy, &cond, err3)
+	t.rotationStatus(transCtx, dag, accounts, secrets, updateSet)
 
 	if err3 != nil {
 		// accountProvision might rely on postProvision to do some initialization, so delay this error to let postProvision run
 		err3 = fmt.Errorf("%w: %w", intctrlutil.NewDelayedRequeueError(time.Second*10, "account provision action failed"), err3)
+	} else if next := t.nextRotationTime(transCtx, dag, accounts, secrets, updateSet); next != nil {
+		// come back on time to rotate the password or discard the previous one
+		err3 = intctrlutil.NewDelayedRequeueError(time.Until(*next), "rotate the passwords of system accounts")
 	}
 
 	return err3
@@ -192,7 +210,11 @@ func (t *componentAccountProvisionTransformer) updateAccount(transCtx *component
 	if hashedPassword == "" {
 		return nil // passwords that generated by KB or restored from backup, do not support updating?
 	}
-	if verifySystemAccountPassword(secret, []byte(hashedPassword)) {
+	data, err := readAccountData(transCtx.Context, secret)
+	if err != nil {
+		return err
+	}
+	if verifySystemAccountPassword(data[constant.AccountPasswdForSecret], []byte(hashedPassword)) {
 		return nil // the password is not changed
 	}
 
@@ -202,20 +224,210 @@ func (t *componentAccountProvisionTransformer) updateAccount(transCtx *component
 
 	// TODO: how to notify other apps to update the new password?
 
-	err := t.provision(transCtx, lfa, account.Statement.Update, secret)
+	err = t.provision(transCtx, lfa, account.Statement.Update, secret)
 	if err == nil {
 		t.updateProvisionedAccount(cond, account.Name, secret.Annotations[systemAccountHashAnnotation])
 	}
 	return err
 }
 
+// rotateAccount rotates the password of the account periodically as the rotation policy defined.
+//
+// The rotation is done in two phases to keep the account secret consistent with the engine:
+//  1. a new password is generated and saved into the secret (or the secret store) as the pending password;
+//  2. the pending password is applied through the accountProvision action, and then it replaces the current password
+//     in the secret, along with the rotation time, in one update.
+//
+// The pending password is kept if the action fails, and it will be retried in the next reconciliation.
+//
+// The previous password is retained as the secondary one until the grace period ends and all the replicas have been
+// restarted to pick up the new one, so the rotation is refused if the engine doesn't support dual passwords.
+func (t *componentAccountProvisionTransformer) rotateAccount(transCtx *componentTransformContext,
+	dag *graph.DAG, lfa lifecycle.Lifecycle, account synthesizedSystemAccount, secret *corev1.Secret) error {
+	if account.Disabled != nil && *account.Disabled {
+		return nil
+	}
+
+	now := time.Now()
+	secret = t.accountSecret(transCtx, dag, secret)
+
+	// discard the previous password once the grace period ends and the new one has been rolled out
+	if gracePeriodEnd := accountSecretTime(secret, systemAccountGracePeriodEndAnnotation); gracePeriodEnd != nil {
+		if now.Before(gracePeriodEnd.Time) || !isAccountPasswordRolledOut(transCtx, secret) {
+			return nil // don't start a new rotation until the previous password is discarded
+		}
+		if account.Statement != nil && len(account.Statement.DiscardSecondaryPassword) > 0 {
+			if err := t.provision(transCtx, lfa, account.Statement.DiscardSecondaryPassword, secret); err != nil {
+				return err
+			}
+		}
+		return t.updateAccountSecret(transCtx, dag, secret, func(s *corev1.Secret, _ map[string][]byte) {
+			delete(s.Annotations, systemAccountGracePeriodEndAnnotation)
+		})
+	}
+
+	if !isAccountRotatable(account) {
+		if account.SecretRef == nil && account.RotationPolicy != nil && transCtx.EventRecorder != nil {
+			transCtx.EventRecorder.Eventf(transCtx.Component, corev1.EventTypeWarning, "SystemAccountRotationUnsupported",
+				"the password of system account %s can't be rotated, the dual-password statements are not defined", account.Name)
+		}
+		return nil
+	}
+
+	data, err := readAccountData(transCtx.Context, secret)
+	if err != nil {
+		return err
+	}
+	password := data[systemAccountPendingPasswordKey]
+	if len(password) == 0 {
+		if now.Before(lastAccountRotationTime(secret).Add(account.RotationPolicy.Interval.Duration)) {
+			return nil
+		}
+		pending, err := common.GeneratePasswordByConfig(account.PasswordGenerationPolicy)
+		if err != nil {
+			return err
+		}
+		if err = t.updateAccountSecret(transCtx, dag, secret, func(_ *corev1.Secret, data map[string][]byte) {
+			data[systemAccountPendingPasswordKey] = []byte(pending)
+		}); err != nil {
+			return err
+		}
+		if _, ok := secret.Annotations[constant.SecretStorePathAnnotationKey]; !ok {
+			return nil // wait for the pending password to be persisted along with the secret
+		}
+		password = []byte(pending)
+	}
+
+	var gracePeriod time.Duration
+	if account.RotationPolicy.GracePeriod != nil {
+		gracePeriod = account.RotationPolicy.GracePeriod.Duration
+	}
+
+	username := data[constant.AccountNameForSecret]
+	statement := account.Statement.DualPasswordUpdate
+	if err = lfa.AccountProvision(transCtx.Context, transCtx.Client, nil, statement, string(username), string(password)); err != nil {
+		return err
+	}
+
+	if err = t.updateAccountSecret(transCtx, dag, secret, func(s *corev1.Secret, data map[string][]byte) {
+		data[constant.AccountPasswdForSecret] = password
+		delete(data, systemAccountPendingPasswordKey)
+		if s.Annotations == nil {
+			s.Annotations = map[string]string{}
+		}
+		s.Annotations[systemAccountRotatedAtAnnotation] = now.UTC().Format(time.RFC3339)
+		s.Annotations[systemAccountGracePeriodEndAnnotation] = now.Add(gracePeriod).UTC().Format(time.RFC3339)
+	}); err != nil {
+		return err
+	}
+	if transCtx.EventRecorder != nil {
+		transCtx.EventRecorder.Eventf(transCtx.Component, corev1.EventTypeNormal, "SystemAccountPasswordRotated",
+			"the password of system account %s has been rotated", account.Name)
+	}
+	return nil
+}
+
+// accountSecret returns the latest account secret, it may have been updated in the DAG.
+func (t *componentAccountProvisionTransformer) accountSecret(transCtx *componentTransformContext,
+	dag *graph.DAG, secret *corev1.Secret) *corev1.Secret {
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	if v := graphCli.FindMatchedVertex(dag, secret); v != nil {
+		return v.(*model.ObjectVertex).Obj.(*corev1.Secret)
+	}
+	return secret
+}
+
+// updateAccountSecret updates the account secret and its credential data, the data is written back to the secret store
+// immediately if the secret refers to it, otherwise it's updated along with the secret.
+func (t *componentAccountProvisionTransformer) updateAccountSecret(transCtx *componentTransformContext,
+	dag *graph.DAG, secret *corev1.Secret, update func(*corev1.Secret, map[string][]byte)) error {
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	var secretCopy *corev1.Secret
+	v := graphCli.FindMatchedVertex(dag, secret)
+	if v != nil {
+		// merge into the update of the account secret that already in the DAG
+		secretCopy = v.(*model.ObjectVertex).Obj.(*corev1.Secret)
+	} else {
+		secretCopy = secret.DeepCopy()
+	}
+
+	if _, ok := secretCopy.Annotations[constant.SecretStorePathAnnotationKey]; ok {
+		data, err := readAccountData(transCtx.Context, secretCopy)
+		if err != nil {
+			return err
+		}
+		update(secretCopy, data)
+		if err = writeAccountData(transCtx.Context, secretCopy, data); err != nil {
+			return err
+		}
+	} else {
+		if secretCopy.Data == nil {
+			secretCopy.Data = map[string][]byte{}
+		}
+		update(secretCopy, secretCopy.Data)
+	}
+
+	if v == nil && !reflect.DeepEqual(secret, secretCopy) {
+		graphCli.Update(dag, secret, secretCopy)
+	}
+	return nil
+}
+
+func (t *componentAccountProvisionTransformer) rotationStatus(transCtx *componentTransformContext,
+	dag *graph.DAG, accounts map[string]synthesizedSystemAccount, secrets map[string]*corev1.Secret, names sets.Set[string]) {
+	var status []appsv1.ComponentSystemAccountStatus
+	for _, name := range sets.List(names) {
+		secret := t.accountSecret(transCtx, dag, secrets[name])
+		lastRotationTime := accountSecretTime(secret, systemAccountRotatedAtAnnotation)
+		gracePeriodEndTime := accountSecretTime(secret, systemAccountGracePeriodEndAnnotation)
+		if !isAccountRotatable(accounts[name]) && lastRotationTime == nil {
+			continue
+		}
+		status = append(status, appsv1.ComponentSystemAccountStatus{
+			Name:               name,
+			LastRotationTime:   lastRotationTime,
+			GracePeriodEndTime: gracePeriodEndTime,
+		})
+	}
+	transCtx.Component.Status.SystemAccounts = status
+}
+
+// nextRotationTime returns the earliest time in the future to rotate a password or to discard a previous one.
+func (t *componentAccountProvisionTransformer) nextRotationTime(transCtx *componentTransformContext,
+	dag *graph.DAG, accounts map[string]synthesizedSystemAccount, secrets map[string]*corev1.Secret, names sets.Set[string]) *time.Time {
+	var next *time.Time
+	now := time.Now()
+	for _, name := range sets.List(names) {
+		account := accounts[name]
+		if account.Disabled != nil && *account.Disabled {
+			continue
+		}
+		secret := t.accountSecret(transCtx, dag, secrets[name])
+		var at time.Time
+		if gracePeriodEnd := accountSecretTime(secret, systemAccountGracePeriodEndAnnotation); gracePeriodEnd != nil {
+			// the rollout of the new password will trigger the reconciliation if the grace period has ended
+			at = gracePeriodEnd.Time
+		} else if isAccountRotatable(account) {
+			at = lastAccountRotationTime(secret).Add(account.RotationPolicy.Interval.Duration)
+		}
+		if at.After(now) && (next == nil || at.Before(*next)) {
+			next = &at
+		}
+	}
+	return next
+}
+
 func (t *componentAccountProvisionTransformer) provision(transCtx *componentTransformContext,
 	lfa lifecycle.Lifecycle, statement string, secret *corev1.Secret) error {
-	username, password := secret.Data[constant.AccountNameForSecret], secret.Data[constant.AccountPasswdForSecret]
+	data, err := readAccountData(transCtx.Context, secret)
+	if err != nil {
+		return err
+	}
+	username, password := data[constant.AccountNameForSecret], data[constant.AccountPasswdForSecret]
 	if len(username) == 0 || len(password) == 0 {
 		return nil
 	}
-	err := lfa.AccountProvision(transCtx.Context, transCtx.Client, nil, statement, string(username), string(password))
+	err = lfa.AccountProvision(transCtx.Context, transCtx.Client, nil, statement, string(username), string(password))
 	return lifecycle.IgnoreNotDefined(err)
 }
 
@@ -322,3 +534,49 @@ func (t *componentAccountProvisionTransformer) hashedPasswordFromCond(cond *meta
 	}
 	return ""
 }
+
+func isAccountRotatable(account synthesizedSystemAccount) bool {
+	// the password referenced from the secretRef is managed by the user
+	if account.SecretRef != nil || account.RotationPolicy == nil || account.RotationPolicy.Interval.Duration <= 0 {
+		return false
+	}
+	// the previous password must be retained until all the replicas pick up the new one
+	return account.Statement != nil &&
+		len(account.Statement.DualPasswordUpdate) > 0 && len(account.Statement.DiscardSecondaryPassword) > 0
+}
+
+// isAccountPasswordRolledOut checks whether all the replicas have been restarted with the rotated password.
+func isAccountPasswordRolledOut(transCtx *componentTransformContext, secret *corev1.Secret) bool {
+	rotatedAt := accountSecretTime(secret, systemAccountRotatedAtAnnotation)
+	if rotatedAt == nil {
+		return true
+	}
+	its, ok := transCtx.RunningWorkload.(*workloads.InstanceSet)
+	if !ok || its == nil {
+		return false
+	}
+	restartedAt, err := time.Parse(time.RFC3339, its.Spec.Template.Annotations[systemAccountRotatedAtAnnotation])
+	if err != nil || restartedAt.Before(rotatedAt.Time) {
+		return false
+	}
+	return its.IsInstancesReady()
+}
+
+func lastAccountRotationTime(secret *corev1.Secret) time.Time {
+	if t := accountSecretTime(secret, systemAccountRotatedAtAnnotation); t != nil {
+		return t.Time
+	}
+	return secret.CreationTimestamp.Time
+}
+
+func accountSecretTime(secret *corev1.Secret, annotation string) *metav1.Time {
+	val, ok := secret.Annotations[annotation]
+	if !ok {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return nil
+	}
+	return &metav1.Time{Time: t}
+}
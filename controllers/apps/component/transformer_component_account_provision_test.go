@@ -0,0 +1,385 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	appsutil "github.com/apecloud/kubeblocks/controllers/apps/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	kbacli "github.com/apecloud/kubeblocks/pkg/kbagent/client"
+	kbagentproto "github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	testsecretstore "github.com/apecloud/kubeblocks/pkg/testutil/secretstore"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+var _ = Describe("account provision transformer test", func() {
+	const (
+		compDefName = "test-compdef"
+		clusterName = "test-cluster"
+		compName    = "comp"
+		accountName = "root"
+		password    = "old-password"
+	)
+
+	var (
+		reader   *appsutil.MockReader
+		dag      *graph.DAG
+		transCtx *componentTransformContext
+		compDef  *appsv1.ComponentDefinition
+		comp     *appsv1.Component
+		secret   *corev1.Secret
+		its      *workloads.InstanceSet
+		requests []kbagentproto.ActionRequest
+	)
+
+	newDAG := func(graphCli model.GraphClient, comp *appsv1.Component) *graph.DAG {
+		d := graph.NewDAG()
+		graphCli.Root(d, comp, comp, model.ActionStatusPtr())
+		return d
+	}
+
+	transform := func() error {
+		synthesizeComponent, err := component.BuildSynthesizedComponent(ctx, reader, compDef.DeepCopy(), comp)
+		Expect(err).Should(BeNil())
+
+		graphCli := model.NewGraphClient(reader)
+		dag = newDAG(graphCli, comp)
+		transCtx = &componentTransformContext{
+			Context:             ctx,
+			Client:              graphCli,
+			EventRecorder:       nil,
+			Logger:              logger,
+			CompDef:             compDef,
+			Component:           comp,
+			ComponentOrig:       comp.DeepCopy(),
+			SynthesizeComponent: synthesizeComponent,
+		}
+		if its != nil {
+			transCtx.RunningWorkload = its
+		}
+		transformer := &componentAccountProvisionTransformer{}
+		return transformer.Transform(transCtx, dag)
+	}
+
+	secretInDAG := func() *corev1.Secret {
+		graphCli := transCtx.Client.(model.GraphClient)
+		objs := graphCli.FindAll(dag, &corev1.Secret{})
+		if len(objs) == 0 {
+			return nil
+		}
+		Expect(objs).Should(HaveLen(1))
+		return objs[0].(*corev1.Secret)
+	}
+
+	// the transformer requeues to rotate the password or to discard the previous one on time
+	expectRequeueAfter := func(err error, after time.Duration) {
+		Expect(intctrlutil.IsDelayedRequeueError(err)).Should(BeTrue())
+		Expect(err.(intctrlutil.RequeueError).RequeueAfter()).Should(BeNumerically("~", after, time.Minute))
+	}
+
+	// persist the secret updated in the DAG
+	persist := func(obj *corev1.Secret) {
+		for i, o := range reader.Objects {
+			if o.GetName() == obj.Name {
+				reader.Objects[i] = obj
+			}
+		}
+		secret = obj
+	}
+
+	BeforeEach(func() {
+		compDef = &appsv1.ComponentDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: compDefName,
+			},
+			Spec: appsv1.ComponentDefinitionSpec{
+				SystemAccounts: []appsv1.SystemAccount{
+					{
+						Name:        accountName,
+						InitAccount: true,
+						PasswordGenerationPolicy: appsv1.PasswordConfig{
+							Length:    16,
+							NumDigits: 4,
+						},
+						Statement: &appsv1.SystemAccountStatement{
+							Update:                   "update",
+							DualPasswordUpdate:       "dual-password-update",
+							DiscardSecondaryPassword: "discard-secondary-password",
+						},
+					},
+				},
+				LifecycleActions: &appsv1.ComponentLifecycleActions{
+					AccountProvision: testapps.NewLifecycleAction("account-provision"),
+				},
+			},
+		}
+
+		comp = &appsv1.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      constant.GenerateClusterComponentName(clusterName, compName),
+				Labels:    constant.GetCompLabels(clusterName, compName),
+				Annotations: map[string]string{
+					constant.KBAppClusterUIDKey: string(uuid.NewUUID()),
+				},
+			},
+			Spec: appsv1.ComponentSpec{
+				CompDef:  compDef.Name,
+				Replicas: 1,
+				SystemAccounts: []appsv1.ComponentSystemAccount{
+					{
+						Name: accountName,
+						RotationPolicy: &appsv1.PasswordRotationPolicy{
+							Interval: metav1.Duration{Duration: 90 * 24 * time.Hour},
+						},
+					},
+				},
+			},
+			Status: appsv1.ComponentStatus{
+				Phase: appsv1.RunningComponentPhase,
+				Conditions: []metav1.Condition{
+					{
+						Type:    accountProvisionConditionType,
+						Status:  metav1.ConditionTrue,
+						Reason:  accountProvisionConditionReasonDone,
+						Message: fmt.Sprintf("%s:", accountName),
+					},
+				},
+			},
+		}
+
+		secretLabels := constant.GetCompLabels(clusterName, compName)
+		secretLabels[systemAccountLabel] = accountName
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         testCtx.DefaultNamespace,
+				Name:              constant.GenerateAccountSecretName(clusterName, compName, accountName),
+				Labels:            secretLabels,
+				CreationTimestamp: metav1.Now(),
+			},
+			Data: map[string][]byte{
+				constant.AccountNameForSecret:   []byte(accountName),
+				constant.AccountPasswdForSecret: []byte(password),
+			},
+		}
+
+		reader = &appsutil.MockReader{
+			Objects: []client.Object{compDef, comp, secret, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testCtx.DefaultNamespace,
+					Name:      fmt.Sprintf("%s-0", constant.GenerateWorkloadNamePattern(clusterName, compName)),
+					Labels:    constant.GetCompLabels(clusterName, compName),
+				},
+			}},
+		}
+
+		its = nil
+		requests = nil
+		testapps.MockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+			recorder.Action(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req kbagentproto.ActionRequest) (kbagentproto.ActionResponse, error) {
+				requests = append(requests, req)
+				return kbagentproto.ActionResponse{}, nil
+			}).AnyTimes()
+		})
+	})
+
+	AfterEach(func() {
+		kbacli.UnsetMockClient()
+	})
+
+	Context("password rotation", func() {
+		It("not due", func() {
+			expectRequeueAfter(transform(), 90*24*time.Hour)
+			Expect(requests).Should(BeEmpty())
+			Expect(secretInDAG()).Should(BeNil())
+			Expect(comp.Status.SystemAccounts).Should(HaveLen(1))
+			Expect(comp.Status.SystemAccounts[0].Name).Should(Equal(accountName))
+			Expect(comp.Status.SystemAccounts[0].LastRotationTime).Should(BeNil())
+		})
+
+		It("no rotation policy", func() {
+			comp.Spec.SystemAccounts = nil
+			secret.CreationTimestamp = metav1.NewTime(time.Now().Add(-100 * 24 * time.Hour))
+			Expect(transform()).Should(Succeed())
+			Expect(requests).Should(BeEmpty())
+			Expect(secretInDAG()).Should(BeNil())
+			Expect(comp.Status.SystemAccounts).Should(BeEmpty())
+		})
+
+		It("rotate", func() {
+			secret.CreationTimestamp = metav1.NewTime(time.Now().Add(-100 * 24 * time.Hour))
+
+			By("generate the pending password")
+			Expect(transform()).Should(Succeed())
+			Expect(requests).Should(BeEmpty())
+			obj := secretInDAG()
+			Expect(obj).ShouldNot(BeNil())
+			Expect(obj.Data[constant.AccountPasswdForSecret]).Should(Equal([]byte(password)))
+			pending := obj.Data[systemAccountPendingPasswordKey]
+			Expect(pending).ShouldNot(BeEmpty())
+			persist(obj)
+
+			By("apply the pending password")
+			Expect(transform()).Should(Succeed())
+			Expect(requests).Should(HaveLen(1))
+			Expect(requests[0].Action).Should(Equal("accountProvision"))
+			Expect(requests[0].Parameters).Should(HaveKeyWithValue("KB_ACCOUNT_STATEMENT", "dual-password-update"))
+			Expect(requests[0].Parameters).Should(HaveKeyWithValue("KB_ACCOUNT_PASSWORD", string(pending)))
+			obj = secretInDAG()
+			Expect(obj).ShouldNot(BeNil())
+			Expect(obj.Data[constant.AccountPasswdForSecret]).Should(Equal(pending))
+			Expect(obj.Data).ShouldNot(HaveKey(systemAccountPendingPasswordKey))
+			Expect(obj.Annotations).Should(HaveKey(systemAccountRotatedAtAnnotation))
+			Expect(obj.Annotations).Should(HaveKey(systemAccountGracePeriodEndAnnotation))
+			Expect(comp.Status.SystemAccounts).Should(HaveLen(1))
+			Expect(comp.Status.SystemAccounts[0].LastRotationTime).ShouldNot(BeNil())
+			Expect(comp.Status.SystemAccounts[0].GracePeriodEndTime).ShouldNot(BeNil())
+			persist(obj)
+
+			By("keep the previous password until the replicas are restarted")
+			Expect(transform()).Should(Succeed())
+			Expect(requests).Should(HaveLen(1))
+			Expect(secretInDAG()).Should(BeNil())
+		})
+
+		It("refuse the rotation w/o the dual-password statements", func() {
+			compDef.Spec.SystemAccounts[0].Statement.DualPasswordUpdate = ""
+			compDef.Spec.SystemAccounts[0].Statement.DiscardSecondaryPassword = ""
+			secret.CreationTimestamp = metav1.NewTime(time.Now().Add(-100 * 24 * time.Hour))
+			Expect(transform()).Should(Succeed())
+			Expect(requests).Should(BeEmpty())
+			Expect(secretInDAG()).Should(BeNil())
+			Expect(comp.Status.SystemAccounts).Should(BeEmpty())
+		})
+
+		It("keep the pending password if the action fails", func() {
+			secret.Data[systemAccountPendingPasswordKey] = []byte("new-password")
+			testapps.MockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+				recorder.Action(gomock.Any(), gomock.Any()).Return(kbagentproto.ActionResponse{}, fmt.Errorf("mock error")).AnyTimes()
+			})
+
+			err := transform()
+			Expect(err).ShouldNot(BeNil())
+			Expect(err.Error()).Should(ContainSubstring("mock error"))
+			Expect(secretInDAG()).Should(BeNil())
+			Expect(comp.Status.SystemAccounts[0].LastRotationTime).Should(BeNil())
+		})
+
+		It("rotate with the password in the secret store", func() {
+			vault := testsecretstore.NewFakeVault()
+			defer vault.Close()
+			viper.Set(constant.CfgKeySecretStoreProvider, secretstore.VaultProvider)
+			viper.Set(constant.CfgKeySecretStoreAddress, vault.URL)
+			viper.Set(constant.CfgKeySecretStoreToken, testsecretstore.FakeVaultToken)
+			defer viper.Set(constant.CfgKeySecretStoreProvider, "")
+
+			storePath := "kubeblocks/default/" + secret.Name
+			vault.SetSecret(storePath, map[string]string{
+				constant.AccountNameForSecret:   accountName,
+				constant.AccountPasswdForSecret: password,
+			})
+			delete(secret.Data, constant.AccountPasswdForSecret)
+			secret.Annotations = map[string]string{constant.SecretStorePathAnnotationKey: storePath}
+			secret.CreationTimestamp = metav1.NewTime(time.Now().Add(-100 * 24 * time.Hour))
+
+			Expect(transform()).Should(Succeed())
+			Expect(requests).Should(HaveLen(1))
+			Expect(requests[0].Parameters).Should(HaveKeyWithValue("KB_ACCOUNT_STATEMENT", "dual-password-update"))
+			data := vault.Secret(storePath)
+			Expect(data).ShouldNot(HaveKey(systemAccountPendingPasswordKey))
+			Expect(data[constant.AccountPasswdForSecret]).ShouldNot(Equal(password))
+			Expect(requests[0].Parameters).Should(HaveKeyWithValue("KB_ACCOUNT_PASSWORD", data[constant.AccountPasswdForSecret]))
+			obj := secretInDAG()
+			Expect(obj).ShouldNot(BeNil())
+			Expect(obj.Data).ShouldNot(HaveKey(constant.AccountPasswdForSecret))
+			Expect(obj.Data).ShouldNot(HaveKey(systemAccountPendingPasswordKey))
+			Expect(obj.Annotations).Should(HaveKey(systemAccountRotatedAtAnnotation))
+		})
+
+		It("rotate with grace period", func() {
+			comp.Spec.SystemAccounts[0].RotationPolicy.GracePeriod = &metav1.Duration{Duration: time.Hour}
+			secret.Data[systemAccountPendingPasswordKey] = []byte("new-password")
+
+			By("apply the pending password and retain the current one")
+			expectRequeueAfter(transform(), time.Hour)
+			Expect(requests).Should(HaveLen(1))
+			Expect(requests[0].Parameters).Should(HaveKeyWithValue("KB_ACCOUNT_STATEMENT", "dual-password-update"))
+			Expect(requests[0].Parameters).Should(HaveKeyWithValue("KB_ACCOUNT_PASSWORD", "new-password"))
+			obj := secretInDAG()
+			Expect(obj).ShouldNot(BeNil())
+			Expect(obj.Data[constant.AccountPasswdForSecret]).Should(Equal([]byte("new-password")))
+			Expect(obj.Annotations).Should(HaveKey(systemAccountGracePeriodEndAnnotation))
+			Expect(comp.Status.SystemAccounts[0].GracePeriodEndTime).ShouldNot(BeNil())
+			persist(obj)
+
+			By("keep the previous password within the grace period")
+			expectRequeueAfter(transform(), time.Hour)
+			Expect(requests).Should(HaveLen(1))
+			Expect(secretInDAG()).Should(BeNil())
+
+			By("keep the previous password until the replicas are restarted")
+			obj = secret.DeepCopy()
+			obj.Annotations[systemAccountGracePeriodEndAnnotation] = time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+			persist(obj)
+			its = testapps.NewInstanceSetFactory(testCtx.DefaultNamespace, comp.Name, clusterName, compName).
+				SetReplicas(1).
+				GetObject()
+			Expect(transform()).Should(Succeed())
+			Expect(requests).Should(HaveLen(1))
+			Expect(secretInDAG()).Should(BeNil())
+
+			By("discard the previous password once the grace period ends and the replicas are restarted")
+			its.Spec.Template.Annotations = map[string]string{
+				systemAccountRotatedAtAnnotation: secret.Annotations[systemAccountRotatedAtAnnotation],
+			}
+			its.Status.Replicas = 1
+			its.Status.ReadyReplicas = 1
+			its.Status.UpdatedReplicas = 1
+			expectRequeueAfter(transform(), 90*24*time.Hour)
+			Expect(requests).Should(HaveLen(2))
+			Expect(requests[1].Parameters).Should(HaveKeyWithValue("KB_ACCOUNT_STATEMENT", "discard-secondary-password"))
+			obj = secretInDAG()
+			Expect(obj).ShouldNot(BeNil())
+			Expect(obj.Annotations).ShouldNot(HaveKey(systemAccountGracePeriodEndAnnotation))
+			Expect(comp.Status.SystemAccounts[0].LastRotationTime).ShouldNot(BeNil())
+			Expect(comp.Status.SystemAccounts[0].GracePeriodEndTime).Should(BeNil())
+		})
+	})
+})
@@ -0,0 +1,235 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsutil "github.com/apecloud/kubeblocks/controllers/apps/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
+	testsecretstore "github.com/apecloud/kubeblocks/pkg/testutil/secretstore"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+var _ = Describe("account transformer test", func() {
+	const (
+		compDefName = "test-compdef"
+		clusterName = "test-cluster"
+		compName    = "comp"
+		accountName = "root"
+	)
+
+	var (
+		reader    *appsutil.MockReader
+		dag       *graph.DAG
+		transCtx  *componentTransformContext
+		vault     *testsecretstore.FakeVault
+		storePath string
+	)
+
+	newDAG := func(graphCli model.GraphClient, comp *appsv1.Component) *graph.DAG {
+		d := graph.NewDAG()
+		graphCli.Root(d, comp, comp, model.ActionStatusPtr())
+		return d
+	}
+
+	secretInDAG := func() (*corev1.Secret, *model.Action) {
+		graphCli := transCtx.Client.(model.GraphClient)
+		objs := graphCli.FindAll(dag, &corev1.Secret{})
+		Expect(objs).Should(HaveLen(1))
+		v := graphCli.FindMatchedVertex(dag, objs[0]).(*model.ObjectVertex)
+		return objs[0].(*corev1.Secret), v.Action
+	}
+
+	BeforeEach(func() {
+		vault = testsecretstore.NewFakeVault()
+		viper.Set(constant.CfgKeySecretStoreProvider, secretstore.VaultProvider)
+		viper.Set(constant.CfgKeySecretStoreAddress, vault.URL)
+		viper.Set(constant.CfgKeySecretStoreToken, testsecretstore.FakeVaultToken)
+
+		compDef := &appsv1.ComponentDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: compDefName,
+			},
+			Spec: appsv1.ComponentDefinitionSpec{
+				SystemAccounts: []appsv1.SystemAccount{
+					{
+						Name:        accountName,
+						InitAccount: true,
+						PasswordGenerationPolicy: appsv1.PasswordConfig{
+							Length:    16,
+							NumDigits: 4,
+						},
+					},
+				},
+			},
+		}
+		comp := &appsv1.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      constant.GenerateClusterComponentName(clusterName, compName),
+				Labels:    constant.GetCompLabels(clusterName, compName),
+			},
+			Spec: appsv1.ComponentSpec{
+				CompDef: compDefName,
+			},
+		}
+		reader = &appsutil.MockReader{}
+
+		graphCli := model.NewGraphClient(reader)
+		dag = newDAG(graphCli, comp)
+		transCtx = &componentTransformContext{
+			Context:       ctx,
+			Client:        graphCli,
+			EventRecorder: nil,
+			Logger:        logger,
+			CompDef:       compDef,
+			Component:     comp,
+			ComponentOrig: comp.DeepCopy(),
+			SynthesizeComponent: &component.SynthesizedComponent{
+				Namespace:   testCtx.DefaultNamespace,
+				ClusterName: clusterName,
+				Name:        compName,
+			},
+		}
+		storePath = secretstore.SecretPath(testCtx.DefaultNamespace, constant.GenerateAccountSecretName(clusterName, compName, accountName))
+	})
+
+	AfterEach(func() {
+		viper.Set(constant.CfgKeySecretStoreProvider, "")
+		vault.Close()
+	})
+
+	Context("secret store", func() {
+		It("keeps the password in the secret store", func() {
+			transformer := &componentAccountTransformer{}
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+
+			secret, action := secretInDAG()
+			Expect(*action).Should(Equal(model.CREATE))
+			Expect(secret.Data).Should(HaveKeyWithValue(constant.AccountNameForSecret, []byte(accountName)))
+			Expect(secret.Data).Should(HaveKeyWithValue(constant.AccountPasswdForSecret, []byte(secretstore.EnvRef(storePath, constant.AccountPasswdForSecret))))
+			Expect(secret.Annotations).Should(HaveKeyWithValue(constant.SecretStorePathAnnotationKey, storePath))
+
+			data := vault.Secret(storePath)
+			Expect(data).Should(HaveKeyWithValue(constant.AccountNameForSecret, accountName))
+			Expect(data[constant.AccountPasswdForSecret]).Should(HaveLen(16))
+
+			By("resolve the password from the secret store")
+			resolved, err := readAccountData(ctx, secret)
+			Expect(err).Should(BeNil())
+			Expect(resolved).Should(HaveKeyWithValue(constant.AccountPasswdForSecret, []byte(data[constant.AccountPasswdForSecret])))
+		})
+
+		It("moves the password kept in the secret to the secret store", func() {
+			secretLabels := constant.GetCompLabels(clusterName, compName)
+			secretLabels[systemAccountLabel] = accountName
+			reader.Objects = []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testCtx.DefaultNamespace,
+					Name:      constant.GenerateAccountSecretName(clusterName, compName, accountName),
+					Labels:    secretLabels,
+				},
+				Data: map[string][]byte{
+					constant.AccountNameForSecret:   []byte(accountName),
+					constant.AccountPasswdForSecret: []byte("password"),
+					systemAccountPendingPasswordKey: []byte("pending"),
+				},
+			}}
+
+			transformer := &componentAccountTransformer{}
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+
+			secret, action := secretInDAG()
+			Expect(*action).Should(Equal(model.UPDATE))
+			Expect(secret.Data).Should(HaveKeyWithValue(constant.AccountPasswdForSecret, []byte(secretstore.EnvRef(storePath, constant.AccountPasswdForSecret))))
+			Expect(secret.Data).ShouldNot(HaveKey(systemAccountPendingPasswordKey))
+			Expect(secret.Annotations).Should(HaveKeyWithValue(constant.SecretStorePathAnnotationKey, storePath))
+			Expect(vault.Secret(storePath)).Should(HaveKeyWithValue(constant.AccountPasswdForSecret, "password"))
+			Expect(vault.Secret(storePath)).Should(HaveKeyWithValue(systemAccountPendingPasswordKey, "pending"))
+		})
+
+		It("syncs the password referenced from the external secret to the secret store", func() {
+			externalPath := secretstore.SecretPath(testCtx.DefaultNamespace, "external")
+			vault.SetSecret(externalPath, map[string]string{constant.AccountPasswdForSecret: "external-password"})
+			external := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testCtx.DefaultNamespace,
+					Name:      "external",
+				},
+			}
+			secretstore.ReferTo(external, externalPath, constant.AccountPasswdForSecret)
+			reader.Objects = []client.Object{external}
+			transCtx.Component.Spec.SystemAccounts = []appsv1.ComponentSystemAccount{
+				{
+					Name: accountName,
+					SecretRef: &appsv1.ProvisionSecretRef{
+						Name:      external.Name,
+						Namespace: external.Namespace,
+					},
+				},
+			}
+
+			transformer := &componentAccountTransformer{}
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+
+			secret, action := secretInDAG()
+			Expect(*action).Should(Equal(model.CREATE))
+			Expect(secret.Data).Should(HaveKeyWithValue(constant.AccountPasswdForSecret, []byte(secretstore.EnvRef(storePath, constant.AccountPasswdForSecret))))
+			Expect(secret.Annotations).Should(HaveKey(systemAccountHashAnnotation))
+			Expect(verifySystemAccountPassword([]byte("external-password"), []byte(secret.Annotations[systemAccountHashAnnotation]))).Should(BeTrue())
+			Expect(vault.Secret(storePath)).Should(HaveKeyWithValue(constant.AccountPasswdForSecret, "external-password"))
+		})
+
+		It("deletes the password from the secret store", func() {
+			vault.SetSecret(storePath, map[string]string{constant.AccountPasswdForSecret: "password"})
+			secretLabels := constant.GetCompLabels(clusterName, compName)
+			secretLabels[systemAccountLabel] = accountName
+			reader.Objects = []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testCtx.DefaultNamespace,
+					Name:      constant.GenerateAccountSecretName(clusterName, compName, accountName),
+					Labels:    secretLabels,
+					Annotations: map[string]string{
+						constant.SecretStorePathAnnotationKey: storePath,
+					},
+				},
+			}}
+			transCtx.CompDef.Spec.SystemAccounts = nil
+
+			transformer := &componentAccountTransformer{}
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+
+			_, action := secretInDAG()
+			Expect(*action).Should(Equal(model.DELETE))
+			Expect(vault.Len()).Should(Equal(0))
+		})
+	})
+})
@@ -128,11 +128,16 @@ func (t *componentDeletionTransformer) deleteCompResources(transCtx *componentTr
 				continue
 			}
 
-			switch object.(type) {
+			switch o := object.(type) {
 			case *corev1.ServiceAccount, *rbacv1.Role, *rbacv1.RoleBinding:
 				if err := handleRBACResourceDeletion(object, transCtx, comp, graphCli, dag, matchLabels); err != nil {
 					return fmt.Errorf("handle rbac deletion failed: %w", err)
 				}
+			case *corev1.Secret:
+				if err := deleteAccountFromSecretStore(transCtx.Context, o); err != nil {
+					return fmt.Errorf("delete account from the secret store failed: %w", err)
+				}
+				graphCli.Delete(dag, object)
 			default:
 				graphCli.Delete(dag, object)
 			}
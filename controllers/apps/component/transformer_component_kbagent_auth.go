@@ -0,0 +1,94 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/plan"
+)
+
+// componentKBAgentAuthTransformer handles the credentials used to authenticate to the kb-agent.
+type componentKBAgentAuthTransformer struct{}
+
+var _ graph.Transformer = &componentKBAgentAuthTransformer{}
+
+func (t *componentKBAgentAuthTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	if isCompDeleting(transCtx.ComponentOrig) {
+		return nil
+	}
+
+	synthesizedComp := transCtx.SynthesizeComponent
+	if !component.IsKBAgentAuthRequired(synthesizedComp) {
+		return nil
+	}
+
+	secretKey := types.NamespacedName{
+		Namespace: synthesizedComp.Namespace,
+		Name:      constant.GenerateKBAgentAuthSecretName(synthesizedComp.ClusterName, synthesizedComp.Name),
+	}
+	secret := &corev1.Secret{}
+	if err := transCtx.Client.Get(transCtx.Context, secretKey, secret); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		// the credentials are generated only once, they are kept until the component is deleted
+		if err = t.createSecret(transCtx, dag, secretKey); err != nil {
+			return err
+		}
+	}
+
+	component.AddInstanceAssistantObject(synthesizedComp, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: secretKey.Namespace,
+			Name:      secretKey.Name,
+		},
+	})
+	return nil
+}
+
+func (t *componentKBAgentAuthTransformer) createSecret(transCtx *componentTransformContext, dag *graph.DAG, secretKey types.NamespacedName) error {
+	synthesizedComp := transCtx.SynthesizeComponent
+	secret := builder.NewSecretBuilder(secretKey.Namespace, secretKey.Name).
+		AddLabelsInMap(synthesizedComp.StaticLabels).
+		AddLabelsInMap(constant.GetCompLabels(synthesizedComp.ClusterName, synthesizedComp.Name)).
+		AddAnnotationsInMap(synthesizedComp.StaticAnnotations).
+		SetData(map[string][]byte{}).
+		GetObject()
+	if err := setCompOwnershipNFinalizer(transCtx.Component, secret); err != nil {
+		return err
+	}
+	secret, err := plan.ComposeKBAgentAuthSecret(secret)
+	if err != nil {
+		return err
+	}
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	graphCli.Create(dag, secret)
+	return nil
+}
@@ -21,9 +21,11 @@ package component
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
 	"reflect"
 	"slices"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -71,14 +73,14 @@ func (t *componentTLSTransformer) Transform(ctx graph.TransformContext, dag *gra
 	issuer := t.newTLSIssuer(transCtx, compDef, synthesizedComp)
 	if enabled {
 		if secretObj == nil {
-			if err = t.handleCreate(transCtx.Context, transCtx.Client, dag, issuer); err != nil {
-				return err
-			}
+			secretObj, err = t.handleCreate(transCtx.Context, transCtx.Client, dag, issuer)
 		} else {
-			if err = t.handleUpdate(transCtx.Context, transCtx.Client, dag, issuer, secretObj); err != nil {
-				return err
-			}
+			secretObj, err = t.handleUpdate(transCtx.Context, transCtx.Client, dag, issuer, secretObj)
 		}
+		if err != nil {
+			return err
+		}
+		t.updateTLSStatus(transCtx, compDef, synthesizedComp, secretObj)
 		component.AddInstanceAssistantObject(synthesizedComp, &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: synthesizedComp.Namespace,
@@ -87,6 +89,7 @@ func (t *componentTLSTransformer) Transform(ctx graph.TransformContext, dag *gra
 		})
 		return t.updateVolumeNVolumeMount(compDef, synthesizedComp)
 	} else {
+		transCtx.Component.Status.TLS = nil
 		// the issuer and secretObj may be nil
 		return t.handleDelete(transCtx.Context, transCtx.Client, dag, issuer, secretObj)
 	}
@@ -148,16 +151,17 @@ func (t *componentTLSTransformer) newTLSIssuer(transCtx *componentTransformConte
 	}
 }
 
-func (t *componentTLSTransformer) handleCreate(ctx context.Context, cli client.Reader, dag *graph.DAG, issuer tlsIssuer) error {
+func (t *componentTLSTransformer) handleCreate(ctx context.Context, cli client.Reader,
+	dag *graph.DAG, issuer tlsIssuer) (*corev1.Secret, error) {
 	secret, err := issuer.create(ctx, cli)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if secret != nil {
 		graphCli, _ := cli.(model.GraphClient)
 		graphCli.Create(dag, secret)
 	}
-	return nil
+	return secret, nil
 }
 
 func (t *componentTLSTransformer) handleDelete(ctx context.Context, cli client.Reader,
@@ -180,16 +184,59 @@ func (t *componentTLSTransformer) handleDelete(ctx context.Context, cli client.R
 }
 
 func (t *componentTLSTransformer) handleUpdate(ctx context.Context, cli client.Reader,
-	dag *graph.DAG, issuer tlsIssuer, secretObj *corev1.Secret) error {
+	dag *graph.DAG, issuer tlsIssuer, secretObj *corev1.Secret) (*corev1.Secret, error) {
 	secret, err := issuer.update(ctx, cli, secretObj)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if secret != nil {
+	if secret == nil {
+		return secretObj, nil
+	}
+
+	// record the rotation of the certs, the workload will roll the new certs out to the replicas
+	rotatedAt, ok := secretObj.Annotations[constant.TLSCertRotatedAtAnnotationKey]
+	if !reflect.DeepEqual(secretObj.Data, secret.Data) {
+		rotatedAt, ok = time.Now().UTC().Format(time.RFC3339), true
+	}
+	if ok {
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secret.Annotations[constant.TLSCertRotatedAtAnnotationKey] = rotatedAt
+	}
+
+	if !reflect.DeepEqual(secretObj, secret) {
 		graphCli, _ := cli.(model.GraphClient)
-		graphCli.Update(dag, secretObj, secret) // TODO: notify the pods
+		graphCli.Update(dag, secretObj, secret)
 	}
-	return nil
+	return secret, nil
+}
+
+// updateTLSStatus records the certificate in the component status, and warns about the user-provided certificate
+// that is close to expiring. The certificates are checked each time the component is reconciled, including the
+// periodic resync.
+func (t *componentTLSTransformer) updateTLSStatus(transCtx *componentTransformContext,
+	compDef *appsv1.ComponentDefinition, synthesizedComp *component.SynthesizedComponent, secret *corev1.Secret) {
+	status := &appsv1.ComponentTLSStatus{
+		Issuer: synthesizedComp.TLSConfig.Issuer.Name,
+	}
+	if secret == nil {
+		transCtx.Component.Status.TLS = status
+		return
+	}
+
+	if cert := tlsCertificate(compDef, secret); cert != nil {
+		status.NotBefore = ptr.To(metav1.NewTime(cert.NotBefore))
+		status.NotAfter = ptr.To(metav1.NewTime(cert.NotAfter))
+		if status.Issuer == appsv1.IssuerUserProvided && plan.IsTLSCertExpiring(cert, time.Now()) && transCtx.EventRecorder != nil {
+			transCtx.EventRecorder.Eventf(transCtx.Component, corev1.EventTypeWarning, "TLSCertExpiring",
+				"the user-provided TLS certificate expires at %s, please renew it", cert.NotAfter.UTC().Format(time.RFC3339))
+		}
+	}
+	if rotatedAt, err := time.Parse(time.RFC3339, secret.Annotations[constant.TLSCertRotatedAtAnnotationKey]); err == nil {
+		status.LastRotationTime = ptr.To(metav1.NewTime(rotatedAt))
+	}
+	transCtx.Component.Status.TLS = status
 }
 
 type tlsIssuerKubeBlocks struct {
@@ -221,6 +268,15 @@ func (i *tlsIssuerKubeBlocks) update(ctx context.Context, cli client.Reader, sec
 	secretCopy.Labels = proto.Labels
 	secretCopy.Annotations = proto.Annotations
 
+	// renew the certs ahead of the expiry
+	if cert := tlsCertificate(i.compDef, secret); cert != nil && plan.IsTLSCertExpiring(cert, time.Now()) {
+		renewed, err := plan.RenewTLSCertsWithSecret(i.compDef, *i.synthesizedComp, secret, proto)
+		if err != nil {
+			return nil, err
+		}
+		secretCopy.Data = renewed.Data
+	}
+
 	if !reflect.DeepEqual(secret, secretCopy) {
 		return secretCopy, nil
 	}
@@ -370,6 +426,18 @@ func tlsSecretName(clusterName, compName string) string {
 	return clusterName + "-" + compName + "-tls-certs"
 }
 
+// tlsCertificate returns the certificate in the TLS secret, or nil if it can't be parsed.
+func tlsCertificate(compDef *appsv1.ComponentDefinition, secret *corev1.Secret) *x509.Certificate {
+	if compDef.Spec.TLS == nil || compDef.Spec.TLS.CertFile == nil {
+		return nil
+	}
+	cert, err := plan.ParseTLSCert(secret.Data[*compDef.Spec.TLS.CertFile])
+	if err != nil {
+		return nil
+	}
+	return cert
+}
+
 func newTLSSecret(comp *appsv1.Component, synthesizedComp *component.SynthesizedComponent) (*corev1.Secret, error) {
 	secretName := tlsSecretName(synthesizedComp.ClusterName, synthesizedComp.Name)
 	secret := builder.NewSecretBuilder(synthesizedComp.Namespace, secretName).
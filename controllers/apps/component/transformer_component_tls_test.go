@@ -21,12 +21,14 @@ package component
 
 import (
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -36,6 +38,8 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/plan"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 var _ = Describe("TLS transformer test", func() {
@@ -315,4 +319,111 @@ var _ = Describe("TLS transformer test", func() {
 			checkVolumeNMounts(false)
 		})
 	})
+
+	Context("rotation", func() {
+		var (
+			recorder *record.FakeRecorder
+		)
+
+		BeforeEach(func() {
+			recorder = record.NewFakeRecorder(10)
+			transCtx.EventRecorder = recorder
+			transCtx.CompDef.Spec.TLS = tls
+		})
+
+		// composeCerts composes a TLS secret with certs valid for the given days
+		composeCerts := func(secret *corev1.Secret, validityDays int) *corev1.Secret {
+			viper.Set(constant.CfgKeyTLSCertValidityDays, validityDays)
+			defer viper.Set(constant.CfgKeyTLSCertValidityDays, 0)
+			secret, err := plan.ComposeTLSCertsWithSecret(transCtx.CompDef, *transCtx.SynthesizeComponent, secret)
+			Expect(err).Should(BeNil())
+			return secret
+		}
+
+		mockRunningSecret := func(validityDays int) *corev1.Secret {
+			secret, err := newTLSSecret(transCtx.Component, transCtx.SynthesizeComponent)
+			Expect(err).Should(BeNil())
+			secret = composeCerts(secret, validityDays)
+			reader.Objects = append(reader.Objects, secret)
+			return secret
+		}
+
+		It("keep the valid certs - kb", func() {
+			transCtx.SynthesizeComponent.TLSConfig = tlsConfig4KB
+			mockRunningSecret(365)
+
+			transformer := &componentTLSTransformer{}
+			err := transformer.Transform(transCtx, dag)
+			Expect(err).Should(BeNil())
+
+			checkTLSSecret(false)
+			status := transCtx.Component.Status.TLS
+			Expect(status).ShouldNot(BeNil())
+			Expect(status.Issuer).Should(Equal(appsv1.IssuerKubeBlocks))
+			Expect(status.NotAfter.Time).Should(BeTemporally("~", time.Now().Add(365*24*time.Hour), time.Hour))
+			Expect(status.LastRotationTime).Should(BeNil())
+		})
+
+		It("renew the expiring certs - kb", func() {
+			transCtx.SynthesizeComponent.TLSConfig = tlsConfig4KB
+			running := mockRunningSecret(10)
+
+			transformer := &componentTLSTransformer{}
+			err := transformer.Transform(transCtx, dag)
+			Expect(err).Should(BeNil())
+
+			// check the secret renewed
+			graphCli := transCtx.Client.(model.GraphClient)
+			objs := graphCli.FindAll(dag, &corev1.Secret{})
+			Expect(objs).Should(HaveLen(1))
+			Expect(graphCli.IsAction(dag, objs[0], model.ActionUpdatePtr())).Should(BeTrue())
+			secret := objs[0].(*corev1.Secret)
+			Expect(secret.Data[*tls.CertFile]).ShouldNot(Equal(running.Data[*tls.CertFile]))
+			Expect(secret.Annotations).Should(HaveKey(constant.TLSCertRotatedAtAnnotationKey))
+
+			// the old CA is still trusted until the new certs are rolled out
+			Expect(string(secret.Data[*tls.CAFile])).Should(HaveSuffix(string(running.Data[*tls.CAFile])))
+			Expect(secret.Data[*tls.CAFile]).ShouldNot(Equal(running.Data[*tls.CAFile]))
+
+			status := transCtx.Component.Status.TLS
+			Expect(status).ShouldNot(BeNil())
+			Expect(status.NotAfter.Time.After(time.Now().Add(plan.TLSCertRenewBefore()))).Should(BeTrue())
+			Expect(status.LastRotationTime).ShouldNot(BeNil())
+		})
+
+		It("warn about the expiring certs - user", func() {
+			secret4User := composeCerts(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testCtx.DefaultNamespace,
+					Name:      "expiring-tls-secret-4-user",
+				},
+				Data: map[string][]byte{},
+			}, 10)
+			reader.Objects = append(reader.Objects, secret4User)
+			transCtx.SynthesizeComponent.TLSConfig = &appsv1.TLSConfig{
+				Enable: true,
+				Issuer: &appsv1.Issuer{
+					Name: appsv1.IssuerUserProvided,
+					SecretRef: &appsv1.TLSSecretRef{
+						Namespace: secret4User.Namespace,
+						Name:      secret4User.Name,
+						CA:        *tls.CAFile,
+						Cert:      *tls.CertFile,
+						Key:       *tls.KeyFile,
+					},
+				},
+			}
+
+			transformer := &componentTLSTransformer{}
+			err := transformer.Transform(transCtx, dag)
+			Expect(err).Should(BeNil())
+
+			status := transCtx.Component.Status.TLS
+			Expect(status).ShouldNot(BeNil())
+			Expect(status.Issuer).Should(Equal(appsv1.IssuerUserProvided))
+			Expect(status.NotAfter.Time).Should(BeTemporally("~", time.Now().Add(10*24*time.Hour), time.Hour))
+			Expect(recorder.Events).Should(HaveLen(1))
+			Expect(<-recorder.Events).Should(ContainSubstring("TLSCertExpiring"))
+		})
+	})
 })
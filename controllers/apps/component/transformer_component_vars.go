@@ -23,21 +23,30 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"golang.org/x/exp/maps"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	"github.com/apecloud/kubeblocks/pkg/common"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
+)
+
+const (
+	secretStoreEnvConditionType        = "SecretStoreEnvResolved"
+	secretStoreEnvConditionReasonNoCmd = "CommandNotSpecified"
 )
 
 // componentVarsTransformer resolves and builds vars for template and Env.
@@ -76,6 +85,8 @@ func (t *componentVarsTransformer) Transform(ctx graph.TransformContext, dag *gr
 	// pass all direct value env vars through CM
 	envVars2, envData := buildEnvVarsNData(envVars)
 	setTemplateNEnvVars(synthesizedComp, templateVars, envVars2)
+	unresolved := secretstore.TryInjectEnvResolver(synthesizedComp.PodSpec)
+	setSecretStoreEnvCondition(transCtx.Component, unresolved)
 
 	if err := createOrUpdateEnvConfigMap(transCtx, dag, envData, nil); err != nil {
 		return err
@@ -97,7 +108,8 @@ func buildEnvVarsNData(vars []corev1.EnvVar) ([]corev1.EnvVar, map[string]string
 	envVars := make([]corev1.EnvVar, 0)
 	envData := make(map[string]string)
 	for i, v := range vars {
-		if v.ValueFrom != nil || hasReference(v) {
+		// the references to the secret store are kept in the container env, to be resolved when the container starts
+		if v.ValueFrom != nil || hasReference(v) || secretstore.HasEnvRef(v.Value) {
 			envVars = append(envVars, vars[i])
 		} else {
 			envData[v.Name] = v.Value
@@ -275,3 +287,20 @@ func (r *varsReader) listFromGraph(objectListType reflect.Type, opts ...client.L
 	}
 	return objects
 }
+
+// setSecretStoreEnvCondition surfaces the containers that refer to the secret store but can't resolve the references,
+// since they have no command specified in the ComponentDefinition to run with the resolved env.
+func setSecretStoreEnvCondition(comp *appsv1.Component, unresolved []string) {
+	if len(unresolved) == 0 {
+		meta.RemoveStatusCondition(&comp.Status.Conditions, secretStoreEnvConditionType)
+		return
+	}
+	meta.SetStatusCondition(&comp.Status.Conditions, metav1.Condition{
+		Type:               secretStoreEnvConditionType,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: comp.Generation,
+		Reason:             secretStoreEnvConditionReasonNoCmd,
+		Message: fmt.Sprintf("the containers %s refer to the secret store but have no command specified, "+
+			"specify the command in the ComponentDefinition to resolve the references", strings.Join(unresolved, ",")),
+	})
+}
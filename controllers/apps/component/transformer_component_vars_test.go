@@ -33,6 +33,8 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 var _ = Describe("vars transformer test", func() {
@@ -185,4 +187,76 @@ var _ = Describe("vars transformer test", func() {
 			checkEnvCM(model.ActionUpdatePtr(), map[string]string{"foo": "bar", "task": "nil"})
 		})
 	})
+
+	Context("secret store", func() {
+		BeforeEach(func() {
+			viper.Set(constant.CfgKeySecretStoreProvider, secretstore.VaultProvider)
+			viper.Set(constant.CfgKeySecretStoreAddress, "http://vault:8200")
+		})
+
+		AfterEach(func() {
+			viper.Set(constant.CfgKeySecretStoreProvider, "")
+			viper.Set(constant.CfgKeySecretStoreAddress, "")
+		})
+
+		It("resolves the password kept in the secret store when the container starts", func() {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testCtx.DefaultNamespace,
+					Name:      constant.GenerateAccountSecretName(clusterName, compName, "root"),
+				},
+				Data: map[string][]byte{
+					constant.AccountNameForSecret: []byte("root"),
+				},
+			}
+			path := secretstore.SecretPath(secret.Namespace, secret.Name)
+			secretstore.ReferTo(secret, path, constant.AccountPasswdForSecret)
+			reader.Objects = append(reader.Objects, secret)
+
+			transCtx.ComponentOrig = transCtx.Component.DeepCopy()
+			transCtx.CompDef = &appsv1.ComponentDefinition{
+				Spec: appsv1.ComponentDefinitionSpec{
+					Vars: []appsv1.EnvVar{
+						{
+							Name: "PASSWORD",
+							ValueFrom: &appsv1.VarSource{
+								CredentialVarRef: &appsv1.CredentialVarSelector{
+									ClusterObjectReference: appsv1.ClusterObjectReference{
+										Name: "root",
+									},
+									CredentialVars: appsv1.CredentialVars{
+										Password: &appsv1.VarRequired,
+									},
+								},
+							},
+						},
+						{
+							Name:  "USER",
+							Value: "root",
+						},
+					},
+				},
+			}
+			transCtx.SynthesizeComponent.PodSpec = &corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:    "mysql",
+						Command: []string{"mysqld"},
+					},
+				},
+			}
+
+			transformer := &componentVarsTransformer{}
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+
+			checkEnvCM(model.ActionCreatePtr(), map[string]string{"USER": "root"})
+
+			podSpec := transCtx.SynthesizeComponent.PodSpec
+			Expect(podSpec.InitContainers).Should(HaveLen(1))
+			c := podSpec.Containers[0]
+			Expect(c.Command).Should(Equal([]string{"/kb-secret-store/kbagent", secretstore.EnvCommand, "--", "mysqld"}))
+			Expect(c.Env).Should(ContainElement(corev1.EnvVar{Name: "PASSWORD", Value: secretstore.EnvRef(path, constant.AccountPasswdForSecret)}))
+			Expect(c.Env).Should(ContainElement(corev1.EnvVar{Name: constant.CfgKeySecretStoreAddress, Value: "http://vault:8200"}))
+		})
+	})
 })
@@ -311,6 +311,12 @@ func (t *componentWorkloadTransformer) handleWorkloadUpdate(transCtx *componentT
 	if err := cwo.reconfigure(); err != nil {
 		return err
 	}
+	if err := cwo.rolloutTLSCerts(); err != nil {
+		return err
+	}
+	if err := cwo.rolloutAccountPasswords(); err != nil {
+		return err
+	}
 	return nil
 }
 
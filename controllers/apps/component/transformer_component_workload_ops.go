@@ -45,6 +45,11 @@ import (
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
+const (
+	// tlsCertsConfigName is the name of the config to reload the rotated TLS certificates.
+	tlsCertsConfigName = "kubeblocks-tls-certs"
+)
+
 type componentWorkloadOps struct {
 	transCtx       *componentTransformContext
 	cli            client.Client
@@ -576,6 +581,114 @@ func (r *componentWorkloadOps) absoluteFilePath(transCtx *componentTransformCont
 	return filepath.Join(mountPath, file)
 }
 
+// rolloutTLSCerts rolls the rotated TLS certificates out to the replicas. The certificates are reloaded by the
+// reconfigure action if the ComponentDefinition opts in, otherwise the replicas are restarted in the order of the
+// update strategy.
+func (r *componentWorkloadOps) rolloutTLSCerts() error {
+	var (
+		synthesizedComp = r.synthesizeComp
+		tls             = r.transCtx.CompDef.Spec.TLS
+	)
+	if synthesizedComp.TLSConfig == nil || !synthesizedComp.TLSConfig.Enable || tls == nil {
+		return nil
+	}
+
+	secret, err := r.tlsSecret()
+	if err != nil || secret == nil {
+		return err
+	}
+	rotatedAt, ok := secret.Annotations[constant.TLSCertRotatedAtAnnotationKey]
+	if !ok {
+		return nil // the certs have never been rotated
+	}
+	timestamp, err := time.Parse(time.RFC3339, rotatedAt)
+	if err != nil {
+		return err
+	}
+
+	reloadable := ptr.Deref(tls.ReloadOnRotation, false) &&
+		synthesizedComp.LifecycleActions != nil && synthesizedComp.LifecycleActions.Reconfigure != nil
+	if !reloadable {
+		// restart the replicas to load the certs, the InstanceSet restarts them in the order of its update strategy
+		if r.protoITS.Spec.Template.Annotations == nil {
+			r.protoITS.Spec.Template.Annotations = map[string]string{}
+		}
+		r.protoITS.Spec.Template.Annotations[constant.TLSCertRotatedAtAnnotationKey] = rotatedAt
+		return nil
+	}
+
+	var files []string
+	for _, file := range []*string{tls.CAFile, tls.CertFile, tls.KeyFile} {
+		if file != nil {
+			checksum := sha256.Sum256(secret.Data[*file])
+			files = append(files, fmt.Sprintf("%s:%x", filepath.Join(tls.MountPath, *file), checksum))
+		}
+	}
+	slices.Sort(files)
+	config := workloads.ConfigTemplate{
+		Name:        tlsCertsConfigName,
+		Generation:  timestamp.Unix(),
+		Reconfigure: synthesizedComp.LifecycleActions.Reconfigure,
+		Parameters:  lifecycle.FileTemplateChanges("", "", strings.Join(files, ",")),
+	}
+	idx := slices.IndexFunc(r.protoITS.Spec.Configs, func(cfg workloads.ConfigTemplate) bool {
+		return cfg.Name == config.Name
+	})
+	if idx >= 0 {
+		r.protoITS.Spec.Configs[idx] = config
+	} else {
+		r.protoITS.Spec.Configs = append(r.protoITS.Spec.Configs, config)
+	}
+	return nil
+}
+
+// rolloutAccountPasswords restarts the replicas to pick up the rotated passwords of the system accounts, the previous
+// passwords are retained until all the replicas are restarted. The InstanceSet restarts them in the order of its
+// update strategy.
+func (r *componentWorkloadOps) rolloutAccountPasswords() error {
+	secrets, err := listSystemAccountObjects(r.transCtx, r.synthesizeComp)
+	if err != nil {
+		return err
+	}
+	var rotatedAt *metav1.Time
+	graphCli, _ := r.transCtx.Client.(model.GraphClient)
+	for _, secret := range secrets {
+		// look up in graph first, the password may be rotated in this round
+		if v := graphCli.FindMatchedVertex(r.dag, secret); v != nil {
+			secret = v.(*model.ObjectVertex).Obj.(*corev1.Secret)
+		}
+		if t := accountSecretTime(secret, systemAccountRotatedAtAnnotation); t != nil && (rotatedAt == nil || t.After(rotatedAt.Time)) {
+			rotatedAt = t
+		}
+	}
+	if rotatedAt == nil {
+		return nil // the passwords have never been rotated
+	}
+	if r.protoITS.Spec.Template.Annotations == nil {
+		r.protoITS.Spec.Template.Annotations = map[string]string{}
+	}
+	r.protoITS.Spec.Template.Annotations[systemAccountRotatedAtAnnotation] = rotatedAt.UTC().Format(time.RFC3339)
+	return nil
+}
+
+func (r *componentWorkloadOps) tlsSecret() (*corev1.Secret, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.synthesizeComp.Namespace,
+			Name:      tlsSecretName(r.synthesizeComp.ClusterName, r.synthesizeComp.Name),
+		},
+	}
+	// look up in graph first, the certs may be rotated in this round
+	graphCli, _ := r.transCtx.Client.(model.GraphClient)
+	if v := graphCli.FindMatchedVertex(r.dag, secret); v != nil {
+		return v.(*model.ObjectVertex).Obj.(*corev1.Secret), nil
+	}
+	if err := r.cli.Get(r.transCtx.Context, client.ObjectKeyFromObject(secret), secret); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return secret, nil
+}
+
 type fileTemplateChanges struct {
 	Created string `json:"created,omitempty"`
 	Removed string `json:"removed,omitempty"`
@@ -24,6 +24,7 @@ import (
 	"github.com/golang/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	appsutil "github.com/apecloud/kubeblocks/controllers/apps/util"
@@ -194,4 +195,178 @@ var _ = Describe("Component Workload Operations Test", func() {
 			Expect(ops.leaveMemberForPod(pod1, pods)).Should(Succeed())
 		})
 	})
+
+	Context("TLS Certs Rollout", func() {
+		var (
+			ops       *componentWorkloadOps
+			rotatedAt = "2026-01-02T03:04:05Z"
+		)
+
+		BeforeEach(func() {
+			synthesizeComp.TLSConfig = &appsv1.TLSConfig{
+				Enable: true,
+				Issuer: &appsv1.Issuer{
+					Name: appsv1.IssuerKubeBlocks,
+				},
+			}
+
+			mockITS := testapps.NewInstanceSetFactory(testCtx.DefaultNamespace,
+				"test-its", clusterName, compName).
+				AddContainer(corev1.Container{Name: "test-container", Image: "test-image"}).
+				SetReplicas(2).
+				GetObject()
+
+			graphCli := model.NewGraphClient(reader)
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testCtx.DefaultNamespace,
+					Name:      tlsSecretName(clusterName, compName),
+				},
+			}
+			secretCopy := secret.DeepCopy()
+			secretCopy.Annotations = map[string]string{
+				constant.TLSCertRotatedAtAnnotationKey: rotatedAt,
+			}
+			secretCopy.Data = map[string][]byte{
+				"ca.pem":   []byte("ca"),
+				"cert.pem": []byte("cert"),
+				"key.pem":  []byte("key"),
+			}
+			graphCli.Update(dag, secret, secretCopy)
+
+			ops = &componentWorkloadOps{
+				transCtx: &componentTransformContext{
+					Context: ctx,
+					Client:  graphCli,
+					Logger:  logger,
+					CompDef: &appsv1.ComponentDefinition{
+						Spec: appsv1.ComponentDefinitionSpec{
+							TLS: &appsv1.TLS{
+								VolumeName: "tls",
+								MountPath:  "/etc/pki/tls",
+								CAFile:     ptr.To("ca.pem"),
+								CertFile:   ptr.To("cert.pem"),
+								KeyFile:    ptr.To("key.pem"),
+							},
+						},
+					},
+				},
+				cli:            k8sClient,
+				component:      comp,
+				synthesizeComp: synthesizeComp,
+				runningITS:     mockITS,
+				protoITS:       mockITS.DeepCopy(),
+				dag:            dag,
+			}
+		})
+
+		It("should restart the replicas w/o the reconfigure action", func() {
+			Expect(ops.rolloutTLSCerts()).Should(Succeed())
+			Expect(ops.protoITS.Spec.Template.Annotations).Should(HaveKeyWithValue(constant.TLSCertRotatedAtAnnotationKey, rotatedAt))
+			Expect(ops.protoITS.Spec.Configs).Should(BeEmpty())
+		})
+
+		It("should restart the replicas w/o opting in to reload the certs", func() {
+			synthesizeComp.LifecycleActions.Reconfigure = &appsv1.Action{
+				Exec: &appsv1.ExecAction{
+					Image: "test-image",
+				},
+			}
+
+			Expect(ops.rolloutTLSCerts()).Should(Succeed())
+			Expect(ops.protoITS.Spec.Template.Annotations).Should(HaveKeyWithValue(constant.TLSCertRotatedAtAnnotationKey, rotatedAt))
+			Expect(ops.protoITS.Spec.Configs).Should(BeEmpty())
+		})
+
+		It("should reload the certs by the reconfigure action", func() {
+			synthesizeComp.LifecycleActions.Reconfigure = &appsv1.Action{
+				Exec: &appsv1.ExecAction{
+					Image: "test-image",
+				},
+			}
+			ops.transCtx.CompDef.Spec.TLS.ReloadOnRotation = ptr.To(true)
+
+			Expect(ops.rolloutTLSCerts()).Should(Succeed())
+			Expect(ops.protoITS.Spec.Template.Annotations).ShouldNot(HaveKey(constant.TLSCertRotatedAtAnnotationKey))
+			Expect(ops.protoITS.Spec.Configs).Should(HaveLen(1))
+			config := ops.protoITS.Spec.Configs[0]
+			Expect(config.Name).Should(Equal(tlsCertsConfigName))
+			Expect(config.Generation).Should(Equal(int64(1767323045)))
+			Expect(config.Reconfigure).Should(Equal(synthesizeComp.LifecycleActions.Reconfigure))
+			Expect(config.Parameters).Should(HaveKeyWithValue("KB_CONFIG_FILES_UPDATED", And(
+				ContainSubstring("/etc/pki/tls/ca.pem:"),
+				ContainSubstring("/etc/pki/tls/cert.pem:"),
+				ContainSubstring("/etc/pki/tls/key.pem:"))))
+
+			By("keep the generation as the certs are not rotated again")
+			Expect(ops.rolloutTLSCerts()).Should(Succeed())
+			Expect(ops.protoITS.Spec.Configs).Should(HaveLen(1))
+			Expect(ops.protoITS.Spec.Configs[0].Generation).Should(Equal(int64(1767323045)))
+		})
+	})
+
+	Context("Account Passwords Rollout", func() {
+		var (
+			ops       *componentWorkloadOps
+			rotatedAt = "2026-01-02T03:04:05Z"
+		)
+
+		newAccountSecret := func(accountName string, annotations map[string]string) *corev1.Secret {
+			secretLabels := constant.GetCompLabels(clusterName, compName)
+			secretLabels[systemAccountLabel] = accountName
+			return &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   testCtx.DefaultNamespace,
+					Name:        constant.GenerateAccountSecretName(clusterName, compName, accountName),
+					Labels:      secretLabels,
+					Annotations: annotations,
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			mockITS := testapps.NewInstanceSetFactory(testCtx.DefaultNamespace,
+				"test-its", clusterName, compName).
+				AddContainer(corev1.Container{Name: "test-container", Image: "test-image"}).
+				SetReplicas(2).
+				GetObject()
+
+			graphCli := model.NewGraphClient(reader)
+			ops = &componentWorkloadOps{
+				transCtx: &componentTransformContext{
+					Context: ctx,
+					Client:  graphCli,
+					Logger:  logger,
+				},
+				cli:            k8sClient,
+				component:      comp,
+				synthesizeComp: synthesizeComp,
+				runningITS:     mockITS,
+				protoITS:       mockITS.DeepCopy(),
+				dag:            dag,
+			}
+		})
+
+		It("should not restart the replicas if the passwords have never been rotated", func() {
+			reader.Objects = append(reader.Objects, newAccountSecret("root", nil))
+
+			Expect(ops.rolloutAccountPasswords()).Should(Succeed())
+			Expect(ops.protoITS.Spec.Template.Annotations).ShouldNot(HaveKey(systemAccountRotatedAtAnnotation))
+		})
+
+		It("should restart the replicas as the latest rotation", func() {
+			reader.Objects = append(reader.Objects,
+				newAccountSecret("root", map[string]string{systemAccountRotatedAtAnnotation: "2026-01-01T00:00:00Z"}),
+				newAccountSecret("admin", nil))
+
+			By("the password rotated in this round")
+			secret := newAccountSecret("admin", nil)
+			secretCopy := secret.DeepCopy()
+			secretCopy.Annotations = map[string]string{systemAccountRotatedAtAnnotation: rotatedAt}
+			ops.transCtx.Client.(model.GraphClient).Update(dag, secret, secretCopy)
+
+			Expect(ops.rolloutAccountPasswords()).Should(Succeed())
+			Expect(ops.protoITS.Spec.Template.Annotations).Should(HaveKeyWithValue(systemAccountRotatedAtAnnotation, rotatedAt))
+		})
+	})
 })
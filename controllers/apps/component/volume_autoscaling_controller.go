@@ -0,0 +1,307 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	volumeAutoscalingSyncPeriod = time.Minute
+	// volumeAutoscalingCooldown is the minimum interval between two expansions of the same volumes,
+	// which leaves time for the file system to be resized and the volume stats to be refreshed.
+	volumeAutoscalingCooldown = 10 * time.Minute
+
+	defaultVolumeAutoscalingThresholdPercent = 80
+	defaultVolumeAutoscalingStepPercent      = 20
+)
+
+// VolumeAutoscalingReconciler expands the volumes of a Component automatically by "VolumeExpansion" OpsRequests
+// when their usage exceeds the threshold of the autoscaling policy.
+type VolumeAutoscalingReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	statsReader volumeStatsReader
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=components,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=components/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=operations.kubeblocks.io,resources=opsrequests,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=core,resources=nodes/proxy,verbs=get
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.14.4/pkg/reconcile
+func (r *VolumeAutoscalingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("component", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	comp := &appsv1.Component{}
+	if err := r.Client.Get(ctx, req.NamespacedName, comp); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if !comp.GetDeletionTimestamp().IsZero() {
+		return intctrlutil.Reconciled()
+	}
+
+	vcts := autoscalingVolumeClaimTemplates(comp)
+	if len(vcts) == 0 {
+		return intctrlutil.Reconciled()
+	}
+
+	if err := r.autoscale(reqCtx, comp, vcts); err != nil {
+		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.RequeueAfter(volumeAutoscalingSyncPeriod, reqCtx.Log, "")
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VolumeAutoscalingReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.statsReader == nil {
+		reader, err := newKubeletVolumeStatsReader(mgr.GetConfig())
+		if err != nil {
+			return err
+		}
+		r.statsReader = reader
+	}
+	return intctrlutil.NewControllerManagedBy(mgr).
+		Named("volume-autoscaling").
+		For(&appsv1.Component{}).
+		Complete(r)
+}
+
+func (r *VolumeAutoscalingReconciler) autoscale(reqCtx intctrlutil.RequestCtx, comp *appsv1.Component, vcts []appsv1.PersistentVolumeClaimTemplate) error {
+	clusterName, err := component.GetClusterName(comp)
+	if err != nil {
+		return err
+	}
+	compName, err := component.ShortName(clusterName, comp.Name)
+	if err != nil {
+		return err
+	}
+
+	// the volumes are expanded one OpsRequest at a time, wait for the running one to finish.
+	running, err := r.hasRunningVolumeExpansion(reqCtx.Ctx, comp.Namespace, clusterName)
+	if err != nil || running {
+		return err
+	}
+
+	usages, err := r.volumeUsages(reqCtx, comp, clusterName, compName, vcts)
+	if err != nil {
+		return err
+	}
+
+	statuses := make([]appsv1.VolumeAutoscalingStatus, 0, len(vcts))
+	expansions := make([]opsv1alpha1.OpsRequestVolumeClaimTemplate, 0)
+	for _, vct := range vcts {
+		status := appsv1.VolumeAutoscalingStatus{Name: vct.Name}
+		for _, s := range comp.Status.VolumeAutoscaling {
+			if s.Name == vct.Name {
+				status = *s.DeepCopy()
+			}
+		}
+		storage, expand := expandVolumeStorage(vct, usages[vct.Name], &status)
+		if expand {
+			expansions = append(expansions, opsv1alpha1.OpsRequestVolumeClaimTemplate{Name: vct.Name, Storage: storage})
+		}
+		if expand || len(status.OpsRequestName) > 0 || len(status.Message) > 0 {
+			statuses = append(statuses, status)
+		}
+	}
+
+	if len(expansions) > 0 {
+		opsRequest := buildVolumeExpansionOpsRequest(comp, clusterName, compName, expansions)
+		if err = r.Client.Create(reqCtx.Ctx, opsRequest); err != nil {
+			return err
+		}
+		now := metav1.Now()
+		for i, status := range statuses {
+			for _, expansion := range expansions {
+				if status.Name == expansion.Name {
+					statuses[i].OpsRequestName = opsRequest.Name
+					statuses[i].LastExpansionTime = now
+				}
+			}
+		}
+		r.Recorder.Eventf(comp, corev1.EventTypeNormal, "VolumeAutoscaling",
+			"the volume usage exceeds the threshold, create the OpsRequest %s to expand the volumes", opsRequest.Name)
+	}
+
+	if equality.Semantic.DeepEqual(statuses, comp.Status.VolumeAutoscaling) {
+		return nil
+	}
+	patch := client.MergeFrom(comp.DeepCopy())
+	comp.Status.VolumeAutoscaling = statuses
+	return r.Client.Status().Patch(reqCtx.Ctx, comp, patch)
+}
+
+func (r *VolumeAutoscalingReconciler) hasRunningVolumeExpansion(ctx context.Context, namespace, clusterName string) (bool, error) {
+	opsList := &opsv1alpha1.OpsRequestList{}
+	if err := r.Client.List(ctx, opsList, client.InNamespace(namespace), client.MatchingLabels{
+		constant.AppInstanceLabelKey:    clusterName,
+		constant.OpsRequestTypeLabelKey: string(opsv1alpha1.VolumeExpansionType),
+	}); err != nil {
+		return false, err
+	}
+	for _, ops := range opsList.Items {
+		if !ops.IsComplete() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// volumeUsages returns the highest usage, in percentage, among the volumes of each volumeClaimTemplate.
+func (r *VolumeAutoscalingReconciler) volumeUsages(reqCtx intctrlutil.RequestCtx,
+	comp *appsv1.Component, clusterName, compName string, vcts []appsv1.PersistentVolumeClaimTemplate) (map[string]int32, error) {
+	podUsages, err := readVolumeUsages(reqCtx, r.Client, r.statsReader, comp.Namespace, constant.GetCompLabels(clusterName, compName))
+	if err != nil {
+		return nil, err
+	}
+	usages := map[string]int32{}
+	for _, volumes := range podUsages {
+		for vctName, usage := range volumes {
+			if usage > usages[vctName] {
+				usages[vctName] = usage
+			}
+		}
+	}
+	return usages, nil
+}
+
+func autoscalingVolumeClaimTemplates(comp *appsv1.Component) []appsv1.PersistentVolumeClaimTemplate {
+	var vcts []appsv1.PersistentVolumeClaimTemplate
+	for _, vct := range comp.Spec.VolumeClaimTemplates {
+		if vct.Autoscaling != nil {
+			vcts = append(vcts, vct)
+		}
+	}
+	return vcts
+}
+
+// expandVolumeStorage checks the usage of the volumes against the autoscaling policy, and returns the storage
+// to expand to if the volumes should be expanded. The status is updated accordingly.
+func expandVolumeStorage(vct appsv1.PersistentVolumeClaimTemplate, usage int32, status *appsv1.VolumeAutoscalingStatus) (resource.Quantity, bool) {
+	policy := vct.Autoscaling
+	threshold := int32(defaultVolumeAutoscalingThresholdPercent)
+	if policy.ThresholdPercent != nil {
+		threshold = *policy.ThresholdPercent
+	}
+	if usage < threshold {
+		status.Message = ""
+		return resource.Quantity{}, false
+	}
+
+	current := vct.Spec.Resources.Requests[corev1.ResourceStorage]
+	if status.Storage != nil && current.Cmp(*status.Storage) < 0 {
+		// the last expansion has not been applied to the Component yet.
+		return resource.Quantity{}, false
+	}
+	if !status.LastExpansionTime.IsZero() && time.Since(status.LastExpansionTime.Time) < volumeAutoscalingCooldown {
+		return resource.Quantity{}, false
+	}
+
+	storage := nextVolumeStorage(current, policy)
+	if storage.Cmp(current) <= 0 {
+		status.Message = fmt.Sprintf("the volume usage %d%% exceeds the threshold %d%%, but the volume has reached the max size %s",
+			usage, threshold, policy.MaxSize.String())
+		return resource.Quantity{}, false
+	}
+	status.UsagePercent = &usage
+	status.Storage = &storage
+	status.Message = ""
+	return storage, true
+}
+
+// nextVolumeStorage calculates the storage of the next expansion, which is rounded up to Gi and capped by the max size.
+func nextVolumeStorage(current resource.Quantity, policy *appsv1.PersistentVolumeClaimAutoscaling) resource.Quantity {
+	stepPercent := int64(defaultVolumeAutoscalingStepPercent)
+	if policy.StepPercent != nil {
+		stepPercent = int64(*policy.StepPercent)
+	}
+	step := current.Value() * stepPercent / 100
+	if policy.MinStep != nil && policy.MinStep.Value() > step {
+		step = policy.MinStep.Value()
+	}
+	const gi = int64(1 << 30)
+	size := (current.Value() + step + gi - 1) / gi * gi
+	if size >= policy.MaxSize.Value() {
+		return policy.MaxSize.DeepCopy()
+	}
+	return *resource.NewQuantity(size, resource.BinarySI)
+}
+
+func buildVolumeExpansionOpsRequest(comp *appsv1.Component, clusterName, compName string,
+	vcts []opsv1alpha1.OpsRequestVolumeClaimTemplate) *opsv1alpha1.OpsRequest {
+	// the volumes of a sharding are expanded for all its shards.
+	if shardingName, ok := comp.Labels[constant.KBAppShardingNameLabelKey]; ok {
+		compName = shardingName
+	}
+	return &opsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-volumeexpansion-%s", comp.Name, rand.String(5)),
+			Namespace: comp.Namespace,
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey:          clusterName,
+				constant.OpsRequestTypeLabelKey:       string(opsv1alpha1.VolumeExpansionType),
+				constant.OpsVolumeAutoscalingLabelKey: comp.Name,
+			},
+		},
+		Spec: opsv1alpha1.OpsRequestSpec{
+			ClusterName: clusterName,
+			Type:        opsv1alpha1.VolumeExpansionType,
+			SpecificOpsRequest: opsv1alpha1.SpecificOpsRequest{
+				VolumeExpansionList: []opsv1alpha1.VolumeExpansion{
+					{
+						ComponentOps:         opsv1alpha1.ComponentOps{ComponentName: compName},
+						VolumeClaimTemplates: vcts,
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,261 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+type fakeVolumeStatsReader struct {
+	summaries map[string]*volumeStatsSummary
+}
+
+func (r *fakeVolumeStatsReader) read(_ context.Context, nodeName string) (*volumeStatsSummary, error) {
+	if summary, ok := r.summaries[nodeName]; ok {
+		return summary, nil
+	}
+	return nil, fmt.Errorf("node %s not found", nodeName)
+}
+
+var _ = Describe("volume autoscaling test", func() {
+	const (
+		namespace   = "default"
+		clusterName = "test-cluster"
+		compName    = "comp"
+		vctName     = "data"
+		nodeName    = "node-0"
+	)
+
+	var (
+		gi         = uint64(1 << 30)
+		compKey    = types.NamespacedName{Namespace: namespace, Name: constant.GenerateClusterComponentName(clusterName, compName)}
+		comp       *appsv1.Component
+		reader     *fakeVolumeStatsReader
+		reconciler *VolumeAutoscalingReconciler
+		objects    []client.Object
+	)
+
+	newReconciler := func() {
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).Should(Succeed())
+		Expect(appsv1.AddToScheme(scheme)).Should(Succeed())
+		Expect(opsv1alpha1.AddToScheme(scheme)).Should(Succeed())
+		cli := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(append(objects, comp)...).
+			WithStatusSubresource(&appsv1.Component{}).
+			Build()
+		reconciler = &VolumeAutoscalingReconciler{
+			Client:      cli,
+			Scheme:      scheme,
+			Recorder:    record.NewFakeRecorder(10),
+			statsReader: reader,
+		}
+	}
+
+	usedBytes := func(used uint64) {
+		reader.summaries[nodeName].Pods[0].Volumes[0].UsedBytes = ptr.To(used)
+	}
+
+	reconcile := func() {
+		_, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: compKey})
+		Expect(err).ShouldNot(HaveOccurred())
+	}
+
+	listOps := func() []opsv1alpha1.OpsRequest {
+		opsList := &opsv1alpha1.OpsRequestList{}
+		Expect(reconciler.Client.List(context.Background(), opsList)).Should(Succeed())
+		return opsList.Items
+	}
+
+	BeforeEach(func() {
+		labels := constant.GetCompLabels(clusterName, compName)
+		comp = &appsv1.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      compKey.Name,
+				Labels:    labels,
+			},
+			Spec: appsv1.ComponentSpec{
+				Replicas: 1,
+				VolumeClaimTemplates: []appsv1.PersistentVolumeClaimTemplate{
+					{
+						Name: vctName,
+						Spec: corev1.PersistentVolumeClaimSpec{
+							Resources: corev1.VolumeResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceStorage: resource.MustParse("10Gi"),
+								},
+							},
+						},
+						Autoscaling: &appsv1.PersistentVolumeClaimAutoscaling{
+							ThresholdPercent: ptr.To[int32](80),
+							StepPercent:      ptr.To[int32](20),
+							MaxSize:          resource.MustParse("15Gi"),
+						},
+					},
+				},
+			},
+		}
+		pvcName := fmt.Sprintf("%s-%s-0", vctName, compKey.Name)
+		objects = []client.Object{
+			&corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      pvcName,
+					Labels: map[string]string{
+						constant.AppManagedByLabelKey:            constant.AppName,
+						constant.AppInstanceLabelKey:             clusterName,
+						constant.KBAppComponentLabelKey:          compName,
+						constant.VolumeClaimTemplateNameLabelKey: vctName,
+					},
+				},
+			},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      compKey.Name + "-0",
+					Labels:    labels,
+				},
+				Spec: corev1.PodSpec{NodeName: nodeName},
+			},
+		}
+		reader = &fakeVolumeStatsReader{
+			summaries: map[string]*volumeStatsSummary{
+				nodeName: {
+					Pods: []podVolumeStats{
+						{
+							PodRef: podReference{Namespace: namespace, Name: compKey.Name + "-0"},
+							Volumes: []volumeStats{
+								{
+									Name:          vctName,
+									PVCRef:        &pvcReference{Namespace: namespace, Name: pvcName},
+									CapacityBytes: ptr.To(10 * gi),
+									UsedBytes:     ptr.To(5 * gi),
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	Context("next volume storage", func() {
+		It("rounds up to Gi and caps at the max size", func() {
+			policy := &appsv1.PersistentVolumeClaimAutoscaling{
+				StepPercent: ptr.To[int32](10),
+				MaxSize:     resource.MustParse("100Gi"),
+			}
+			storage := nextVolumeStorage(resource.MustParse("10Gi"), policy)
+			Expect(storage.Cmp(resource.MustParse("11Gi"))).Should(Equal(0))
+
+			storage = nextVolumeStorage(resource.MustParse("5Gi"), policy)
+			Expect(storage.Cmp(resource.MustParse("6Gi"))).Should(Equal(0))
+
+			policy.MinStep = ptr.To(resource.MustParse("20Gi"))
+			storage = nextVolumeStorage(resource.MustParse("10Gi"), policy)
+			Expect(storage.Cmp(resource.MustParse("30Gi"))).Should(Equal(0))
+
+			storage = nextVolumeStorage(resource.MustParse("90Gi"), policy)
+			Expect(storage.Cmp(resource.MustParse("100Gi"))).Should(Equal(0))
+		})
+	})
+
+	Context("reconcile", func() {
+		It("does nothing if the usage is below the threshold", func() {
+			newReconciler()
+			reconcile()
+			Expect(listOps()).Should(BeEmpty())
+		})
+
+		It("creates a VolumeExpansion OpsRequest if the usage exceeds the threshold", func() {
+			usedBytes(9 * gi)
+			newReconciler()
+			reconcile()
+
+			opsList := listOps()
+			Expect(opsList).Should(HaveLen(1))
+			ops := opsList[0]
+			Expect(ops.Spec.Type).Should(Equal(opsv1alpha1.VolumeExpansionType))
+			Expect(ops.Spec.ClusterName).Should(Equal(clusterName))
+			Expect(ops.Labels).Should(HaveKeyWithValue(constant.OpsVolumeAutoscalingLabelKey, compKey.Name))
+			Expect(ops.Spec.VolumeExpansionList).Should(HaveLen(1))
+			Expect(ops.Spec.VolumeExpansionList[0].ComponentName).Should(Equal(compName))
+			Expect(ops.Spec.VolumeExpansionList[0].VolumeClaimTemplates).Should(HaveLen(1))
+			Expect(ops.Spec.VolumeExpansionList[0].VolumeClaimTemplates[0].Storage.Cmp(resource.MustParse("12Gi"))).Should(Equal(0))
+
+			Expect(reconciler.Client.Get(context.Background(), compKey, comp)).Should(Succeed())
+			Expect(comp.Status.VolumeAutoscaling).Should(HaveLen(1))
+			status := comp.Status.VolumeAutoscaling[0]
+			Expect(status.Name).Should(Equal(vctName))
+			Expect(status.OpsRequestName).Should(Equal(ops.Name))
+			Expect(*status.UsagePercent).Should(BeEquivalentTo(90))
+			Expect(status.Storage.Cmp(resource.MustParse("12Gi"))).Should(Equal(0))
+			Expect(status.LastExpansionTime.IsZero()).Should(BeFalse())
+
+			By("waiting for the running OpsRequest")
+			reconcile()
+			Expect(listOps()).Should(HaveLen(1))
+		})
+
+		It("reports the volume has reached the max size", func() {
+			usedBytes(9 * gi)
+			comp.Spec.VolumeClaimTemplates[0].Autoscaling.MaxSize = resource.MustParse("10Gi")
+			newReconciler()
+			reconcile()
+
+			Expect(listOps()).Should(BeEmpty())
+			Expect(reconciler.Client.Get(context.Background(), compKey, comp)).Should(Succeed())
+			Expect(comp.Status.VolumeAutoscaling).Should(HaveLen(1))
+			Expect(comp.Status.VolumeAutoscaling[0].Message).Should(ContainSubstring("reached the max size"))
+		})
+
+		It("expands the volumes of a sharding by the sharding name", func() {
+			usedBytes(9 * gi)
+			comp.Labels[constant.KBAppShardingNameLabelKey] = "shard"
+			newReconciler()
+			reconcile()
+
+			opsList := listOps()
+			Expect(opsList).Should(HaveLen(1))
+			Expect(opsList[0].Spec.VolumeExpansionList[0].ComponentName).Should(Equal("shard"))
+		})
+	})
+})
@@ -0,0 +1,250 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	volumeProtectionSyncPeriod = 30 * time.Second
+
+	// defaultVolumeWatermarkGap is the default gap between the high and low watermarks of a volume.
+	defaultVolumeWatermarkGap = 10
+)
+
+// VolumeProtectionReconciler switches the replicas of a Component into the read-only state by the `readonly` lifecycle action
+// when the usage of their volumes exceeds the high watermark, to prevent the database from running out of space.
+// The replicas are switched back by the `readwrite` lifecycle action once the usage falls below the low watermark.
+type VolumeProtectionReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	statsReader volumeStatsReader
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=components,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=components/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=componentdefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes/proxy,verbs=get
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.14.4/pkg/reconcile
+func (r *VolumeProtectionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("component", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	comp := &appsv1.Component{}
+	if err := r.Client.Get(ctx, req.NamespacedName, comp); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if !comp.GetDeletionTimestamp().IsZero() || len(comp.Spec.CompDef) == 0 {
+		return intctrlutil.Reconciled()
+	}
+
+	compDef, err := component.GetCompDefByName(ctx, r.Client, comp.Spec.CompDef)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	watermarks := volumeWatermarks(compDef)
+	if len(watermarks) == 0 || !volumeProtectionSupported(compDef) {
+		return intctrlutil.Reconciled()
+	}
+
+	if err = r.protect(reqCtx, comp, compDef, watermarks); err != nil {
+		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.RequeueAfter(volumeProtectionSyncPeriod, reqCtx.Log, "")
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VolumeProtectionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.statsReader == nil {
+		reader, err := newKubeletVolumeStatsReader(mgr.GetConfig())
+		if err != nil {
+			return err
+		}
+		r.statsReader = reader
+	}
+	return intctrlutil.NewControllerManagedBy(mgr).
+		Named("volume-protection").
+		For(&appsv1.Component{}).
+		Complete(r)
+}
+
+func (r *VolumeProtectionReconciler) protect(reqCtx intctrlutil.RequestCtx,
+	comp *appsv1.Component, compDef *appsv1.ComponentDefinition, watermarks map[string]volumeWatermark) error {
+	synthesizedComp, err := component.BuildSynthesizedComponent(reqCtx.Ctx, r.Client, compDef, comp)
+	if err != nil {
+		return err
+	}
+	synthesizedComp.TemplateVars, _, err = component.ResolveTemplateNEnvVars(reqCtx.Ctx, r.Client, synthesizedComp, compDef.Spec.Vars)
+	if err != nil {
+		return err
+	}
+
+	pods, err := component.ListOwnedPods(reqCtx.Ctx, r.Client, comp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name)
+	if err != nil {
+		return err
+	}
+	usages, err := readVolumeUsages(reqCtx, r.Client, r.statsReader, comp.Namespace,
+		constant.GetCompLabels(synthesizedComp.ClusterName, synthesizedComp.Name))
+	if err != nil {
+		return err
+	}
+
+	compCopy := comp.DeepCopy()
+	existed := map[string]bool{}
+	for _, pod := range pods {
+		existed[pod.Name] = true
+		volumes, ok := usages[pod.Name]
+		if !ok {
+			// keep the replica as it is until the usage of its volumes is known.
+			continue
+		}
+		replica := component.GetReadonlyReplica(comp, pod.Name)
+		message, exceeded := exceedVolumeHighWatermark(volumes, watermarks)
+		switch {
+		case exceeded && replica == nil:
+			if err = r.switchAccessMode(reqCtx.Ctx, synthesizedComp, pod, pods, true); err != nil {
+				r.Recorder.Eventf(comp, corev1.EventTypeWarning, "ReadonlyFailed",
+					"failed to switch the replica %s into the read-only state: %s", pod.Name, err.Error())
+				continue
+			}
+			component.SetReadonlyReplica(comp, pod.Name, appsv1.ReadonlyReasonVolumeHighWatermark, message)
+			r.Recorder.Eventf(comp, corev1.EventTypeWarning, string(appsv1.ReadonlyReasonVolumeHighWatermark),
+				"the replica %s is switched into the read-only state: %s", pod.Name, message)
+		case !exceeded && replica != nil && replica.Reason == appsv1.ReadonlyReasonVolumeHighWatermark &&
+			belowVolumeLowWatermark(volumes, watermarks):
+			if err = r.switchAccessMode(reqCtx.Ctx, synthesizedComp, pod, pods, false); err != nil {
+				r.Recorder.Eventf(comp, corev1.EventTypeWarning, "ReadwriteFailed",
+					"failed to switch the replica %s back to the read-write state: %s", pod.Name, err.Error())
+				continue
+			}
+			component.RemoveReadonlyReplica(comp, pod.Name)
+			r.Recorder.Eventf(comp, corev1.EventTypeNormal, "VolumeSpaceAvailable",
+				"the replica %s is switched back to the read-write state", pod.Name)
+		}
+	}
+	// the replicas which have been deleted, such as by scaling in, are not read-only anymore.
+	for _, replica := range compCopy.Status.ReadonlyReplicas {
+		if !existed[replica.Name] {
+			component.RemoveReadonlyReplica(comp, replica.Name)
+		}
+	}
+
+	if equality.Semantic.DeepEqual(compCopy.Status, comp.Status) {
+		return nil
+	}
+	return r.Client.Status().Patch(reqCtx.Ctx, comp, client.MergeFrom(compCopy))
+}
+
+func (r *VolumeProtectionReconciler) switchAccessMode(ctx context.Context,
+	synthesizedComp *component.SynthesizedComponent, pod *corev1.Pod, pods []*corev1.Pod, readonly bool) error {
+	lfa, err := lifecycle.New(synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name,
+		synthesizedComp.LifecycleActions, synthesizedComp.TemplateVars, pod, pods...)
+	if err != nil {
+		return err
+	}
+	if readonly {
+		return lfa.Readonly(ctx, r.Client, nil)
+	}
+	return lfa.Readwrite(ctx, r.Client, nil)
+}
+
+// volumeWatermark is the watermarks of a volume, the usage between them keeps the access mode of the replica
+// unchanged, to prevent the replica from flapping between the read-only and read-write states.
+type volumeWatermark struct {
+	high int
+	low  int
+}
+
+// volumeWatermarks returns the watermarks of the volumes which have the protection enabled.
+func volumeWatermarks(compDef *appsv1.ComponentDefinition) map[string]volumeWatermark {
+	watermarks := map[string]volumeWatermark{}
+	for _, vol := range compDef.Spec.Volumes {
+		if vol.HighWatermark > 0 {
+			low := vol.LowWatermark
+			if low <= 0 || low >= vol.HighWatermark {
+				low = vol.HighWatermark - min(defaultVolumeWatermarkGap, vol.HighWatermark/2)
+			}
+			watermarks[vol.Name] = volumeWatermark{high: vol.HighWatermark, low: low}
+		}
+	}
+	return watermarks
+}
+
+func volumeProtectionSupported(compDef *appsv1.ComponentDefinition) bool {
+	actions := compDef.Spec.LifecycleActions
+	return actions != nil && actions.Readonly != nil && actions.Readwrite != nil
+}
+
+// exceedVolumeHighWatermark checks whether the usage of any volume exceeds its high watermark,
+// and returns a message about the exceeded volumes.
+func exceedVolumeHighWatermark(usages map[string]int32, watermarks map[string]volumeWatermark) (string, bool) {
+	var names []string
+	for name, watermark := range watermarks {
+		if usage, ok := usages[name]; ok && usage > int32(watermark.high) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "", false
+	}
+	sort.Strings(names)
+	name := names[0]
+	return fmt.Sprintf("the usage of volume %s is %d%%, which exceeds the high watermark %d%%",
+		name, usages[name], watermarks[name].high), true
+}
+
+// belowVolumeLowWatermark checks whether the usages of all volumes fall below their low watermarks.
+func belowVolumeLowWatermark(usages map[string]int32, watermarks map[string]volumeWatermark) bool {
+	for name, watermark := range watermarks {
+		if usage, ok := usages[name]; ok && usage >= int32(watermark.low) {
+			return false
+		}
+	}
+	return true
+}
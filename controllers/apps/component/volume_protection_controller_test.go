@@ -0,0 +1,271 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	kbacli "github.com/apecloud/kubeblocks/pkg/kbagent/client"
+	kbagentproto "github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("volume protection test", func() {
+	const (
+		namespace   = "default"
+		clusterName = "test-cluster"
+		compName    = "comp"
+		compDefName = "test-compdef"
+		volumeName  = "data"
+		nodeName    = "node-0"
+	)
+
+	var (
+		gi         = uint64(1 << 30)
+		compKey    = types.NamespacedName{Namespace: namespace, Name: constant.GenerateClusterComponentName(clusterName, compName)}
+		podName    = compKey.Name + "-0"
+		compDef    *appsv1.ComponentDefinition
+		comp       *appsv1.Component
+		reader     *fakeVolumeStatsReader
+		reconciler *VolumeProtectionReconciler
+		actions    []string
+	)
+
+	newReconciler := func() {
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).Should(Succeed())
+		Expect(appsv1.AddToScheme(scheme)).Should(Succeed())
+		cli := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(compDef, comp, &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      fmt.Sprintf("%s-%s", volumeName, podName),
+					Labels: map[string]string{
+						constant.AppManagedByLabelKey:            constant.AppName,
+						constant.AppInstanceLabelKey:             clusterName,
+						constant.KBAppComponentLabelKey:          compName,
+						constant.VolumeClaimTemplateNameLabelKey: volumeName,
+					},
+				},
+			}, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      podName,
+					Labels:    constant.GetCompLabels(clusterName, compName),
+				},
+				Spec: corev1.PodSpec{NodeName: nodeName},
+			}).
+			WithStatusSubresource(&appsv1.Component{}).
+			Build()
+		reconciler = &VolumeProtectionReconciler{
+			Client:      cli,
+			Scheme:      scheme,
+			Recorder:    record.NewFakeRecorder(10),
+			statsReader: reader,
+		}
+	}
+
+	usedBytes := func(used uint64) {
+		reader.summaries[nodeName].Pods[0].Volumes[0].UsedBytes = ptr.To(used)
+	}
+
+	reconcile := func() {
+		_, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: compKey})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(reconciler.Client.Get(context.Background(), compKey, comp)).Should(Succeed())
+	}
+
+	BeforeEach(func() {
+		compDef = testapps.NewComponentDefinitionFactory(compDefName).
+			SetDefaultSpec().
+			SetLifecycleAction("Readonly", testapps.NewLifecycleAction("readonly")).
+			SetLifecycleAction("Readwrite", testapps.NewLifecycleAction("readwrite")).
+			GetObject()
+		compDef.Spec.Volumes = []appsv1.ComponentVolume{
+			{
+				Name:          volumeName,
+				HighWatermark: 90,
+			},
+		}
+		comp = &appsv1.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      compKey.Name,
+				Labels:    constant.GetCompLabels(clusterName, compName),
+				Annotations: map[string]string{
+					constant.KBAppClusterUIDKey: "uid",
+				},
+			},
+			Spec: appsv1.ComponentSpec{
+				CompDef:  compDefName,
+				Replicas: 1,
+			},
+		}
+		pvcName := fmt.Sprintf("%s-%s", volumeName, podName)
+		reader = &fakeVolumeStatsReader{
+			summaries: map[string]*volumeStatsSummary{
+				nodeName: {
+					Pods: []podVolumeStats{
+						{
+							PodRef: podReference{Namespace: namespace, Name: podName},
+							Volumes: []volumeStats{
+								{
+									Name:          volumeName,
+									PVCRef:        &pvcReference{Namespace: namespace, Name: pvcName},
+									CapacityBytes: ptr.To(10 * gi),
+									UsedBytes:     ptr.To(5 * gi),
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		actions = nil
+		testapps.MockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+			recorder.Action(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req kbagentproto.ActionRequest) (kbagentproto.ActionResponse, error) {
+				actions = append(actions, req.Action)
+				return kbagentproto.ActionResponse{}, nil
+			}).AnyTimes()
+		})
+	})
+
+	AfterEach(func() {
+		kbacli.UnsetMockClient()
+	})
+
+	Context("exceed volume high watermark", func() {
+		It("checks the usages against the watermarks", func() {
+			watermarks := map[string]volumeWatermark{"data": {high: 90, low: 80}, "log": {high: 80, low: 70}}
+			_, exceeded := exceedVolumeHighWatermark(map[string]int32{"data": 90, "log": 50}, watermarks)
+			Expect(exceeded).Should(BeFalse())
+
+			message, exceeded := exceedVolumeHighWatermark(map[string]int32{"data": 95, "log": 85}, watermarks)
+			Expect(exceeded).Should(BeTrue())
+			Expect(message).Should(ContainSubstring("volume data is 95%"))
+
+			_, exceeded = exceedVolumeHighWatermark(map[string]int32{"other": 100}, watermarks)
+			Expect(exceeded).Should(BeFalse())
+		})
+
+		It("checks the usages against the low watermarks", func() {
+			watermarks := map[string]volumeWatermark{"data": {high: 90, low: 80}, "log": {high: 80, low: 70}}
+			Expect(belowVolumeLowWatermark(map[string]int32{"data": 79, "log": 69}, watermarks)).Should(BeTrue())
+			Expect(belowVolumeLowWatermark(map[string]int32{"data": 85, "log": 50}, watermarks)).Should(BeFalse())
+			Expect(belowVolumeLowWatermark(map[string]int32{"data": 50, "log": 70}, watermarks)).Should(BeFalse())
+		})
+
+		It("defaults the low watermarks", func() {
+			compDef.Spec.Volumes = []appsv1.ComponentVolume{
+				{Name: "data", HighWatermark: 90},
+				{Name: "log", HighWatermark: 80, LowWatermark: 50},
+				{Name: "tmp", HighWatermark: 5},
+				{Name: "none"},
+			}
+			Expect(volumeWatermarks(compDef)).Should(Equal(map[string]volumeWatermark{
+				"data": {high: 90, low: 80},
+				"log":  {high: 80, low: 50},
+				"tmp":  {high: 5, low: 3},
+			}))
+		})
+	})
+
+	Context("reconcile", func() {
+		It("does nothing if the usage is below the high watermark", func() {
+			newReconciler()
+			reconcile()
+			Expect(actions).Should(BeEmpty())
+			Expect(comp.Status.ReadonlyReplicas).Should(BeEmpty())
+			Expect(meta.FindStatusCondition(comp.Status.Conditions, appsv1.ConditionTypeReadonly)).Should(BeNil())
+		})
+
+		It("does nothing if the readonly & readwrite actions are not defined", func() {
+			compDef.Spec.LifecycleActions.Readwrite = nil
+			usedBytes(10 * gi)
+			newReconciler()
+			reconcile()
+			Expect(actions).Should(BeEmpty())
+			Expect(comp.Status.ReadonlyReplicas).Should(BeEmpty())
+		})
+
+		It("switches the replica into read-only and back to read-write", func() {
+			usedBytes(uint64(9.5 * float64(gi)))
+			newReconciler()
+			reconcile()
+			Expect(actions).Should(Equal([]string{"readonly"}))
+			Expect(comp.Status.ReadonlyReplicas).Should(HaveLen(1))
+			Expect(comp.Status.ReadonlyReplicas[0].Name).Should(Equal(podName))
+			Expect(comp.Status.ReadonlyReplicas[0].Reason).Should(Equal(appsv1.ReadonlyReasonVolumeHighWatermark))
+			cond := meta.FindStatusCondition(comp.Status.Conditions, appsv1.ConditionTypeReadonly)
+			Expect(cond).ShouldNot(BeNil())
+			Expect(cond.Status).Should(Equal(metav1.ConditionTrue))
+			Expect(cond.Reason).Should(Equal(string(appsv1.ReadonlyReasonVolumeHighWatermark)))
+
+			By("keeping the replica read-only while the usage is still high")
+			reconcile()
+			Expect(actions).Should(Equal([]string{"readonly"}))
+
+			By("keeping the replica read-only until the usage falls below the low watermark")
+			usedBytes(uint64(8.5 * float64(gi)))
+			reconcile()
+			Expect(actions).Should(Equal([]string{"readonly"}))
+			Expect(comp.Status.ReadonlyReplicas).Should(HaveLen(1))
+
+			By("switching back once the space is available")
+			usedBytes(5 * gi)
+			reconcile()
+			Expect(actions).Should(Equal([]string{"readonly", "readwrite"}))
+			Expect(comp.Status.ReadonlyReplicas).Should(BeEmpty())
+			Expect(meta.IsStatusConditionFalse(comp.Status.Conditions, appsv1.ConditionTypeReadonly)).Should(BeTrue())
+		})
+
+		It("keeps the replica switched into read-only by the OpsRequest", func() {
+			comp.Status.ReadonlyReplicas = []appsv1.ReadonlyReplicaStatus{
+				{
+					Name:   podName,
+					Reason: appsv1.ReadonlyReasonOpsRequest,
+				},
+			}
+			newReconciler()
+			reconcile()
+			Expect(actions).Should(BeEmpty())
+			Expect(comp.Status.ReadonlyReplicas).Should(HaveLen(1))
+		})
+	})
+})
@@ -0,0 +1,154 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// volumeStatsSummary is the subset of the kubelet stats summary about the volumes of pods.
+type volumeStatsSummary struct {
+	Pods []podVolumeStats `json:"pods"`
+}
+
+type podVolumeStats struct {
+	PodRef  podReference  `json:"podRef"`
+	Volumes []volumeStats `json:"volume,omitempty"`
+}
+
+type podReference struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+type volumeStats struct {
+	Name          string        `json:"name"`
+	PVCRef        *pvcReference `json:"pvcRef,omitempty"`
+	CapacityBytes *uint64       `json:"capacityBytes,omitempty"`
+	UsedBytes     *uint64       `json:"usedBytes,omitempty"`
+}
+
+type pvcReference struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+type volumeStatsReader interface {
+	read(ctx context.Context, nodeName string) (*volumeStatsSummary, error)
+}
+
+// kubeletVolumeStatsReader reads the stats summary of kubelet through the node proxy of the API server.
+type kubeletVolumeStatsReader struct {
+	restClient rest.Interface
+}
+
+var _ volumeStatsReader = &kubeletVolumeStatsReader{}
+
+func newKubeletVolumeStatsReader(config *rest.Config) (*kubeletVolumeStatsReader, error) {
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &kubeletVolumeStatsReader{restClient: clientset.CoreV1().RESTClient()}, nil
+}
+
+func (r *kubeletVolumeStatsReader) read(ctx context.Context, nodeName string) (*volumeStatsSummary, error) {
+	data, err := r.restClient.Get().
+		Resource("nodes").
+		Name(nodeName).
+		SubResource("proxy").
+		Suffix("stats/summary").
+		DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+	summary := &volumeStatsSummary{}
+	if err = json.Unmarshal(data, summary); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// readVolumeUsages returns the usage, in percentage, of the volumes of each pod matching the labels,
+// the usages are keyed by the pod name and then the volumeClaimTemplate name.
+func readVolumeUsages(reqCtx intctrlutil.RequestCtx, cli client.Reader, reader volumeStatsReader,
+	namespace string, labels map[string]string) (map[string]map[string]int32, error) {
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := cli.List(reqCtx.Ctx, pvcList, client.InNamespace(namespace), client.MatchingLabels(labels)); err != nil {
+		return nil, err
+	}
+	pvcs := map[string]string{}
+	for _, pvc := range pvcList.Items {
+		pvcs[pvc.Name] = pvc.Labels[constant.VolumeClaimTemplateNameLabelKey]
+	}
+
+	podList := &corev1.PodList{}
+	if err := cli.List(reqCtx.Ctx, podList, client.InNamespace(namespace), client.MatchingLabels(labels)); err != nil {
+		return nil, err
+	}
+	nodes := map[string]bool{}
+	for _, pod := range podList.Items {
+		if len(pod.Spec.NodeName) > 0 {
+			nodes[pod.Spec.NodeName] = true
+		}
+	}
+
+	usages := map[string]map[string]int32{}
+	for node := range nodes {
+		summary, err := reader.read(reqCtx.Ctx, node)
+		if err != nil {
+			// the stats of the other nodes are still useful, the volumes on this node will be checked next time.
+			reqCtx.Log.Info("failed to read the volume stats", "node", node, "error", err.Error())
+			continue
+		}
+		for _, pod := range summary.Pods {
+			if pod.PodRef.Namespace != namespace {
+				continue
+			}
+			for _, volume := range pod.Volumes {
+				if volume.PVCRef == nil || volume.CapacityBytes == nil || volume.UsedBytes == nil || *volume.CapacityBytes == 0 {
+					continue
+				}
+				vctName, ok := pvcs[volume.PVCRef.Name]
+				if !ok {
+					continue
+				}
+				if usages[pod.PodRef.Name] == nil {
+					usages[pod.PodRef.Name] = map[string]int32{}
+				}
+				usage := int32(math.Ceil(float64(*volume.UsedBytes) * 100 / float64(*volume.CapacityBytes)))
+				if usage > usages[pod.PodRef.Name][vctName] {
+					usages[pod.PodRef.Name][vctName] = usage
+				}
+			}
+		}
+	}
+	return usages, nil
+}
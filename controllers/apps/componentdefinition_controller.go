@@ -443,12 +443,40 @@ func (r *ComponentDefinitionReconciler) validateAvailableWithProbe(cmpd *appsv1.
 	if withProbe == nil {
 		return nil
 	}
+	if withProbe.Synthetic != nil {
+		return r.validateAvailableSyntheticProbe(cmpd, withProbe.Synthetic)
+	}
 	if cmpd.Spec.LifecycleActions == nil || cmpd.Spec.LifecycleActions.AvailableProbe == nil {
 		return fmt.Errorf("the available probe is required to be defined when withProbe of available is specified")
 	}
 	return nil
 }
 
+func (r *ComponentDefinitionReconciler) validateAvailableSyntheticProbe(cmpd *appsv1.ComponentDefinition,
+	synthetic *appsv1.ComponentAvailableSyntheticProbe) error {
+	roles := sets.New[string]()
+	for _, role := range cmpd.Spec.Roles {
+		roles.Insert(role.Name)
+	}
+	for _, target := range synthetic.Targets {
+		idx := slices.IndexFunc(cmpd.Spec.Services, func(svc appsv1.ComponentService) bool {
+			return svc.Name == target.Service
+		})
+		if idx < 0 {
+			return fmt.Errorf("the service of synthetic probe target %s is not defined: %s", target.Name, target.Service)
+		}
+		if podService := cmpd.Spec.Services[idx].PodService; podService != nil && *podService {
+			return fmt.Errorf("the service of synthetic probe target %s is a pod service: %s", target.Name, target.Service)
+		}
+		for _, role := range target.Roles {
+			if !roles.Has(role) {
+				return fmt.Errorf("the role of synthetic probe target %s is not defined: %s", target.Name, role)
+			}
+		}
+	}
+	return nil
+}
+
 func (r *ComponentDefinitionReconciler) validateReplicaRoles(cli client.Client, reqCtx intctrlutil.RequestCtx,
 	cmpd *appsv1.ComponentDefinition) error {
 	if !checkUniqueItemWithValue(cmpd.Spec.Roles, "Name", nil) {
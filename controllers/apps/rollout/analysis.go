@@ -0,0 +1,323 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	promapi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	analysisInstancesPlaceholder = "$instances"
+	analysisQueryTimeout         = 30 * time.Second
+)
+
+// analysisMetricProvider queries the value of a metric.
+type analysisMetricProvider interface {
+	query(ctx context.Context, query string) (float64, error)
+}
+
+// newAnalysisMetricProvider creates the provider to query the metric, it can be replaced in tests.
+var newAnalysisMetricProvider = func(metric appsv1alpha1.RolloutAnalysisMetric) (analysisMetricProvider, error) {
+	cli, err := promapi.NewClient(promapi.Config{Address: metric.Prometheus.Address})
+	if err != nil {
+		return nil, err
+	}
+	return &prometheusMetricProvider{api: promv1.NewAPI(cli)}, nil
+}
+
+type prometheusMetricProvider struct {
+	api promv1.API
+}
+
+var _ analysisMetricProvider = &prometheusMetricProvider{}
+
+func (p *prometheusMetricProvider) query(ctx context.Context, query string) (float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, analysisQueryTimeout)
+	defer cancel()
+
+	value, _, err := p.api.Query(ctx, query, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	switch v := value.(type) {
+	case *model.Scalar:
+		return float64(v.Value), nil
+	case model.Vector:
+		if len(v) != 1 {
+			return 0, fmt.Errorf("the query returns %d samples, but only one is expected", len(v))
+		}
+		return float64(v[0].Value), nil
+	default:
+		return 0, fmt.Errorf("the query returns an unsupported result type %s", value.Type())
+	}
+}
+
+// analyze runs the analysis against the new instances of the rollout, and returns whether the analysis has succeeded.
+//
+// The instances are selected by the matching labels, and the ones created from the instance templates of the rollout
+// are the new instances. It returns false without error if the analysis has failed.
+func analyze(transCtx *rolloutTransformContext, analysis *appsv1alpha1.RolloutAnalysis,
+	status *appsv1alpha1.RolloutAnalysisStatus, matchingLabels map[string]string) (bool, error) {
+	switch status.Phase {
+	case appsv1alpha1.SucceedRolloutAnalysisPhase:
+		return true, nil
+	case appsv1alpha1.FailedRolloutAnalysisPhase:
+		return false, nil
+	}
+
+	interval := time.Duration(ptr.Deref(analysis.IntervalSeconds, 60)) * time.Second
+	if !status.LastMeasurementTimestamp.IsZero() {
+		if diff := time.Until(status.LastMeasurementTimestamp.Add(interval)); diff > 0 {
+			return false, controllerutil.NewDelayedRequeueError(diff, "wait for the next measurement of the analysis")
+		}
+	}
+
+	canary, stable, err := analysisInstances(transCtx, matchingLabels)
+	if err != nil {
+		return false, err
+	}
+	passed := true
+	status.Metrics = make([]appsv1alpha1.RolloutAnalysisMetricStatus, 0, len(analysis.Metrics))
+	for _, metric := range analysis.Metrics {
+		metricStatus := measureAnalysisMetric(transCtx.Context, metric, canary, stable)
+		if !metricStatus.Passed {
+			passed = false
+		}
+		status.Metrics = append(status.Metrics, metricStatus)
+	}
+	status.LastMeasurementTimestamp = metav1.Now()
+	if passed {
+		status.SuccessfulMeasurements++
+	} else {
+		status.FailedMeasurements++
+	}
+
+	if status.FailedMeasurements > ptr.Deref(analysis.FailureLimit, 0) {
+		status.Phase = appsv1alpha1.FailedRolloutAnalysisPhase
+		status.Message = fmt.Sprintf("the analysis has failed %d times", status.FailedMeasurements)
+		return false, nil
+	}
+	if status.SuccessfulMeasurements >= ptr.Deref(analysis.SuccessfulMeasurements, 3) {
+		status.Phase = appsv1alpha1.SucceedRolloutAnalysisPhase
+		status.Message = ""
+		return true, nil
+	}
+	return false, controllerutil.NewDelayedRequeueError(interval, "wait for the next measurement of the analysis")
+}
+
+// analysisInstances returns the names of the new instances and the old instances.
+func analysisInstances(transCtx *rolloutTransformContext, matchingLabels map[string]string) ([]string, []string, error) {
+	pods := &corev1.PodList{}
+	listOpts := []client.ListOption{
+		client.InNamespace(transCtx.Rollout.Namespace),
+		client.MatchingLabels(matchingLabels),
+	}
+	if err := transCtx.Client.List(transCtx.Context, pods, listOpts...); err != nil {
+		return nil, nil, err
+	}
+	prefix := replaceInstanceTemplateNamePrefix(transCtx.Rollout)
+	canary, stable := make([]string, 0), make([]string, 0)
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		if strings.HasPrefix(pod.Labels[constant.KBAppInstanceTemplateLabelKey], prefix) {
+			canary = append(canary, pod.Name)
+		} else {
+			stable = append(stable, pod.Name)
+		}
+	}
+	return canary, stable, nil
+}
+
+// measureAnalysisMetric measures the metric of the canary and stable instances, and checks whether it meets the thresholds.
+func measureAnalysisMetric(ctx context.Context, metric appsv1alpha1.RolloutAnalysisMetric,
+	canaryInstances, stableInstances []string) appsv1alpha1.RolloutAnalysisMetricStatus {
+	status := appsv1alpha1.RolloutAnalysisMetricStatus{Name: metric.Name}
+	provider, err := newAnalysisMetricProvider(metric)
+	if err != nil {
+		status.Message = err.Error()
+		return status
+	}
+
+	canary, err := provider.query(ctx, analysisQuery(metric.Prometheus.Query, canaryInstances))
+	if err != nil {
+		status.Message = fmt.Sprintf("failed to query the metric of the new instances: %s", err.Error())
+		return status
+	}
+	status.CanaryValue = formatAnalysisValue(canary)
+
+	var stable *float64
+	if metric.MaxDeviationPercent != nil {
+		value, err := provider.query(ctx, analysisQuery(metric.Prometheus.Query, stableInstances))
+		if err != nil {
+			status.Message = fmt.Sprintf("failed to query the metric of the old instances: %s", err.Error())
+			return status
+		}
+		status.StableValue = formatAnalysisValue(value)
+		stable = &value
+	}
+
+	if err = checkAnalysisThresholds(metric, canary, stable); err != nil {
+		status.Message = err.Error()
+		return status
+	}
+	status.Passed = true
+	return status
+}
+
+func checkAnalysisThresholds(metric appsv1alpha1.RolloutAnalysisMetric, canary float64, stable *float64) error {
+	if math.IsNaN(canary) {
+		return fmt.Errorf("the value of the new instances is NaN")
+	}
+	if metric.Max != nil {
+		threshold, err := strconv.ParseFloat(*metric.Max, 64)
+		if err != nil {
+			return fmt.Errorf("invalid max threshold %s: %s", *metric.Max, err.Error())
+		}
+		if canary > threshold {
+			return fmt.Errorf("the value %s is greater than the max threshold %s", formatAnalysisValue(canary), *metric.Max)
+		}
+	}
+	if metric.Min != nil {
+		threshold, err := strconv.ParseFloat(*metric.Min, 64)
+		if err != nil {
+			return fmt.Errorf("invalid min threshold %s: %s", *metric.Min, err.Error())
+		}
+		if canary < threshold {
+			return fmt.Errorf("the value %s is less than the min threshold %s", formatAnalysisValue(canary), *metric.Min)
+		}
+	}
+	if metric.MaxDeviationPercent != nil && stable != nil {
+		deviation := analysisDeviationPercent(canary, *stable)
+		if deviation > float64(*metric.MaxDeviationPercent) {
+			return fmt.Errorf("the deviation %s%% from the old instances is greater than %d%%",
+				formatAnalysisValue(deviation), *metric.MaxDeviationPercent)
+		}
+	}
+	return nil
+}
+
+func analysisDeviationPercent(canary, stable float64) float64 {
+	if canary == stable {
+		return 0
+	}
+	if stable == 0 {
+		return math.Inf(1)
+	}
+	return math.Abs(canary-stable) / math.Abs(stable) * 100
+}
+
+// analysisQuery replaces the instances placeholder in the query with a regular expression matching the instances.
+func analysisQuery(query string, instances []string) string {
+	quoted := make([]string, 0, len(instances))
+	for _, instance := range instances {
+		quoted = append(quoted, regexp.QuoteMeta(instance))
+	}
+	return strings.ReplaceAll(query, analysisInstancesPlaceholder, strings.Join(quoted, "|"))
+}
+
+func formatAnalysisValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// compAnalysisStatus returns the analysis status of the component, it will be initialized if not exist.
+func compAnalysisStatus(rollout *appsv1alpha1.Rollout, compName string) *appsv1alpha1.RolloutAnalysisStatus {
+	for i, status := range rollout.Status.Components {
+		if status.Name == compName {
+			if status.Analysis == nil {
+				rollout.Status.Components[i].Analysis = &appsv1alpha1.RolloutAnalysisStatus{
+					Phase: appsv1alpha1.RunningRolloutAnalysisPhase,
+				}
+			}
+			return rollout.Status.Components[i].Analysis
+		}
+	}
+	return nil
+}
+
+// shardingAnalysisStatus returns the analysis status of the sharding, it will be initialized if not exist.
+func shardingAnalysisStatus(rollout *appsv1alpha1.Rollout, shardingName string) *appsv1alpha1.RolloutAnalysisStatus {
+	for i, status := range rollout.Status.Shardings {
+		if status.Name == shardingName {
+			if status.Analysis == nil {
+				rollout.Status.Shardings[i].Analysis = &appsv1alpha1.RolloutAnalysisStatus{
+					Phase: appsv1alpha1.RunningRolloutAnalysisPhase,
+				}
+			}
+			return rollout.Status.Shardings[i].Analysis
+		}
+	}
+	return nil
+}
+
+func isCompAnalysisFailed(rollout *appsv1alpha1.Rollout, compName string) bool {
+	for _, status := range rollout.Status.Components {
+		if status.Name == compName {
+			return isAnalysisFailed(status.Analysis)
+		}
+	}
+	return false
+}
+
+func isShardingAnalysisFailed(rollout *appsv1alpha1.Rollout, shardingName string) bool {
+	for _, status := range rollout.Status.Shardings {
+		if status.Name == shardingName {
+			return isAnalysisFailed(status.Analysis)
+		}
+	}
+	return false
+}
+
+func isRolloutAnalysisFailed(rollout *appsv1alpha1.Rollout) bool {
+	for _, comp := range rollout.Spec.Components {
+		if isCompAnalysisFailed(rollout, comp.Name) {
+			return true
+		}
+	}
+	for _, sharding := range rollout.Spec.Shardings {
+		if isShardingAnalysisFailed(rollout, sharding.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func isAnalysisFailed(status *appsv1alpha1.RolloutAnalysisStatus) bool {
+	return status != nil && status.Phase == appsv1alpha1.FailedRolloutAnalysisPhase
+}
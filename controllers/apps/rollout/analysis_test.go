@@ -0,0 +1,373 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	appsutil "github.com/apecloud/kubeblocks/controllers/apps/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+var _ = Describe("rollout analysis", func() {
+	var (
+		server  *httptest.Server
+		queries []string
+	)
+
+	// the fake metrics server returns 0.5 for the new instances (canary-*) and 0.4 for the others.
+	BeforeEach(func() {
+		queries = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.ParseForm()).Should(Succeed())
+			query := r.Form.Get("query")
+			queries = append(queries, query)
+			value := "0.4"
+			if strings.Contains(query, "canary-") {
+				value = "0.5"
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"%s"]}]}}`, value)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	metric := func(max, min *string, maxDeviationPercent *int32) appsv1alpha1.RolloutAnalysisMetric {
+		return appsv1alpha1.RolloutAnalysisMetric{
+			Name: "error-rate",
+			Prometheus: appsv1alpha1.RolloutPrometheusMetric{
+				Address: server.URL,
+				Query:   `sum(rate(errors_total{pod=~"$instances"}[1m]))`,
+			},
+			Max:                 max,
+			Min:                 min,
+			MaxDeviationPercent: maxDeviationPercent,
+		}
+	}
+
+	It("replaces the instances placeholder", func() {
+		Expect(analysisQuery(`up{pod=~"$instances"}`, []string{"a-0", "a.1"})).Should(Equal(`up{pod=~"a-0|a\.1"}`))
+	})
+
+	It("passes the thresholds", func() {
+		status := measureAnalysisMetric(context.Background(), metric(ptr.To("0.6"), ptr.To("0.1"), nil), []string{"canary-0"}, []string{"stable-0"})
+		Expect(status.Passed).Should(BeTrue())
+		Expect(status.CanaryValue).Should(Equal("0.5"))
+		Expect(status.StableValue).Should(BeEmpty())
+		Expect(queries).Should(HaveLen(1))
+		Expect(queries[0]).Should(ContainSubstring(`pod=~"canary-0"`))
+	})
+
+	It("exceeds the max threshold", func() {
+		status := measureAnalysisMetric(context.Background(), metric(ptr.To("0.45"), nil, nil), []string{"canary-0"}, []string{"stable-0"})
+		Expect(status.Passed).Should(BeFalse())
+		Expect(status.Message).Should(ContainSubstring("max threshold"))
+	})
+
+	It("compares with the old instances", func() {
+		status := measureAnalysisMetric(context.Background(), metric(nil, nil, ptr.To[int32](30)), []string{"canary-0"}, []string{"stable-0", "stable-1"})
+		Expect(status.Passed).Should(BeTrue())
+		Expect(status.StableValue).Should(Equal("0.4"))
+		Expect(queries).Should(HaveLen(2))
+		Expect(queries[1]).Should(ContainSubstring(`pod=~"stable-0|stable-1"`))
+
+		status = measureAnalysisMetric(context.Background(), metric(nil, nil, ptr.To[int32](10)), []string{"canary-0"}, []string{"stable-0"})
+		Expect(status.Passed).Should(BeFalse())
+		Expect(status.Message).Should(ContainSubstring("deviation"))
+	})
+
+	It("fails to query the metric", func() {
+		m := metric(ptr.To("1"), nil, nil)
+		u, _ := url.Parse(server.URL)
+		m.Prometheus.Address = fmt.Sprintf("http://%s/not-found", u.Host)
+		server.Config.Handler = http.NotFoundHandler()
+		status := measureAnalysisMetric(context.Background(), m, []string{"canary-0"}, nil)
+		Expect(status.Passed).Should(BeFalse())
+		Expect(status.Message).Should(ContainSubstring("failed to query"))
+	})
+})
+
+var _ = Describe("rollout analysis - replace", func() {
+	const (
+		clusterName     = "test-cluster"
+		compName        = "comp"
+		serviceVersion1 = "1.0.1"
+		serviceVersion2 = "1.0.2"
+		replicas        = int32(3)
+	)
+
+	var (
+		server      *httptest.Server
+		canaryValue string
+		transCtx    *rolloutTransformContext
+		dag         *graph.DAG
+	)
+
+	// the fake metrics server returns the canaryValue for the new instance, and 0.1 for the others.
+	BeforeEach(func() {
+		canaryValue = "0.1"
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.ParseForm()).Should(Succeed())
+			value := "0.1"
+			if strings.Contains(r.Form.Get("query"), fmt.Sprintf("%s-%s-%d", clusterName, compName, replicas)) {
+				value = canaryValue
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"%s"]}]}}`, value)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	// the first new instance has been created and is ready, the rollout is about to scale down the first old instance
+	newTransCtx := func(successfulMeasurements int32, rollbackPolicy *appsv1alpha1.RolloutRollbackPolicy) {
+		rollout := &appsv1alpha1.Rollout{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-rollout",
+				UID:       types.UID("8dc1f4d2-0c44-4b6e-a7c1-2d16a2f0c0a1"),
+			},
+			Spec: appsv1alpha1.RolloutSpec{
+				ClusterName: clusterName,
+				Components: []appsv1alpha1.RolloutComponent{
+					{
+						Name:           compName,
+						ServiceVersion: ptr.To(serviceVersion2),
+						Strategy: appsv1alpha1.RolloutStrategy{
+							Replace: &appsv1alpha1.RolloutStrategyReplace{
+								Analysis: &appsv1alpha1.RolloutAnalysis{
+									IntervalSeconds:        ptr.To[int32](1),
+									SuccessfulMeasurements: ptr.To(successfulMeasurements),
+									Metrics: []appsv1alpha1.RolloutAnalysisMetric{
+										{
+											Name: "error-rate",
+											Prometheus: appsv1alpha1.RolloutPrometheusMetric{
+												Address: server.URL,
+												Query:   `sum(rate(errors_total{pod=~"$instances"}[1m]))`,
+											},
+											Max: ptr.To("0.5"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				RollbackPolicy: rollbackPolicy,
+			},
+			Status: appsv1alpha1.RolloutStatus{
+				State: appsv1alpha1.RollingRolloutState,
+				Components: []appsv1alpha1.RolloutComponentStatus{
+					{
+						Name:           compName,
+						ServiceVersion: serviceVersion1,
+						Replicas:       replicas,
+						NewReplicas:    1,
+					},
+				},
+			},
+		}
+		prefix := replaceInstanceTemplateNamePrefix(rollout)
+
+		cluster := &appsv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:  "default",
+				Name:       clusterName,
+				Generation: 2,
+			},
+			Spec: appsv1.ClusterSpec{
+				ComponentSpecs: []appsv1.ClusterComponentSpec{
+					{
+						Name:           compName,
+						ServiceVersion: serviceVersion1,
+						Replicas:       replicas + 1,
+						Instances: []appsv1.InstanceTemplate{
+							{
+								Name:           prefix,
+								ServiceVersion: serviceVersion2,
+								Replicas:       ptr.To[int32](1),
+							},
+						},
+						FlatInstanceOrdinal: true,
+					},
+				},
+			},
+			Status: appsv1.ClusterStatus{
+				ObservedGeneration: 2,
+				Components: map[string]appsv1.ClusterComponentStatus{
+					compName: {Phase: appsv1.RunningComponentPhase},
+				},
+			},
+		}
+
+		reader := &appsutil.MockReader{}
+		for i := int32(0); i <= replicas; i++ {
+			labels := constant.GetCompLabels(clusterName, compName)
+			if i == replicas {
+				labels[constant.KBAppInstanceTemplateLabelKey] = prefix
+			} else {
+				labels[constant.KBAppReleasePhaseKey] = constant.ReleasePhaseStable
+			}
+			reader.Objects = append(reader.Objects, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      fmt.Sprintf("%s-%s-%d", clusterName, compName, i),
+					Labels:    labels,
+				},
+			})
+		}
+
+		transCtx = &rolloutTransformContext{
+			Context:     context.Background(),
+			Client:      model.NewGraphClient(reader),
+			Logger:      logr.Discard(),
+			Rollout:     rollout,
+			RolloutOrig: rollout.DeepCopy(),
+			Cluster:     cluster,
+			ClusterOrig: cluster.DeepCopy(),
+			ClusterComps: map[string]*appsv1.ClusterComponentSpec{
+				compName: &cluster.Spec.ComponentSpecs[0],
+			},
+			Components: map[string]*appsv1.Component{
+				compName: {
+					Status: appsv1.ComponentStatus{Phase: appsv1.RunningComponentPhase},
+				},
+			},
+		}
+		dag = graph.NewDAG()
+		dag.AddVertex(&model.ObjectVertex{Obj: transCtx.Rollout, OriObj: transCtx.RolloutOrig, Action: model.ActionStatusPtr()})
+	}
+
+	It("promotes the new instances after the analysis succeeds", func() {
+		newTransCtx(1, nil)
+
+		Expect((&rolloutReplaceTransformer{}).Transform(transCtx, dag)).Should(Succeed())
+
+		analysis := transCtx.Rollout.Status.Components[0].Analysis
+		Expect(analysis).ShouldNot(BeNil())
+		Expect(analysis.Phase).Should(Equal(appsv1alpha1.SucceedRolloutAnalysisPhase))
+		Expect(analysis.SuccessfulMeasurements).Should(Equal(int32(1)))
+		Expect(analysis.Metrics).Should(HaveLen(1))
+		Expect(analysis.Metrics[0].CanaryValue).Should(Equal("0.1"))
+
+		By("scaling down the first old instance")
+		spec := transCtx.ClusterComps[compName]
+		Expect(spec.Replicas).Should(Equal(replicas))
+		Expect(spec.OfflineInstances).Should(Equal([]string{fmt.Sprintf("%s-%s-%d", clusterName, compName, replicas-1)}))
+	})
+
+	It("waits for more measurements", func() {
+		newTransCtx(2, nil)
+
+		err := (&rolloutReplaceTransformer{}).Transform(transCtx, dag)
+		Expect(err).Should(HaveOccurred())
+		Expect(controllerutil.IsDelayedRequeueError(err)).Should(BeTrue())
+
+		analysis := transCtx.Rollout.Status.Components[0].Analysis
+		Expect(analysis.Phase).Should(Equal(appsv1alpha1.RunningRolloutAnalysisPhase))
+		Expect(analysis.SuccessfulMeasurements).Should(Equal(int32(1)))
+		Expect(analysis.LastMeasurementTimestamp.IsZero()).Should(BeFalse())
+
+		By("keeping the old instances")
+		spec := transCtx.ClusterComps[compName]
+		Expect(spec.Replicas).Should(Equal(replicas + 1))
+		Expect(spec.OfflineInstances).Should(BeEmpty())
+
+		By("waiting for the next measurement")
+		err = (&rolloutReplaceTransformer{}).Transform(transCtx, dag)
+		Expect(controllerutil.IsDelayedRequeueError(err)).Should(BeTrue())
+		Expect(analysis.SuccessfulMeasurements).Should(Equal(int32(1)))
+	})
+
+	It("stops the rollout after the analysis fails", func() {
+		newTransCtx(1, nil)
+		canaryValue = "0.9"
+
+		Expect((&rolloutReplaceTransformer{}).Transform(transCtx, dag)).Should(Succeed())
+
+		analysis := transCtx.Rollout.Status.Components[0].Analysis
+		Expect(analysis.Phase).Should(Equal(appsv1alpha1.FailedRolloutAnalysisPhase))
+		Expect(analysis.FailedMeasurements).Should(Equal(int32(1)))
+		Expect(analysis.Metrics[0].Passed).Should(BeFalse())
+		Expect(analysis.Metrics[0].Message).Should(ContainSubstring("max threshold"))
+
+		By("keeping the old instances")
+		spec := transCtx.ClusterComps[compName]
+		Expect(spec.Replicas).Should(Equal(replicas + 1))
+		Expect(spec.OfflineInstances).Should(BeEmpty())
+
+		By("checking the rollout state")
+		Expect((&rolloutStatusTransformer{}).Transform(transCtx, dag)).Should(Succeed())
+		Expect(transCtx.Rollout.Status.State).Should(Equal(appsv1alpha1.ErrorRolloutState))
+
+		By("not rolling out anymore")
+		Expect((&rolloutReplaceTransformer{}).Transform(transCtx, dag)).Should(Succeed())
+		Expect(spec.Replicas).Should(Equal(replicas + 1))
+		Expect(spec.OfflineInstances).Should(BeEmpty())
+
+		By("not rolling back without the rollback policy")
+		Expect((&rolloutRollbackTransformer{}).Transform(transCtx, dag)).Should(Succeed())
+		Expect(transCtx.Rollout.Status.Rollback).Should(BeNil())
+	})
+
+	It("rolls back the rollout after the analysis fails", func() {
+		newTransCtx(1, &appsv1alpha1.RolloutRollbackPolicy{})
+		canaryValue = "0.9"
+
+		Expect((&rolloutReplaceTransformer{}).Transform(transCtx, dag)).Should(Succeed())
+		Expect((&rolloutStatusTransformer{}).Transform(transCtx, dag)).Should(Succeed())
+		Expect(transCtx.Rollout.Status.State).Should(Equal(appsv1alpha1.ErrorRolloutState))
+
+		err := (&rolloutRollbackTransformer{}).Transform(transCtx, dag)
+		Expect(controllerutil.IsRequeueError(err)).Should(BeTrue())
+		Expect(transCtx.Rollout.Status.State).Should(Equal(appsv1alpha1.RollingBackRolloutState))
+		Expect(transCtx.Rollout.Status.Rollback).ShouldNot(BeNil())
+		Expect(transCtx.Rollout.Status.Rollback.Message).Should(ContainSubstring("analysis"))
+
+		spec := transCtx.ClusterComps[compName]
+		Expect(spec.Replicas).Should(Equal(replicas))
+		Expect(spec.ServiceVersion).Should(Equal(serviceVersion1))
+		Expect(spec.Instances).Should(BeEmpty())
+	})
+})
@@ -88,6 +88,8 @@ func (r *RolloutReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			&rolloutMetaTransformer{},
 			&rolloutLoadTransformer{},
 			&rolloutSetupTransformer{},
+			&rolloutMaintenanceWindowTransformer{},
+			&rolloutRollbackTransformer{},
 			&rolloutTearDownTransformer{},
 			&rolloutInplaceTransformer{},
 			&rolloutReplaceTransformer{},
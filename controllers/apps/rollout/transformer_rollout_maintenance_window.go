@@ -0,0 +1,97 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rollout
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	maintenanceWindowMessagePrefix   = "wait for the maintenance window of the cluster"
+	maintenanceWindowRequeueInterval = 5 * time.Minute
+)
+
+// rolloutMaintenanceWindowTransformer holds the rollouts with the replace strategy until the cluster enters its maintenance window.
+type rolloutMaintenanceWindowTransformer struct{}
+
+var _ graph.Transformer = &rolloutMaintenanceWindowTransformer{}
+
+func (t *rolloutMaintenanceWindowTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx := ctx.(*rolloutTransformContext)
+	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutSucceed(transCtx.RolloutOrig) {
+		return nil
+	}
+
+	var (
+		graphCli = transCtx.Client.(model.GraphClient)
+		rollout  = transCtx.Rollout
+	)
+	if rollout.Spec.BypassMaintenanceWindow || !t.disruptive(rollout) || t.started(rollout) {
+		return nil
+	}
+
+	inWindow, nextWindowStart := controllerutil.IsInMaintenanceWindow(transCtx.Cluster.Spec.MaintenanceWindows, time.Now())
+	if inWindow {
+		if strings.HasPrefix(rollout.Status.Message, maintenanceWindowMessagePrefix) {
+			rollout.Status.Message = ""
+		}
+		return nil
+	}
+
+	requeueAfter := maintenanceWindowRequeueInterval
+	message := maintenanceWindowMessagePrefix
+	if nextWindowStart != nil {
+		if d := time.Until(*nextWindowStart); d < requeueAfter {
+			requeueAfter = d
+		}
+		message = fmt.Sprintf("%s, the next window starts at %s", message, nextWindowStart.UTC().Format(time.RFC3339))
+	}
+	rollout.Status.State = appsv1alpha1.PendingRolloutState
+	rollout.Status.Message = message
+	graphCli.Status(dag, transCtx.RolloutOrig, rollout)
+	return controllerutil.NewRequeueError(requeueAfter, message)
+}
+
+// disruptive checks whether the rollout replaces the instances of the cluster.
+func (t *rolloutMaintenanceWindowTransformer) disruptive(rollout *appsv1alpha1.Rollout) bool {
+	for _, comp := range rollout.Spec.Components {
+		if comp.Strategy.Replace != nil {
+			return true
+		}
+	}
+	for _, sharding := range rollout.Spec.Shardings {
+		if sharding.Strategy.Replace != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// started checks whether the rollout has been started, a started rollout will not be interrupted by the maintenance window.
+func (t *rolloutMaintenanceWindowTransformer) started(rollout *appsv1alpha1.Rollout) bool {
+	return len(rollout.Status.State) > 0 && rollout.Status.State != appsv1alpha1.PendingRolloutState
+}
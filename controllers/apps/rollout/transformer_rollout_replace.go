@@ -89,6 +89,10 @@ func (t *rolloutReplaceTransformer) component(transCtx *rolloutTransformContext,
 		return nil
 	}
 
+	if isCompAnalysisFailed(rollout, comp.Name) {
+		return nil // stop rolling out, the rollout will be rolled back if the rollback policy is specified
+	}
+
 	if !checkClusterNCompRunning(transCtx, comp.Name) {
 		return controllerutil.NewDelayedRequeueError(componentNotReadyRequeueDuration, fmt.Sprintf("the component %s is not ready", comp.Name))
 	}
@@ -115,6 +119,9 @@ func (t *rolloutReplaceTransformer) component(transCtx *rolloutTransformContext,
 	if spec.Replicas == replicas {
 		return t.compUp(rollout, comp, spec, newReplicas, tpl)
 	} else {
+		if passed, err := t.checkCompAnalysis(transCtx, rollout, comp); err != nil || !passed {
+			return err
+		}
 		return t.compDown(rollout, comp, spec, newReplicas, instance, instTpl)
 	}
 }
@@ -159,6 +166,20 @@ func (t *rolloutReplaceTransformer) compDown(rollout *appsv1alpha1.Rollout, comp
 	return nil
 }
 
+// checkCompAnalysis checks whether the analysis of the new instances has succeeded before scaling down the old instances.
+func (t *rolloutReplaceTransformer) checkCompAnalysis(transCtx *rolloutTransformContext,
+	rollout *appsv1alpha1.Rollout, comp appsv1alpha1.RolloutComponent) (bool, error) {
+	analysis := comp.Strategy.Replace.Analysis
+	if analysis == nil {
+		return true, nil
+	}
+	status := compAnalysisStatus(rollout, comp.Name)
+	if status == nil {
+		return false, fmt.Errorf("the status of component %s is not found", comp.Name)
+	}
+	return analyze(transCtx, analysis, status, constant.GetCompLabels(rollout.Spec.ClusterName, comp.Name))
+}
+
 func (t *rolloutReplaceTransformer) checkCompDelaySeconds(rollout *appsv1alpha1.Rollout,
 	comp appsv1alpha1.RolloutComponent, newReplicas int32, scaleDown bool) error {
 	delaySeconds := comp.Strategy.Replace.PerInstanceIntervalSeconds
@@ -272,6 +293,10 @@ func (t *rolloutReplaceTransformer) sharding(transCtx *rolloutTransformContext,
 		return nil
 	}
 
+	if isShardingAnalysisFailed(rollout, sharding.Name) {
+		return nil // stop rolling out, the rollout will be rolled back if the rollback policy is specified
+	}
+
 	if !checkClusterNShardingRunning(transCtx, sharding.Name) {
 		return controllerutil.NewDelayedRequeueError(componentNotReadyRequeueDuration, fmt.Sprintf("the sharding %s is not ready", sharding.Name))
 	}
@@ -298,6 +323,9 @@ func (t *rolloutReplaceTransformer) sharding(transCtx *rolloutTransformContext,
 	if spec.Template.Replicas == replicas {
 		return t.shardingUp(rollout, sharding, spec, newReplicas, tpl)
 	} else {
+		if passed, err := t.checkShardingAnalysis(transCtx, rollout, sharding); err != nil || !passed {
+			return err
+		}
 		return t.shardingDown(rollout, sharding, spec, newReplicas, instance, instTpl)
 	}
 }
@@ -342,6 +370,22 @@ func (t *rolloutReplaceTransformer) shardingDown(rollout *appsv1alpha1.Rollout,
 	return nil
 }
 
+// checkShardingAnalysis checks whether the analysis of the new instances has succeeded before scaling down the old instances.
+func (t *rolloutReplaceTransformer) checkShardingAnalysis(transCtx *rolloutTransformContext,
+	rollout *appsv1alpha1.Rollout, sharding appsv1alpha1.RolloutSharding) (bool, error) {
+	analysis := sharding.Strategy.Replace.Analysis
+	if analysis == nil {
+		return true, nil
+	}
+	status := shardingAnalysisStatus(rollout, sharding.Name)
+	if status == nil {
+		return false, fmt.Errorf("the status of sharding %s is not found", sharding.Name)
+	}
+	return analyze(transCtx, analysis, status, constant.GetClusterLabels(rollout.Spec.ClusterName, map[string]string{
+		constant.KBAppShardingNameLabelKey: sharding.Name,
+	}))
+}
+
 func (t *rolloutReplaceTransformer) checkShardingDelaySeconds(rollout *appsv1alpha1.Rollout,
 	sharding appsv1alpha1.RolloutSharding, newReplicas int32, scaleDown bool) error {
 	delaySeconds := sharding.Strategy.Replace.PerInstanceIntervalSeconds
@@ -0,0 +1,234 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rollout
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// rolloutRollbackTransformer rolls back the rollout automatically when it fails or times out.
+type rolloutRollbackTransformer struct{}
+
+var _ graph.Transformer = &rolloutRollbackTransformer{}
+
+func (t *rolloutRollbackTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx := ctx.(*rolloutTransformContext)
+	if model.IsObjectDeleting(transCtx.RolloutOrig) || isRolloutSucceed(transCtx.RolloutOrig) {
+		return nil
+	}
+
+	var (
+		graphCli = transCtx.Client.(model.GraphClient)
+		rollout  = transCtx.Rollout
+	)
+	if rollout.Status.Rollback == nil {
+		if rollout.Spec.RollbackPolicy == nil {
+			return nil
+		}
+		// the rollout goes to the Error state once the analysis fails
+		if rollout.Status.State != appsv1alpha1.RollingRolloutState && !isRolloutAnalysisFailed(rollout) {
+			return nil
+		}
+		reason := t.checkFailure(transCtx, rollout)
+		if len(reason) == 0 {
+			return nil
+		}
+		rollout.Status.State = appsv1alpha1.RollingBackRolloutState
+		rollout.Status.Message = reason
+		rollout.Status.Rollback = &appsv1alpha1.RolloutRollbackStatus{
+			StartTimestamp: metav1.Now(),
+			Message:        reason,
+		}
+	}
+
+	// the rollback has been completed, or it has failed
+	if rollout.Status.State != appsv1alpha1.RollingBackRolloutState {
+		return graph.ErrPrematureStop
+	}
+
+	if err := t.rollback(transCtx); err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(transCtx.ClusterOrig.Spec, transCtx.Cluster.Spec) {
+		graphCli.Update(dag, transCtx.ClusterOrig, transCtx.Cluster)
+		graphCli.Status(dag, transCtx.RolloutOrig, rollout)
+		return controllerutil.NewRequeueError(componentNotReadyRequeueDuration, "rolling back")
+	}
+
+	if t.rolledBack(transCtx, rollout) {
+		rollout.Status.State = appsv1alpha1.RolledBackRolloutState
+		rollout.Status.Rollback.CompletionTimestamp = metav1.Now()
+		graphCli.Status(dag, transCtx.RolloutOrig, rollout)
+		return graph.ErrPrematureStop
+	}
+	if t.timedOut(rollout, rollout.Status.Rollback.StartTimestamp) {
+		rollout.Status.State = appsv1alpha1.ErrorRolloutState
+		rollout.Status.Message = "the rollback has timed out"
+		graphCli.Status(dag, transCtx.RolloutOrig, rollout)
+		return graph.ErrPrematureStop
+	}
+	graphCli.Status(dag, transCtx.RolloutOrig, rollout)
+	return controllerutil.NewRequeueError(componentNotReadyRequeueDuration, "wait for the rollback to be completed")
+}
+
+// checkFailure checks whether the rollout has failed or timed out, and returns the reason if so.
+func (t *rolloutRollbackTransformer) checkFailure(transCtx *rolloutTransformContext, rollout *appsv1alpha1.Rollout) string {
+	cluster := transCtx.ClusterOrig
+	for _, comp := range rollout.Spec.Components {
+		if isCompAnalysisFailed(rollout, comp.Name) {
+			return fmt.Sprintf("the analysis of component %s has failed", comp.Name)
+		}
+		if cluster.Status.Components[comp.Name].Phase == appsv1.FailedComponentPhase {
+			return fmt.Sprintf("the component %s has failed", comp.Name)
+		}
+	}
+	for _, sharding := range rollout.Spec.Shardings {
+		if isShardingAnalysisFailed(rollout, sharding.Name) {
+			return fmt.Sprintf("the analysis of sharding %s has failed", sharding.Name)
+		}
+		if cluster.Status.Shardings[sharding.Name].Phase == appsv1.FailedComponentPhase {
+			return fmt.Sprintf("the sharding %s has failed", sharding.Name)
+		}
+	}
+	if t.timedOut(rollout, rollout.Status.StartTimestamp) {
+		return fmt.Sprintf("the rollout has not succeeded within %d seconds", *rollout.Spec.RollbackPolicy.TimeoutSeconds)
+	}
+	return ""
+}
+
+func (t *rolloutRollbackTransformer) timedOut(rollout *appsv1alpha1.Rollout, start metav1.Time) bool {
+	timeout := rolloutRollbackTimeout(rollout)
+	if timeout == 0 || start.IsZero() {
+		return false
+	}
+	return time.Since(start.Time) > timeout
+}
+
+func (t *rolloutRollbackTransformer) rolledBack(transCtx *rolloutTransformContext, rollout *appsv1alpha1.Rollout) bool {
+	for _, comp := range rollout.Spec.Components {
+		if !checkClusterNCompRunning(transCtx, comp.Name) {
+			return false
+		}
+	}
+	for _, sharding := range rollout.Spec.Shardings {
+		if !checkClusterNShardingRunning(transCtx, sharding.Name) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *rolloutRollbackTransformer) rollback(transCtx *rolloutTransformContext) error {
+	rollout := transCtx.Rollout
+	for _, comp := range rollout.Spec.Components {
+		if comp.Strategy.Create != nil {
+			return createStrategyNotSupportedError
+		}
+		for _, status := range rollout.Status.Components {
+			if status.Name == comp.Name {
+				t.component(rollout, transCtx.ClusterComps[comp.Name], status)
+				break
+			}
+		}
+	}
+	for _, sharding := range rollout.Spec.Shardings {
+		if sharding.Strategy.Create != nil {
+			return createStrategyNotSupportedError
+		}
+		for _, status := range rollout.Status.Shardings {
+			if status.Name == sharding.Name {
+				t.sharding(rollout, transCtx.ClusterShardings[sharding.Name], status)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (t *rolloutRollbackTransformer) component(rollout *appsv1alpha1.Rollout,
+	spec *appsv1.ClusterComponentSpec, status appsv1alpha1.RolloutComponentStatus) {
+	spec.ServiceVersion = status.ServiceVersion
+	spec.ComponentDef = status.CompDef
+	spec.Replicas = status.Replicas
+	spec.Instances = t.instanceTemplates(rollout, spec.Instances, status.ServiceVersion, status.CompDef, status.InstanceReplicas)
+	spec.OfflineInstances = slices.DeleteFunc(spec.OfflineInstances, func(instance string) bool {
+		return slices.Contains(status.ScaleDownInstances, instance)
+	})
+}
+
+func (t *rolloutRollbackTransformer) sharding(rollout *appsv1alpha1.Rollout,
+	spec *appsv1.ClusterSharding, status appsv1alpha1.RolloutShardingStatus) {
+	spec.ShardingDef = status.ShardingDef
+	spec.Template.ServiceVersion = status.ServiceVersion
+	spec.Template.ComponentDef = status.CompDef
+	spec.Template.Replicas = status.Replicas
+	spec.Template.Instances = t.instanceTemplates(rollout, spec.Template.Instances, status.ServiceVersion, status.CompDef, status.InstanceReplicas)
+	spec.Template.OfflineInstances = slices.DeleteFunc(spec.Template.OfflineInstances, func(instance string) bool {
+		return slices.Contains(status.ScaleDownInstances, instance)
+	})
+	for i, tpl := range spec.ShardTemplates {
+		if tpl.ShardingDef != nil {
+			spec.ShardTemplates[i].ShardingDef = ptr.To(status.ShardingDef)
+		}
+		if tpl.ServiceVersion != nil || tpl.CompDef != nil {
+			spec.ShardTemplates[i].ServiceVersion = ptr.To(status.ServiceVersion)
+			spec.ShardTemplates[i].CompDef = ptr.To(status.CompDef)
+		}
+	}
+}
+
+// instanceTemplates removes the instance templates created by the rollout, and restores the original ones.
+func (t *rolloutRollbackTransformer) instanceTemplates(rollout *appsv1alpha1.Rollout, tpls []appsv1.InstanceTemplate,
+	serviceVersion, compDef string, replicas map[string]int32) []appsv1.InstanceTemplate {
+	prefix := replaceInstanceTemplateNamePrefix(rollout)
+	tpls = slices.DeleteFunc(tpls, func(tpl appsv1.InstanceTemplate) bool {
+		return strings.HasPrefix(tpl.Name, prefix)
+	})
+	for i := range tpls {
+		if len(tpls[i].ServiceVersion) > 0 || len(tpls[i].CompDef) > 0 {
+			tpls[i].ServiceVersion = serviceVersion
+			tpls[i].CompDef = compDef
+		}
+		if r, ok := replicas[tpls[i].Name]; ok {
+			tpls[i].Replicas = ptr.To(r)
+		}
+	}
+	return tpls
+}
+
+func rolloutRollbackTimeout(rollout *appsv1alpha1.Rollout) time.Duration {
+	if rollout.Spec.RollbackPolicy == nil || rollout.Spec.RollbackPolicy.TimeoutSeconds == nil {
+		return 0
+	}
+	return time.Duration(*rollout.Spec.RollbackPolicy.TimeoutSeconds) * time.Second
+}
@@ -0,0 +1,332 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	appsutil "github.com/apecloud/kubeblocks/controllers/apps/util"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+var _ = Describe("rollout rollback transformer", func() {
+	const (
+		clusterName     = "test-cluster"
+		compName        = "comp"
+		shardingName    = "sharding"
+		compDef1        = "test-compdef-1.0.1"
+		compDef2        = "test-compdef-1.0.2"
+		serviceVersion1 = "1.0.1"
+		serviceVersion2 = "1.0.2"
+		replicas        = int32(3)
+		shards          = int32(2)
+	)
+
+	var (
+		transCtx *rolloutTransformContext
+		dag      *graph.DAG
+	)
+
+	// the rollout is replacing the instances of the component and the sharding with the new service version,
+	// the first new instance has been created and the first old instance has been scaled down.
+	newTransCtx := func(rollbackPolicy *appsv1alpha1.RolloutRollbackPolicy) {
+		rollout := &appsv1alpha1.Rollout{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-rollout",
+				UID:       types.UID("5f0bfa38-7cf2-4d29-9b1e-0c3b2d4b6a71"),
+			},
+			Spec: appsv1alpha1.RolloutSpec{
+				ClusterName: clusterName,
+				Components: []appsv1alpha1.RolloutComponent{
+					{
+						Name:           compName,
+						ServiceVersion: ptr.To(serviceVersion2),
+						CompDef:        ptr.To(compDef2),
+						Strategy: appsv1alpha1.RolloutStrategy{
+							Replace: &appsv1alpha1.RolloutStrategyReplace{},
+						},
+					},
+				},
+				Shardings: []appsv1alpha1.RolloutSharding{
+					{
+						Name:           shardingName,
+						ServiceVersion: ptr.To(serviceVersion2),
+						CompDef:        ptr.To(compDef2),
+						Strategy: appsv1alpha1.RolloutStrategy{
+							Replace: &appsv1alpha1.RolloutStrategyReplace{},
+						},
+					},
+				},
+				RollbackPolicy: rollbackPolicy,
+			},
+			Status: appsv1alpha1.RolloutStatus{
+				State:          appsv1alpha1.RollingRolloutState,
+				StartTimestamp: metav1.Now(),
+				Components: []appsv1alpha1.RolloutComponentStatus{
+					{
+						Name:               compName,
+						ServiceVersion:     serviceVersion1,
+						CompDef:            compDef1,
+						Replicas:           replicas,
+						NewReplicas:        1,
+						ScaleDownInstances: []string{fmt.Sprintf("%s-%s-%d", clusterName, compName, replicas-1)},
+					},
+				},
+				Shardings: []appsv1alpha1.RolloutShardingStatus{
+					{
+						Name:           shardingName,
+						ServiceVersion: serviceVersion1,
+						CompDef:        compDef1,
+						Replicas:       replicas,
+						NewReplicas:    1,
+					},
+				},
+			},
+		}
+		tpl := appsv1.InstanceTemplate{
+			Name:           replaceInstanceTemplateNamePrefix(rollout),
+			ServiceVersion: serviceVersion2,
+			CompDef:        compDef2,
+			Replicas:       ptr.To[int32](1),
+		}
+
+		cluster := &appsv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:  "default",
+				Name:       clusterName,
+				Generation: 2,
+			},
+			Spec: appsv1.ClusterSpec{
+				ComponentSpecs: []appsv1.ClusterComponentSpec{
+					{
+						Name:             compName,
+						ServiceVersion:   serviceVersion1,
+						ComponentDef:     compDef1,
+						Replicas:         replicas,
+						Instances:        []appsv1.InstanceTemplate{tpl},
+						OfflineInstances: []string{fmt.Sprintf("%s-%s-%d", clusterName, compName, replicas-1)},
+					},
+				},
+				Shardings: []appsv1.ClusterSharding{
+					{
+						Name:   shardingName,
+						Shards: shards,
+						Template: appsv1.ClusterComponentSpec{
+							ServiceVersion: serviceVersion1,
+							ComponentDef:   compDef1,
+							Replicas:       replicas + 1,
+							Instances:      []appsv1.InstanceTemplate{tpl},
+						},
+					},
+				},
+			},
+			Status: appsv1.ClusterStatus{
+				ObservedGeneration: 2,
+				Components: map[string]appsv1.ClusterComponentStatus{
+					compName: {Phase: appsv1.UpdatingComponentPhase},
+				},
+				Shardings: map[string]appsv1.ClusterComponentStatus{
+					shardingName: {Phase: appsv1.UpdatingComponentPhase},
+				},
+			},
+		}
+
+		transCtx = &rolloutTransformContext{
+			Context:     context.Background(),
+			Client:      model.NewGraphClient(&appsutil.MockReader{}),
+			Logger:      logr.Discard(),
+			Rollout:     rollout,
+			RolloutOrig: rollout.DeepCopy(),
+			Cluster:     cluster,
+			ClusterOrig: cluster.DeepCopy(),
+			ClusterComps: map[string]*appsv1.ClusterComponentSpec{
+				compName: &cluster.Spec.ComponentSpecs[0],
+			},
+			ClusterShardings: map[string]*appsv1.ClusterSharding{
+				shardingName: &cluster.Spec.Shardings[0],
+			},
+			Components: map[string]*appsv1.Component{
+				compName: {
+					Status: appsv1.ComponentStatus{Phase: appsv1.UpdatingComponentPhase},
+				},
+			},
+			ShardingComps: map[string][]*appsv1.Component{
+				shardingName: {
+					{Status: appsv1.ComponentStatus{Phase: appsv1.UpdatingComponentPhase}},
+					{Status: appsv1.ComponentStatus{Phase: appsv1.UpdatingComponentPhase}},
+				},
+			},
+		}
+		dag = graph.NewDAG()
+		dag.AddVertex(&model.ObjectVertex{Obj: transCtx.Rollout, OriObj: transCtx.RolloutOrig, Action: model.ActionStatusPtr()})
+	}
+
+	setComponentPhase := func(phase appsv1.ComponentPhase) {
+		transCtx.ClusterOrig.Status.Components[compName] = appsv1.ClusterComponentStatus{Phase: phase}
+		transCtx.ClusterOrig.Status.Shardings[shardingName] = appsv1.ClusterComponentStatus{Phase: phase}
+		transCtx.Components[compName].Status.Phase = phase
+		for _, comp := range transCtx.ShardingComps[shardingName] {
+			comp.Status.Phase = phase
+		}
+	}
+
+	// the cluster has been updated with the rolled back spec in the last reconciliation
+	rolledBackCluster := func() {
+		transCtx.ClusterOrig = transCtx.Cluster.DeepCopy()
+		transCtx.RolloutOrig = transCtx.Rollout.DeepCopy()
+		dag = graph.NewDAG()
+		dag.AddVertex(&model.ObjectVertex{Obj: transCtx.Rollout, OriObj: transCtx.RolloutOrig, Action: model.ActionStatusPtr()})
+	}
+
+	clusterUpdated := func() bool {
+		for _, obj := range transCtx.Client.(model.GraphClient).FindAll(dag, &appsv1.Cluster{}) {
+			if obj.GetName() == clusterName {
+				return true
+			}
+		}
+		return false
+	}
+
+	It("does nothing w/o the rollback policy", func() {
+		newTransCtx(nil)
+		setComponentPhase(appsv1.FailedComponentPhase)
+
+		Expect((&rolloutRollbackTransformer{}).Transform(transCtx, dag)).Should(Succeed())
+		Expect(transCtx.Rollout.Status.Rollback).Should(BeNil())
+		Expect(transCtx.Rollout.Status.State).Should(Equal(appsv1alpha1.RollingRolloutState))
+		Expect(clusterUpdated()).Should(BeFalse())
+	})
+
+	It("does nothing if the rollout is in progress", func() {
+		newTransCtx(&appsv1alpha1.RolloutRollbackPolicy{TimeoutSeconds: ptr.To[int32](600)})
+
+		Expect((&rolloutRollbackTransformer{}).Transform(transCtx, dag)).Should(Succeed())
+		Expect(transCtx.Rollout.Status.Rollback).Should(BeNil())
+		Expect(clusterUpdated()).Should(BeFalse())
+	})
+
+	It("rolls back the rollout after the component fails", func() {
+		newTransCtx(&appsv1alpha1.RolloutRollbackPolicy{})
+		transCtx.ClusterOrig.Status.Components[compName] = appsv1.ClusterComponentStatus{Phase: appsv1.FailedComponentPhase}
+
+		err := (&rolloutRollbackTransformer{}).Transform(transCtx, dag)
+		Expect(controllerutil.IsRequeueError(err)).Should(BeTrue())
+		Expect(clusterUpdated()).Should(BeTrue())
+
+		status := transCtx.Rollout.Status
+		Expect(status.State).Should(Equal(appsv1alpha1.RollingBackRolloutState))
+		Expect(status.Rollback).ShouldNot(BeNil())
+		Expect(status.Rollback.Message).Should(ContainSubstring(fmt.Sprintf("the component %s has failed", compName)))
+		Expect(status.Rollback.StartTimestamp.IsZero()).Should(BeFalse())
+
+		By("restoring the component spec")
+		spec := transCtx.ClusterComps[compName]
+		Expect(spec.ServiceVersion).Should(Equal(serviceVersion1))
+		Expect(spec.ComponentDef).Should(Equal(compDef1))
+		Expect(spec.Replicas).Should(Equal(replicas))
+		Expect(spec.Instances).Should(BeEmpty())
+		Expect(spec.OfflineInstances).Should(BeEmpty())
+
+		By("restoring the sharding spec")
+		sharding := transCtx.ClusterShardings[shardingName]
+		Expect(sharding.Template.ServiceVersion).Should(Equal(serviceVersion1))
+		Expect(sharding.Template.ComponentDef).Should(Equal(compDef1))
+		Expect(sharding.Template.Replicas).Should(Equal(replicas))
+		Expect(sharding.Template.Instances).Should(BeEmpty())
+	})
+
+	It("rolls back the rollout after the sharding fails", func() {
+		newTransCtx(&appsv1alpha1.RolloutRollbackPolicy{})
+		transCtx.ClusterOrig.Status.Shardings[shardingName] = appsv1.ClusterComponentStatus{Phase: appsv1.FailedComponentPhase}
+
+		err := (&rolloutRollbackTransformer{}).Transform(transCtx, dag)
+		Expect(controllerutil.IsRequeueError(err)).Should(BeTrue())
+		Expect(transCtx.Rollout.Status.State).Should(Equal(appsv1alpha1.RollingBackRolloutState))
+		Expect(transCtx.Rollout.Status.Rollback.Message).Should(ContainSubstring(fmt.Sprintf("the sharding %s has failed", shardingName)))
+		Expect(transCtx.ClusterShardings[shardingName].Template.Replicas).Should(Equal(replicas))
+	})
+
+	It("rolls back the rollout after it times out", func() {
+		newTransCtx(&appsv1alpha1.RolloutRollbackPolicy{TimeoutSeconds: ptr.To[int32](60)})
+		transCtx.Rollout.Status.StartTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+
+		err := (&rolloutRollbackTransformer{}).Transform(transCtx, dag)
+		Expect(controllerutil.IsRequeueError(err)).Should(BeTrue())
+		Expect(clusterUpdated()).Should(BeTrue())
+		Expect(transCtx.Rollout.Status.State).Should(Equal(appsv1alpha1.RollingBackRolloutState))
+		Expect(transCtx.Rollout.Status.Rollback.Message).Should(ContainSubstring("has not succeeded within 60 seconds"))
+		Expect(transCtx.ClusterComps[compName].ServiceVersion).Should(Equal(serviceVersion1))
+	})
+
+	It("completes the rollback after the component and the sharding are running", func() {
+		newTransCtx(&appsv1alpha1.RolloutRollbackPolicy{})
+		setComponentPhase(appsv1.FailedComponentPhase)
+		err := (&rolloutRollbackTransformer{}).Transform(transCtx, dag)
+		Expect(controllerutil.IsRequeueError(err)).Should(BeTrue())
+
+		By("waiting for the rollback to be completed")
+		rolledBackCluster()
+		setComponentPhase(appsv1.UpdatingComponentPhase)
+		err = (&rolloutRollbackTransformer{}).Transform(transCtx, dag)
+		Expect(controllerutil.IsRequeueError(err)).Should(BeTrue())
+		Expect(clusterUpdated()).Should(BeFalse())
+		Expect(transCtx.Rollout.Status.State).Should(Equal(appsv1alpha1.RollingBackRolloutState))
+
+		By("completing the rollback")
+		setComponentPhase(appsv1.RunningComponentPhase)
+		Expect((&rolloutRollbackTransformer{}).Transform(transCtx, dag)).Should(Equal(graph.ErrPrematureStop))
+		Expect(transCtx.Rollout.Status.State).Should(Equal(appsv1alpha1.RolledBackRolloutState))
+		Expect(transCtx.Rollout.Status.Rollback.CompletionTimestamp.IsZero()).Should(BeFalse())
+
+		By("stopping the rollout after it has been rolled back")
+		rolledBackCluster()
+		Expect((&rolloutRollbackTransformer{}).Transform(transCtx, dag)).Should(Equal(graph.ErrPrematureStop))
+		Expect(transCtx.Rollout.Status.State).Should(Equal(appsv1alpha1.RolledBackRolloutState))
+	})
+
+	It("fails the rollback after it times out", func() {
+		newTransCtx(&appsv1alpha1.RolloutRollbackPolicy{TimeoutSeconds: ptr.To[int32](60)})
+		setComponentPhase(appsv1.FailedComponentPhase)
+		err := (&rolloutRollbackTransformer{}).Transform(transCtx, dag)
+		Expect(controllerutil.IsRequeueError(err)).Should(BeTrue())
+
+		rolledBackCluster()
+		transCtx.Rollout.Status.Rollback.StartTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+		Expect((&rolloutRollbackTransformer{}).Transform(transCtx, dag)).Should(Equal(graph.ErrPrematureStop))
+		Expect(transCtx.Rollout.Status.State).Should(Equal(appsv1alpha1.ErrorRolloutState))
+		Expect(transCtx.Rollout.Status.Message).Should(ContainSubstring("the rollback has timed out"))
+		Expect(transCtx.Rollout.Status.Rollback.CompletionTimestamp.IsZero()).Should(BeTrue())
+	})
+})
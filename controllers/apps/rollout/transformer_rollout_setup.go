@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"reflect"
 
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
@@ -172,10 +173,11 @@ func (t *rolloutSetupTransformer) initCompStatus(transCtx *rolloutTransformConte
 		}
 	}
 	rollout.Status.Components = append(rollout.Status.Components, appsv1alpha1.RolloutComponentStatus{
-		Name:           comp.Name,
-		ServiceVersion: spec.ServiceVersion,
-		CompDef:        spec.ComponentDef,
-		Replicas:       spec.Replicas,
+		Name:             comp.Name,
+		ServiceVersion:   spec.ServiceVersion,
+		CompDef:          spec.ComponentDef,
+		Replicas:         spec.Replicas,
+		InstanceReplicas: t.instanceReplicas(spec.Instances),
 	})
 	return nil
 }
@@ -192,11 +194,26 @@ func (t *rolloutSetupTransformer) initShardingStatus(transCtx *rolloutTransformC
 		}
 	}
 	rollout.Status.Shardings = append(rollout.Status.Shardings, appsv1alpha1.RolloutShardingStatus{
-		Name:           sharding.Name,
-		ShardingDef:    spec.ShardingDef,
-		ServiceVersion: spec.Template.ServiceVersion,
-		CompDef:        spec.Template.ComponentDef,
-		Replicas:       spec.Template.Replicas,
+		Name:             sharding.Name,
+		ShardingDef:      spec.ShardingDef,
+		ServiceVersion:   spec.Template.ServiceVersion,
+		CompDef:          spec.Template.ComponentDef,
+		Replicas:         spec.Template.Replicas,
+		InstanceReplicas: t.instanceReplicas(spec.Template.Instances),
 	})
 	return nil
 }
+
+// instanceReplicas records the replicas of the instance templates, which are used to roll back the rollout.
+func (t *rolloutSetupTransformer) instanceReplicas(tpls []appsv1.InstanceTemplate) map[string]int32 {
+	var replicas map[string]int32
+	for _, tpl := range tpls {
+		if tpl.Replicas != nil {
+			if replicas == nil {
+				replicas = make(map[string]int32)
+			}
+			replicas[tpl.Name] = *tpl.Replicas
+		}
+	}
+	return replicas
+}
@@ -22,6 +22,7 @@ package rollout
 import (
 	"slices"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -32,6 +33,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/generics"
 )
 
@@ -57,10 +59,25 @@ func (t *rolloutStatusTransformer) Transform(ctx graph.TransformContext, dag *gr
 
 	rollout.Status.ObservedGeneration = rollout.Generation
 	rollout.Status.State = t.compose(states1, states2)
+	if rollout.Status.State == appsv1alpha1.RollingRolloutState && rollout.Status.StartTimestamp.IsZero() {
+		rollout.Status.StartTimestamp = metav1.Now()
+	}
 
 	// TODO: error message, conditions
 
-	return nil
+	return t.checkRollbackTimeout(rollout)
+}
+
+// checkRollbackTimeout requeues the rollout to check whether it should be rolled back when the timeout is reached.
+func (t *rolloutStatusTransformer) checkRollbackTimeout(rollout *appsv1alpha1.Rollout) error {
+	timeout := rolloutRollbackTimeout(rollout)
+	if timeout == 0 || rollout.Status.State != appsv1alpha1.RollingRolloutState {
+		return nil
+	}
+	if diff := time.Until(rollout.Status.StartTimestamp.Add(timeout)); diff > 0 {
+		return controllerutil.NewDelayedRequeueError(diff, "wait for the rollout timeout")
+	}
+	return controllerutil.NewDelayedRequeueError(time.Second, "the rollout has timed out")
 }
 
 func (t *rolloutStatusTransformer) compose(states1, states2 []appsv1alpha1.RolloutState) appsv1alpha1.RolloutState {
@@ -145,6 +162,10 @@ func (t *rolloutStatusTransformer) compInplace(transCtx *rolloutTransformContext
 
 func (t *rolloutStatusTransformer) compReplace(transCtx *rolloutTransformContext,
 	rollout *appsv1alpha1.Rollout, comp appsv1alpha1.RolloutComponent) (appsv1alpha1.RolloutState, error) {
+	if isCompAnalysisFailed(rollout, comp.Name) {
+		return appsv1alpha1.ErrorRolloutState, nil
+	}
+
 	spec := t.compSpec(transCtx, comp.Name)
 	prefix := replaceInstanceTemplateNamePrefix(rollout)
 	if slices.IndexFunc(spec.Instances, func(tpl appsv1.InstanceTemplate) bool {
@@ -261,6 +282,10 @@ func (t *rolloutStatusTransformer) shardingInplace(transCtx *rolloutTransformCon
 
 func (t *rolloutStatusTransformer) shardingReplace(transCtx *rolloutTransformContext,
 	rollout *appsv1alpha1.Rollout, sharding appsv1alpha1.RolloutSharding) (appsv1alpha1.RolloutState, error) {
+	if isShardingAnalysisFailed(rollout, sharding.Name) {
+		return appsv1alpha1.ErrorRolloutState, nil
+	}
+
 	spec := t.shardingSpec(transCtx, sharding.Name)
 	prefix := replaceInstanceTemplateNamePrefix(rollout)
 	if slices.IndexFunc(spec.Template.Instances, func(tpl appsv1.InstanceTemplate) bool {
@@ -31,6 +31,8 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -54,9 +56,12 @@ import (
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/action"
 	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
+	dperrors "github.com/apecloud/kubeblocks/pkg/dataprotection/errors"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+	"github.com/apecloud/kubeblocks/pkg/metrics"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
@@ -226,10 +231,34 @@ func (r *BackupReconciler) deleteBackupFiles(reqCtx intctrlutil.RequestCtx, back
 	}
 	deleter.WorkerServiceAccount = saName
 
+	// stop the replication in progress, and delete the copies in the secondary backup repositories first
+	replicator := &dpbackup.Replicator{
+		RequestCtx: reqCtx,
+		Client:     r.Client,
+		Scheme:     r.Scheme,
+	}
+	if err = replicator.DeleteReplicationJob(backup); err != nil {
+		return err
+	}
+	for i := range backup.Status.Replicas {
+		status, err := deleter.DeleteReplicaBackupFiles(backup, i)
+		if status != dpbackup.DeletionStatusSucceeded {
+			return r.handleBackupFilesDeletionStatus(reqCtx, backup, status, err)
+		}
+	}
+
 	status, err := deleter.DeleteBackupFiles(backup)
-	switch status {
-	case dpbackup.DeletionStatusSucceeded:
+	if status == dpbackup.DeletionStatusSucceeded {
 		return deleteBackup()
+	}
+	return r.handleBackupFilesDeletionStatus(reqCtx, backup, status, err)
+}
+
+func (r *BackupReconciler) handleBackupFilesDeletionStatus(reqCtx intctrlutil.RequestCtx,
+	backup *dpv1alpha1.Backup,
+	status dpbackup.DeletionStatus,
+	err error) error {
+	switch status {
 	case dpbackup.DeletionStatusFailed:
 		failureReason := err.Error()
 		if backup.Status.FailureReason == failureReason {
@@ -437,6 +466,11 @@ func (r *BackupReconciler) prepareBackupRequest(
 		}
 	}
 
+	if len(backup.Spec.ConsolidateBackupName) > 0 {
+		// requires backup repo info to validate the consolidated backups
+		return prepare4Consolidation(request)
+	}
+
 	switch dpv1alpha1.BackupType(request.GetBackupType()) {
 	case dpv1alpha1.BackupTypeIncremental:
 		// requires backup repo info to validate parent backup
@@ -455,6 +489,17 @@ func (r *BackupReconciler) prepareBackupRequest(
 func (r *BackupReconciler) prepareRequestTargetInfo(reqCtx intctrlutil.RequestCtx,
 	request *dpbackup.Request,
 	target *dpv1alpha1.BackupTarget) error {
+	if request.IsConsolidation() {
+		// the consolidation works on the backup repository only, it does not require
+		// the target pods to be running.
+		targetPods, err := getConsolidationTargetPods(request, target)
+		if err != nil {
+			return err
+		}
+		request.Target = target
+		request.TargetPods = targetPods
+		return r.prepareRequestWorkerServiceAccount(reqCtx, request, target)
+	}
 	var selectedPods []string
 	backupStatusTarget := dputils.GetBackupStatusTarget(request.Backup, target.Name)
 	if backupStatusTarget != nil {
@@ -488,8 +533,16 @@ func (r *BackupReconciler) prepareRequestTargetInfo(reqCtx intctrlutil.RequestCt
 	}
 
 	request.TargetPods = targetPods
+	return r.prepareRequestWorkerServiceAccount(reqCtx, request, target)
+}
+
+// prepareRequestWorkerServiceAccount prepares the service account of the backup workers for request object.
+func (r *BackupReconciler) prepareRequestWorkerServiceAccount(reqCtx intctrlutil.RequestCtx,
+	request *dpbackup.Request,
+	target *dpv1alpha1.BackupTarget) error {
 	saName := target.ServiceAccountName
 	if saName == "" {
+		var err error
 		// TODO: update the mcMgr param
 		saName, err = EnsureWorkerServiceAccount(reqCtx, r.Client, request.Backup.Namespace, nil)
 		if err != nil {
@@ -500,6 +553,28 @@ func (r *BackupReconciler) prepareRequestTargetInfo(reqCtx intctrlutil.RequestCt
 	return nil
 }
 
+// getConsolidationTargetPods returns the target pods recorded by the last consolidated backup.
+// Only the pod names are used to locate the backup data of each target pod in the repository,
+// so the pods are not fetched and are not required to exist any more.
+func getConsolidationTargetPods(request *dpbackup.Request, target *dpv1alpha1.BackupTarget) ([]*corev1.Pod, error) {
+	lastBackup := request.ConsolidatedBackups[len(request.ConsolidatedBackups)-1]
+	statusTarget := dputils.GetBackupStatusTarget(lastBackup, target.Name)
+	if statusTarget == nil || len(statusTarget.SelectedTargetPods) == 0 {
+		return nil, fmt.Errorf("backup %s/%s has no selected target pods for target %s",
+			lastBackup.Namespace, lastBackup.Name, target.Name)
+	}
+	var targetPods []*corev1.Pod
+	for _, podName := range statusTarget.SelectedTargetPods {
+		targetPods = append(targetPods, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: request.Namespace,
+				Name:      podName,
+			},
+		})
+	}
+	return targetPods, nil
+}
+
 func (r *BackupReconciler) patchBackupStatus(
 	original *dpv1alpha1.Backup,
 	request *dpbackup.Request) error {
@@ -522,6 +597,9 @@ func (r *BackupReconciler) patchBackupStatus(
 	if request.ParentBackup != nil {
 		// inherit encryption config from parent backup
 		request.Status.EncryptionConfig = request.ParentBackup.Status.EncryptionConfig
+	} else if request.IsConsolidation() {
+		// inherit encryption config from the consolidated base backup
+		request.Status.EncryptionConfig = request.ConsolidatedBackups[0].Status.EncryptionConfig
 	} else if request.BackupPolicy.Spec.EncryptionConfig != nil {
 		request.Status.EncryptionConfig = request.BackupPolicy.Spec.EncryptionConfig
 	}
@@ -553,6 +631,21 @@ func (r *BackupReconciler) patchBackupStatus(
 		request.Status.BaseBackupName = request.BaseBackup.Name
 	}
 
+	// set status consolidated backup names and time range, the synthetic full backup
+	// holds the same data as the last consolidated incremental backup.
+	if request.IsConsolidation() {
+		for _, b := range request.ConsolidatedBackups {
+			request.Status.ConsolidatedBackupNames = append(request.Status.ConsolidatedBackupNames, b.Name)
+		}
+		baseBackup := request.ConsolidatedBackups[0]
+		lastBackup := request.ConsolidatedBackups[len(request.ConsolidatedBackups)-1]
+		request.Status.TimeRange = &dpv1alpha1.BackupTimeRange{
+			TimeZone: lastBackup.GetTimeZone(),
+			Start:    baseBackup.GetStartTime(),
+			End:      lastBackup.GetEndTime(),
+		}
+	}
+
 	if err = dpbackup.SetExpirationTime(request.Backup); err != nil {
 		return err
 	}
@@ -669,6 +762,7 @@ func (r *BackupReconciler) handleRunningPhase(
 	if err = r.Client.Status().Patch(reqCtx.Ctx, request.Backup, client.MergeFrom(backup)); err != nil {
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 	}
+	metrics.RecordBackupFinished(request.Backup)
 	return intctrlutil.Reconciled()
 }
 
@@ -756,7 +850,11 @@ func (r *BackupReconciler) checkIsCompletedDuringRunning(reqCtx intctrlutil.Requ
 		act.CompletionTimestamp = backup.Status.CompletionTimestamp
 	}
 
-	return true, r.Client.Status().Patch(reqCtx.Ctx, backup, patch)
+	if err = r.Client.Status().Patch(reqCtx.Ctx, backup, patch); err != nil {
+		return true, err
+	}
+	metrics.RecordBackupFinished(backup)
+	return true, nil
 }
 
 // handleCompletedPhase handles the backup object in completed phase.
@@ -768,9 +866,408 @@ func (r *BackupReconciler) handleCompletedPhase(
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 	}
 
+	if err := r.reparentIncrementalBackups(reqCtx, backup); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
+	if err := r.createSyntheticBackupIfNeeded(reqCtx, backup); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
+	if err := r.verifyBackupIfRequested(reqCtx, backup); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
+	if err := r.replicateBackupIfNeeded(reqCtx, backup); err != nil {
+		return checkedRequeueWithError(err, reqCtx.Log, "")
+	}
+
 	return intctrlutil.Reconciled()
 }
 
+// verifyBackupIfRequested verifies the integrity of the backup data if the backup is annotated
+// with the verify-backup annotation, and reports the result by the Verified and Corrupted conditions.
+// The annotation is removed after the verification finishes, and it can be added again to re-verify.
+func (r *BackupReconciler) verifyBackupIfRequested(
+	reqCtx intctrlutil.RequestCtx,
+	backup *dpv1alpha1.Backup) error {
+	if backup.Annotations[dptypes.VerifyBackupAnnotationKey] != trueVal {
+		return nil
+	}
+
+	saName, err := EnsureWorkerServiceAccount(reqCtx, r.Client, backup.Namespace, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get worker service account: %w", err)
+	}
+	verifier := &dpbackup.Verifier{
+		RequestCtx:           reqCtx,
+		Client:               r.Client,
+		Scheme:               r.Scheme,
+		WorkerServiceAccount: saName,
+	}
+	status, message, err := verifier.VerifyBackupFiles(backup)
+	if err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(backup.DeepCopy())
+	verifiedCond := metav1.Condition{
+		Type:               dpv1alpha1.ConditionTypeVerified,
+		ObservedGeneration: backup.Generation,
+		Message:            message,
+	}
+	switch status {
+	case dpbackup.VerificationStatusVerifying:
+		if cond := meta.FindStatusCondition(backup.Status.Conditions, dpv1alpha1.ConditionTypeVerified); cond != nil &&
+			cond.Reason == ReasonBackupVerifying {
+			return nil
+		}
+		verifiedCond.Status = metav1.ConditionUnknown
+		verifiedCond.Reason = ReasonBackupVerifying
+		verifiedCond.Message = "the backup data is being verified"
+	case dpbackup.VerificationStatusVerified:
+		verifiedCond.Status = metav1.ConditionTrue
+		verifiedCond.Reason = ReasonBackupVerified
+		meta.RemoveStatusCondition(&backup.Status.Conditions, dpv1alpha1.ConditionTypeCorrupted)
+		r.Recorder.Event(backup, corev1.EventTypeNormal, ReasonBackupVerified, message)
+	case dpbackup.VerificationStatusUnverifiable:
+		// the backup files are readable, but their integrity is unknown without the checksum manifest
+		verifiedCond.Status = metav1.ConditionUnknown
+		verifiedCond.Reason = ReasonBackupUnverifiable
+		meta.RemoveStatusCondition(&backup.Status.Conditions, dpv1alpha1.ConditionTypeCorrupted)
+		r.Recorder.Event(backup, corev1.EventTypeWarning, ReasonBackupUnverifiable, message)
+	case dpbackup.VerificationStatusCorrupted:
+		verifiedCond.Status = metav1.ConditionFalse
+		verifiedCond.Reason = ReasonBackupCorrupted
+		meta.SetStatusCondition(&backup.Status.Conditions, metav1.Condition{
+			Type:               dpv1alpha1.ConditionTypeCorrupted,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: backup.Generation,
+			Reason:             ReasonBackupCorrupted,
+			Message:            message,
+		})
+		r.Recorder.Event(backup, corev1.EventTypeWarning, ReasonBackupCorrupted, message)
+	case dpbackup.VerificationStatusFailed:
+		verifiedCond.Status = metav1.ConditionFalse
+		verifiedCond.Reason = ReasonVerificationFailed
+		r.Recorder.Event(backup, corev1.EventTypeWarning, ReasonVerificationFailed, message)
+	}
+	meta.SetStatusCondition(&backup.Status.Conditions, verifiedCond)
+	if err = r.Client.Status().Patch(reqCtx.Ctx, backup, patch); err != nil {
+		return err
+	}
+	if status == dpbackup.VerificationStatusVerifying {
+		return nil
+	}
+
+	// the verification is finished, clean up the verification job and remove the annotation.
+	if err = verifier.DeleteVerificationJob(backup); err != nil {
+		return err
+	}
+	patch = client.MergeFrom(backup.DeepCopy())
+	delete(backup.Annotations, dptypes.VerifyBackupAnnotationKey)
+	return r.Client.Patch(reqCtx.Ctx, backup, patch)
+}
+
+// replicateBackupIfNeeded copies the backup data to the secondary backup repository specified
+// by the replication policy of the backup policy, and records the copy in the backup status.
+func (r *BackupReconciler) replicateBackupIfNeeded(
+	reqCtx intctrlutil.RequestCtx,
+	backup *dpv1alpha1.Backup) error {
+	// the data of a continuous backup keeps changing, do not replicate it.
+	if backup.GetLabels()[dptypes.BackupTypeLabelKey] == string(dpv1alpha1.BackupTypeContinuous) {
+		return nil
+	}
+	backupPolicy, err := dputils.GetBackupPolicyByName(reqCtx, r.Client, backup.Spec.BackupPolicyName)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	replication := backupPolicy.Spec.Replication
+	if replication == nil || replication.BackupRepoName == "" ||
+		replication.BackupRepoName == backup.Status.BackupRepoName {
+		return nil
+	}
+	replicaIndex := -1
+	for i := range backup.Status.Replicas {
+		if backup.Status.Replicas[i].BackupRepoName == replication.BackupRepoName {
+			replicaIndex = i
+			break
+		}
+	}
+	if replicaIndex >= 0 && backup.Status.Replicas[replicaIndex].Phase != dpv1alpha1.BackupReplicaPhaseCopying {
+		// the backup has been replicated to the repo.
+		return nil
+	}
+
+	patch := client.MergeFrom(backup.DeepCopy())
+	started := replicaIndex < 0
+	if started {
+		backup.Status.Replicas = append(backup.Status.Replicas, dpv1alpha1.BackupReplicaStatus{
+			BackupRepoName: replication.BackupRepoName,
+			Phase:          dpv1alpha1.BackupReplicaPhaseCopying,
+			StartTimestamp: &metav1.Time{Time: r.clock.Now().UTC()},
+		})
+		replicaIndex = len(backup.Status.Replicas) - 1
+	}
+	replica := &backup.Status.Replicas[replicaIndex]
+	setReplicaFailed := func(reason string) error {
+		replica.Phase = dpv1alpha1.BackupReplicaPhaseFailed
+		replica.FailureReason = reason
+		replica.CompletionTimestamp = &metav1.Time{Time: r.clock.Now().UTC()}
+		r.Recorder.Event(backup, corev1.EventTypeWarning, ReasonReplicationFailed, reason)
+		return r.Client.Status().Patch(reqCtx.Ctx, backup, patch)
+	}
+
+	replicaRepo := &dpv1alpha1.BackupRepo{}
+	if err = r.Client.Get(reqCtx.Ctx, client.ObjectKey{Name: replication.BackupRepoName}, replicaRepo); err != nil {
+		if apierrors.IsNotFound(err) {
+			return setReplicaFailed(fmt.Sprintf("backup repo %s not found", replication.BackupRepoName))
+		}
+		return err
+	}
+	if replicaRepo.Status.Phase != dpv1alpha1.BackupRepoReady {
+		return dperrors.NewBackupRepoIsNotReady(replicaRepo.Name)
+	}
+	prepared, err := checkBackupRepoPreparedInNamespace(reqCtx.Ctx, r.Client, replicaRepo, backup.Namespace)
+	if err != nil {
+		return err
+	}
+	if !prepared {
+		// wait for the backup repo controller to prepare the secondary backup repo in the namespace.
+		if backup.Labels[dataProtectionWaitReplicaRepoPreparationKey] == trueVal {
+			return nil
+		}
+		metaPatch := client.MergeFrom(backup.DeepCopy())
+		if backup.Labels == nil {
+			backup.Labels = map[string]string{}
+		}
+		backup.Labels[dataProtectionReplicaRepoKey] = replicaRepo.Name
+		backup.Labels[dataProtectionWaitReplicaRepoPreparationKey] = trueVal
+		return r.Client.Patch(reqCtx.Ctx, backup, metaPatch)
+	}
+
+	saName, err := EnsureWorkerServiceAccount(reqCtx, r.Client, backup.Namespace, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get worker service account: %w", err)
+	}
+	replicator := &dpbackup.Replicator{
+		RequestCtx:           reqCtx,
+		Client:               r.Client,
+		Scheme:               r.Scheme,
+		WorkerServiceAccount: saName,
+	}
+	if started {
+		replica.Path = dpbackup.BuildBaseBackupPath(backup, replicaRepo.Spec.PathPrefix, backupPolicy.Spec.PathPrefix)
+	}
+	status, message, err := replicator.ReplicateBackupFiles(backup, replicaRepo, replica.Path)
+	if err != nil {
+		return err
+	}
+	switch status {
+	case dpbackup.ReplicationStatusCopying:
+		if !started {
+			return nil
+		}
+		r.Recorder.Eventf(backup, corev1.EventTypeNormal, ReasonReplicatingBackup,
+			"replicating the backup to backup repo %s", replicaRepo.Name)
+		return r.Client.Status().Patch(reqCtx.Ctx, backup, patch)
+	case dpbackup.ReplicationStatusJobFailed:
+		if replica.Retries >= maxReplicationRetries {
+			if err = setReplicaFailed(message); err != nil {
+				return err
+			}
+			break
+		}
+		// retry the replication by recreating the job with an exponential backoff.
+		wait, err := replicator.RetryReplicationJob(backup, replicationRetryBackoff<<replica.Retries)
+		if err != nil {
+			return err
+		}
+		if wait > 0 {
+			return intctrlutil.NewRequeueError(wait, "wait for the backoff to retry the replication")
+		}
+		replica.Retries++
+		r.Recorder.Eventf(backup, corev1.EventTypeWarning, ReasonRetryReplication,
+			"retry the replication to backup repo %s (%d/%d): %s", replicaRepo.Name, replica.Retries, maxReplicationRetries, message)
+		return r.Client.Status().Patch(reqCtx.Ctx, backup, patch)
+	case dpbackup.ReplicationStatusFailed:
+		if err = setReplicaFailed(message); err != nil {
+			return err
+		}
+	case dpbackup.ReplicationStatusCompleted:
+		replica.Phase = dpv1alpha1.BackupReplicaPhaseCompleted
+		replica.CompletionTimestamp = &metav1.Time{Time: r.clock.Now().UTC()}
+		r.Recorder.Eventf(backup, corev1.EventTypeNormal, ReasonBackupReplicated,
+			"the backup has been replicated to backup repo %s", replicaRepo.Name)
+		if err = r.Client.Status().Patch(reqCtx.Ctx, backup, patch); err != nil {
+			return err
+		}
+	}
+	// the replication is finished, clean up the replication job.
+	return replicator.DeleteReplicationJob(backup)
+}
+
+// reparentIncrementalBackups re-parents the incremental backups taken after the consolidated
+// backup chain onto the synthetic full backup, so that the consolidated chain can be deleted
+// without deleting them.
+func (r *BackupReconciler) reparentIncrementalBackups(
+	reqCtx intctrlutil.RequestCtx,
+	backup *dpv1alpha1.Backup) error {
+	if len(backup.Status.ConsolidatedBackupNames) < 2 {
+		return nil
+	}
+	lastBackupName := backup.Status.ConsolidatedBackupNames[len(backup.Status.ConsolidatedBackupNames)-1]
+	backupList := &dpv1alpha1.BackupList{}
+	if err := r.Client.List(reqCtx.Ctx, backupList, client.InNamespace(backup.Namespace),
+		client.MatchingLabels{dptypes.BackupPolicyLabelKey: backup.Spec.BackupPolicyName}); err != nil {
+		return err
+	}
+	children := map[string][]*dpv1alpha1.Backup{}
+	for i := range backupList.Items {
+		b := &backupList.Items[i]
+		if len(b.Status.ParentBackupName) > 0 {
+			children[b.Status.ParentBackupName] = append(children[b.Status.ParentBackupName], b)
+		}
+	}
+	// the descendants of the last consolidated backup and the synthetic full backup
+	// should all be based on the synthetic full backup.
+	visited := map[string]struct{}{}
+	descendants := append(append([]*dpv1alpha1.Backup{}, children[lastBackupName]...), children[backup.Name]...)
+	for len(descendants) > 0 {
+		b := descendants[0]
+		descendants = descendants[1:]
+		if _, ok := visited[b.Name]; ok {
+			continue
+		}
+		visited[b.Name] = struct{}{}
+		descendants = append(descendants, children[b.Name]...)
+		if !b.DeletionTimestamp.IsZero() {
+			continue
+		}
+		parentBackupName := b.Status.ParentBackupName
+		if parentBackupName == lastBackupName {
+			parentBackupName = backup.Name
+		}
+		if b.Status.ParentBackupName == parentBackupName && b.Status.BaseBackupName == backup.Name {
+			continue
+		}
+		patch := client.MergeFrom(b.DeepCopy())
+		b.Status.ParentBackupName = parentBackupName
+		b.Status.BaseBackupName = backup.Name
+		if err := r.Client.Status().Patch(reqCtx.Ctx, b, patch); err != nil {
+			return err
+		}
+		reqCtx.Log.Info("re-parent the incremental backup onto the synthetic full backup",
+			"backup", fmt.Sprintf("%s/%s", b.Namespace, b.Name), "parent", b.Status.ParentBackupName)
+	}
+	return nil
+}
+
+// createSyntheticBackupIfNeeded creates a synthetic full backup to consolidate the incremental
+// backup chain, when the chain of the incremental backup created by a backup schedule reaches
+// the consolidation threshold.
+func (r *BackupReconciler) createSyntheticBackupIfNeeded(
+	reqCtx intctrlutil.RequestCtx,
+	backup *dpv1alpha1.Backup) error {
+	scheduleName := backup.Labels[dptypes.BackupScheduleLabelKey]
+	if backup.Labels[dptypes.BackupTypeLabelKey] != string(dpv1alpha1.BackupTypeIncremental) ||
+		len(scheduleName) == 0 || backup.Status.BackupMethod == nil {
+		return nil
+	}
+	backupSchedule := &dpv1alpha1.BackupSchedule{}
+	if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Name: scheduleName, Namespace: backup.Namespace}, backupSchedule); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	var incSchedule, fullSchedule *dpv1alpha1.SchedulePolicy
+	for i := range backupSchedule.Spec.Schedules {
+		switch backupSchedule.Spec.Schedules[i].BackupMethod {
+		case backup.Spec.BackupMethod:
+			incSchedule = &backupSchedule.Spec.Schedules[i]
+		case backup.Status.BackupMethod.CompatibleMethod:
+			fullSchedule = &backupSchedule.Spec.Schedules[i]
+		}
+	}
+	if incSchedule == nil || incSchedule.ConsolidationThreshold == nil {
+		return nil
+	}
+
+	backupList := &dpv1alpha1.BackupList{}
+	if err := r.Client.List(reqCtx.Ctx, backupList, client.InNamespace(backup.Namespace),
+		client.MatchingLabels{dptypes.BackupPolicyLabelKey: backup.Spec.BackupPolicyName}); err != nil {
+		return err
+	}
+	for _, b := range backupList.Items {
+		switch {
+		case b.Status.ParentBackupName == backup.Name:
+			// a newer incremental backup has been taken, leave it to the newer one.
+			return nil
+		case b.Spec.ConsolidateBackupName == backup.Name:
+			// the backup chain has been consolidated.
+			return nil
+		case len(b.Spec.ConsolidateBackupName) > 0 && b.Status.Phase != dpv1alpha1.BackupPhaseCompleted &&
+			b.Status.Phase != dpv1alpha1.BackupPhaseFailed && b.Status.Phase != dpv1alpha1.BackupPhaseDeleting:
+			// another consolidation is in progress.
+			return nil
+		}
+	}
+
+	chain, err := GetIncrementalBackupChain(reqCtx.Ctx, r.Client, backup)
+	if err != nil {
+		reqCtx.Log.V(1).Info("skip consolidating the backup chain", "reason", err.Error())
+		return nil
+	}
+	if len(chain)-1 < int(*incSchedule.ConsolidationThreshold) {
+		return nil
+	}
+
+	backupPolicy, err := dputils.GetBackupPolicyByName(reqCtx, r.Client, backup.Spec.BackupPolicyName)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	fullMethod := dputils.GetBackupMethodByName(backup.Status.BackupMethod.CompatibleMethod, backupPolicy)
+	if fullMethod == nil {
+		return nil
+	}
+	actionSet, err := dputils.GetActionSetByName(reqCtx, r.Client, fullMethod.ActionSetName)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !actionSet.HasConsolidateDataAction() {
+		reqCtx.Log.V(1).Info("skip consolidating the backup chain, the full backup method does not support consolidation",
+			"backupMethod", fullMethod.Name)
+		return nil
+	}
+
+	retentionPeriod := incSchedule.RetentionPeriod
+	if fullSchedule != nil {
+		retentionPeriod = fullSchedule.RetentionPeriod
+	}
+	syntheticBackup := &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dpbackup.GenerateSyntheticBackupName(backup),
+			Namespace: backup.Namespace,
+			Labels: map[string]string{
+				dptypes.AutoBackupLabelKey:     trueVal,
+				dptypes.BackupScheduleLabelKey: scheduleName,
+				dptypes.BackupPolicyLabelKey:   backup.Spec.BackupPolicyName,
+			},
+		},
+		Spec: dpv1alpha1.BackupSpec{
+			BackupPolicyName:      backup.Spec.BackupPolicyName,
+			BackupMethod:          fullMethod.Name,
+			DeletionPolicy:        dpv1alpha1.BackupDeletionPolicyDelete,
+			RetentionPeriod:       retentionPeriod,
+			ConsolidateBackupName: backup.Name,
+		},
+	}
+	if err = r.Client.Create(reqCtx.Ctx, syntheticBackup); err != nil {
+		return client.IgnoreAlreadyExists(err)
+	}
+	r.Recorder.Eventf(backup, corev1.EventTypeNormal, "CreatedSyntheticBackup",
+		"created synthetic full backup %s to consolidate %d incremental backups", syntheticBackup.Name, len(chain)-1)
+	return nil
+}
+
 func (r *BackupReconciler) updateStatusIfFailed(
 	reqCtx intctrlutil.RequestCtx,
 	original *dpv1alpha1.Backup,
@@ -790,6 +1287,9 @@ func (r *BackupReconciler) updateStatusIfFailed(
 	if errUpdate := r.Client.Status().Patch(reqCtx.Ctx, backup, client.MergeFrom(original)); errUpdate != nil {
 		return intctrlutil.CheckedRequeueWithError(errUpdate, reqCtx.Log, "")
 	}
+	if original.Status.Phase != dpv1alpha1.BackupPhaseFailed {
+		metrics.RecordBackupFinished(backup)
+	}
 	return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 }
 
@@ -875,24 +1375,30 @@ func (r *BackupReconciler) deleteRelatedBackups(
 func PatchBackupObjectMeta(
 	original *dpv1alpha1.Backup,
 	request *dpbackup.Request) (bool, error) {
-	targetPod := request.TargetPods[0]
-
-	// get KubeBlocks cluster and set labels and annotations for backup
-	// TODO(ldm): we should remove this dependency of cluster in the future
-	cluster := getCluster(request.Ctx, request.Client, targetPod)
-	if cluster != nil {
-		if err := setClusterSnapshotAnnotation(request, cluster); err != nil {
-			return false, err
-		}
-		if err := setEncryptedSystemAccountsAnnotation(request, cluster); err != nil {
-			return false, err
+	if request.IsConsolidation() {
+		// the synthetic full backup holds the same data as the last consolidated backup,
+		// inherit the cluster info from it instead of the target pods.
+		setClusterMetaByBackup(request, request.ConsolidatedBackups[len(request.ConsolidatedBackups)-1])
+	} else {
+		targetPod := request.TargetPods[0]
+
+		// get KubeBlocks cluster and set labels and annotations for backup
+		// TODO(ldm): we should remove this dependency of cluster in the future
+		cluster := getCluster(request.Ctx, request.Client, targetPod)
+		if cluster != nil {
+			if err := setClusterSnapshotAnnotation(request, cluster); err != nil {
+				return false, err
+			}
+			if err := setEncryptedSystemAccountsAnnotation(request, cluster); err != nil {
+				return false, err
+			}
+			request.Labels[dptypes.ClusterUIDLabelKey] = string(cluster.UID)
 		}
-		request.Labels[dptypes.ClusterUIDLabelKey] = string(cluster.UID)
-	}
 
-	for _, v := range getClusterLabelKeys() {
-		if labelValue, ok := targetPod.Labels[v]; ok {
-			request.Labels[v] = labelValue
+		for _, v := range getClusterLabelKeys() {
+			if labelValue, ok := targetPod.Labels[v]; ok {
+				request.Labels[v] = labelValue
+			}
 		}
 	}
 
@@ -934,6 +1440,9 @@ func mergeActionStatus(request *dpbackup.Request, status *dpv1alpha1.ActionStatu
 			if request.Status.Actions[i].StartTimestamp != nil {
 				as.StartTimestamp = request.Status.Actions[i].StartTimestamp
 			}
+			if as.Checksum == "" {
+				as.Checksum = request.Status.Actions[i].Checksum
+			}
 			request.Status.Actions[i] = *as
 			exist = true
 			break
@@ -959,9 +1468,9 @@ func updateBackupStatusByActionStatus(backupStatus *dpv1alpha1.BackupStatus) {
 func setEncryptedSystemAccountsAnnotation(request *dpbackup.Request, cluster *kbappsv1.Cluster) error {
 	usernameKey := constant.AccountNameForSecret
 	passwordKey := constant.AccountPasswdForSecret
-	isSystemAccountSecret := func(secret *corev1.Secret) bool {
-		username := secret.Data[usernameKey]
-		password := secret.Data[passwordKey]
+	isSystemAccountSecret := func(data map[string][]byte) bool {
+		username := data[usernameKey]
+		password := data[passwordKey]
 		return username != nil && password != nil
 	}
 	// fetch secret objects
@@ -973,16 +1482,21 @@ func setEncryptedSystemAccountsAnnotation(request *dpbackup.Request, cluster *kb
 	// store the data of secrets in a map data structure, which contains the name of component, the username, and the encrypted password.
 	secretMap := map[string]map[string]string{}
 	for i := range secretList.Items {
-		if !isSystemAccountSecret(&secretList.Items[i]) {
+		// the password may be kept in the external secret store
+		data, err := secretstore.ResolveSecretData(request.Ctx, &secretList.Items[i])
+		if err != nil {
+			return err
+		}
+		if !isSystemAccountSecret(data) {
 			continue
 		}
 		componentName := secretList.Items[i].Labels[constant.KBAppComponentLabelKey]
 		if componentName == "" {
 			componentName = secretList.Items[i].Labels[constant.KBAppShardingNameLabelKey]
 		}
-		userName := string(secretList.Items[i].Data[usernameKey])
+		userName := string(data[usernameKey])
 		e := intctrlutil.NewEncryptor(viper.GetString(constant.CfgKeyDPEncryptionKey))
-		encryptedPwd, err := e.Encrypt(secretList.Items[i].Data[passwordKey])
+		encryptedPwd, err := e.Encrypt(data[passwordKey])
 		if err != nil {
 			return err
 		}
@@ -1002,6 +1516,20 @@ func setEncryptedSystemAccountsAnnotation(request *dpbackup.Request, cluster *kb
 	return nil
 }
 
+// setClusterMetaByBackup copies the cluster labels and annotations of the source backup to the backup.
+func setClusterMetaByBackup(request *dpbackup.Request, source *dpv1alpha1.Backup) {
+	for _, k := range []string{constant.ClusterSnapshotAnnotationKey, constant.EncryptedSystemAccountsAnnotationKey} {
+		if v, ok := source.Annotations[k]; ok {
+			request.Backup.Annotations[k] = v
+		}
+	}
+	for _, k := range append(getClusterLabelKeys(), dptypes.ClusterUIDLabelKey) {
+		if v, ok := source.Labels[k]; ok {
+			request.Labels[k] = v
+		}
+	}
+}
+
 // getClusterObjectString gets the cluster object and convert it to string.
 func getClusterObjectString(cluster *kbappsv1.Cluster) (*string, error) {
 	// maintain only the cluster's spec and name/namespace.
@@ -1103,6 +1631,46 @@ func prepare4Incremental(request *dpbackup.Request) (*dpbackup.Request, error) {
 	return request, nil
 }
 
+// prepare4Consolidation prepares for the synthetic full backup which consolidates an incremental backup chain.
+func prepare4Consolidation(request *dpbackup.Request) (*dpbackup.Request, error) {
+	if request.BackupRepo == nil {
+		return nil, fmt.Errorf("backupRepo for synthetic full backup can't be empty")
+	}
+	if len(request.Spec.ParentBackupName) > 0 {
+		return nil, fmt.Errorf("synthetic full backup cannot specify parent backup")
+	}
+	if dpv1alpha1.BackupType(request.GetBackupType()) != dpv1alpha1.BackupTypeFull {
+		return nil, fmt.Errorf("backup method %s is not a full backup method", request.BackupMethod.Name)
+	}
+	if !request.ActionSet.HasConsolidateDataAction() {
+		return nil, fmt.Errorf("actionSet %s does not support consolidating backups", request.ActionSet.Name)
+	}
+	lastBackup := &dpv1alpha1.Backup{}
+	if err := request.Client.Get(request.Ctx, client.ObjectKey{Name: request.Spec.ConsolidateBackupName,
+		Namespace: request.Namespace}, lastBackup); err != nil {
+		return nil, fmt.Errorf("failed to get backup %s/%s: %w", request.Namespace, request.Spec.ConsolidateBackupName, err)
+	}
+	// validate the backup to be consolidated
+	if lastBackup.Spec.BackupPolicyName != request.Spec.BackupPolicyName {
+		return nil, fmt.Errorf("backup %s/%s policy %s is not consistent with the backup",
+			lastBackup.Namespace, lastBackup.Name, lastBackup.Spec.BackupPolicyName)
+	}
+	if lastBackup.Status.BackupMethod == nil || lastBackup.Status.BackupMethod.CompatibleMethod != request.BackupMethod.Name {
+		return nil, fmt.Errorf("backup %s/%s is not compatible with the backup method %s",
+			lastBackup.Namespace, lastBackup.Name, request.BackupMethod.Name)
+	}
+	if lastBackup.Status.BackupRepoName != request.BackupRepo.Name {
+		return nil, fmt.Errorf("backup %s/%s repo %s is not consistent with the backup repo %s",
+			lastBackup.Namespace, lastBackup.Name, lastBackup.Status.BackupRepoName, request.BackupRepo.Name)
+	}
+	chain, err := GetIncrementalBackupChain(request.Ctx, request.Client, lastBackup)
+	if err != nil {
+		return nil, err
+	}
+	request.ConsolidatedBackups = chain
+	return request, nil
+}
+
 // clusterIsCreating will return true when the backup is Continuous backup and the cluster is creating
 func clusterIsCreating(reqCtx intctrlutil.RequestCtx, backup *dpv1alpha1.Backup, cli client.Client) bool {
 	if backup.Labels[constant.AppInstanceLabelKey] != "" {
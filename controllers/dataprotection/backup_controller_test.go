@@ -1049,6 +1049,74 @@ var _ = Describe("Backup Controller test", func() {
 				checkBackupDeleting(fullBackupKey)
 				checkBackupDeleting(incBackup1)
 			})
+
+			It("consolidates incremental backups into a synthetic full backup", func() {
+				By(fmt.Sprintf("waiting for the full backup %s to complete", fullBackupKey.String()))
+				checkBackupCompleted(fullBackup)
+				mockBackupStatus(fullBackup, "", "", testdp.BackupRepoName)
+				By(fmt.Sprintf("creating an incremental backup %s", incBackupName+"1"))
+				incBackup1 := mockIncBackupAndComplete(false, incBackupName+"1", fullBackup.Name, fullBackup.Name, fullBackup.Name)
+				By(fmt.Sprintf("creating an incremental backup %s", incBackupName+"2"))
+				incBackup2 := mockIncBackupAndComplete(false, incBackupName+"2", incBackup1.Name, incBackup1.Name, fullBackup.Name)
+				Eventually(testapps.GetAndChangeObjStatus(&testCtx, incBackup1, func(fetched *dpv1alpha1.Backup) {
+					fetched.Status.BackupMethod = dputils.GetBackupMethodByName(testdp.IncBackupMethodName, backupPolicy)
+					fetched.Status.Target = &dpv1alpha1.BackupStatusTarget{
+						BackupTarget:       *backupPolicy.Spec.Target,
+						SelectedTargetPods: []string{clusterInfo.TargetPod.Name},
+					}
+				})).Should(Succeed())
+
+				By("deleting the target pod, the consolidation works on the backup repository only")
+				testapps.DeleteObject(&testCtx, client.ObjectKeyFromObject(clusterInfo.TargetPod), &corev1.Pod{})
+				Eventually(testapps.CheckObjExists(&testCtx, client.ObjectKeyFromObject(clusterInfo.TargetPod),
+					&corev1.Pod{}, false)).Should(Succeed())
+
+				By("enabling the consolidateData action of the full backup actionSet")
+				Expect(testapps.ChangeObj(&testCtx, actionSet, func(as *dpv1alpha1.ActionSet) {
+					as.Spec.Backup.ConsolidateData = as.Spec.Backup.BackupData.JobActionSpec.DeepCopy()
+				})).Should(Succeed())
+
+				By(fmt.Sprintf("creating a synthetic full backup consolidating %s", incBackup1.String()))
+				syntheticBackup := testdp.NewFakeBackup(&testCtx, func(backup *dpv1alpha1.Backup) {
+					backup.Name = "synthetic-backup"
+					backup.Spec.ConsolidateBackupName = incBackup1.Name
+				})
+				Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(syntheticBackup), func(g Gomega, fetched *dpv1alpha1.Backup) {
+					g.Expect(fetched.Status.Phase).To(Equal(dpv1alpha1.BackupPhaseRunning))
+					g.Expect(fetched.Status.ParentBackupName).To(BeEmpty())
+					g.Expect(fetched.Status.ConsolidatedBackupNames).To(Equal([]string{fullBackup.Name, incBackup1.Name}))
+				})).Should(Succeed())
+
+				By("check the consolidate job does not depend on the target pod")
+				consolidateJobKey := client.ObjectKey{
+					Name:      dpbackup.GenerateBackupJobName(syntheticBackup, dpbackup.ConsolidateDataJobNamePrefix+"-0"),
+					Namespace: syntheticBackup.Namespace,
+				}
+				Eventually(testapps.CheckObj(&testCtx, consolidateJobKey, func(g Gomega, fetched *batchv1.Job) {
+					g.Expect(fetched.Spec.Template.Spec.NodeSelector).To(BeEmpty())
+					g.Expect(fetched.Spec.Template.Spec.Containers[0].Env).To(ContainElement(
+						corev1.EnvVar{Name: dptypes.DPTargetPodName, Value: clusterInfo.TargetPod.Name}))
+				})).Should(Succeed())
+
+				By("check the synthetic full backup completed")
+				testdp.PatchK8sJobStatus(&testCtx, consolidateJobKey, batchv1.JobComplete)
+				Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(syntheticBackup), func(g Gomega, fetched *dpv1alpha1.Backup) {
+					g.Expect(fetched.Status.Phase).To(Equal(dpv1alpha1.BackupPhaseCompleted))
+				})).Should(Succeed())
+
+				By(fmt.Sprintf("the incremental backup %s should be re-parented onto the synthetic full backup", incBackup2.String()))
+				Eventually(testapps.CheckObj(&testCtx, incBackup2, func(g Gomega, fetched *dpv1alpha1.Backup) {
+					g.Expect(fetched.Status.ParentBackupName).To(Equal(syntheticBackup.Name))
+					g.Expect(fetched.Status.BaseBackupName).To(Equal(syntheticBackup.Name))
+				})).Should(Succeed())
+
+				By(fmt.Sprintf("deleting the full backup %s will not delete the re-parented incremental backup", fullBackupKey.String()))
+				testapps.DeleteObject(&testCtx, fullBackupKey, &dpv1alpha1.Backup{})
+				checkBackupDeleting(incBackup1)
+				Consistently(testapps.CheckObj(&testCtx, incBackup2, func(g Gomega, fetched *dpv1alpha1.Backup) {
+					g.Expect(fetched.Status.Phase).To(Equal(dpv1alpha1.BackupPhaseCompleted))
+				})).Should(Succeed())
+			})
 		})
 
 		It("delays backup job when restore is in progress", func() {
@@ -281,12 +281,15 @@ func (r *backupPolicyAndScheduleBuilder) buildBackupSchedule(
 	for _, s := range r.backupPolicyTPL.Spec.Schedules {
 		name = s.GetScheduleName()
 		schedules = append(schedules, dpv1alpha1.SchedulePolicy{
-			BackupMethod:    s.BackupMethod,
-			CronExpression:  s.CronExpression,
-			Enabled:         s.Enabled,
-			RetentionPeriod: s.RetentionPeriod,
-			Name:            name,
-			Parameters:      s.Parameters,
+			BackupMethod:           s.BackupMethod,
+			CronExpression:         s.CronExpression,
+			Enabled:                s.Enabled,
+			RetentionPeriod:        s.RetentionPeriod,
+			Name:                   name,
+			ConsolidationThreshold: s.ConsolidationThreshold,
+			VerifyBackup:           s.VerifyBackup,
+			GFSRetention:           s.GFSRetention,
+			Parameters:             s.Parameters,
 		})
 	}
 	backupSchedule.Spec.Schedules = schedules
@@ -314,12 +317,15 @@ func (r *backupPolicyAndScheduleBuilder) syncBackupSchedule(backupSchedule *dpv1
 			continue
 		}
 		backupSchedule.Spec.Schedules = append(backupSchedule.Spec.Schedules, dpv1alpha1.SchedulePolicy{
-			BackupMethod:    s.BackupMethod,
-			CronExpression:  s.CronExpression,
-			Enabled:         s.Enabled,
-			RetentionPeriod: s.RetentionPeriod,
-			Name:            name,
-			Parameters:      s.Parameters,
+			BackupMethod:           s.BackupMethod,
+			CronExpression:         s.CronExpression,
+			Enabled:                s.Enabled,
+			RetentionPeriod:        s.RetentionPeriod,
+			Name:                   name,
+			ConsolidationThreshold: s.ConsolidationThreshold,
+			VerifyBackup:           s.VerifyBackup,
+			GFSRetention:           s.GFSRetention,
+			Parameters:             s.Parameters,
 		})
 	}
 }
@@ -68,6 +68,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
 	"github.com/apecloud/kubeblocks/pkg/generics"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
@@ -287,6 +288,12 @@ func (r *BackupRepoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			return checkedRequeueWithError(err, reqCtx.Log,
 				"check associated restores failed")
 		}
+
+		// check backups replicated to the repo, to create PVC in their namespaces
+		if err = r.prepareForReplicatedBackups(reconCtx); err != nil {
+			return checkedRequeueWithError(err, reqCtx.Log,
+				"check replicated backups failed")
+		}
 	}
 
 	return ctrl.Result{}, nil
@@ -416,7 +423,7 @@ func (r *BackupRepoReconciler) checkParameters(reqCtx intctrlutil.RequestCtx,
 	// collect parameters for rendering templates
 	parameters, err = r.collectParameters(reqCtx, repo, provider)
 	if err != nil {
-		if apierrors.IsNotFound(err) {
+		if apierrors.IsNotFound(err) || secretstore.IsNotFound(err) {
 			reason = ReasonCredentialSecretNotFound
 		}
 		return nil, err
@@ -885,6 +892,7 @@ datasafed rm %s`, precheckFilePath, precheckFilePath, precheckFilePath),
 			intctrlutil.InjectZeroResourcesLimitsIfEmpty(&job.Spec.Template.Spec.Containers[i])
 		}
 		utils.InjectDatasafedWithConfig(&job.Spec.Template.Spec, secretName, "")
+		utils.InjectDatasafedConfigResolver(&job.Spec.Template.Spec, reconCtx.repo)
 		return controllerutil.SetControllerReference(reconCtx.repo, job, r.Scheme)
 	}, multicluster.InControlContext())
 	if err != nil {
@@ -1067,6 +1075,36 @@ func (r *BackupRepoReconciler) prepareForAssociatedBackups(reconCtx *reconcileCo
 	return retErr
 }
 
+func (r *BackupRepoReconciler) prepareForReplicatedBackups(reconCtx *reconcileContext) error {
+	backupList := &dpv1alpha1.BackupList{}
+	if err := r.Client.List(reconCtx.Ctx, backupList, client.MatchingLabels{
+		dataProtectionReplicaRepoKey:                reconCtx.repo.Name,
+		dataProtectionWaitReplicaRepoPreparationKey: trueVal,
+	}, multicluster.InControlContext()); err != nil {
+		return err
+	}
+	// return any error to reconcile the repo
+	var retErr error
+	for idx := range backupList.Items {
+		backup := &backupList.Items[idx]
+		err := r.prepareBackupRepoInNamespace(reconCtx, backup.Namespace)
+		if retErr == nil {
+			retErr = err
+		}
+		if err == nil {
+			patch := client.MergeFrom(backup.DeepCopy())
+			delete(backup.Labels, dataProtectionWaitReplicaRepoPreparationKey)
+			if err = r.Client.Patch(reconCtx.Ctx, backup, patch, multicluster.InControlContext()); err != nil {
+				reconCtx.Log.Error(err, "failed to patch backup",
+					"backup", client.ObjectKeyFromObject(backup))
+				retErr = err
+				continue
+			}
+		}
+	}
+	return retErr
+}
+
 func (r *BackupRepoReconciler) prepareBackupRepoInNamespace(reconCtx *reconcileContext, namespace string) error {
 	switch {
 	case reconCtx.repo.AccessByMount():
@@ -1294,7 +1332,28 @@ func (r *BackupRepoReconciler) collectParameters(
 			valuesFromSecret[k] = string(v)
 		}
 	}
-	return mergeParameters(defaultValues, repo.Spec.Config, valuesFromSecret), nil
+
+	var valuesFromStore map[string]string
+	if len(repo.Spec.CredentialStorePath) > 0 {
+		if !repo.AccessByTool() {
+			return nil, fmt.Errorf("the credential of the BackupRepo can only refer to the secret store with the %s access method", dpv1alpha1.AccessMethodTool)
+		}
+		store, err := secretstore.Default()
+		if err != nil {
+			return nil, err
+		}
+		if store == nil {
+			return nil, fmt.Errorf("the credential of the BackupRepo refers to the secret store, but the secret store is not configured")
+		}
+		if valuesFromStore, err = store.Get(reqCtx.Ctx, repo.Spec.CredentialStorePath); err != nil {
+			return nil, fmt.Errorf("failed to get the credential from the secret store: %w", err)
+		}
+		// render the references rather than the values, they are resolved by the pods using the tool config when they start
+		for k := range valuesFromStore {
+			valuesFromStore[k] = secretstore.EnvRef(repo.Spec.CredentialStorePath, k)
+		}
+	}
+	return mergeParameters(defaultValues, repo.Spec.Config, valuesFromSecret, valuesFromStore), nil
 }
 
 func (r *BackupRepoReconciler) deleteExternalResources(
@@ -1477,14 +1536,22 @@ func (r *BackupRepoReconciler) mapBackupToRepo(ctx context.Context, obj client.O
 	// we should reconcile the BackupRepo when:
 	//   1. the Backup needs to use the BackupRepo, but it's not ready for the namespace.
 	//   2. the Backup is being deleted, because it may block the deletion of the BackupRepo.
+	//   3. the Backup needs to be replicated to the BackupRepo, but it's not ready for the namespace.
 	shouldReconcileRepo := backup.Labels[dataProtectionWaitRepoPreparationKey] == trueVal ||
 		!backup.DeletionTimestamp.IsZero()
+	var requests []ctrl.Request
 	if shouldReconcileRepo {
-		return []ctrl.Request{{
+		requests = append(requests, ctrl.Request{
 			NamespacedName: client.ObjectKey{Name: repoName},
-		}}
+		})
 	}
-	return nil
+	if replicaRepoName := backup.Labels[dataProtectionReplicaRepoKey]; replicaRepoName != "" &&
+		backup.Labels[dataProtectionWaitReplicaRepoPreparationKey] == trueVal {
+		requests = append(requests, ctrl.Request{
+			NamespacedName: client.ObjectKey{Name: replicaRepoName},
+		})
+	}
+	return requests
 }
 
 func (r *BackupRepoReconciler) mapRestoreToRepo(ctx context.Context, obj client.Object) []ctrl.Request {
@@ -22,11 +22,15 @@ package dataprotection
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
+	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -37,6 +41,7 @@ import (
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
@@ -48,6 +53,21 @@ type GCReconciler struct {
 	Recorder  record.EventRecorder
 	clock     clock.WithTickerAndDelayedExecution
 	frequency time.Duration
+
+	// gfsRetentionCache caches the GFS retention of the backup schedules, so that the retention
+	// is computed once per schedule in a GC period instead of once per backup.
+	gfsRetentionCache     map[types.NamespacedName]*gfsRetention
+	gfsRetentionCacheLock sync.Mutex
+}
+
+// gfsRetention is the GFS retention of the backups created by a backup schedule.
+type gfsRetention struct {
+	generation int64
+	computedAt time.Time
+	// the backups whose backup method has a GFS retention policy
+	managed sets.Set[string]
+	// the backups retained by the GFS retention policies, and their ancestors
+	retained sets.Set[string]
 }
 
 func NewGCReconciler(mgr ctrl.Manager) *GCReconciler {
@@ -72,6 +92,7 @@ func (r *GCReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 // +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups,verbs=get;list;watch;delete
 // +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups/status,verbs=get
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backupschedules,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // delete expired backups.
@@ -102,8 +123,18 @@ func (r *GCReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Re
 		"phase", backup.Status.Phase, "expiration", backup.Status.Expiration)
 	reqCtx.Log = reqCtx.Log.WithValues("expiration", backup.Status.Expiration)
 
+	// the completed backups created by a schedule with GFS retention policy are retained by
+	// their positions among all the backups of the schedule instead of their own expiration.
+	managedByGFS, retainedByGFS, err := r.checkGFSRetention(reqCtx, backup, false)
+	if err != nil {
+		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+	}
+	if retainedByGFS {
+		reqCtx.Log.V(1).Info("backup is retained by the GFS retention policy, skipping")
+		return intctrlutil.Reconciled()
+	}
 	now := r.clock.Now()
-	if backup.Status.Expiration == nil || backup.Status.Expiration.After(now) {
+	if !managedByGFS && (backup.Status.Expiration == nil || backup.Status.Expiration.After(now)) {
 		reqCtx.Log.V(1).Info("backup is not expired yet, skipping")
 		return intctrlutil.Reconciled()
 	}
@@ -115,6 +146,14 @@ func (r *GCReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Re
 		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
 	}
 
+	// the cached GFS retention may be stale, check it again before deleting the backup.
+	if _, retainedByGFS, err = r.checkGFSRetention(reqCtx, backup, true); err != nil {
+		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+	} else if retainedByGFS {
+		reqCtx.Log.V(1).Info("backup is retained by the GFS retention policy, skipping")
+		return intctrlutil.Reconciled()
+	}
+
 	reqCtx.Log.Info("backup has expired, delete it", "backup", req.String())
 	if err := intctrlutil.BackgroundDeleteObject(r.Client, reqCtx.Ctx, backup); err != nil {
 		reqCtx.Log.Error(err, "failed to delete backup")
@@ -187,6 +226,91 @@ func (r *GCReconciler) isBackupDeletable(reqCtx intctrlutil.RequestCtx, backup *
 	return true, nil
 }
 
+// checkGFSRetention checks whether the backup is managed by the GFS retention policy of the
+// schedule which creates it, and whether it is retained by the policy, or as an ancestor of a
+// retained backup. The retention is computed again if refresh is true.
+func (r *GCReconciler) checkGFSRetention(reqCtx intctrlutil.RequestCtx, backup *dpv1alpha1.Backup, refresh bool) (bool, bool, error) {
+	scheduleName := backup.Labels[dptypes.BackupScheduleLabelKey]
+	if len(scheduleName) == 0 || backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted {
+		return false, false, nil
+	}
+	backupSchedule := &dpv1alpha1.BackupSchedule{}
+	if err := r.Get(reqCtx.Ctx, client.ObjectKey{Name: scheduleName, Namespace: backup.Namespace}, backupSchedule); err != nil {
+		return false, false, client.IgnoreNotFound(err)
+	}
+	retention, err := r.getGFSRetention(reqCtx, backupSchedule, refresh)
+	if err != nil || retention == nil {
+		return false, false, err
+	}
+	return retention.managed.Has(backup.Name), retention.retained.Has(backup.Name), nil
+}
+
+// getGFSRetention returns the GFS retention of the backups created by the schedule, or nil if
+// the schedule has no GFS retention policy. The cached retention is returned if it is computed
+// in the current GC period and the schedule has not been changed since then.
+func (r *GCReconciler) getGFSRetention(reqCtx intctrlutil.RequestCtx,
+	backupSchedule *dpv1alpha1.BackupSchedule, refresh bool) (*gfsRetention, error) {
+	if !slices.ContainsFunc(backupSchedule.Spec.Schedules, func(s dpv1alpha1.SchedulePolicy) bool {
+		return s.GFSRetention != nil
+	}) {
+		return nil, nil
+	}
+
+	r.gfsRetentionCacheLock.Lock()
+	defer r.gfsRetentionCacheLock.Unlock()
+	if r.gfsRetentionCache == nil {
+		r.gfsRetentionCache = map[types.NamespacedName]*gfsRetention{}
+	}
+	key := client.ObjectKeyFromObject(backupSchedule)
+	now := r.clock.Now()
+	if cached, ok := r.gfsRetentionCache[key]; ok && !refresh &&
+		cached.generation == backupSchedule.Generation && now.Sub(cached.computedAt) < r.frequency {
+		return cached, nil
+	}
+	// drop the outdated retentions, including the ones of the deleted schedules
+	for k, cached := range r.gfsRetentionCache {
+		if now.Sub(cached.computedAt) >= r.frequency {
+			delete(r.gfsRetentionCache, k)
+		}
+	}
+
+	backupList := &dpv1alpha1.BackupList{}
+	if err := r.List(reqCtx.Ctx, backupList, client.InNamespace(backupSchedule.Namespace),
+		client.MatchingLabels{dptypes.BackupScheduleLabelKey: backupSchedule.Name}); err != nil {
+		return nil, err
+	}
+	var backups []*dpv1alpha1.Backup
+	for i := range backupList.Items {
+		if backupList.Items[i].DeletionTimestamp.IsZero() {
+			backups = append(backups, &backupList.Items[i])
+		}
+	}
+	retention := &gfsRetention{
+		generation: backupSchedule.Generation,
+		computedAt: now,
+		managed:    sets.New[string](),
+		retained:   sets.New[string](),
+	}
+	for _, s := range backupSchedule.Spec.Schedules {
+		if s.GFSRetention == nil {
+			continue
+		}
+		var scheduledBackups []*dpv1alpha1.Backup
+		for _, b := range backups {
+			if b.Spec.BackupMethod == s.BackupMethod {
+				scheduledBackups = append(scheduledBackups, b)
+				retention.managed.Insert(b.Name)
+			}
+		}
+		retention.retained = retention.retained.Union(dpbackup.GetBackupsRetainedByGFS(scheduledBackups, s.GFSRetention))
+	}
+	// the ancestors of the retained incremental backups are retained too, otherwise the retained
+	// backups will be deleted with them.
+	retention.retained = retention.retained.Union(dpbackup.GetAncestorsOfBackups(retention.retained, backups))
+	r.gfsRetentionCache[key] = retention
+	return retention, nil
+}
+
 // isLatestCompletedBackup returns true if the backup is the latest completed backup.
 func (r *GCReconciler) isLatestCompletedBackup(ctx context.Context, backup *dpv1alpha1.Backup) (bool, error) {
 	if backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted {
@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package dataprotection
 
 import (
+	"context"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -27,9 +28,14 @@ import (
 
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testclocks "k8s.io/utils/clock/testing"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	"github.com/apecloud/kubeblocks/pkg/generics"
@@ -268,3 +274,116 @@ var _ = Describe("Data Protection Garbage Collection Controller", func() {
 		})
 	})
 })
+
+var _ = Describe("Data Protection Garbage Collection GFS Retention", func() {
+	const (
+		namespace    = "default"
+		scheduleName = "test-schedule"
+		fullMethod   = "full"
+		incMethod    = "incremental"
+	)
+
+	var (
+		reconciler *GCReconciler
+		reqCtx     intctrlutil.RequestCtx
+		listCalls  int
+		start      = time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	)
+
+	newBackup := func(name, method string, day int, parent string) *dpv1alpha1.Backup {
+		completionTime := metav1.NewTime(start.AddDate(0, 0, day))
+		return &dpv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    map[string]string{dptypes.BackupScheduleLabelKey: scheduleName},
+			},
+			Spec: dpv1alpha1.BackupSpec{
+				BackupMethod:     method,
+				ParentBackupName: parent,
+			},
+			Status: dpv1alpha1.BackupStatus{
+				Phase:               dpv1alpha1.BackupPhaseCompleted,
+				CompletionTimestamp: &completionTime,
+				ParentBackupName:    parent,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(dpv1alpha1.AddToScheme(scheme)).Should(Succeed())
+		schedule := &dpv1alpha1.BackupSchedule{
+			ObjectMeta: metav1.ObjectMeta{Name: scheduleName, Namespace: namespace},
+			Spec: dpv1alpha1.BackupScheduleSpec{
+				Schedules: []dpv1alpha1.SchedulePolicy{
+					{
+						BackupMethod: fullMethod,
+						GFSRetention: &dpv1alpha1.GFSRetentionPolicy{Daily: 1},
+					},
+					{
+						BackupMethod: incMethod,
+						GFSRetention: &dpv1alpha1.GFSRetentionPolicy{Daily: 1},
+					},
+				},
+			},
+		}
+		listCalls = 0
+		cli := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(schedule,
+				newBackup("full-0", fullMethod, 0, ""),
+				newBackup("full-1", fullMethod, 1, ""),
+				newBackup("full-2", fullMethod, 2, ""),
+				newBackup("inc-1", incMethod, 3, "full-1")).
+			WithInterceptorFuncs(interceptor.Funcs{
+				List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+					listCalls++
+					return c.List(ctx, list, opts...)
+				},
+			}).Build()
+		reconciler = &GCReconciler{
+			Client:    cli,
+			clock:     testclocks.NewFakeClock(time.Now()),
+			frequency: time.Minute,
+		}
+		reqCtx = intctrlutil.RequestCtx{Ctx: context.Background()}
+	})
+
+	check := func(name string, refresh bool) (bool, bool) {
+		backup := &dpv1alpha1.Backup{}
+		Expect(reconciler.Get(reqCtx.Ctx, client.ObjectKey{Name: name, Namespace: namespace}, backup)).Should(Succeed())
+		managed, retained, err := reconciler.checkGFSRetention(reqCtx, backup, refresh)
+		Expect(err).ShouldNot(HaveOccurred())
+		return managed, retained
+	}
+
+	It("retain the ancestors of the retained incremental backups", func() {
+		for name, expected := range map[string]bool{
+			"full-0": false,
+			"full-1": true, // the parent of the retained incremental backup
+			"full-2": true,
+			"inc-1":  true,
+		} {
+			managed, retained := check(name, false)
+			Expect(managed).Should(BeTrue())
+			Expect(retained).Should(Equal(expected), name)
+		}
+	})
+
+	It("compute the retention once per GC period", func() {
+		for _, name := range []string{"full-0", "full-1", "full-2", "inc-1"} {
+			check(name, false)
+		}
+		Expect(listCalls).Should(Equal(1))
+
+		By("compute again if required")
+		check("full-0", true)
+		Expect(listCalls).Should(Equal(2))
+
+		By("compute again in the next GC period")
+		reconciler.clock.(*testclocks.FakeClock).Step(reconciler.frequency)
+		check("full-0", false)
+		check("full-1", false)
+		Expect(listCalls).Should(Equal(3))
+	})
+})
@@ -48,6 +48,7 @@ import (
 	dprestore "github.com/apecloud/kubeblocks/pkg/dataprotection/restore"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/metrics"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
@@ -168,6 +169,11 @@ func CheckBackupRepoForRestore(reqCtx intctrlutil.RequestCtx, cli client.Client,
 		}
 		return "", err
 	}
+	// fall back to the copy in the secondary backup repo if the backup repo is unavailable.
+	backup, err := utils.ResolveBackupReplica(reqCtx.Ctx, cli, backup)
+	if err != nil {
+		return "", err
+	}
 	if backup.Status.BackupRepoName == "" {
 		// The backup doesn't use backup repo.
 		return "", nil
@@ -288,6 +294,9 @@ func (r *RestoreReconciler) newAction(reqCtx intctrlutil.RequestCtx, restore *dp
 	if err := r.Client.Status().Patch(reqCtx.Ctx, restore, patch); err != nil {
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 	}
+	if restore.Status.Phase == dpv1alpha1.RestorePhaseFailed {
+		metrics.RecordRestoreFinished(restore)
+	}
 	return intctrlutil.Reconciled()
 }
 
@@ -318,6 +327,11 @@ func (r *RestoreReconciler) handleRunningPhase(reqCtx intctrlutil.RequestCtx, re
 	// patch restore status if changes occur
 	if !reflect.DeepEqual(restoreMgr.OriginalRestore.Status, restoreMgr.Restore.Status) {
 		err = r.Client.Status().Patch(reqCtx.Ctx, restoreMgr.Restore, client.MergeFrom(restoreMgr.OriginalRestore))
+		phase := restoreMgr.Restore.Status.Phase
+		finished := phase == dpv1alpha1.RestorePhaseCompleted || phase == dpv1alpha1.RestorePhaseFailed
+		if err == nil && finished && restoreMgr.OriginalRestore.Status.Phase != phase {
+			metrics.RecordRestoreFinished(restoreMgr.Restore)
+		}
 	}
 	if err != nil {
 		r.Recorder.Event(restore, corev1.EventTypeWarning, corev1.EventTypeWarning, err.Error())
@@ -32,9 +32,11 @@ const (
 const (
 
 	// label keys
-	dataProtectionBackupRepoKey          = "dataprotection.kubeblocks.io/backup-repo-name"
-	dataProtectionWaitRepoPreparationKey = "dataprotection.kubeblocks.io/wait-repo-preparation"
-	dataProtectionIsToolConfigKey        = "dataprotection.kubeblocks.io/is-tool-config"
+	dataProtectionBackupRepoKey                 = "dataprotection.kubeblocks.io/backup-repo-name"
+	dataProtectionWaitRepoPreparationKey        = "dataprotection.kubeblocks.io/wait-repo-preparation"
+	dataProtectionIsToolConfigKey               = "dataprotection.kubeblocks.io/is-tool-config"
+	dataProtectionReplicaRepoKey                = "dataprotection.kubeblocks.io/replica-repo-name"
+	dataProtectionWaitReplicaRepoPreparationKey = "dataprotection.kubeblocks.io/wait-replica-repo-preparation"
 
 	// annotation keys
 	dataProtectionBackupRepoDigestAnnotationKey     = "dataprotection.kubeblocks.io/backup-repo-digest"
@@ -71,6 +73,19 @@ const (
 	ReasonDigestChanged             = "DigestChanged"
 	ReasonUnknownError              = "UnknownError"
 	ReasonSkipped                   = "Skipped"
+
+	// backup verification condition reasons
+	ReasonBackupVerifying    = "Verifying"
+	ReasonBackupVerified     = "BackupVerified"
+	ReasonBackupUnverifiable = "BackupUnverifiable"
+	ReasonBackupCorrupted    = "BackupCorrupted"
+	ReasonVerificationFailed = "VerificationFailed"
+
+	// backup replication event reasons
+	ReasonReplicatingBackup = "ReplicatingBackup"
+	ReasonBackupReplicated  = "BackupReplicated"
+	ReasonReplicationFailed = "ReplicationFailed"
+	ReasonRetryReplication  = "RetryReplication"
 )
 
 // constant  for volume populator
@@ -95,3 +110,11 @@ const (
 )
 
 var reconcileInterval = time.Second
+
+const (
+	// maxReplicationRetries is the number of times a failed replication job is retried.
+	maxReplicationRetries = 3
+	// replicationRetryBackoff is the backoff before the first retry of a failed replication job,
+	// it is doubled on each retry.
+	replicationRetryBackoff = 30 * time.Second
+)
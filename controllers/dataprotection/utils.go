@@ -131,6 +131,36 @@ func HandleBackupRepo(request *dpbackup.Request) error {
 	return nil
 }
 
+// checkBackupRepoPreparedInNamespace checks whether the PVC or the tool config secret
+// of the backup repo has been created in the namespace.
+func checkBackupRepoPreparedInNamespace(ctx context.Context,
+	cli client.Client,
+	repo *dpv1alpha1.BackupRepo,
+	namespace string) (bool, error) {
+	var (
+		obj  client.Object
+		name string
+	)
+	switch {
+	case repo.AccessByMount():
+		obj, name = &corev1.PersistentVolumeClaim{}, repo.Status.BackupPVCName
+	case repo.AccessByTool():
+		obj, name = &corev1.Secret{}, repo.Status.ToolConfigSecretName
+	default:
+		return false, fmt.Errorf("unknown access method: %s", repo.Spec.AccessMethod)
+	}
+	if name == "" {
+		return false, nil
+	}
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
 // GetTargetPods gets the target pods by BackupPolicy. If podName is not empty,
 // it will return the pod which name is podName. Otherwise, it will return the
 // pods which are selected by BackupPolicy selector and strategy.
@@ -589,6 +619,8 @@ func fromFlattenName(flatten string) (name string, namespace string) {
 // If parentBackupName is specified, the backup should be a on-demand backup,
 // then validate and return the parent backup.
 // If parentBackupName is not specified, find the latest valid parent backup.
+// The parent backup name in the status takes precedence over the spec, as the backup
+// may be re-parented onto a synthetic full backup after the parent is consolidated.
 func GetParentBackup(ctx context.Context, cli client.Client, backup *dpv1alpha1.Backup,
 	backupMethod *dpv1alpha1.BackupMethod, backupRepoName string) (*dpv1alpha1.Backup, error) {
 	if backup == nil || backupMethod == nil {
@@ -598,15 +630,15 @@ func GetParentBackup(ctx context.Context, cli client.Client, backup *dpv1alpha1.
 	if schedule, ok := backup.Labels[dptypes.BackupScheduleLabelKey]; ok && len(schedule) > 0 {
 		scheduleName = schedule
 	}
+	// only on-demand backup can specify parent backup
+	if len(backup.Spec.ParentBackupName) != 0 && len(scheduleName) != 0 {
+		return nil, fmt.Errorf("schedule backup cannot specify parent backup")
+	}
 	var parentBackupName string
-	if len(backup.Spec.ParentBackupName) != 0 {
-		// only on-demand backup can specify parent backup
-		if len(scheduleName) != 0 {
-			return nil, fmt.Errorf("schedule backup cannot specify parent backup")
-		}
-		parentBackupName = backup.Spec.ParentBackupName
-	} else if len(backup.Status.ParentBackupName) != 0 {
+	if len(backup.Status.ParentBackupName) != 0 {
 		parentBackupName = backup.Status.ParentBackupName
+	} else if len(backup.Spec.ParentBackupName) != 0 {
+		parentBackupName = backup.Spec.ParentBackupName
 	}
 	if len(parentBackupName) != 0 {
 		parentBackup := &dpv1alpha1.Backup{}
@@ -631,6 +663,47 @@ func GetParentBackup(ctx context.Context, cli client.Client, backup *dpv1alpha1.
 	return parentBackup, nil
 }
 
+// GetIncrementalBackupChain returns the backup chain that the incremental backup relies on,
+// starting with the base full backup and ending with the incremental backup itself.
+// All backups in the chain should be completed.
+func GetIncrementalBackupChain(ctx context.Context, cli client.Client, backup *dpv1alpha1.Backup) ([]*dpv1alpha1.Backup, error) {
+	if backup.Labels[dptypes.BackupTypeLabelKey] != string(dpv1alpha1.BackupTypeIncremental) {
+		return nil, fmt.Errorf("backup %s/%s is not an incremental backup", backup.Namespace, backup.Name)
+	}
+	baseBackupName := backup.Status.BaseBackupName
+	if len(baseBackupName) == 0 {
+		return nil, fmt.Errorf("backup %s/%s base backup name is empty", backup.Namespace, backup.Name)
+	}
+	chain := []*dpv1alpha1.Backup{backup}
+	visited := map[string]struct{}{backup.Name: {}}
+	for current := backup; current.Name != baseBackupName; current = chain[0] {
+		if current.Status.Phase != dpv1alpha1.BackupPhaseCompleted {
+			return nil, fmt.Errorf("backup %s/%s is not completed", current.Namespace, current.Name)
+		}
+		parentBackupName := current.Status.ParentBackupName
+		if len(parentBackupName) == 0 {
+			return nil, fmt.Errorf("backup %s/%s parent backup name is empty", current.Namespace, current.Name)
+		}
+		if _, ok := visited[parentBackupName]; ok {
+			return nil, fmt.Errorf("backup %s/%s relies on its child backup %s", current.Namespace, current.Name, parentBackupName)
+		}
+		parentBackup := &dpv1alpha1.Backup{}
+		if err := cli.Get(ctx, client.ObjectKey{Namespace: backup.Namespace, Name: parentBackupName}, parentBackup); err != nil {
+			return nil, fmt.Errorf("failed to get parent backup %s/%s: %w", backup.Namespace, parentBackupName, err)
+		}
+		if parentBackup.Name != baseBackupName && parentBackup.Status.BaseBackupName != baseBackupName {
+			return nil, fmt.Errorf("parent backup %s/%s base backup %s is not consistent with the base backup %s",
+				parentBackup.Namespace, parentBackup.Name, parentBackup.Status.BaseBackupName, baseBackupName)
+		}
+		visited[parentBackupName] = struct{}{}
+		chain = append([]*dpv1alpha1.Backup{parentBackup}, chain...)
+	}
+	if chain[0].Status.Phase != dpv1alpha1.BackupPhaseCompleted {
+		return nil, fmt.Errorf("base backup %s/%s is not completed", chain[0].Namespace, chain[0].Name)
+	}
+	return chain, nil
+}
+
 // FindParentBackupIfNotSet finds the latest valid parent backup for the incremental backup.
 // a. return the latest full backup when it is newer than the base backup of the latest incremental backup,
 // or when the base backup of the latest incremental backup is not found.
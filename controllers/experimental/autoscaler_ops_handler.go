@@ -0,0 +1,60 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package experimental
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+// autoscalerOpsRequestHandler enqueues the ComponentAutoscaler that created the OpsRequest.
+type autoscalerOpsRequestHandler struct{}
+
+func (h *autoscalerOpsRequestHandler) Create(ctx context.Context, event event.CreateEvent, limitingInterface workqueue.RateLimitingInterface) {
+}
+
+func (h *autoscalerOpsRequestHandler) Update(ctx context.Context, event event.UpdateEvent, limitingInterface workqueue.RateLimitingInterface) {
+	h.mapAndEnqueue(limitingInterface, event.ObjectNew)
+}
+
+func (h *autoscalerOpsRequestHandler) Delete(ctx context.Context, event event.DeleteEvent, limitingInterface workqueue.RateLimitingInterface) {
+	h.mapAndEnqueue(limitingInterface, event.Object)
+}
+
+func (h *autoscalerOpsRequestHandler) Generic(ctx context.Context, event event.GenericEvent, limitingInterface workqueue.RateLimitingInterface) {
+}
+
+func (h *autoscalerOpsRequestHandler) mapAndEnqueue(q workqueue.RateLimitingInterface, object client.Object) {
+	name, ok := object.GetLabels()[constant.OpsAutoscalerNameLabelKey]
+	if !ok {
+		return
+	}
+	q.Add(ctrl.Request{NamespacedName: types.NamespacedName{Namespace: object.GetNamespace(), Name: name}})
+}
+
+var _ handler.EventHandler = &autoscalerOpsRequestHandler{}
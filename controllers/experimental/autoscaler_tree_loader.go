@@ -0,0 +1,170 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package experimental
+
+import (
+	"context"
+	"slices"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	experimental "github.com/apecloud/kubeblocks/apis/experimental/v1alpha1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+// podMetricsListGVK is the kind of the pod metrics served by the metrics API.
+var podMetricsListGVK = schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "PodMetricsList"}
+
+type autoscalerTreeLoader struct{}
+
+func (t *autoscalerTreeLoader) Load(ctx context.Context, reader client.Reader, req ctrl.Request, recorder record.EventRecorder, logger logr.Logger) (*kubebuilderx.ObjectTree, error) {
+	tree, err := kubebuilderx.ReadObjectTree[*experimental.ComponentAutoscaler](ctx, reader, req, nil)
+	if err != nil {
+		return nil, err
+	}
+	tree.EventRecorder = recorder
+	tree.Logger = logger
+
+	root := tree.GetRoot()
+	if root == nil {
+		return tree, nil
+	}
+	scaler, _ := root.(*experimental.ComponentAutoscaler)
+	key := types.NamespacedName{Namespace: scaler.Namespace, Name: scaler.Spec.TargetClusterName}
+	cluster := &appsv1.Cluster{}
+	if err = reader.Get(ctx, key, cluster); err != nil {
+		// a missing cluster is reported in the status of the autoscaler
+		if apierrors.IsNotFound(err) {
+			return tree, nil
+		}
+		return nil, err
+	}
+	if err = tree.Add(cluster); err != nil {
+		return nil, err
+	}
+
+	var labels map[string]string
+	if scaler.Spec.Sharding {
+		labels = constant.GetClusterLabels(cluster.Name, map[string]string{constant.KBAppShardingNameLabelKey: scaler.Spec.TargetComponentName})
+	} else {
+		labels = constant.GetCompLabels(cluster.Name, scaler.Spec.TargetComponentName)
+	}
+	inNS := client.InNamespace(scaler.Namespace)
+
+	compList := &appsv1.ComponentList{}
+	if err = reader.List(ctx, compList, inNS, client.MatchingLabels(labels)); err != nil {
+		return nil, err
+	}
+	for i := range compList.Items {
+		if err = tree.Add(&compList.Items[i]); err != nil {
+			return nil, err
+		}
+	}
+	if len(compList.Items) > 0 && len(compList.Items[0].Spec.CompDef) > 0 {
+		compDef := &appsv1.ComponentDefinition{}
+		if err = reader.Get(ctx, types.NamespacedName{Name: compList.Items[0].Spec.CompDef}, compDef); err != nil {
+			return nil, err
+		}
+		if err = tree.Add(compDef); err != nil {
+			return nil, err
+		}
+	}
+
+	podList := &corev1.PodList{}
+	if err = reader.List(ctx, podList, inNS, client.MatchingLabels(labels)); err != nil {
+		return nil, err
+	}
+	podNames := make([]string, 0, len(podList.Items))
+	for i := range podList.Items {
+		podNames = append(podNames, podList.Items[i].Name)
+		if err = tree.Add(&podList.Items[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	if hasResourceMetric(scaler) {
+		podMetricsList := &unstructured.UnstructuredList{}
+		podMetricsList.SetGroupVersionKind(podMetricsListGVK)
+		if err = reader.List(ctx, podMetricsList, inNS, client.MatchingLabels(labels)); err != nil {
+			// the metrics API may not be installed, the resource metrics are reported as unavailable then
+			if !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+				return nil, err
+			}
+			logger.Info("metrics API is unavailable", "error", err.Error())
+		}
+		for i := range podMetricsList.Items {
+			if err = tree.Add(&podMetricsList.Items[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if probes := probeMetricNames(scaler); len(probes) > 0 {
+		eventList := &corev1.EventList{}
+		if err = reader.List(ctx, eventList, inNS); err != nil {
+			return nil, err
+		}
+		for i := range eventList.Items {
+			event := &eventList.Items[i]
+			if event.ReportingController != proto.ProbeEventReportingController ||
+				event.InvolvedObject.FieldPath != proto.ProbeEventFieldPath ||
+				!slices.Contains(probes, event.Reason) ||
+				!slices.Contains(podNames, event.InvolvedObject.Name) {
+				continue
+			}
+			if err = tree.Add(event); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(scaler.Status.OpsRequestName) > 0 {
+		ops := &opsv1alpha1.OpsRequest{}
+		key = types.NamespacedName{Namespace: scaler.Namespace, Name: scaler.Status.OpsRequestName}
+		if err = reader.Get(ctx, key, ops); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+		} else if err = tree.Add(ops); err != nil {
+			return nil, err
+		}
+	}
+
+	return tree, nil
+}
+
+func autoscalerObjectTree() kubebuilderx.TreeLoader {
+	return &autoscalerTreeLoader{}
+}
+
+var _ kubebuilderx.TreeLoader = &autoscalerTreeLoader{}
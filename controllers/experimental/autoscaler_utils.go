@@ -0,0 +1,358 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package experimental
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	experimental "github.com/apecloud/kubeblocks/apis/experimental/v1alpha1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+const (
+	// autoscalerSyncPeriod is the interval at which the metrics are collected.
+	autoscalerSyncPeriod = 15 * time.Second
+
+	defaultTolerancePercent           int32 = 10
+	defaultCooldownSeconds            int32 = 300
+	defaultStabilizationWindowSeconds int32 = 300
+	defaultMaxStep                    int32 = 1
+	defaultMaxStepPercent             int32 = 50
+)
+
+// autoscalerTarget is the Component or Sharding a ComponentAutoscaler applies to.
+type autoscalerTarget struct {
+	cluster *appsv1.Cluster
+	spec    *appsv1.ClusterComponentSpec
+	compDef *appsv1.ComponentDefinition
+	pods    []*corev1.Pod
+}
+
+func (t *autoscalerTarget) replicasLimit() *appsv1.ReplicasLimit {
+	if t.compDef == nil {
+		return nil
+	}
+	return t.compDef.Spec.ReplicasLimit
+}
+
+func getAutoscalerTarget(tree *kubebuilderx.ObjectTree, scaler *experimental.ComponentAutoscaler) (*autoscalerTarget, error) {
+	object, err := tree.Get(builder.NewClusterBuilder(scaler.Namespace, scaler.Spec.TargetClusterName).GetObject())
+	if err != nil {
+		return nil, err
+	}
+	if object == nil {
+		return nil, fmt.Errorf("cluster %s not found", scaler.Spec.TargetClusterName)
+	}
+	target := &autoscalerTarget{}
+	target.cluster, _ = object.(*appsv1.Cluster)
+	if scaler.Spec.Sharding {
+		for i, sharding := range target.cluster.Spec.Shardings {
+			if sharding.Name == scaler.Spec.TargetComponentName {
+				target.spec = &target.cluster.Spec.Shardings[i].Template
+			}
+		}
+	} else {
+		for i, spec := range target.cluster.Spec.ComponentSpecs {
+			if spec.Name == scaler.Spec.TargetComponentName {
+				target.spec = &target.cluster.Spec.ComponentSpecs[i]
+			}
+		}
+	}
+	if target.spec == nil {
+		return nil, fmt.Errorf("%s %s not found in cluster %s", targetKind(scaler), scaler.Spec.TargetComponentName, target.cluster.Name)
+	}
+	if compDefs := tree.List(&appsv1.ComponentDefinition{}); len(compDefs) > 0 {
+		target.compDef, _ = compDefs[0].(*appsv1.ComponentDefinition)
+	}
+	for _, object := range tree.List(&corev1.Pod{}) {
+		pod, _ := object.(*corev1.Pod)
+		if pod.DeletionTimestamp == nil && pod.Status.Phase == corev1.PodRunning {
+			target.pods = append(target.pods, pod)
+		}
+	}
+	slices.SortFunc(target.pods, func(a, b *corev1.Pod) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return target, nil
+}
+
+func targetKind(scaler *experimental.ComponentAutoscaler) string {
+	if scaler.Spec.Sharding {
+		return "sharding"
+	}
+	return "component"
+}
+
+func hasResourceMetric(scaler *experimental.ComponentAutoscaler) bool {
+	return slices.ContainsFunc(scaler.Spec.Metrics, func(metric experimental.AutoscalerMetric) bool {
+		return metric.Resource != nil
+	})
+}
+
+func probeMetricNames(scaler *experimental.ComponentAutoscaler) []string {
+	var probes []string
+	for _, metric := range scaler.Spec.Metrics {
+		if metric.Probe != nil {
+			probes = append(probes, metric.Probe.Probe)
+		}
+	}
+	return probes
+}
+
+// resourceUtilization returns the average utilization of the resource in percentage, against the requests of the pods.
+func resourceUtilization(tree *kubebuilderx.ObjectTree, target *autoscalerTarget, name corev1.ResourceName) (int64, error) {
+	usages := make(map[string]int64)
+	for _, object := range tree.List(&unstructured.Unstructured{}) {
+		podMetrics, _ := object.(*unstructured.Unstructured)
+		if podMetrics.GetKind() != "PodMetrics" {
+			continue
+		}
+		containers, _, _ := unstructured.NestedSlice(podMetrics.Object, "containers")
+		for _, container := range containers {
+			m, _ := container.(map[string]interface{})
+			value, found, _ := unstructured.NestedString(m, "usage", string(name))
+			if !found {
+				continue
+			}
+			quantity, err := resource.ParseQuantity(value)
+			if err != nil {
+				return 0, fmt.Errorf("invalid %s usage of pod %s: %s", name, podMetrics.GetName(), err.Error())
+			}
+			usages[podMetrics.GetName()] += quantity.MilliValue()
+		}
+	}
+	var usage, request int64
+	for _, pod := range target.pods {
+		podUsage, ok := usages[pod.Name]
+		if !ok {
+			continue
+		}
+		var podRequest int64
+		for _, container := range pod.Spec.Containers {
+			if quantity, ok := container.Resources.Requests[name]; ok {
+				podRequest += quantity.MilliValue()
+			}
+		}
+		if podRequest == 0 {
+			return 0, fmt.Errorf("missing %s request of pod %s", name, pod.Name)
+		}
+		usage += podUsage
+		request += podRequest
+	}
+	if request == 0 {
+		return 0, fmt.Errorf("no %s metrics returned from the metrics API", name)
+	}
+	return usage * 100 / request, nil
+}
+
+// probeAverage returns the average value of the latest outputs of the probe across the pods.
+func probeAverage(tree *kubebuilderx.ObjectTree, target *autoscalerTarget, probe string) (float64, error) {
+	latest := make(map[string]*corev1.Event)
+	for _, object := range tree.List(&corev1.Event{}) {
+		event, _ := object.(*corev1.Event)
+		if event.Reason != probe {
+			continue
+		}
+		podName := event.InvolvedObject.Name
+		if prev, ok := latest[podName]; !ok || eventTime(event).After(eventTime(prev)) {
+			latest[podName] = event
+		}
+	}
+	var (
+		sum   float64
+		count int
+	)
+	for _, pod := range target.pods {
+		event, ok := latest[pod.Name]
+		if !ok {
+			continue
+		}
+		probeEvent := &proto.ProbeEvent{}
+		if err := json.Unmarshal([]byte(event.Message), probeEvent); err != nil {
+			return 0, fmt.Errorf("invalid %s probe event of pod %s: %s", probe, pod.Name, err.Error())
+		}
+		if probeEvent.Code != 0 {
+			continue
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(string(probeEvent.Output)), 64)
+		if err != nil {
+			return 0, fmt.Errorf("the output of %s probe of pod %s is not a number: %s", probe, pod.Name, err.Error())
+		}
+		sum += value
+		count++
+	}
+	if count == 0 {
+		return 0, fmt.Errorf("no %s probe output reported", probe)
+	}
+	return sum / float64(count), nil
+}
+
+func eventTime(event *corev1.Event) time.Time {
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+// metricRatios returns the ratios of the current values to the target values of the available metrics,
+// and whether all the metrics are available.
+func metricRatios(scaler *experimental.ComponentAutoscaler) (map[string]float64, bool) {
+	ratios := make(map[string]float64)
+	for _, status := range scaler.Status.CurrentMetrics {
+		current, err1 := strconv.ParseFloat(status.CurrentValue, 64)
+		target, err2 := strconv.ParseFloat(status.TargetValue, 64)
+		if err1 != nil || err2 != nil || target <= 0 {
+			continue
+		}
+		ratios[status.Name] = current / target
+	}
+	return ratios, len(ratios) == len(scaler.Spec.Metrics)
+}
+
+// resourceRatios returns the highest ratio of each resource among the resource metrics.
+func resourceRatios(scaler *experimental.ComponentAutoscaler, ratios map[string]float64) map[corev1.ResourceName]float64 {
+	result := make(map[corev1.ResourceName]float64)
+	for _, metric := range scaler.Spec.Metrics {
+		ratio, ok := ratios[metric.Name]
+		if metric.Resource == nil || !ok {
+			continue
+		}
+		if prev, ok := result[metric.Resource.Name]; !ok || ratio > prev {
+			result[metric.Resource.Name] = ratio
+		}
+	}
+	return result
+}
+
+// desiredReplicas calculates the replicas that bring the metric ratio to 1, bounded by the step, the policy
+// and the replicas limit of the ComponentDefinition.
+func desiredReplicas(current int32, ratio, tolerance float64,
+	policy *experimental.HorizontalAutoscalingPolicy, limit *appsv1.ReplicasLimit) int32 {
+	desired := current
+	if math.Abs(ratio-1) > tolerance {
+		desired = int32(math.Ceil(float64(current) * ratio))
+	}
+	step := ptr.Deref(policy.MaxStep, defaultMaxStep)
+	desired = min(max(desired, current-step), current+step)
+	minReplicas, maxReplicas := policy.MinReplicas, policy.MaxReplicas
+	if limit != nil {
+		minReplicas = max(minReplicas, limit.MinReplicas)
+		maxReplicas = min(maxReplicas, limit.MaxReplicas)
+	}
+	return min(max(desired, minReplicas), maxReplicas)
+}
+
+// desiredRequests calculates the resource requests that bring the resource ratios to 1, bounded by the step
+// and the policy. It returns nil if no request needs to be changed.
+func desiredRequests(current corev1.ResourceList, ratios map[corev1.ResourceName]float64, tolerance float64,
+	policy *experimental.VerticalAutoscalingPolicy) corev1.ResourceList {
+	step := float64(ptr.Deref(policy.MaxStepPercent, defaultMaxStepPercent)) / 100
+	var desired corev1.ResourceList
+	for name, ratio := range ratios {
+		request, ok := current[name]
+		if !ok || request.IsZero() || math.Abs(ratio-1) <= tolerance {
+			continue
+		}
+		quantity := scaleQuantity(name, request, min(max(ratio, 1-step), 1+step))
+		if lower, ok := policy.MinAllowed[name]; ok && quantity.Cmp(lower) < 0 {
+			quantity = lower.DeepCopy()
+		}
+		if upper, ok := policy.MaxAllowed[name]; ok && quantity.Cmp(upper) > 0 {
+			quantity = upper.DeepCopy()
+		}
+		if quantity.Cmp(request) == 0 {
+			continue
+		}
+		if desired == nil {
+			desired = current.DeepCopy()
+		}
+		desired[name] = quantity
+	}
+	return desired
+}
+
+// desiredLimits changes the resource limits in proportion to the changes of the requests.
+func desiredLimits(current corev1.ResourceRequirements, requests corev1.ResourceList) corev1.ResourceList {
+	if current.Limits == nil {
+		return nil
+	}
+	limits := current.Limits.DeepCopy()
+	for name, limit := range current.Limits {
+		request, ok1 := current.Requests[name]
+		desired, ok2 := requests[name]
+		if !ok1 || !ok2 || request.IsZero() {
+			continue
+		}
+		limits[name] = scaleQuantity(name, limit, float64(desired.MilliValue())/float64(request.MilliValue()))
+	}
+	return limits
+}
+
+func scaleQuantity(name corev1.ResourceName, quantity resource.Quantity, ratio float64) resource.Quantity {
+	if name == corev1.ResourceCPU {
+		return *resource.NewMilliQuantity(int64(math.Ceil(float64(quantity.MilliValue())*ratio)), quantity.Format)
+	}
+	// round the memory up to Mi
+	mebibytes := int64(math.Ceil(float64(quantity.Value()) * ratio / (1 << 20)))
+	return *resource.NewQuantity(mebibytes<<20, resource.BinarySI)
+}
+
+func buildAutoscalerOpsRequest(scaler *experimental.ComponentAutoscaler, opsType opsv1alpha1.OpsType) *opsv1alpha1.OpsRequest {
+	action := "hscale"
+	if opsType == opsv1alpha1.VerticalScalingType {
+		action = "vscale"
+	}
+	return &opsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s-%s", scaler.Name, action, rand.String(5)),
+			Namespace: scaler.Namespace,
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey:       scaler.Spec.TargetClusterName,
+				constant.OpsRequestTypeLabelKey:    string(opsType),
+				constant.OpsAutoscalerNameLabelKey: scaler.Name,
+			},
+		},
+		Spec: opsv1alpha1.OpsRequestSpec{
+			ClusterName: scaler.Spec.TargetClusterName,
+			Type:        opsType,
+		},
+	}
+}
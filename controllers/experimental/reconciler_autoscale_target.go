@@ -0,0 +1,234 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package experimental
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	experimental "github.com/apecloud/kubeblocks/apis/experimental/v1alpha1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+// autoscalerHistoryLimit is the maximum number of scaling records kept in the status.
+const autoscalerHistoryLimit = 10
+
+type autoscaleTargetReconciler struct{}
+
+func (r *autoscaleTargetReconciler) PreCondition(tree *kubebuilderx.ObjectTree) *kubebuilderx.CheckResult {
+	if tree.GetRoot() == nil || model.IsObjectDeleting(tree.GetRoot()) {
+		return kubebuilderx.ConditionUnsatisfied
+	}
+	return kubebuilderx.ConditionSatisfied
+}
+
+func (r *autoscaleTargetReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilderx.Result, error) {
+	scaler, _ := tree.GetRoot().(*experimental.ComponentAutoscaler)
+	target, err := getAutoscalerTarget(tree, scaler)
+	if err != nil {
+		return kubebuilderx.Continue, err
+	}
+
+	now := time.Now()
+	current := target.spec.Replicas
+	currentRequests := target.spec.Resources.Requests
+	ratios, complete := metricRatios(scaler)
+	tolerance := float64(ptr.Deref(scaler.Spec.TolerancePercent, defaultTolerancePercent)) / 100
+
+	// the horizontal scaling goes first, the vertical scaling takes over when the replicas can't be changed
+	recommendation := experimental.AutoscalerRecommendation{Timestamp: metav1.NewTime(now)}
+	if policy := scaler.Spec.Horizontal; policy != nil {
+		ratio := 0.0
+		for _, v := range ratios {
+			ratio = max(ratio, v)
+		}
+		recommendation.Replicas = ptr.To(desiredReplicas(current, ratio, tolerance, policy, target.replicasLimit()))
+	}
+	if policy := scaler.Spec.Vertical; policy != nil && (recommendation.Replicas == nil || *recommendation.Replicas == current) {
+		recommendation.Requests = desiredRequests(currentRequests, resourceRatios(scaler, ratios), tolerance, policy)
+	}
+	window := time.Duration(ptr.Deref(scaler.Spec.StabilizationWindowSeconds, defaultStabilizationWindowSeconds)) * time.Second
+	scaler.Status.Recommendations = appendRecommendation(scaler.Status.Recommendations, recommendation, now.Add(-window))
+
+	replicas, requests := stabilize(scaler.Status.Recommendations, recommendation, current, currentRequests, complete)
+	scaler.Status.DesiredReplicas = replicas
+
+	if !isAutoscalerReadyToScale(tree, scaler, target, now) {
+		return kubebuilderx.RetryAfter(autoscalerSyncPeriod), nil
+	}
+
+	var ops *opsv1alpha1.OpsRequest
+	record := experimental.AutoscalerScalingRecord{
+		Timestamp: metav1.NewTime(now),
+		Reason:    scalingReason(scaler),
+	}
+	switch {
+	case replicas != current:
+		ops = buildAutoscalerOpsRequest(scaler, opsv1alpha1.HorizontalScalingType)
+		hScale := opsv1alpha1.HorizontalScaling{
+			ComponentOps: opsv1alpha1.ComponentOps{ComponentName: scaler.Spec.TargetComponentName},
+		}
+		if replicas > current {
+			hScale.ScaleOut = &opsv1alpha1.ScaleOut{ReplicaChanger: opsv1alpha1.ReplicaChanger{ReplicaChanges: ptr.To(replicas - current)}}
+		} else {
+			hScale.ScaleIn = &opsv1alpha1.ScaleIn{ReplicaChanger: opsv1alpha1.ReplicaChanger{ReplicaChanges: ptr.To(current - replicas)}}
+		}
+		ops.Spec.HorizontalScalingList = []opsv1alpha1.HorizontalScaling{hScale}
+		record.FromReplicas = ptr.To(current)
+		record.ToReplicas = ptr.To(replicas)
+	case requests != nil:
+		ops = buildAutoscalerOpsRequest(scaler, opsv1alpha1.VerticalScalingType)
+		ops.Spec.VerticalScalingList = []opsv1alpha1.VerticalScaling{
+			{
+				ComponentOps: opsv1alpha1.ComponentOps{ComponentName: scaler.Spec.TargetComponentName},
+				ResourceRequirements: corev1.ResourceRequirements{
+					Requests: requests,
+					Limits:   desiredLimits(target.spec.Resources, requests),
+				},
+			},
+		}
+		record.FromRequests = currentRequests.DeepCopy()
+		record.ToRequests = requests
+	default:
+		return kubebuilderx.RetryAfter(autoscalerSyncPeriod), nil
+	}
+	if err = tree.Add(ops); err != nil {
+		return kubebuilderx.Continue, err
+	}
+
+	record.OpsRequestName = ops.Name
+	scaler.Status.OpsRequestName = ops.Name
+	scaler.Status.LastScaleTime = metav1.NewTime(now)
+	scaler.Status.History = append(scaler.Status.History, record)
+	if len(scaler.Status.History) > autoscalerHistoryLimit {
+		scaler.Status.History = scaler.Status.History[len(scaler.Status.History)-autoscalerHistoryLimit:]
+	}
+
+	return kubebuilderx.RetryAfter(autoscalerSyncPeriod), nil
+}
+
+// appendRecommendation appends the recommendation and drops the ones made before the stabilization window.
+func appendRecommendation(recommendations []experimental.AutoscalerRecommendation,
+	recommendation experimental.AutoscalerRecommendation, since time.Time) []experimental.AutoscalerRecommendation {
+	var result []experimental.AutoscalerRecommendation
+	for _, r := range recommendations {
+		if r.Timestamp.Time.After(since) {
+			result = append(result, r)
+		}
+	}
+	return append(result, recommendation)
+}
+
+// stabilize returns the replicas and the requests to scale to. Scaling down uses the highest recommendation
+// within the stabilization window, and is suppressed if any metric is unavailable. A nil requests means
+// the requests are unchanged.
+func stabilize(recommendations []experimental.AutoscalerRecommendation, recommendation experimental.AutoscalerRecommendation,
+	current int32, currentRequests corev1.ResourceList, complete bool) (int32, corev1.ResourceList) {
+	replicas := current
+	if recommendation.Replicas != nil {
+		replicas = *recommendation.Replicas
+		if replicas < current {
+			if !complete {
+				replicas = current
+			}
+			for _, r := range recommendations {
+				if r.Replicas != nil {
+					replicas = max(replicas, *r.Replicas)
+				}
+			}
+			replicas = min(replicas, current)
+		}
+	}
+
+	if recommendation.Requests == nil {
+		return replicas, nil
+	}
+	requests := recommendation.Requests.DeepCopy()
+	changed := false
+	for name, request := range recommendation.Requests {
+		currentRequest := currentRequests[name]
+		if request.Cmp(currentRequest) < 0 {
+			if !complete {
+				request = currentRequest
+			}
+			for _, r := range recommendations {
+				if q, ok := r.Requests[name]; ok && q.Cmp(request) > 0 {
+					request = q
+				} else if !ok && currentRequest.Cmp(request) > 0 {
+					request = currentRequest
+				}
+			}
+			if request.Cmp(currentRequest) > 0 {
+				request = currentRequest
+			}
+			requests[name] = request.DeepCopy()
+		}
+		if request.Cmp(currentRequest) != 0 {
+			changed = true
+		}
+	}
+	if !changed {
+		return replicas, nil
+	}
+	return replicas, requests
+}
+
+// isAutoscalerReadyToScale checks whether a new scaling can be performed: the previous scaling has completed,
+// the cluster is running and the cooldown has passed.
+func isAutoscalerReadyToScale(tree *kubebuilderx.ObjectTree, scaler *experimental.ComponentAutoscaler,
+	target *autoscalerTarget, now time.Time) bool {
+	if len(scaler.Status.OpsRequestName) > 0 {
+		for _, object := range tree.List(&opsv1alpha1.OpsRequest{}) {
+			ops, _ := object.(*opsv1alpha1.OpsRequest)
+			if ops.Name == scaler.Status.OpsRequestName && !ops.IsComplete() {
+				return false
+			}
+		}
+	}
+	if target.cluster.Status.Phase != appsv1.RunningClusterPhase {
+		return false
+	}
+	cooldown := time.Duration(ptr.Deref(scaler.Spec.CooldownSeconds, defaultCooldownSeconds)) * time.Second
+	return scaler.Status.LastScaleTime.IsZero() || !now.Before(scaler.Status.LastScaleTime.Add(cooldown))
+}
+
+func scalingReason(scaler *experimental.ComponentAutoscaler) string {
+	var values []string
+	for _, status := range scaler.Status.CurrentMetrics {
+		if len(status.CurrentValue) > 0 {
+			values = append(values, fmt.Sprintf("%s: %s/%s", status.Name, status.CurrentValue, status.TargetValue))
+		}
+	}
+	return fmt.Sprintf("current/target metric values: %s", strings.Join(values, ", "))
+}
+
+func autoscaleTarget() kubebuilderx.Reconciler {
+	return &autoscaleTargetReconciler{}
+}
+
+var _ kubebuilderx.Reconciler = &autoscaleTargetReconciler{}
@@ -0,0 +1,153 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package experimental
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	experimentalv1alpha1 "github.com/apecloud/kubeblocks/apis/experimental/v1alpha1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+)
+
+var _ = Describe("autoscale target reconciler test", func() {
+	BeforeEach(func() {
+		tree = mockAutoscalerTestTree()
+		res, err := collectAutoscalerMetrics().Reconcile(tree)
+		Expect(err).Should(BeNil())
+		Expect(res).Should(Equal(kubebuilderx.Continue))
+	})
+
+	listOps := func() []*opsv1alpha1.OpsRequest {
+		var opsList []*opsv1alpha1.OpsRequest
+		for _, object := range tree.List(&opsv1alpha1.OpsRequest{}) {
+			ops, _ := object.(*opsv1alpha1.OpsRequest)
+			opsList = append(opsList, ops)
+		}
+		return opsList
+	}
+
+	reconcile := func() {
+		reconciler := autoscaleTarget()
+		Expect(reconciler.PreCondition(tree)).Should(Equal(kubebuilderx.ConditionSatisfied))
+		res, err := reconciler.Reconcile(tree)
+		Expect(err).Should(BeNil())
+		Expect(res).Should(Equal(kubebuilderx.RetryAfter(autoscalerSyncPeriod)))
+	}
+
+	Context("PreCondition & Reconcile", func() {
+		It("should scale out by steps", func() {
+			beforeReconcile := metav1.Now()
+			reconcile()
+
+			Expect(cas.Status.DesiredReplicas).Should(Equal(int32(3)))
+			Expect(cas.Status.Recommendations).Should(HaveLen(1))
+			opsList := listOps()
+			Expect(opsList).Should(HaveLen(1))
+			ops := opsList[0]
+			Expect(ops.Spec.Type).Should(Equal(opsv1alpha1.HorizontalScalingType))
+			Expect(ops.Spec.ClusterName).Should(Equal(clusterName))
+			Expect(ops.Labels[constant.OpsAutoscalerNameLabelKey]).Should(Equal(cas.Name))
+			Expect(ops.Spec.HorizontalScalingList).Should(HaveLen(1))
+			Expect(ops.Spec.HorizontalScalingList[0].ComponentName).Should(Equal(componentNames[0]))
+			Expect(ops.Spec.HorizontalScalingList[0].ScaleOut).ShouldNot(BeNil())
+			Expect(ops.Spec.HorizontalScalingList[0].ScaleOut.ReplicaChanges).Should(Equal(ptr.To(int32(1))))
+			Expect(cas.Status.OpsRequestName).Should(Equal(ops.Name))
+			Expect(cas.Status.LastScaleTime.Compare(beforeReconcile.Time)).Should(BeNumerically(">=", 0))
+			Expect(cas.Status.History).Should(HaveLen(1))
+			Expect(cas.Status.History[0].FromReplicas).Should(Equal(ptr.To(int32(2))))
+			Expect(cas.Status.History[0].ToReplicas).Should(Equal(ptr.To(int32(3))))
+
+			By("wait for the running OpsRequest")
+			cas.Status.LastScaleTime = metav1.NewTime(time.Now().Add(-time.Hour))
+			reconcile()
+			Expect(listOps()).Should(HaveLen(1))
+		})
+
+		It("should respect the replicas limit and the cooldown", func() {
+			compDef := builder.NewComponentDefinitionBuilder("test-compdef").SetReplicasLimit(1, 2).GetObject()
+			Expect(tree.Add(compDef)).Should(Succeed())
+			reconcile()
+			Expect(cas.Status.DesiredReplicas).Should(Equal(int32(2)))
+			Expect(listOps()).Should(BeEmpty())
+
+			Expect(tree.Delete(compDef)).Should(Succeed())
+			cas.Status.LastScaleTime = metav1.Now()
+			reconcile()
+			Expect(cas.Status.DesiredReplicas).Should(Equal(int32(3)))
+			Expect(listOps()).Should(BeEmpty())
+		})
+
+		It("should stabilize scaling in", func() {
+			cas.Status.CurrentMetrics[0].CurrentValue = "10"
+			cas.Status.Recommendations = []experimentalv1alpha1.AutoscalerRecommendation{
+				{Timestamp: metav1.NewTime(time.Now().Add(-time.Minute)), Replicas: ptr.To(int32(2))},
+			}
+			reconcile()
+			Expect(cas.Status.Recommendations).Should(HaveLen(2))
+			Expect(cas.Status.DesiredReplicas).Should(Equal(int32(2)))
+			Expect(listOps()).Should(BeEmpty())
+
+			By("the recommendations out of the window are dropped")
+			cas.Status.Recommendations = []experimentalv1alpha1.AutoscalerRecommendation{
+				{Timestamp: metav1.NewTime(time.Now().Add(-time.Hour)), Replicas: ptr.To(int32(2))},
+			}
+			reconcile()
+			Expect(cas.Status.Recommendations).Should(HaveLen(1))
+			Expect(cas.Status.DesiredReplicas).Should(Equal(int32(1)))
+			opsList := listOps()
+			Expect(opsList).Should(HaveLen(1))
+			Expect(opsList[0].Spec.HorizontalScalingList[0].ScaleIn).ShouldNot(BeNil())
+			Expect(opsList[0].Spec.HorizontalScalingList[0].ScaleIn.ReplicaChanges).Should(Equal(ptr.To(int32(1))))
+		})
+
+		It("should scale vertically when the replicas reach the bound", func() {
+			cas.Spec.Horizontal.MaxReplicas = 2
+			cas.Spec.Vertical = &experimentalv1alpha1.VerticalAutoscalingPolicy{
+				MaxAllowed: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1500m")},
+			}
+			reconcile()
+
+			Expect(cas.Status.DesiredReplicas).Should(Equal(int32(2)))
+			opsList := listOps()
+			Expect(opsList).Should(HaveLen(1))
+			ops := opsList[0]
+			Expect(ops.Spec.Type).Should(Equal(opsv1alpha1.VerticalScalingType))
+			Expect(ops.Spec.VerticalScalingList).Should(HaveLen(1))
+			requests := ops.Spec.VerticalScalingList[0].Requests
+			Expect(requests.Cpu().String()).Should(Equal("1500m"))
+			Expect(requests.Memory().String()).Should(Equal("1Gi"))
+			limits := ops.Spec.VerticalScalingList[0].Limits
+			Expect(limits.Cpu().String()).Should(Equal("3"))
+			Expect(cas.Status.History).Should(HaveLen(1))
+			Expect(cas.Status.History[0].ToRequests.Cpu().String()).Should(Equal("1500m"))
+		})
+	})
+})
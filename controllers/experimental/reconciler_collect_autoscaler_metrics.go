@@ -0,0 +1,105 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package experimental
+
+import (
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	experimental "github.com/apecloud/kubeblocks/apis/experimental/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+type collectAutoscalerMetricsReconciler struct{}
+
+func (r *collectAutoscalerMetricsReconciler) PreCondition(tree *kubebuilderx.ObjectTree) *kubebuilderx.CheckResult {
+	if tree.GetRoot() == nil || model.IsObjectDeleting(tree.GetRoot()) {
+		return kubebuilderx.ConditionUnsatisfied
+	}
+	return kubebuilderx.ConditionSatisfied
+}
+
+func (r *collectAutoscalerMetricsReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilderx.Result, error) {
+	scaler, _ := tree.GetRoot().(*experimental.ComponentAutoscaler)
+	scaler.Status.ObservedGeneration = scaler.Generation
+
+	target, err := getAutoscalerTarget(tree, scaler)
+	if err != nil {
+		setScalingActiveCondition(scaler, metav1.ConditionFalse, experimental.ReasonTargetNotFound, err.Error())
+		return kubebuilderx.RetryAfter(autoscalerSyncPeriod), nil
+	}
+	scaler.Status.CurrentReplicas = target.spec.Replicas
+
+	available := 0
+	metrics := make([]experimental.AutoscalerMetricStatus, 0, len(scaler.Spec.Metrics))
+	for _, metric := range scaler.Spec.Metrics {
+		status := experimental.AutoscalerMetricStatus{Name: metric.Name}
+		switch {
+		case metric.Resource != nil:
+			status.TargetValue = strconv.Itoa(int(metric.Resource.TargetUtilization))
+			if utilization, err := resourceUtilization(tree, target, metric.Resource.Name); err != nil {
+				status.Message = err.Error()
+			} else {
+				status.CurrentValue = strconv.FormatInt(utilization, 10)
+			}
+		case metric.Probe != nil:
+			status.TargetValue = metric.Probe.TargetValue
+			if value, err := probeAverage(tree, target, metric.Probe.Probe); err != nil {
+				status.Message = err.Error()
+			} else {
+				status.CurrentValue = strconv.FormatFloat(value, 'f', -1, 64)
+			}
+		}
+		if len(status.CurrentValue) > 0 {
+			available++
+		}
+		metrics = append(metrics, status)
+	}
+	scaler.Status.CurrentMetrics = metrics
+
+	if available == 0 {
+		setScalingActiveCondition(scaler, metav1.ConditionFalse, experimental.ReasonMetricsUnavailable, "no metric is available")
+		return kubebuilderx.RetryAfter(autoscalerSyncPeriod), nil
+	}
+	setScalingActiveCondition(scaler, metav1.ConditionTrue, experimental.ReasonMetricsAvailable,
+		fmt.Sprintf("%d of %d metrics are available", available, len(metrics)))
+
+	return kubebuilderx.Continue, nil
+}
+
+func setScalingActiveCondition(scaler *experimental.ComponentAutoscaler, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&scaler.Status.Conditions, metav1.Condition{
+		Type:               string(experimental.ScalingActive),
+		Status:             status,
+		ObservedGeneration: scaler.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+func collectAutoscalerMetrics() kubebuilderx.Reconciler {
+	return &collectAutoscalerMetricsReconciler{}
+}
+
+var _ kubebuilderx.Reconciler = &collectAutoscalerMetricsReconciler{}
@@ -0,0 +1,127 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package experimental
+
+import (
+	"encoding/json"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	experimentalv1alpha1 "github.com/apecloud/kubeblocks/apis/experimental/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+var _ = Describe("collect autoscaler metrics reconciler test", func() {
+	BeforeEach(func() {
+		tree = mockAutoscalerTestTree()
+	})
+
+	probeEvent := func(podName, output string) *corev1.Event {
+		message, err := json.Marshal(proto.ProbeEvent{Probe: "connections", Output: []byte(output)})
+		Expect(err).Should(BeNil())
+		return &corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s-connections", podName),
+			},
+			InvolvedObject: corev1.ObjectReference{
+				Kind:      "Pod",
+				Namespace: namespace,
+				Name:      podName,
+				FieldPath: proto.ProbeEventFieldPath,
+			},
+			Reason:              "connections",
+			Message:             string(message),
+			ReportingController: proto.ProbeEventReportingController,
+			EventTime:           metav1.NowMicro(),
+		}
+	}
+
+	Context("PreCondition & Reconcile", func() {
+		It("should work well", func() {
+			By("PreCondition")
+			reconciler := collectAutoscalerMetrics()
+			Expect(reconciler.PreCondition(tree)).Should(Equal(kubebuilderx.ConditionSatisfied))
+
+			By("add a probe metric")
+			cas.Spec.Metrics = append(cas.Spec.Metrics, experimentalv1alpha1.AutoscalerMetric{
+				Name: "connections",
+				Probe: &experimentalv1alpha1.ProbeMetricSource{
+					Probe:       "connections",
+					TargetValue: "50",
+				},
+			})
+			compName := constant.GenerateClusterComponentName(clusterName, componentNames[0])
+			Expect(tree.Add(probeEvent(compName+"-0", "120"), probeEvent(compName+"-1", "80\n"))).Should(Succeed())
+
+			By("Reconcile")
+			res, err := reconciler.Reconcile(tree)
+			Expect(err).Should(BeNil())
+			Expect(res).Should(Equal(kubebuilderx.Continue))
+			Expect(cas.Status.CurrentReplicas).Should(Equal(int32(2)))
+			Expect(cas.Status.CurrentMetrics).Should(Equal([]experimentalv1alpha1.AutoscalerMetricStatus{
+				{Name: "cpu", CurrentValue: "80", TargetValue: "50"},
+				{Name: "connections", CurrentValue: "100", TargetValue: "50"},
+			}))
+			condition := meta.FindStatusCondition(cas.Status.Conditions, string(experimentalv1alpha1.ScalingActive))
+			Expect(condition).ShouldNot(BeNil())
+			Expect(condition.Status).Should(Equal(metav1.ConditionTrue))
+		})
+
+		It("should report unavailable metrics", func() {
+			for _, object := range tree.List(&corev1.Pod{}) {
+				pod, _ := object.(*corev1.Pod)
+				pod.Spec.Containers[0].Resources.Requests = nil
+			}
+
+			res, err := collectAutoscalerMetrics().Reconcile(tree)
+			Expect(err).Should(BeNil())
+			Expect(res).Should(Equal(kubebuilderx.RetryAfter(autoscalerSyncPeriod)))
+			Expect(cas.Status.CurrentMetrics).Should(HaveLen(1))
+			Expect(cas.Status.CurrentMetrics[0].CurrentValue).Should(BeEmpty())
+			Expect(cas.Status.CurrentMetrics[0].Message).Should(ContainSubstring("missing cpu request"))
+			condition := meta.FindStatusCondition(cas.Status.Conditions, string(experimentalv1alpha1.ScalingActive))
+			Expect(condition).ShouldNot(BeNil())
+			Expect(condition.Status).Should(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).Should(Equal(experimentalv1alpha1.ReasonMetricsUnavailable))
+		})
+
+		It("should report the missing target", func() {
+			Expect(tree.Delete(builder.NewClusterBuilder(namespace, clusterName).GetObject())).Should(Succeed())
+
+			res, err := collectAutoscalerMetrics().Reconcile(tree)
+			Expect(err).Should(BeNil())
+			Expect(res).Should(Equal(kubebuilderx.RetryAfter(autoscalerSyncPeriod)))
+			condition := meta.FindStatusCondition(cas.Status.Conditions, string(experimentalv1alpha1.ScalingActive))
+			Expect(condition).ShouldNot(BeNil())
+			Expect(condition.Status).Should(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).Should(Equal(experimentalv1alpha1.ReasonTargetNotFound))
+		})
+	})
+})
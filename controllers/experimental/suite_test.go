@@ -20,13 +20,17 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package experimental
 
 import (
+	"fmt"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
@@ -51,6 +55,7 @@ const (
 var (
 	tree           *kubebuilderx.ObjectTree
 	ncs            *experimentalv1alpha1.NodeCountScaler
+	cas            *experimentalv1alpha1.ComponentAutoscaler
 	clusterName    = "foo"
 	componentNames = []string{"bar-0", "bar-1"}
 )
@@ -92,6 +97,68 @@ func mockTestTree() *kubebuilderx.ObjectTree {
 	return tree
 }
 
+func mockAutoscalerTestTree() *kubebuilderx.ObjectTree {
+	cas = builder.NewComponentAutoscalerBuilder(namespace, name).
+		SetTargetClusterName(clusterName).
+		SetTargetComponentName(componentNames[0]).
+		AddMetric(experimentalv1alpha1.AutoscalerMetric{
+			Name: "cpu",
+			Resource: &experimentalv1alpha1.ResourceMetricSource{
+				Name:              corev1.ResourceCPU,
+				TargetUtilization: 50,
+			},
+		}).
+		SetHorizontal(&experimentalv1alpha1.HorizontalAutoscalingPolicy{
+			MinReplicas: 1,
+			MaxReplicas: 5,
+		}).
+		GetObject()
+
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("1"),
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("2"),
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		},
+	}
+	specs := []appsv1.ClusterComponentSpec{
+		{
+			Name:      componentNames[0],
+			Replicas:  2,
+			Resources: resources,
+		},
+	}
+	cluster := builder.NewClusterBuilder(namespace, clusterName).SetComponentSpecs(specs).GetObject()
+	cluster.Status.Phase = appsv1.RunningClusterPhase
+
+	tree = kubebuilderx.NewObjectTree()
+	tree.SetRoot(cas)
+	Expect(tree.Add(cluster)).Should(Succeed())
+	for i := 0; i < 2; i++ {
+		podName := fmt.Sprintf("%s-%d", constant.GenerateClusterComponentName(clusterName, componentNames[0]), i)
+		pod := builder.NewPodBuilder(namespace, podName).
+			AddContainer(corev1.Container{Name: "main", Resources: resources}).
+			GetObject()
+		pod.Status.Phase = corev1.PodRunning
+		podMetrics := &unstructured.Unstructured{}
+		podMetrics.SetGroupVersionKind(schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "PodMetrics"})
+		podMetrics.SetNamespace(namespace)
+		podMetrics.SetName(podName)
+		Expect(unstructured.SetNestedSlice(podMetrics.Object, []interface{}{
+			map[string]interface{}{
+				"name":  "main",
+				"usage": map[string]interface{}{"cpu": "800m", "memory": "512Mi"},
+			},
+		}, "containers")).Should(Succeed())
+		Expect(tree.Add(pod, podMetrics)).Should(Succeed())
+	}
+
+	return tree
+}
+
 func TestAPIs(t *testing.T) {
 	RegisterFailHandler(Fail)
 
@@ -67,6 +67,10 @@ func init() {
 
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch
 
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=componentdefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=componentversions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=components,verbs=get;list;watch
+
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 //
@@ -99,6 +103,10 @@ func (r *AddonReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		})
 	}
 
+	rollbackStageBuilder := func(next ...ctrlerihandler.Handler) ctrlerihandler.Handler {
+		return ctrlerihandler.NewTypeHandler(&rollbackStage{stageCtx: buildStageCtx(next...)})
+	}
+
 	genIDProceedStageBuilder := func(next ...ctrlerihandler.Handler) ctrlerihandler.Handler {
 		return ctrlerihandler.NewTypeHandler(&genIDProceedCheckStage{stageCtx: buildStageCtx(next...)})
 	}
@@ -119,6 +127,10 @@ func (r *AddonReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		return ctrlerihandler.NewTypeHandler(&enabledWithDefaultValuesStage{stageCtx: buildStageCtx(next...)})
 	}
 
+	dependencyCheckStageBuilder := func(next ...ctrlerihandler.Handler) ctrlerihandler.Handler {
+		return ctrlerihandler.NewTypeHandler(&dependencyCheckStage{stageCtx: buildStageCtx(next...)})
+	}
+
 	progressingStageBuilder := func(next ...ctrlerihandler.Handler) ctrlerihandler.Handler {
 		return ctrlerihandler.NewTypeHandler(&progressingHandler{stageCtx: buildStageCtx(next...)})
 	}
@@ -129,11 +141,13 @@ func (r *AddonReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 
 	handlers := ctrlerihandler.Chain(
 		fetchNDeletionCheckStageBuilder,
+		rollbackStageBuilder,
 		genIDProceedStageBuilder,
 		metadataCheckStageBuilder,
 		installableCheckStageBuilder,
 		autoInstallCheckStageBuilder,
 		enabledAutoValuesStageBuilder,
+		dependencyCheckStageBuilder,
 		progressingStageBuilder,
 		terminalStateStageBuilder,
 	).Handler("")
@@ -156,6 +170,7 @@ func (r *AddonReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return intctrlutil.NewControllerManagedBy(mgr).
 		For(&extensionsv1alpha1.Addon{}).
 		Watches(&batchv1.Job{}, handler.EnqueueRequestsFromMapFunc(r.findAddonJobs)).
+		Watches(&extensionsv1alpha1.Addon{}, handler.EnqueueRequestsFromMapFunc(r.findRelatedAddons)).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: viper.GetInt(maxConcurrentReconcilesKey),
 		}).
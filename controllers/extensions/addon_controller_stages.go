@@ -121,6 +121,10 @@ type deletionStage struct {
 	disablingStage disablingStage
 }
 
+type rollbackStage struct {
+	stageCtx
+}
+
 type genIDProceedCheckStage struct {
 	stageCtx
 }
@@ -141,6 +145,10 @@ type enabledWithDefaultValuesStage struct {
 	stageCtx
 }
 
+type dependencyCheckStage struct {
+	stageCtx
+}
+
 type progressingHandler struct {
 	stageCtx
 	enablingStage  enablingStage
@@ -190,6 +198,10 @@ func (r *fetchNDeletionCheckStage) Handle(ctx context.Context) {
 			r.updateResultNErr(res, err)
 			return
 		}
+		if res, err := checkAddonDependents(ctx, &r.stageCtx, addon); res != nil || err != nil {
+			r.updateResultNErr(res, err)
+			return
+		}
 	}
 	res, err := intctrlutil.HandleCRDeletion(*r.reqCtx, r.reconciler, addon, addonFinalizerName, func() (*ctrl.Result, error) {
 		r.deletionStage.Handle(ctx)
@@ -203,6 +215,47 @@ func (r *fetchNDeletionCheckStage) Handle(ctx context.Context) {
 	r.next.Handle(ctx)
 }
 
+// Handle rolls back the add-on by restoring the version and values of the revision to roll back to into its spec,
+// then the following stages apply them by upgrading the Helm release with the chart of the revision, as a new
+// revision. The `helm rollback` is not used, since the spec is the source of truth of the add-on, which would
+// drift from the release otherwise.
+func (r *rollbackStage) Handle(ctx context.Context) {
+	r.process(func(addon *extensionsv1alpha1.Addon) {
+		if addon.Annotations[AddonRollback] != trueVal {
+			return
+		}
+		r.reqCtx.Log.V(1).Info("rollbackStage", "phase", addon.Status.Phase)
+		switch addon.Status.Phase {
+		case extensionsv1alpha1.AddonEnabling, extensionsv1alpha1.AddonDisabling:
+			r.setRequeueAfter(time.Second, "wait for the addon to be stable before rolling back")
+			return
+		}
+		revision := addonRollbackRevision(addon)
+		delete(addon.Annotations, AddonRollback)
+		if revision != nil {
+			addon.Spec.Version = revision.Version
+			addon.Spec.Helm = revision.Helm.DeepCopy()
+			addon.Spec.InstallSpec = revision.InstallSpec.DeepCopy()
+			if len(addon.Labels[AddonVersion]) > 0 && len(revision.Version) > 0 {
+				addon.Labels[AddonVersion] = revision.Version
+			}
+		}
+		if err := r.reconciler.Client.Update(ctx, addon); err != nil {
+			r.setRequeueWithErr(err, "")
+			return
+		}
+		if revision == nil {
+			r.reconciler.Event(addon, corev1.EventTypeWarning, AddonRollbackFailed,
+				"There is no succeeded revision to roll back to")
+		} else {
+			r.reconciler.Event(addon, corev1.EventTypeNormal, AddonRollingBack,
+				fmt.Sprintf("Roll back to revision %d, version %s", revision.Revision, revision.Version))
+		}
+		r.setReconciled()
+	})
+	r.next.Handle(ctx)
+}
+
 func (r *genIDProceedCheckStage) Handle(ctx context.Context) {
 	r.process(func(addon *extensionsv1alpha1.Addon) {
 		r.reqCtx.Log.V(1).Info("genIDProceedCheckStage", "phase", addon.Status.Phase)
@@ -393,6 +446,54 @@ func (r *enabledWithDefaultValuesStage) Handle(ctx context.Context) {
 	r.next.Handle(ctx)
 }
 
+func (r *dependencyCheckStage) Handle(ctx context.Context) {
+	r.process(func(addon *extensionsv1alpha1.Addon) {
+		r.reqCtx.Log.V(1).Info("dependencyCheckStage", "phase", addon.Status.Phase)
+		if len(addon.Spec.Dependencies) == 0 && len(addon.Status.Dependencies) == 0 {
+			return
+		}
+		// the dependencies are checked before installing or upgrading the add-on
+		if !addon.Spec.InstallSpec.GetEnabled() || addon.Status.Phase == extensionsv1alpha1.AddonEnabling {
+			return
+		}
+		addons, err := listAddons(ctx, r.reconciler.Client)
+		if err != nil {
+			r.setRequeueWithErr(err, "")
+			return
+		}
+		dependencies, satisfied := resolveAddonDependencies(addon, addons)
+		condition := metav1.Condition{
+			Type:               extensionsv1alpha1.ConditionTypeDependenciesSatisfied,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: addon.Generation,
+			Reason:             AddonDependenciesSatisfied,
+			LastTransitionTime: metav1.Now(),
+		}
+		if !satisfied {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = AddonDependencyUnsatisfied
+			condition.Message = unsatisfiedAddonDependencyMessage(dependencies)
+		}
+		unsatisfiedBefore := meta.IsStatusConditionFalse(addon.Status.Conditions, extensionsv1alpha1.ConditionTypeDependenciesSatisfied)
+
+		patch := client.MergeFrom(addon.DeepCopy())
+		addon.Status.Dependencies = dependencies
+		meta.SetStatusCondition(&addon.Status.Conditions, condition)
+		if err := r.reconciler.Status().Patch(ctx, addon, patch); err != nil {
+			r.setRequeueWithErr(err, "")
+			return
+		}
+		if !satisfied {
+			if !unsatisfiedBefore {
+				r.reconciler.Event(addon, corev1.EventTypeWarning, AddonDependencyUnsatisfied, condition.Message)
+			}
+			// defer the installation until the dependencies are satisfied
+			r.setRequeueAfter(addonDependencyRequeueAfter, "")
+		}
+	})
+	r.next.Handle(ctx)
+}
+
 func (r *progressingHandler) Handle(ctx context.Context) {
 	r.enablingStage.stageCtx = r.stageCtx
 	r.disablingStage.stageCtx = r.stageCtx
@@ -558,6 +659,11 @@ func (r *helmTypeInstallStage) Handle(ctx context.Context) {
 				// job failed set terminal state phase
 				setAddonErrorConditions(ctx, &r.stageCtx, addon, true, true, InstallationFailed,
 					fmt.Sprintf("Installation failed, do inspect error from jobs.batch %s", key.String()))
+				if err := patchAddonRevision(ctx, &r.stageCtx, addon, extensionsv1alpha1.AddonRevisionFailed,
+					fmt.Sprintf("Installation failed, do inspect error from jobs.batch %s", key.String())); err != nil {
+					r.setRequeueWithErr(err, "")
+					return
+				}
 				// only allow to do pod logs if max concurrent reconciles > 1, also considered that helm
 				// cmd error only has limited contents
 				if viper.GetInt(maxConcurrentReconcilesKey) > 1 {
@@ -572,6 +678,16 @@ func (r *helmTypeInstallStage) Handle(ctx context.Context) {
 			return
 		}
 
+		// check the compatibility before upgrading an installed add-on
+		upgrade := isAddonInstalled(addon)
+		if upgrade {
+			if err := checkAddonUpgradeCompatibility(ctx, r.reconciler.Client, addon); err != nil {
+				setAddonErrorConditions(ctx, &r.stageCtx, addon, true, true, AddonUpgradeIncompatible, err.Error())
+				r.setReconciled()
+				return
+			}
+		}
+
 		var err error
 		helmInstallJob, err = createHelmJobProto(addon)
 		if err != nil {
@@ -591,14 +707,17 @@ func (r *helmTypeInstallStage) Handle(ctx context.Context) {
 		helmInstallJob.ObjectMeta.Namespace = key.Namespace
 		helmJobPodSpec := &helmInstallJob.Spec.Template.Spec
 		helmContainer := &helmInstallJob.Spec.Template.Spec.Containers[0]
-		helmContainer.Args = append([]string{
-			"upgrade",
-			"--install",
+		helmContainer.Args = []string{"upgrade"}
+		if !upgrade {
+			helmContainer.Args = append(helmContainer.Args, "--install")
+		}
+		helmContainer.Args = append(helmContainer.Args, []string{
 			"$(RELEASE_NAME)",
 			chartsPath,
 			"--namespace",
 			"$(RELEASE_NS)",
-		}, viper.GetStringSlice(addonHelmInstallOptKey)...)
+		}...)
+		helmContainer.Args = append(helmContainer.Args, viper.GetStringSlice(addonHelmInstallOptKey)...)
 
 		installValues := addon.Spec.Helm.BuildMergedValues(addon.Spec.InstallSpec)
 		if err = addon.Spec.Helm.BuildContainerArgs(helmContainer, installValues); err != nil {
@@ -844,6 +963,9 @@ func (r *terminalStateStage) Handle(ctx context.Context) {
 			patch := client.MergeFrom(addon.DeepCopy())
 			addon.Status.Phase = phase
 			addon.Status.ObservedGeneration = addon.Generation
+			if phase == extensionsv1alpha1.AddonEnabled {
+				appendAddonRevision(addon, extensionsv1alpha1.AddonRevisionSucceeded, "")
+			}
 
 			meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
 				Type:               extensionsv1alpha1.ConditionTypeSucceed,
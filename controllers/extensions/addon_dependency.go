@@ -0,0 +1,199 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package extensions
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	extensionsv1alpha1 "github.com/apecloud/kubeblocks/apis/extensions/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	addonDependencyRequeueAfter = 10 * time.Second
+)
+
+func listAddons(ctx context.Context, cli client.Reader) (map[string]*extensionsv1alpha1.Addon, error) {
+	addonList := &extensionsv1alpha1.AddonList{}
+	if err := cli.List(ctx, addonList); err != nil {
+		return nil, err
+	}
+	addons := make(map[string]*extensionsv1alpha1.Addon, len(addonList.Items))
+	for i := range addonList.Items {
+		addons[addonList.Items[i].Name] = &addonList.Items[i]
+	}
+	return addons, nil
+}
+
+// resolveAddonDependencies resolves the dependencies of the add-on against the installed add-ons,
+// and returns whether all the dependencies are satisfied.
+func resolveAddonDependencies(addon *extensionsv1alpha1.Addon,
+	addons map[string]*extensionsv1alpha1.Addon) ([]extensionsv1alpha1.AddonDependencyStatus, bool) {
+	var (
+		cyclic    = hasCyclicAddonDependency(addon.Name, addons)
+		satisfied = true
+		statuses  []extensionsv1alpha1.AddonDependencyStatus
+	)
+	for _, dep := range addon.Spec.Dependencies {
+		status := resolveAddonDependency(dep, addons[dep.Name], cyclic)
+		satisfied = satisfied && status.Satisfied
+		statuses = append(statuses, status)
+	}
+	return statuses, satisfied
+}
+
+func resolveAddonDependency(dep extensionsv1alpha1.AddonDependency,
+	target *extensionsv1alpha1.Addon, cyclic bool) extensionsv1alpha1.AddonDependencyStatus {
+	status := extensionsv1alpha1.AddonDependencyStatus{
+		Name:    dep.Name,
+		Version: dep.Version,
+	}
+	if target != nil {
+		status.ResolvedVersion = target.Spec.Version
+		status.Phase = target.Status.Phase
+	}
+	switch {
+	case cyclic:
+		status.Message = "circular dependency between add-ons"
+	case target == nil:
+		status.Message = "the add-on is not found"
+	case !target.Spec.InstallSpec.GetEnabled() || target.Status.Phase != extensionsv1alpha1.AddonEnabled:
+		status.Message = "the add-on is not enabled"
+	case len(dep.Version) > 0:
+		if ok, err := validateVersion(dep.Version, target.Spec.Version); err != nil {
+			status.Message = fmt.Sprintf("invalid version constraint %s: %s", dep.Version, err.Error())
+		} else if !ok {
+			status.Message = fmt.Sprintf("the version %q doesn't match %s", target.Spec.Version, dep.Version)
+		}
+	}
+	status.Satisfied = len(status.Message) == 0
+	return status
+}
+
+// hasCyclicAddonDependency checks whether the add-on depends on itself, directly or indirectly.
+func hasCyclicAddonDependency(name string, addons map[string]*extensionsv1alpha1.Addon) bool {
+	visited := map[string]bool{}
+	var depends func(string) bool
+	depends = func(current string) bool {
+		addon, ok := addons[current]
+		if !ok || visited[current] {
+			return false
+		}
+		visited[current] = true
+		for _, dep := range addon.Spec.Dependencies {
+			if dep.Name == name || depends(dep.Name) {
+				return true
+			}
+		}
+		return false
+	}
+	return depends(name)
+}
+
+// enabledAddonDependents returns the names of the enabled add-ons that depend on the add-on.
+func enabledAddonDependents(name string, addons map[string]*extensionsv1alpha1.Addon) []string {
+	var dependents []string
+	for _, addon := range addons {
+		if addon.Name == name || !addon.GetDeletionTimestamp().IsZero() || !addon.Spec.InstallSpec.GetEnabled() {
+			continue
+		}
+		switch addon.Status.Phase {
+		case extensionsv1alpha1.AddonEnabled, extensionsv1alpha1.AddonEnabling:
+		default:
+			continue
+		}
+		if slices.ContainsFunc(addon.Spec.Dependencies, func(dep extensionsv1alpha1.AddonDependency) bool {
+			return dep.Name == name
+		}) {
+			dependents = append(dependents, addon.Name)
+		}
+	}
+	slices.Sort(dependents)
+	return dependents
+}
+
+func unsatisfiedAddonDependencyMessage(statuses []extensionsv1alpha1.AddonDependencyStatus) string {
+	var messages []string
+	for _, status := range statuses {
+		if !status.Satisfied {
+			messages = append(messages, fmt.Sprintf("%s: %s", status.Name, status.Message))
+		}
+	}
+	return fmt.Sprintf("unsatisfied dependencies: %s", strings.Join(messages, "; "))
+}
+
+// checkAddonDependents blocks disabling or deleting the add-on while other enabled add-ons depend on it.
+func checkAddonDependents(ctx context.Context, stageCtx *stageCtx, addon *extensionsv1alpha1.Addon) (*ctrl.Result, error) {
+	switch addon.Status.Phase {
+	case "", extensionsv1alpha1.AddonDisabled, extensionsv1alpha1.AddonDisabling:
+		return nil, nil
+	}
+	addons, err := listAddons(ctx, stageCtx.reconciler.Client)
+	if err != nil {
+		return nil, err
+	}
+	dependents := enabledAddonDependents(addon.Name, addons)
+	if len(dependents) == 0 {
+		return nil, nil
+	}
+	stageCtx.reconciler.Event(addon, corev1.EventTypeWarning, AddonRequiredByOthers,
+		fmt.Sprintf("Addon is required by enabled addons: %s, please disable them first", strings.Join(dependents, ",")))
+	return intctrlutil.ResultToP(intctrlutil.RequeueAfter(addonDependencyRequeueAfter, stageCtx.reqCtx.Log, ""))
+}
+
+// findRelatedAddons finds the add-ons that depend on the add-on, or that the add-on depends on,
+// to re-check the dependencies when the add-on changes.
+func (r *AddonReconciler) findRelatedAddons(ctx context.Context, obj client.Object) []reconcile.Request {
+	addon, ok := obj.(*extensionsv1alpha1.Addon)
+	if !ok {
+		return nil
+	}
+	addons, err := listAddons(ctx, r.Client)
+	if err != nil {
+		return nil
+	}
+	names := map[string]bool{}
+	for _, dep := range addon.Spec.Dependencies {
+		names[dep.Name] = true
+	}
+	for _, other := range addons {
+		for _, dep := range other.Spec.Dependencies {
+			if dep.Name == addon.Name {
+				names[other.Name] = true
+			}
+		}
+	}
+	var requests []reconcile.Request
+	for name := range names {
+		if _, ok := addons[name]; ok && name != addon.Name {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKey{Name: name}})
+		}
+	}
+	return requests
+}
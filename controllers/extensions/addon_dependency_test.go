@@ -0,0 +1,121 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package extensions
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	extensionsv1alpha1 "github.com/apecloud/kubeblocks/apis/extensions/v1alpha1"
+)
+
+var _ = Describe("Addon dependency", func() {
+	newAddon := func(name, version string, phase extensionsv1alpha1.AddonPhase, deps ...extensionsv1alpha1.AddonDependency) *extensionsv1alpha1.Addon {
+		return &extensionsv1alpha1.Addon{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: extensionsv1alpha1.AddonSpec{
+				Version:      version,
+				Dependencies: deps,
+				InstallSpec: &extensionsv1alpha1.AddonInstallSpec{
+					Enabled: phase == extensionsv1alpha1.AddonEnabled || phase == extensionsv1alpha1.AddonEnabling,
+				},
+			},
+			Status: extensionsv1alpha1.AddonStatus{
+				Phase: phase,
+			},
+		}
+	}
+
+	addonMap := func(addons ...*extensionsv1alpha1.Addon) map[string]*extensionsv1alpha1.Addon {
+		m := map[string]*extensionsv1alpha1.Addon{}
+		for _, addon := range addons {
+			m[addon.Name] = addon
+		}
+		return m
+	}
+
+	It("resolves satisfied dependencies", func() {
+		mysql := newAddon("mysql", "1.0.0", "", extensionsv1alpha1.AddonDependency{Name: "dp", Version: ">= 1.0"})
+		dp := newAddon("dp", "1.0.1", extensionsv1alpha1.AddonEnabled)
+
+		statuses, satisfied := resolveAddonDependencies(mysql, addonMap(mysql, dp))
+		Expect(satisfied).Should(BeTrue())
+		Expect(statuses).Should(Equal([]extensionsv1alpha1.AddonDependencyStatus{{
+			Name:            "dp",
+			Version:         ">= 1.0",
+			ResolvedVersion: "1.0.1",
+			Phase:           extensionsv1alpha1.AddonEnabled,
+			Satisfied:       true,
+		}}))
+	})
+
+	It("resolves unsatisfied dependencies", func() {
+		mysql := newAddon("mysql", "1.0.0", "",
+			extensionsv1alpha1.AddonDependency{Name: "dp", Version: ">= 1.0"},
+			extensionsv1alpha1.AddonDependency{Name: "backup", Version: ">= 2.0"},
+			extensionsv1alpha1.AddonDependency{Name: "monitor"},
+			extensionsv1alpha1.AddonDependency{Name: "storage", Version: "invalid"})
+		dp := newAddon("dp", "1.0.0", extensionsv1alpha1.AddonEnabling)
+		backup := newAddon("backup", "1.2.0", extensionsv1alpha1.AddonEnabled)
+		storage := newAddon("storage", "1.0.0", extensionsv1alpha1.AddonEnabled)
+
+		statuses, satisfied := resolveAddonDependencies(mysql, addonMap(mysql, dp, backup, storage))
+		Expect(satisfied).Should(BeFalse())
+		Expect(statuses).Should(HaveLen(4))
+		for _, status := range statuses {
+			Expect(status.Satisfied).Should(BeFalse())
+			Expect(status.Message).ShouldNot(BeEmpty())
+		}
+		Expect(statuses[0].Message).Should(ContainSubstring("not enabled"))
+		Expect(statuses[1].Message).Should(ContainSubstring("doesn't match"))
+		Expect(statuses[2].Message).Should(ContainSubstring("not found"))
+		Expect(statuses[3].Message).Should(ContainSubstring("invalid version constraint"))
+		Expect(unsatisfiedAddonDependencyMessage(statuses)).Should(ContainSubstring("dp: the add-on is not enabled"))
+	})
+
+	It("detects circular dependencies", func() {
+		a := newAddon("a", "1.0.0", "", extensionsv1alpha1.AddonDependency{Name: "b"})
+		b := newAddon("b", "1.0.0", extensionsv1alpha1.AddonEnabled, extensionsv1alpha1.AddonDependency{Name: "c"})
+		c := newAddon("c", "1.0.0", extensionsv1alpha1.AddonEnabled, extensionsv1alpha1.AddonDependency{Name: "a"})
+		d := newAddon("d", "1.0.0", "", extensionsv1alpha1.AddonDependency{Name: "b"})
+
+		Expect(hasCyclicAddonDependency("a", addonMap(a, b, c, d))).Should(BeTrue())
+		Expect(hasCyclicAddonDependency("d", addonMap(a, b, c, d))).Should(BeFalse())
+
+		statuses, satisfied := resolveAddonDependencies(a, addonMap(a, b, c, d))
+		Expect(satisfied).Should(BeFalse())
+		Expect(statuses[0].Message).Should(ContainSubstring("circular"))
+	})
+
+	It("finds the enabled dependents", func() {
+		dp := newAddon("dp", "1.0.0", extensionsv1alpha1.AddonEnabled)
+		mysql := newAddon("mysql", "1.0.0", extensionsv1alpha1.AddonEnabled, extensionsv1alpha1.AddonDependency{Name: "dp"})
+		pg := newAddon("pg", "1.0.0", extensionsv1alpha1.AddonEnabling, extensionsv1alpha1.AddonDependency{Name: "dp"})
+		redis := newAddon("redis", "1.0.0", extensionsv1alpha1.AddonDisabled, extensionsv1alpha1.AddonDependency{Name: "dp"})
+		mongo := newAddon("mongo", "1.0.0", extensionsv1alpha1.AddonEnabled)
+
+		Expect(enabledAddonDependents("dp", addonMap(dp, mysql, pg, redis, mongo))).Should(Equal([]string{"mysql", "pg"}))
+		Expect(enabledAddonDependents("mysql", addonMap(dp, mysql, pg, redis, mongo))).Should(BeEmpty())
+	})
+})
@@ -0,0 +1,223 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package extensions
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	extensionsv1alpha1 "github.com/apecloud/kubeblocks/apis/extensions/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+)
+
+const (
+	maxAddonRevisions = 10
+
+	// the annotation set by Helm on the resources of a release
+	helmReleaseNameAnnotationKey = "meta.helm.sh/release-name"
+)
+
+// isAddonInstalled checks whether the Helm release of the add-on has been installed, so the following
+// installation is an upgrade.
+func isAddonInstalled(addon *extensionsv1alpha1.Addon) bool {
+	cond := meta.FindStatusCondition(addon.Status.Conditions, extensionsv1alpha1.ConditionTypeSucceed)
+	return cond != nil && cond.Reason == AddonEnabled
+}
+
+// lastSucceededAddonRevision returns the latest revision applied successfully, that is, the installed one.
+func lastSucceededAddonRevision(revisions []extensionsv1alpha1.AddonRevision) *extensionsv1alpha1.AddonRevision {
+	for i := len(revisions) - 1; i >= 0; i-- {
+		if revisions[i].Phase == extensionsv1alpha1.AddonRevisionSucceeded {
+			return &revisions[i]
+		}
+	}
+	return nil
+}
+
+// addonRollbackRevision returns the revision to roll back to, that is, the last revision succeeded
+// before the latest one.
+func addonRollbackRevision(addon *extensionsv1alpha1.Addon) *extensionsv1alpha1.AddonRevision {
+	history := addon.Status.History
+	if len(history) == 0 {
+		return nil
+	}
+	return lastSucceededAddonRevision(history[:len(history)-1])
+}
+
+// appendAddonRevision records the version and values of the add-on being applied into its status.
+// A failure is not recorded again if the latest revision is the same failure, so that a failed installation
+// retried by the reconciliation does not flush out the history. It returns whether a revision is appended.
+func appendAddonRevision(addon *extensionsv1alpha1.Addon, phase extensionsv1alpha1.AddonRevisionPhase, message string) bool {
+	revision := int64(1)
+	if len(addon.Status.History) > 0 {
+		last := addon.Status.History[len(addon.Status.History)-1]
+		if phase == extensionsv1alpha1.AddonRevisionFailed && isSameAddonRevision(addon, &last, phase) {
+			return false
+		}
+		revision = last.Revision + 1
+	}
+	addon.Status.History = append(addon.Status.History, extensionsv1alpha1.AddonRevision{
+		Revision:    revision,
+		Version:     addon.Spec.Version,
+		Helm:        addon.Spec.Helm.DeepCopy(),
+		InstallSpec: addon.Spec.InstallSpec.DeepCopy(),
+		Phase:       phase,
+		AppliedTime: metav1.Now(),
+		Message:     message,
+	})
+	if len(addon.Status.History) > maxAddonRevisions {
+		addon.Status.History = addon.Status.History[len(addon.Status.History)-maxAddonRevisions:]
+	}
+	return true
+}
+
+// isSameAddonRevision checks whether the revision records the version and values of the add-on with the phase.
+func isSameAddonRevision(addon *extensionsv1alpha1.Addon, revision *extensionsv1alpha1.AddonRevision,
+	phase extensionsv1alpha1.AddonRevisionPhase) bool {
+	return revision.Phase == phase &&
+		revision.Version == addon.Spec.Version &&
+		equality.Semantic.DeepEqual(revision.Helm, addon.Spec.Helm) &&
+		equality.Semantic.DeepEqual(revision.InstallSpec, addon.Spec.InstallSpec)
+}
+
+func patchAddonRevision(ctx context.Context, stageCtx *stageCtx, addon *extensionsv1alpha1.Addon,
+	phase extensionsv1alpha1.AddonRevisionPhase, message string) error {
+	patch := client.MergeFrom(addon.DeepCopy())
+	if !appendAddonRevision(addon, phase, message) {
+		return nil
+	}
+	return stageCtx.reconciler.Status().Patch(ctx, addon, patch)
+}
+
+// isAddonVersionUpgraded checks whether the version of the add-on is higher than the installed one.
+func isAddonVersionUpgraded(installed, version string) bool {
+	if installed == version {
+		return false
+	}
+	v1, err1 := semver.NewVersion(installed)
+	v2, err2 := semver.NewVersion(version)
+	if err1 != nil || err2 != nil {
+		// can't tell, take it as an upgrade to be checked
+		return true
+	}
+	return v2.GreaterThan(v1)
+}
+
+// checkAddonUpgradeCompatibility checks whether the installed add-on can be upgraded to the version specified,
+// against the installed version and the ComponentDefinitions and ComponentVersions in use.
+func checkAddonUpgradeCompatibility(ctx context.Context, cli client.Reader, addon *extensionsv1alpha1.Addon) error {
+	installed := lastSucceededAddonRevision(addon.Status.History)
+	if addon.Spec.Upgrade == nil || installed == nil || !isAddonVersionUpgraded(installed.Version, addon.Spec.Version) {
+		return nil
+	}
+
+	if len(addon.Spec.Upgrade.FromVersion) > 0 {
+		ok, err := validateVersion(addon.Spec.Upgrade.FromVersion, installed.Version)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("upgrading from version %s to %s is not supported, the version upgraded from should be %s",
+				installed.Version, addon.Spec.Version, addon.Spec.Upgrade.FromVersion)
+		}
+	}
+
+	if len(addon.Spec.Upgrade.ServiceVersions) > 0 {
+		comps, err := addonComponentsInUse(ctx, cli, addon)
+		if err != nil {
+			return err
+		}
+		var unsupported []string
+		for _, comp := range comps {
+			if len(comp.Spec.ServiceVersion) > 0 && !slices.Contains(addon.Spec.Upgrade.ServiceVersions, comp.Spec.ServiceVersion) {
+				unsupported = append(unsupported, fmt.Sprintf("%s/%s(%s)", comp.Namespace, comp.Name, comp.Spec.ServiceVersion))
+			}
+		}
+		if len(unsupported) > 0 {
+			slices.Sort(unsupported)
+			return fmt.Errorf("the service versions of components in use are not supported by version %s: %s",
+				addon.Spec.Version, strings.Join(unsupported, ","))
+		}
+	}
+	return nil
+}
+
+// addonComponentsInUse returns the Components referencing the ComponentDefinitions provided by the add-on,
+// either directly or through the compatibility rules of the ComponentVersions provided by the add-on.
+func addonComponentsInUse(ctx context.Context, cli client.Reader, addon *extensionsv1alpha1.Addon) ([]appsv1.Component, error) {
+	releaseName := getHelmReleaseName(addon)
+	providedByAddon := func(obj client.Object) bool {
+		return obj.GetAnnotations()[helmReleaseNameAnnotationKey] == releaseName
+	}
+
+	var compDefs []string
+	compDefList := &appsv1.ComponentDefinitionList{}
+	if err := cli.List(ctx, compDefList); err != nil {
+		return nil, err
+	}
+	for i, compDef := range compDefList.Items {
+		if providedByAddon(&compDefList.Items[i]) {
+			compDefs = append(compDefs, compDef.Name)
+		}
+	}
+	compVersionList := &appsv1.ComponentVersionList{}
+	if err := cli.List(ctx, compVersionList); err != nil {
+		return nil, err
+	}
+	for i, compVersion := range compVersionList.Items {
+		if !providedByAddon(&compVersionList.Items[i]) {
+			continue
+		}
+		for _, rule := range compVersion.Spec.CompatibilityRules {
+			compDefs = append(compDefs, rule.CompDefs...)
+		}
+	}
+	if len(compDefs) == 0 {
+		return nil, nil
+	}
+
+	compList := &appsv1.ComponentList{}
+	if err := cli.List(ctx, compList); err != nil {
+		return nil, err
+	}
+	var comps []appsv1.Component
+	for _, comp := range compList.Items {
+		compDef := comp.Spec.CompDef
+		if label, ok := comp.Labels[constant.ComponentDefinitionLabelKey]; ok {
+			compDef = label
+		}
+		if slices.ContainsFunc(compDefs, func(pattern string) bool {
+			return component.PrefixOrRegexMatched(compDef, pattern)
+		}) {
+			comps = append(comps, comp)
+		}
+	}
+	return comps, nil
+}
@@ -0,0 +1,200 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package extensions
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	extensionsv1alpha1 "github.com/apecloud/kubeblocks/apis/extensions/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+var _ = Describe("Addon upgrade", func() {
+	newAddon := func(version string, revisions ...extensionsv1alpha1.AddonRevision) *extensionsv1alpha1.Addon {
+		return &extensionsv1alpha1.Addon{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "mysql",
+			},
+			Spec: extensionsv1alpha1.AddonSpec{
+				Version: version,
+				Type:    extensionsv1alpha1.HelmType,
+				Helm: &extensionsv1alpha1.HelmTypeInstallSpec{
+					ChartLocationURL: fmt.Sprintf("https://charts/mysql-%s.tgz", version),
+				},
+			},
+			Status: extensionsv1alpha1.AddonStatus{
+				History: revisions,
+			},
+		}
+	}
+
+	revision := func(revision int64, version string, phase extensionsv1alpha1.AddonRevisionPhase) extensionsv1alpha1.AddonRevision {
+		return extensionsv1alpha1.AddonRevision{
+			Revision: revision,
+			Version:  version,
+			Phase:    phase,
+		}
+	}
+
+	Context("history", func() {
+		It("appends revisions", func() {
+			addon := newAddon("1.0.0")
+			for i := 0; i < maxAddonRevisions+2; i++ {
+				appendAddonRevision(addon, extensionsv1alpha1.AddonRevisionSucceeded, "")
+			}
+			Expect(addon.Status.History).Should(HaveLen(maxAddonRevisions))
+			Expect(addon.Status.History[0].Revision).Should(BeEquivalentTo(3))
+			last := addon.Status.History[maxAddonRevisions-1]
+			Expect(last.Revision).Should(BeEquivalentTo(maxAddonRevisions + 2))
+			Expect(last.Version).Should(Equal("1.0.0"))
+			Expect(last.Helm).Should(Equal(addon.Spec.Helm))
+		})
+
+		It("records a failure once", func() {
+			addon := newAddon("1.0.0")
+			Expect(appendAddonRevision(addon, extensionsv1alpha1.AddonRevisionFailed, "failed")).Should(BeTrue())
+			Expect(appendAddonRevision(addon, extensionsv1alpha1.AddonRevisionFailed, "failed again")).Should(BeFalse())
+			Expect(addon.Status.History).Should(HaveLen(1))
+			Expect(addon.Status.History[0].Message).Should(Equal("failed"))
+
+			By("recording the failure of the changed values")
+			addon.Spec.Helm.ChartLocationURL = "https://charts/mysql-1.0.1.tgz"
+			Expect(appendAddonRevision(addon, extensionsv1alpha1.AddonRevisionFailed, "failed")).Should(BeTrue())
+			Expect(addon.Status.History).Should(HaveLen(2))
+
+			By("recording the success after the failure")
+			Expect(appendAddonRevision(addon, extensionsv1alpha1.AddonRevisionSucceeded, "")).Should(BeTrue())
+			Expect(appendAddonRevision(addon, extensionsv1alpha1.AddonRevisionFailed, "failed")).Should(BeTrue())
+			Expect(addon.Status.History).Should(HaveLen(4))
+			Expect(addon.Status.History[3].Revision).Should(BeEquivalentTo(4))
+		})
+
+		It("finds the revision to roll back to", func() {
+			Expect(addonRollbackRevision(newAddon("1.0.0"))).Should(BeNil())
+			Expect(addonRollbackRevision(newAddon("1.0.0",
+				revision(1, "1.0.0", extensionsv1alpha1.AddonRevisionSucceeded)))).Should(BeNil())
+
+			// the latest revision succeeded
+			addon := newAddon("1.1.0",
+				revision(1, "1.0.0", extensionsv1alpha1.AddonRevisionSucceeded),
+				revision(2, "1.1.0", extensionsv1alpha1.AddonRevisionSucceeded))
+			Expect(addonRollbackRevision(addon).Revision).Should(BeEquivalentTo(1))
+
+			// the latest revision failed
+			addon = newAddon("1.2.0",
+				revision(1, "1.0.0", extensionsv1alpha1.AddonRevisionSucceeded),
+				revision(2, "1.1.0", extensionsv1alpha1.AddonRevisionSucceeded),
+				revision(3, "1.2.0", extensionsv1alpha1.AddonRevisionFailed))
+			Expect(addonRollbackRevision(addon).Revision).Should(BeEquivalentTo(2))
+		})
+	})
+
+	Context("compatibility", func() {
+		newClient := func(objs ...client.Object) client.Client {
+			scheme := runtime.NewScheme()
+			Expect(appsv1.AddToScheme(scheme)).Should(Succeed())
+			return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+		}
+
+		newComp := func(name, compDef, serviceVersion string) *appsv1.Component {
+			return &appsv1.Component{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      name,
+					Labels: map[string]string{
+						constant.ComponentDefinitionLabelKey: compDef,
+					},
+				},
+				Spec: appsv1.ComponentSpec{
+					CompDef:        compDef,
+					ServiceVersion: serviceVersion,
+				},
+			}
+		}
+
+		providedBy := metav1.ObjectMeta{
+			Annotations: map[string]string{
+				helmReleaseNameAnnotationKey: "kb-addon-mysql",
+			},
+		}
+
+		It("compares versions", func() {
+			Expect(isAddonVersionUpgraded("1.0.0", "1.0.0")).Should(BeFalse())
+			Expect(isAddonVersionUpgraded("1.0.0", "1.1.0")).Should(BeTrue())
+			Expect(isAddonVersionUpgraded("1.1.0", "1.0.0")).Should(BeFalse())
+			Expect(isAddonVersionUpgraded("1.0.0", "latest")).Should(BeTrue())
+		})
+
+		It("checks the version upgraded from", func() {
+			addon := newAddon("1.1.0", revision(1, "0.8.0", extensionsv1alpha1.AddonRevisionSucceeded))
+			addon.Spec.Upgrade = &extensionsv1alpha1.AddonUpgradeSpec{
+				FromVersion: ">= 0.9.0",
+			}
+			cli := newClient()
+			Expect(checkAddonUpgradeCompatibility(ctx, cli, addon)).ShouldNot(Succeed())
+
+			addon.Status.History = append(addon.Status.History, revision(2, "0.9.1", extensionsv1alpha1.AddonRevisionSucceeded))
+			Expect(checkAddonUpgradeCompatibility(ctx, cli, addon)).Should(Succeed())
+
+			// roll back to a lower version
+			addon.Spec.Version = "0.8.0"
+			Expect(checkAddonUpgradeCompatibility(ctx, cli, addon)).Should(Succeed())
+		})
+
+		It("checks the service versions in use", func() {
+			compDef := &appsv1.ComponentDefinition{ObjectMeta: *providedBy.DeepCopy()}
+			compDef.Name = "mysql-8.0-1.0.0"
+			compVersion := &appsv1.ComponentVersion{ObjectMeta: *providedBy.DeepCopy()}
+			compVersion.Name = "mysql"
+			compVersion.Spec.CompatibilityRules = []appsv1.ComponentVersionCompatibilityRule{
+				{CompDefs: []string{"mysql-5.7-"}},
+			}
+			cli := newClient(compDef, compVersion,
+				newComp("c1", "mysql-8.0-1.0.0", "8.0.33"),
+				newComp("c2", "mysql-5.7-1.0.0", "5.7.44"),
+				newComp("c3", "postgresql-1.0.0", "14.8.0"))
+
+			addon := newAddon("1.1.0", revision(1, "1.0.0", extensionsv1alpha1.AddonRevisionSucceeded))
+			comps, err := addonComponentsInUse(ctx, cli, addon)
+			Expect(err).Should(BeNil())
+			Expect(comps).Should(HaveLen(2))
+
+			addon.Spec.Upgrade = &extensionsv1alpha1.AddonUpgradeSpec{
+				ServiceVersions: []string{"8.0.33", "8.0.36"},
+			}
+			err = checkAddonUpgradeCompatibility(ctx, cli, addon)
+			Expect(err).ShouldNot(BeNil())
+			Expect(err.Error()).Should(ContainSubstring("default/c2(5.7.44)"))
+			Expect(err.Error()).ShouldNot(ContainSubstring("c1"))
+
+			addon.Spec.Upgrade.ServiceVersions = append(addon.Spec.Upgrade.ServiceVersions, "5.7.44")
+			Expect(checkAddonUpgradeCompatibility(ctx, cli, addon)).Should(Succeed())
+		})
+	})
+})
@@ -29,6 +29,7 @@ const (
 	NoDeleteJobs         = "extensions.kubeblocks.io/no-delete-jobs"
 	AddonDefaultIsEmpty  = "addons.extensions.kubeblocks.io/default-is-empty"
 	KBVersionValidate    = "addon.kubeblocks.io/kubeblocks-version"
+	AddonRollback        = "extensions.kubeblocks.io/rollback"
 
 	// label keys
 	AddonProvider = "addon.kubeblocks.io/provider"
@@ -38,6 +39,8 @@ const (
 	AddonDisabled = "AddonDisabled"
 	AddonEnabled  = "AddonEnabled"
 
+	AddonDependenciesSatisfied = "DependenciesSatisfied"
+
 	// event reasons
 	InstallableCheckSkipped         = "InstallableCheckSkipped"
 	InstallableRequirementUnmatched = "InstallableRequirementUnmatched"
@@ -51,6 +54,11 @@ const (
 	UninstallationFailedLogs        = "UninstallationFailedLogs"
 	AddonRefObjError                = "ReferenceObjectError"
 	AddonCheckError                 = "AddonCheckError"
+	AddonDependencyUnsatisfied      = "DependencyUnsatisfied"
+	AddonRequiredByOthers           = "RequiredByOtherAddons"
+	AddonUpgradeIncompatible        = "UpgradeIncompatible"
+	AddonRollingBack                = "RollingBack"
+	AddonRollbackFailed             = "RollbackFailed"
 
 	// config keys used in viper
 	maxConcurrentReconcilesKey = "MAXCONCURRENTRECONCILES_ADDON"
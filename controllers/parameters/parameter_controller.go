@@ -105,6 +105,13 @@ func (r *ParameterReconciler) handleComponent(rctx *ReconcileContext, updatedPar
 		updateParameters,
 		updateComponentParameterStatus(configmaps),
 	}
+	if parameter.Spec.DryRun {
+		handles = []reconfigureReconcileHandle{
+			prepareResources,
+			classifyParameters(updatedParameters, configmaps),
+			dryRunParameters(configmaps),
+		}
+	}
 
 	for _, handle := range handles {
 		if err := handle(rctx, parameter); err != nil {
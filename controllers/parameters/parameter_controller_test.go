@@ -133,6 +133,59 @@ var _ = Describe("Parameter Controller", func() {
 		})
 	})
 
+	Context("parameter dry-run", func() {
+		It("Should preview the changes without applying them", func() {
+			prepareTestEnv()
+
+			By("submit the dry-run parameter update request")
+			key := testapps.GetRandomizedKey(comp.Namespace, comp.FullCompName)
+			parameterObj := testparameters.NewParameterFactory(key.Name, key.Namespace, comp.ClusterName, comp.Name).
+				AddParameters("max_connections", "100").
+				SetDryRun(true).
+				Create(&testCtx).
+				GetObject()
+
+			By("check parameter status")
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(parameterObj), func(g Gomega, parameter *parametersv1alpha1.Parameter) {
+				g.Expect(parameter.Status.Phase).Should(BeEquivalentTo(parametersv1alpha1.CFinishedPhase))
+				compStatus := parameters.GetParameterStatus(&parameter.Status, comp.Name)
+				g.Expect(compStatus).ShouldNot(BeNil())
+				status := parameters.GetParameterReconfiguringStatus(compStatus, configSpecName)
+				g.Expect(status).ShouldNot(BeNil())
+				g.Expect(status.DryRun).ShouldNot(BeNil())
+				g.Expect(status.DryRun.Diff).Should(HaveLen(1))
+				g.Expect(status.DryRun.Diff[0].FileName).Should(BeEquivalentTo(testparameters.MysqlConfigFile))
+				g.Expect(status.DryRun.Diff[0].Type).Should(BeEquivalentTo(parametersv1alpha1.ConfigFileUpdated))
+				g.Expect(status.DryRun.Diff[0].Patch).Should(ContainSubstring("max_connections"))
+			})).Should(Succeed())
+
+			By("check the component parameter is not changed")
+			Consistently(testapps.CheckObj(&testCtx, compParamKey, func(g Gomega, compParameter *parametersv1alpha1.ComponentParameter) {
+				g.Expect(compParameter.Status.ObservedGeneration).Should(BeEquivalentTo(int64(1)))
+				item := parameters.GetConfigTemplateItem(&compParameter.Spec, configSpecName)
+				g.Expect(item).ShouldNot(BeNil())
+				g.Expect(item.ConfigFileParams[testparameters.MysqlConfigFile].Parameters).ShouldNot(HaveKey("max_connections"))
+			})).Should(Succeed())
+		})
+
+		It("parameters validate fails", func() {
+			prepareTestEnv()
+
+			By("submit the dry-run parameter update request with invalid max_connection")
+			key := testapps.GetRandomizedKey(comp.Namespace, comp.FullCompName)
+			parameterObj := testparameters.NewParameterFactory(key.Name, key.Namespace, comp.ClusterName, comp.Name).
+				AddParameters("max_connections", "-100").
+				SetDryRun(true).
+				Create(&testCtx).
+				GetObject()
+
+			By("check parameter status")
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(parameterObj), func(g Gomega, parameter *parametersv1alpha1.Parameter) {
+				g.Expect(parameter.Status.Phase).Should(BeEquivalentTo(parametersv1alpha1.CMergeFailedPhase))
+			})).Should(Succeed())
+		})
+	})
+
 	Context("custom template update", func() {
 		It("update user template", func() {
 			prepareTestEnv()
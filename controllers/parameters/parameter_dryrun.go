@@ -0,0 +1,168 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package parameters
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/parameters"
+	cfgcm "github.com/apecloud/kubeblocks/pkg/parameters/configmanager"
+	"github.com/apecloud/kubeblocks/pkg/parameters/core"
+)
+
+// dryRunParameters previews the impact of the classified parameters on each configuration template of the component,
+// the merged configuration is only used to compute the diff and reload policies, nothing is applied.
+func dryRunParameters(configmaps map[string]*corev1.ConfigMap) func(*ReconcileContext, *parametersv1alpha1.Parameter) error {
+	return func(rctx *ReconcileContext, parameter *parametersv1alpha1.Parameter) error {
+		compStatus := parameters.GetParameterStatus(&parameter.Status, rctx.ComponentName)
+		if compStatus == nil || parameters.IsParameterFinished(compStatus.Phase) {
+			return nil
+		}
+		for i := range compStatus.ParameterStatus {
+			status := &compStatus.ParameterStatus[i]
+			result, err := dryRunConfigTemplate(rctx, status, configmaps[status.Name])
+			if err != nil {
+				status.Phase = parametersv1alpha1.CMergeFailedPhase
+				compStatus.Phase = parametersv1alpha1.CMergeFailedPhase
+				return err
+			}
+			status.DryRun = result
+			status.Phase = parametersv1alpha1.CFinishedPhase
+		}
+		compStatus.Phase = parametersv1alpha1.CFinishedPhase
+		return nil
+	}
+}
+
+func dryRunConfigTemplate(rctx *ReconcileContext, status *parametersv1alpha1.ReconfiguringStatus, cm *corev1.ConfigMap) (*parametersv1alpha1.ReconfiguringDryRunResult, error) {
+	templateSpec := resolveComponentConfigTemplate(rctx.ComponentDefObj, status.Name)
+	if templateSpec == nil || cm == nil {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf("not found config template or configmap for template: %s", status.Name))
+	}
+
+	configDescs := parameters.GetComponentConfigDescriptions(&rctx.ConfigRender.Spec, status.Name)
+	updated, err := parameters.DoMerge(maps.Clone(cm.Data), status.UpdatedParameters, toArray(rctx.ParametersDefs), configDescs)
+	if err != nil {
+		return nil, intctrlutil.NewFatalError(err.Error())
+	}
+	patch, restart, err := core.CreateConfigPatch(cm.Data, updated, rctx.ConfigRender.Spec, true)
+	if err != nil {
+		return nil, intctrlutil.NewFatalError(err.Error())
+	}
+	if !restart {
+		restart = cfgcm.NeedRestart(rctx.ParametersDefs, patch)
+	}
+
+	result := &parametersv1alpha1.ReconfiguringDryRunResult{
+		Diff: buildConfigFileDiff(patch),
+	}
+	// No parameters updated, the reconfigure controller skips the reconfiguring.
+	if !patch.IsModify && !restart {
+		return result, nil
+	}
+
+	// resolve the workloads that mount the configmap, as the reconfigure controller does.
+	rctx.ConfigMap = cm
+	rctx.Name = status.Name
+	rctx.MatchingLabels = constant.GetCompLabels(rctx.ClusterName, rctx.ComponentName)
+	if err = rctx.ComponentSpec().Workload().Complete(); err != nil {
+		return nil, err
+	}
+	if len(rctx.InstanceSetList) == 0 {
+		return result, nil
+	}
+
+	tasks, err := genReconfigureActionTasks(templateSpec, rctx, patch, restart)
+	if err != nil {
+		return nil, intctrlutil.NewFatalError(err.Error())
+	}
+	for _, task := range tasks {
+		if policy := parametersv1alpha1.ReloadPolicy(task.ReloadType()); !slices.Contains(result.Policies, policy) {
+			result.Policies = append(result.Policies, policy)
+		}
+	}
+
+	pods, err := resolveAffectedPods(rctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods {
+		result.AffectedPods = append(result.AffectedPods, pod.Name)
+	}
+	return result, nil
+}
+
+func resolveComponentConfigTemplate(compDef *appsv1.ComponentDefinition, tpl string) *appsv1.ComponentFileTemplate {
+	if compDef == nil {
+		return nil
+	}
+	for i, config := range compDef.Spec.Configs {
+		if config.Name == tpl {
+			return &compDef.Spec.Configs[i]
+		}
+	}
+	return nil
+}
+
+func resolveAffectedPods(rctx *ReconcileContext) ([]corev1.Pod, error) {
+	var pods []corev1.Pod
+	for i := range rctx.InstanceSetList {
+		podList, err := intctrlutil.GetPodListByInstanceSet(rctx.Ctx, rctx.Client, &rctx.InstanceSetList[i])
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, podList...)
+	}
+	if rctx.BuiltinComponent != nil {
+		instanceset.SortPods(pods, instanceset.ComposeRolePriorityMap(rctx.BuiltinComponent.Roles), true)
+	}
+	return pods, nil
+}
+
+func buildConfigFileDiff(patch *core.ConfigPatchInfo) []parametersv1alpha1.ConfigFileDiff {
+	if patch == nil {
+		return nil
+	}
+
+	var diff []parametersv1alpha1.ConfigFileDiff
+	for _, file := range slices.Sorted(maps.Keys(patch.AddConfig)) {
+		diff = append(diff, parametersv1alpha1.ConfigFileDiff{FileName: file, Type: parametersv1alpha1.ConfigFileAdded})
+	}
+	for _, file := range slices.Sorted(maps.Keys(patch.DeleteConfig)) {
+		diff = append(diff, parametersv1alpha1.ConfigFileDiff{FileName: file, Type: parametersv1alpha1.ConfigFileDeleted})
+	}
+	for _, file := range slices.Sorted(maps.Keys(patch.UpdateConfig)) {
+		diff = append(diff, parametersv1alpha1.ConfigFileDiff{
+			FileName: file,
+			Type:     parametersv1alpha1.ConfigFileUpdated,
+			Patch:    string(patch.UpdateConfig[file]),
+		})
+	}
+	return diff
+}
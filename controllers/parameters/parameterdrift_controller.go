@@ -0,0 +1,458 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package parameters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/render"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/parameters"
+	"github.com/apecloud/kubeblocks/pkg/parameters/core"
+	"github.com/apecloud/kubeblocks/pkg/parameters/openapi"
+)
+
+const (
+	defaultDriftDetectionPeriodSeconds = 300
+
+	reasonParametersDrifted        = "Drifted"
+	reasonParametersNotDrifted     = "NotDrifted"
+	reasonParametersDetectFailed   = "DetectFailed"
+	reasonParametersDriftRepaired  = "DriftRepaired"
+	reasonParametersRepairFailed   = "DriftRepairFailed"
+	reasonParameterQueryNotDefined = "ActionNotDefined"
+)
+
+// ParameterDriftReconciler periodically detects the drift of the effective parameters of the instances
+// from the desired configuration of a ComponentParameter.
+type ParameterDriftReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=parameters.kubeblocks.io,resources=componentparameters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=parameters.kubeblocks.io,resources=componentparameters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+
+func (r *ParameterDriftReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Recorder: r.Recorder,
+		Log: log.FromContext(ctx).
+			WithName("ParameterDriftReconciler").
+			WithValues("Namespace", req.Namespace, "ComponentParameter", req.Name),
+	}
+
+	compParam := &parametersv1alpha1.ComponentParameter{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, compParam); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if model.IsObjectDeleting(compParam) {
+		return intctrlutil.Reconciled()
+	}
+
+	detection := compParam.Spec.DriftDetection
+	if detection == nil {
+		if err := r.updateDriftStatus(reqCtx, compParam, nil, nil); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		return intctrlutil.Reconciled()
+	}
+
+	period := time.Duration(defaultDriftDetectionPeriodSeconds) * time.Second
+	if detection.PeriodSeconds > 0 {
+		period = time.Duration(detection.PeriodSeconds) * time.Second
+	}
+	// the reconfiguring is in progress, the effective parameters are expected to differ from the desired ones.
+	if compParam.Status.Phase != parametersv1alpha1.CFinishedPhase {
+		return intctrlutil.RequeueAfter(period, reqCtx.Log, "reconfiguring is in progress")
+	}
+
+	desired := desiredParameters(compParam)
+	if len(desired) == 0 {
+		if err := r.updateDriftStatus(reqCtx, compParam, nil, nil); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		return intctrlutil.RequeueAfter(period, reqCtx.Log, "")
+	}
+
+	drifts, cond, err := r.detect(reqCtx, compParam, desired)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if err = r.updateDriftStatus(reqCtx, compParam, drifts, cond); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.RequeueAfter(period, reqCtx.Log, "")
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ParameterDriftReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewControllerManagedBy(mgr).
+		Named("componentparameter-drift").
+		For(&parametersv1alpha1.ComponentParameter{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+func (r *ParameterDriftReconciler) detect(reqCtx intctrlutil.RequestCtx,
+	compParam *parametersv1alpha1.ComponentParameter,
+	desired []parametersv1alpha1.ParameterDrift) ([]parametersv1alpha1.InstanceParameterDrift, *metav1.Condition, error) {
+	rctx := newParameterReconcileContext(reqCtx,
+		&render.ResourceCtx{
+			Context:       reqCtx.Ctx,
+			Client:        r.Client,
+			Namespace:     compParam.Namespace,
+			ClusterName:   compParam.Spec.ClusterName,
+			ComponentName: compParam.Spec.ComponentName,
+		}, nil, nil, "", nil)
+	if err := rctx.Cluster().
+		ComponentAndComponentDef().
+		SynthesizedComponent().
+		ParametersDefinitions().
+		Complete(); err != nil {
+		return nil, nil, err
+	}
+
+	synthesizedComp := rctx.BuiltinComponent
+	if synthesizedComp.LifecycleActions == nil || !synthesizedComp.LifecycleActions.ParameterQuery.Defined() {
+		return nil, &metav1.Condition{
+			Type:    parametersv1alpha1.ParametersDriftedConditionType,
+			Status:  metav1.ConditionUnknown,
+			Reason:  reasonParameterQueryNotDefined,
+			Message: "the parameterQuery action is not defined by the ComponentDefinition",
+		}, nil
+	}
+
+	pods, err := component.ListOwnedPods(reqCtx.Ctx, r.Client, compParam.Namespace, compParam.Spec.ClusterName, compParam.Spec.ComponentName)
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+
+	var drifts []parametersv1alpha1.InstanceParameterDrift
+	for _, pod := range pods {
+		if !intctrlutil.IsPodReady(pod) {
+			continue
+		}
+		drift := r.detectInstance(rctx, pod, desired)
+		if drift == nil {
+			continue
+		}
+		if compParam.Spec.DriftDetection.AutoRepair && len(drift.Parameters) > 0 {
+			r.repairInstance(rctx, compParam, pod, drift.Parameters)
+		}
+		drifts = append(drifts, *drift)
+	}
+	return drifts, buildParametersDriftedCondition(drifts), nil
+}
+
+func (r *ParameterDriftReconciler) detectInstance(rctx *ReconcileContext, pod *corev1.Pod, desired []parametersv1alpha1.ParameterDrift) *parametersv1alpha1.InstanceParameterDrift {
+	synthesizedComp := rctx.BuiltinComponent
+	drift := &parametersv1alpha1.InstanceParameterDrift{
+		Name:           pod.Name,
+		LastDetectTime: metav1.Now(),
+	}
+
+	queried := make(map[string][]string)
+	for _, param := range desired {
+		queried[param.FileName] = append(queried[param.FileName], param.Name)
+	}
+	lfa, err := lifecycle.New(synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name,
+		synthesizedComp.LifecycleActions, synthesizedComp.TemplateVars, pod)
+	if err != nil {
+		drift.Message = err.Error()
+		return drift
+	}
+	output, err := lfa.ParameterQuery(rctx.Ctx, r.Client, nil, queried)
+	if err != nil {
+		drift.Message = err.Error()
+		return drift
+	}
+	effective := make(map[string]map[string]string)
+	if err = json.Unmarshal(output, &effective); err != nil {
+		drift.Message = fmt.Sprintf("the output of the parameterQuery action is invalid: %s", err.Error())
+		return drift
+	}
+
+	drift.Parameters = compareParameters(desired, effective, parameterSchemas(rctx.ParametersDefs))
+	if len(drift.Parameters) == 0 {
+		return nil
+	}
+	return drift
+}
+
+func (r *ParameterDriftReconciler) repairInstance(rctx *ReconcileContext,
+	compParam *parametersv1alpha1.ComponentParameter, pod *corev1.Pod, drifted []parametersv1alpha1.ParameterDrift) {
+	type configFile struct {
+		template string
+		file     string
+	}
+	updated := make(map[configFile]map[string]string)
+	for _, param := range drifted {
+		pd, ok := rctx.ParametersDefs[param.FileName]
+		if !ok || param.Expected == nil || !enableSyncTrigger(pd.Spec.ReloadAction) || !core.IsDynamicParameter(param.Name, &pd.Spec) {
+			continue
+		}
+		key := configFile{template: param.TemplateName, file: param.FileName}
+		if updated[key] == nil {
+			updated[key] = make(map[string]string)
+		}
+		updated[key][param.Name] = *param.Expected
+	}
+
+	for key, params := range updated {
+		err := commonOnlineUpdateWithPod(pod, rctx.Ctx, getClientFactory(), key.template, key.file, params)
+		if err != nil {
+			r.Recorder.Eventf(compParam, corev1.EventTypeWarning, reasonParametersRepairFailed,
+				"failed to re-apply the parameters of file %s to pod %s: %s", key.file, pod.Name, err.Error())
+			continue
+		}
+		r.Recorder.Eventf(compParam, corev1.EventTypeNormal, reasonParametersDriftRepaired,
+			"the parameters of file %s have been re-applied to pod %s", key.file, pod.Name)
+	}
+}
+
+func (r *ParameterDriftReconciler) updateDriftStatus(reqCtx intctrlutil.RequestCtx, compParam *parametersv1alpha1.ComponentParameter,
+	drifts []parametersv1alpha1.InstanceParameterDrift, cond *metav1.Condition) error {
+	origin := compParam.DeepCopy()
+	compParam.Status.InstanceDrifts = drifts
+	if cond == nil {
+		apimeta.RemoveStatusCondition(&compParam.Status.Conditions, parametersv1alpha1.ParametersDriftedConditionType)
+	} else {
+		cond.ObservedGeneration = compParam.Generation
+		apimeta.SetStatusCondition(&compParam.Status.Conditions, *cond)
+	}
+	if reflect.DeepEqual(origin.Status, compParam.Status) {
+		return nil
+	}
+	patch := client.MergeFrom(origin)
+	if err := r.Client.Status().Patch(reqCtx.Ctx, compParam, patch); err != nil {
+		return errors.Wrap(err, "failed to update the drift status of componentParameter")
+	}
+	return nil
+}
+
+// desiredParameters returns the parameters which are explicitly specified in the ComponentParameter.
+func desiredParameters(compParam *parametersv1alpha1.ComponentParameter) []parametersv1alpha1.ParameterDrift {
+	var desired []parametersv1alpha1.ParameterDrift
+	for _, item := range compParam.Spec.ConfigItemDetails {
+		for file, params := range item.ConfigFileParams {
+			for name, value := range params.Parameters {
+				if value == nil {
+					continue
+				}
+				desired = append(desired, parametersv1alpha1.ParameterDrift{
+					TemplateName: item.Name,
+					FileName:     file,
+					Name:         name,
+					Expected:     value,
+				})
+			}
+		}
+	}
+	sort.Slice(desired, func(i, j int) bool {
+		if desired[i].FileName != desired[j].FileName {
+			return desired[i].FileName < desired[j].FileName
+		}
+		return desired[i].Name < desired[j].Name
+	})
+	return desired
+}
+
+// parameterSchemas returns the flattened schemas of the parameters, keyed by the config file name.
+func parameterSchemas(paramsDefs map[string]*parametersv1alpha1.ParametersDefinition) map[string]map[string]apiextv1.JSONSchemaProps {
+	schemas := make(map[string]map[string]apiextv1.JSONSchemaProps)
+	for file, paramsDef := range paramsDefs {
+		if paramsDef.Spec.ParametersSchema == nil || paramsDef.Spec.ParametersSchema.SchemaInJSON == nil {
+			continue
+		}
+		schema, ok := paramsDef.Spec.ParametersSchema.SchemaInJSON.Properties[openapi.DefaultSchemaName]
+		if !ok {
+			continue
+		}
+		schemas[file] = openapi.FlattenSchema(schema).Properties
+	}
+	return schemas
+}
+
+func compareParameters(desired []parametersv1alpha1.ParameterDrift, effective map[string]map[string]string,
+	schemas map[string]map[string]apiextv1.JSONSchemaProps) []parametersv1alpha1.ParameterDrift {
+	var drifted []parametersv1alpha1.ParameterDrift
+	for _, param := range desired {
+		drift := param
+		actual, ok := effective[param.FileName][param.Name]
+		if ok {
+			drift.Actual = &actual
+		}
+		schema, found := parameters.FindParameterSchema(schemas[param.FileName], param.Name)
+		if !found {
+			schema = apiextv1.JSONSchemaProps{}
+		}
+		if !ok || !equalParameterValues(&schema, actual, *param.Expected) {
+			drifted = append(drifted, drift)
+		}
+	}
+	return drifted
+}
+
+// equalParameterValues checks whether two values of a parameter are equivalent according to its type in the schema,
+// the numbers are compared with the size units resolved, such as 1G and 1073741824,
+// and the booleans are compared with the different literals resolved, such as ON and 1.
+func equalParameterValues(schema *apiextv1.JSONSchemaProps, v1, v2 string) bool {
+	v1, v2 = normalizeParameterValue(v1), normalizeParameterValue(v2)
+	if v1 == v2 {
+		return true
+	}
+	switch {
+	case schema.Type == "integer" || schema.Type == "number":
+		n1, err1 := parseParameterNumber(v1)
+		n2, err2 := parseParameterNumber(v2)
+		return err1 == nil && err2 == nil && n1 == n2
+	case schema.Type == "boolean" || isBooleanEnum(schema):
+		b1, ok1 := parseParameterBool(v1)
+		b2, ok2 := parseParameterBool(v2)
+		return ok1 && ok2 && b1 == b2
+	}
+	return false
+}
+
+func normalizeParameterValue(v string) string {
+	return strings.ToLower(strings.Trim(strings.TrimSpace(v), `"'`))
+}
+
+// parameterSizeUnits are the multipliers of the size units used by the database parameters, which are 1024-based.
+var parameterSizeUnits = map[string]float64{
+	"b": 1,
+	"k": 1 << 10,
+	"m": 1 << 20,
+	"g": 1 << 30,
+	"t": 1 << 40,
+	"p": 1 << 50,
+}
+
+// parseParameterNumber parses a normalized number value, with an optional size unit, such as 128M, 1gb or 2Gi.
+func parseParameterNumber(v string) (float64, error) {
+	if n, err := strconv.ParseFloat(v, 64); err == nil {
+		return n, nil
+	}
+	i := strings.IndexFunc(v, unicode.IsLetter)
+	if i <= 0 {
+		return 0, fmt.Errorf("invalid number: %s", v)
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(v[:i]), 64)
+	if err != nil {
+		return 0, err
+	}
+	unit := strings.TrimSuffix(strings.TrimSuffix(v[i:], "b"), "i")
+	if unit == "" {
+		unit = "b"
+	}
+	multiplier, ok := parameterSizeUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit of number: %s", v)
+	}
+	return n * multiplier, nil
+}
+
+// parseParameterBool parses a normalized boolean value.
+func parseParameterBool(v string) (bool, bool) {
+	switch v {
+	case "true", "on", "yes", "1":
+		return true, true
+	case "false", "off", "no", "0":
+		return false, true
+	}
+	return false, false
+}
+
+// isBooleanEnum checks whether the parameter is a string enumerating the boolean literals, such as ON and OFF.
+func isBooleanEnum(schema *apiextv1.JSONSchemaProps) bool {
+	if schema.Type != "string" || len(schema.Enum) == 0 {
+		return false
+	}
+	for _, e := range schema.Enum {
+		var v string
+		if err := json.Unmarshal(e.Raw, &v); err != nil {
+			return false
+		}
+		if _, ok := parseParameterBool(normalizeParameterValue(v)); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func buildParametersDriftedCondition(drifts []parametersv1alpha1.InstanceParameterDrift) *metav1.Condition {
+	var drifted, failed []string
+	for _, drift := range drifts {
+		if len(drift.Parameters) > 0 {
+			drifted = append(drifted, drift.Name)
+		} else {
+			failed = append(failed, drift.Name)
+		}
+	}
+
+	cond := &metav1.Condition{
+		Type: parametersv1alpha1.ParametersDriftedConditionType,
+	}
+	switch {
+	case len(drifted) > 0:
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = reasonParametersDrifted
+		cond.Message = fmt.Sprintf("the effective parameters of instances drift from the desired configuration: %s", strings.Join(drifted, ","))
+	case len(failed) > 0:
+		cond.Status = metav1.ConditionUnknown
+		cond.Reason = reasonParametersDetectFailed
+		cond.Message = fmt.Sprintf("failed to query the effective parameters of instances: %s", strings.Join(failed, ","))
+	default:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = reasonParametersNotDrifted
+	}
+	return cond
+}
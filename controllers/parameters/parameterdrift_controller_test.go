@@ -0,0 +1,182 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package parameters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	parametersv1alpha1 "github.com/apecloud/kubeblocks/apis/parameters/v1alpha1"
+)
+
+func TestDesiredParameters(t *testing.T) {
+	compParam := &parametersv1alpha1.ComponentParameter{
+		Spec: parametersv1alpha1.ComponentParameterSpec{
+			ConfigItemDetails: []parametersv1alpha1.ConfigTemplateItemDetail{{
+				Name: "mysql-config",
+				ConfigFileParams: map[string]parametersv1alpha1.ParametersInFile{
+					"my.cnf": {
+						Parameters: map[string]*string{
+							"max_connections":    pointer.String("1000"),
+							"innodb_io_capacity": pointer.String("200"),
+							"read_only":          nil,
+						},
+					},
+				},
+			}},
+		},
+	}
+
+	desired := desiredParameters(compParam)
+	assert.Equal(t, 2, len(desired))
+	assert.Equal(t, "innodb_io_capacity", desired[0].Name)
+	assert.Equal(t, "max_connections", desired[1].Name)
+	assert.Equal(t, "mysql-config", desired[1].TemplateName)
+	assert.Equal(t, "my.cnf", desired[1].FileName)
+	assert.Equal(t, "1000", *desired[1].Expected)
+}
+
+func TestCompareParameters(t *testing.T) {
+	desired := []parametersv1alpha1.ParameterDrift{
+		{FileName: "my.cnf", Name: "max_connections", Expected: pointer.String("1000")},
+		{FileName: "my.cnf", Name: "innodb_io_capacity", Expected: pointer.String("200")},
+		{FileName: "my.cnf", Name: "slow_query_log", Expected: pointer.String("ON")},
+	}
+
+	drifted := compareParameters(desired, map[string]map[string]string{
+		"my.cnf": {
+			"max_connections":    "1000",
+			"innodb_io_capacity": "400",
+			"slow_query_log":     `"on"`,
+		},
+	}, nil)
+	assert.Equal(t, 1, len(drifted))
+	assert.Equal(t, "innodb_io_capacity", drifted[0].Name)
+	assert.Equal(t, "400", *drifted[0].Actual)
+
+	drifted = compareParameters(desired, map[string]map[string]string{}, nil)
+	assert.Equal(t, 3, len(drifted))
+	assert.Nil(t, drifted[0].Actual)
+}
+
+func TestCompareParametersWithSchema(t *testing.T) {
+	schemas := map[string]map[string]apiextv1.JSONSchemaProps{
+		"my.cnf": {
+			"innodb_buffer_pool_size": {Type: "integer"},
+			"max_connections":         {Type: "integer"},
+			"long_query_time":         {Type: "number"},
+			"slow_query_log":          {Type: "string", Enum: []apiextv1.JSON{{Raw: []byte(`"ON"`)}, {Raw: []byte(`"OFF"`)}}},
+			"general_log":             {Type: "boolean"},
+			"sql_mode":                {Type: "string", Enum: []apiextv1.JSON{{Raw: []byte(`"ANSI"`)}, {Raw: []byte(`"TRADITIONAL"`)}}},
+			"log_bin":                 {Type: "string"},
+		},
+	}
+	desired := []parametersv1alpha1.ParameterDrift{
+		{FileName: "my.cnf", Name: "innodb_buffer_pool_size", Expected: pointer.String("1G")},
+		{FileName: "my.cnf", Name: "max_connections", Expected: pointer.String("1000")},
+		{FileName: "my.cnf", Name: "long_query_time", Expected: pointer.String("1.5")},
+		{FileName: "my.cnf", Name: "slow_query_log", Expected: pointer.String("ON")},
+		{FileName: "my.cnf", Name: "general_log", Expected: pointer.String("off")},
+		{FileName: "my.cnf", Name: "sql_mode", Expected: pointer.String("ANSI")},
+		{FileName: "my.cnf", Name: "log_bin", Expected: pointer.String("ON")},
+	}
+
+	drifted := compareParameters(desired, map[string]map[string]string{
+		"my.cnf": {
+			"innodb_buffer_pool_size": "1073741824",
+			"max_connections":         "1000",
+			"long_query_time":         "1.500000",
+			"slow_query_log":          "1",
+			"general_log":             "0",
+			"sql_mode":                "ansi",
+			"log_bin":                 "1",
+		},
+	}, schemas)
+	// the string parameter which is not a boolean enum is compared literally
+	assert.Equal(t, 1, len(drifted))
+	assert.Equal(t, "log_bin", drifted[0].Name)
+
+	drifted = compareParameters(desired, map[string]map[string]string{
+		"my.cnf": {
+			"innodb_buffer_pool_size": "512M",
+			"max_connections":         "1000K",
+			"long_query_time":         "1.5",
+			"slow_query_log":          "OFF",
+			"general_log":             "true",
+			"sql_mode":                "TRADITIONAL",
+			"log_bin":                 "ON",
+		},
+	}, schemas)
+	var names []string
+	for _, drift := range drifted {
+		names = append(names, drift.Name)
+	}
+	assert.Equal(t, []string{"innodb_buffer_pool_size", "max_connections", "slow_query_log", "general_log", "sql_mode"}, names)
+}
+
+func TestParseParameterNumber(t *testing.T) {
+	for v, expected := range map[string]float64{
+		"1024": 1024,
+		"1.5":  1.5,
+		"1e3":  1000,
+		"512b": 512,
+		"8k":   8 << 10,
+		"8kb":  8 << 10,
+		"128m": 128 << 20,
+		"1g":   1 << 30,
+		"2gi":  2 << 30,
+		"1gib": 1 << 30,
+		"1 t":  1 << 40,
+		"1p":   1 << 50,
+	} {
+		n, err := parseParameterNumber(v)
+		assert.NoError(t, err, v)
+		assert.Equal(t, expected, n, v)
+	}
+	for _, v := range []string{"", "g", "1x", "10ms", "abc"} {
+		_, err := parseParameterNumber(v)
+		assert.Error(t, err, v)
+	}
+}
+
+func TestBuildParametersDriftedCondition(t *testing.T) {
+	cond := buildParametersDriftedCondition(nil)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, reasonParametersNotDrifted, cond.Reason)
+
+	cond = buildParametersDriftedCondition([]parametersv1alpha1.InstanceParameterDrift{
+		{Name: "pod-0", Message: "timeout"},
+	})
+	assert.Equal(t, metav1.ConditionUnknown, cond.Status)
+	assert.Equal(t, reasonParametersDetectFailed, cond.Reason)
+
+	cond = buildParametersDriftedCondition([]parametersv1alpha1.InstanceParameterDrift{
+		{Name: "pod-0", Message: "timeout"},
+		{Name: "pod-1", Parameters: []parametersv1alpha1.ParameterDrift{{FileName: "my.cnf", Name: "max_connections"}}},
+	})
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, reasonParametersDrifted, cond.Reason)
+	assert.Contains(t, cond.Message, "pod-1")
+	assert.NotContains(t, cond.Message, "pod-0")
+}
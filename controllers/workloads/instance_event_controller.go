@@ -142,6 +142,9 @@ func (r *InstanceEventReconciler) handleRoleChangedEvent(ctx context.Context, lo
 
 func (r *InstanceEventReconciler) updatePodRoleLabel(ctx context.Context, pod *corev1.Pod, roleName string) error {
 	newPod := pod.DeepCopy()
+	if !constant.AcceptProbedRole(newPod.Labels, roleName) {
+		return nil
+	}
 	if len(roleName) == 0 {
 		delete(newPod.Labels, constant.RoleLabelKey)
 	} else {
@@ -39,6 +39,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
 	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/tracing"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
@@ -48,7 +49,10 @@ type InstanceSetReconciler2 struct {
 	Recorder record.EventRecorder
 }
 
-func (r *InstanceSetReconciler2) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *InstanceSetReconciler2) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
+	ctx, span := tracing.StartReconcile(ctx, "InstanceSet", req)
+	defer func() { tracing.EndReconcile(span, res, err) }()
+
 	logger := log.FromContext(ctx).WithValues("InstanceSet2", req.NamespacedName)
 	return kubebuilderx.NewController(ctx, r.Client, req, r.Recorder, logger).
 		Prepare(instanceset2.NewTreeLoader()).
@@ -60,6 +60,7 @@ clusterRoles=(
     "dataprotection-exec-worker-role"
     "restore-editor-role"
     "nodecountscaler-editor-role"
+    "componentautoscaler-editor-role"
     "editor-role"
     "leader-election-role"
     "rbac-manager-role"
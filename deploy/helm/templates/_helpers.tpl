@@ -237,3 +237,32 @@ Define the replica count for kubeblocks.
 {{- define "kubeblocks.i18nResourcesName" -}}
 {{ include "kubeblocks.fullname" . }}-i18n-resources
 {{- end }}
+
+{{/*
+Render the env of the external secret store, empty if the provider is not set.
+*/}}
+{{- define "kubeblocks.secretStoreEnv" -}}
+{{- with .Values.secretStore }}
+{{- if .provider }}
+- name: SECRET_STORE_PROVIDER
+  value: {{ .provider | quote }}
+- name: SECRET_STORE_ADDRESS
+  value: {{ .address | quote }}
+- name: SECRET_STORE_MOUNT_PATH
+  value: {{ .mountPath | quote }}
+{{- with .podAuth }}
+- name: SECRET_STORE_AUTH_ROLE
+  value: {{ .role | quote }}
+- name: SECRET_STORE_AUTH_MOUNT_PATH
+  value: {{ .mountPath | quote }}
+{{- end }}
+{{- if and .tokenSecretKeyRef.name .tokenSecretKeyRef.key }}
+- name: SECRET_STORE_TOKEN
+  valueFrom:
+    secretKeyRef:
+      name: {{ .tokenSecretKeyRef.name }}
+      key: {{ .tokenSecretKeyRef.key }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- end }}
@@ -46,6 +46,14 @@ const (
 	// The mutation check is only applied to the fields that are declared as immutable.
 	SkipImmutableCheckAnnotationKey = "apps.kubeblocks.io/skip-immutable-check"
 
+	// TLSCertRotatedAtAnnotationKey records the time when the TLS certificates of a component were rotated last time.
+	// It is set on the TLS secret, and on the pod template if the replicas are restarted to load the new certificates.
+	TLSCertRotatedAtAnnotationKey = "apps.kubeblocks.io/tls-cert-rotated-at"
+
+	// SecretStorePathAnnotationKey refers to the path in the external secret store where the sensitive data of the secret is kept,
+	// the secret itself only keeps the references to the data.
+	SecretStorePathAnnotationKey = "apps.kubeblocks.io/secret-store-path"
+
 	// NodeSelectorOnceAnnotationKey adds nodeSelector in podSpec for one pod exactly once
 	NodeSelectorOnceAnnotationKey = "workloads.kubeblocks.io/node-selector-once"
 
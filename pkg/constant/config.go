@@ -55,6 +55,9 @@ const (
 	KBParameterUpdateSourceAnnotationKey        = "config.kubeblocks.io/reconfigure-source"
 	UpgradeRestartAnnotationKey                 = "config.kubeblocks.io/restart"
 	ConfigAppliedVersionAnnotationKey           = "config.kubeblocks.io/config-applied-version"
+
+	// ReconfigureDryRunAnnotationKey specifies whether a Reconfiguring OpsRequest only previews the impact of the changes.
+	ReconfigureDryRunAnnotationKey = "config.kubeblocks.io/dry-run"
 )
 
 const (
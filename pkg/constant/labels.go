@@ -53,8 +53,27 @@ const (
 	KBAppReleasePhaseKey   = "apps.kubeblocks.io/release-phase" // TODO: release or service phase?
 
 	RoleLabelKey = "kubeblocks.io/role"
+
+	// FencedRoleLabelKey is set on the replica fenced by the automated failover, with the role it held as the value.
+	// The replica doesn't get the role back from its role probe, until it comes back in another role.
+	FencedRoleLabelKey = "apps.kubeblocks.io/fenced-role"
 )
 
+// AcceptProbedRole tells whether the role probed from the replica can be set in its labels.
+// The replica fenced by the automated failover can't take the role it held back, to avoid split brain,
+// and it's unfenced by removing the FencedRoleLabelKey from the labels once it comes back in another role.
+func AcceptProbedRole(labels map[string]string, role string) bool {
+	fenced, ok := labels[FencedRoleLabelKey]
+	if !ok {
+		return true
+	}
+	if role == fenced {
+		return false
+	}
+	delete(labels, FencedRoleLabelKey)
+	return true
+}
+
 func GetClusterLabels(clusterName string, labels ...map[string]string) map[string]string {
 	return withShardingLabels(map[string]string{
 		AppManagedByLabelKey: AppName,
@@ -21,9 +21,13 @@ package constant
 
 // labels
 const (
-	OpsRequestTypeLabelKey      = "operations.kubeblocks.io/ops-type"
-	OpsRequestNameLabelKey      = "operations.kubeblocks.io/ops-name"
-	OpsRequestNamespaceLabelKey = "operations.kubeblocks.io/ops-namespace"
+	OpsRequestTypeLabelKey       = "operations.kubeblocks.io/ops-type"
+	OpsRequestNameLabelKey       = "operations.kubeblocks.io/ops-name"
+	OpsRequestNamespaceLabelKey  = "operations.kubeblocks.io/ops-namespace"
+	OpsPipelineNameLabelKey      = "operations.kubeblocks.io/pipeline-name"
+	OpsPipelineStepLabelKey      = "operations.kubeblocks.io/pipeline-step"
+	OpsAutoscalerNameLabelKey    = "operations.kubeblocks.io/autoscaler-name"
+	OpsVolumeAutoscalingLabelKey = "operations.kubeblocks.io/volume-autoscaling"
 )
 
 // annotations
@@ -35,6 +35,11 @@ func GenerateAccountSecretName(clusterName, compName, name string) string {
 	return fmt.Sprintf("%s-%s-account-%s", clusterName, compName, replacedName)
 }
 
+// GenerateKBAgentAuthSecretName generates the secret name of the kb-agent credentials for a component.
+func GenerateKBAgentAuthSecretName(clusterName, compName string) string {
+	return fmt.Sprintf("%s-%s-kbagent-auth", clusterName, compName)
+}
+
 // GenerateClusterServiceName generates the service name for cluster.
 func GenerateClusterServiceName(clusterName, svcName string) string {
 	if len(svcName) > 0 {
@@ -56,6 +56,29 @@ const (
 	CfgClientQPS          = "CLIENT_QPS"
 	CfgClientBurst        = "CLIENT_BURST"
 
+	// kb-agent config keys
+	CfgKBAgentTLSEnabled       = "KBAGENT_TLS_ENABLED"
+	CfgKBAgentTokenAuthEnabled = "KBAGENT_TOKEN_AUTH_ENABLED"
+
+	// reconciliation tracing config keys
+	CfgKeyTracingEnabled     = "ENABLED_RECONCILE_TRACING"
+	CfgKeyTracingEndpoint    = "RECONCILE_TRACING_ENDPOINT" // OTLP gRPC endpoint of the collector, "host:port"
+	CfgKeyTracingInsecure    = "RECONCILE_TRACING_INSECURE"
+	CfgKeyTracingSampleRatio = "RECONCILE_TRACING_SAMPLE_RATIO" // in the range [0, 1]
+
+	// TLS certificates config keys
+	CfgKeyTLSCertValidityDays    = "TLS_CERT_VALIDITY_DAYS"     // the validity of the certificates issued by KubeBlocks
+	CfgKeyTLSCertRenewBeforeDays = "TLS_CERT_RENEW_BEFORE_DAYS" // renew the certificates these days ahead of the expiry
+
+	// external secret store config keys
+	CfgKeySecretStoreProvider  = "SECRET_STORE_PROVIDER" // the external secret store is disabled if it's empty
+	CfgKeySecretStoreAddress   = "SECRET_STORE_ADDRESS"
+	CfgKeySecretStoreMountPath = "SECRET_STORE_MOUNT_PATH"
+	CfgKeySecretStoreToken     = "SECRET_STORE_TOKEN"
+	// the role and mount path of the Kubernetes auth method, used by the pods to log in with their service account tokens
+	CfgKeySecretStoreAuthRole      = "SECRET_STORE_AUTH_ROLE"
+	CfgKeySecretStoreAuthMountPath = "SECRET_STORE_AUTH_MOUNT_PATH"
+
 	CfgRegistries     = "registries"
 	I18nResourcesName = "I18N_RESOURCES_NAME"
 )
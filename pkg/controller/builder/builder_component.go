@@ -211,3 +211,8 @@ func (builder *ComponentBuilder) SetEnableInstanceAPI(enable *bool) *ComponentBu
 	builder.get().Spec.EnableInstanceAPI = enable
 	return builder
 }
+
+func (builder *ComponentBuilder) SetFailoverPolicy(policy *appsv1.FailoverPolicy) *ComponentBuilder {
+	builder.get().Spec.FailoverPolicy = policy
+	return builder
+}
@@ -38,6 +38,11 @@ func (c *ParameterBuilder) ClusterRef(clusterName string) *ParameterBuilder {
 	return c
 }
 
+func (c *ParameterBuilder) SetDryRun(dryRun bool) *ParameterBuilder {
+	c.get().Spec.DryRun = dryRun
+	return c
+}
+
 func (c *ParameterBuilder) SetComponentParameters(component string, parameters parametersv1alpha1.ComponentParameters) *ParameterBuilder {
 	componentSpec := safeGetComponentSpec(&c.get().Spec, component)
 	componentSpec.Parameters = parameters
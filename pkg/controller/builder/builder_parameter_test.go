@@ -60,4 +60,17 @@ var _ = Describe("parameter builder", func() {
 		Expect(config.Spec.ComponentParameters[0].Parameters).Should(HaveLen(2))
 		Expect(config.Spec.ComponentParameters[0].CustomTemplates).Should(HaveLen(2))
 	})
+
+	It("should set dry-run", func() {
+		config := NewParameterBuilder("default", "test-mysql").
+			ClusterRef("test").
+			GetObject()
+		Expect(config.Spec.DryRun).Should(BeFalse())
+
+		config = NewParameterBuilder("default", "test-mysql").
+			ClusterRef("test").
+			SetDryRun(true).
+			GetObject()
+		Expect(config.Spec.DryRun).Should(BeTrue())
+	})
 })
@@ -533,19 +533,38 @@ func (h *AvailableEventHandler) distinct(msgs []string) []string {
 func GetComponentAvailablePolicy(compDef *appsv1.ComponentDefinition) appsv1.ComponentAvailable {
 	timeWindowSeconds := func() *int32 {
 		periodSeconds := int32(0)
-		if compDef.Spec.LifecycleActions != nil && compDef.Spec.LifecycleActions.AvailableProbe != nil {
+		if synthetic := syntheticProbe(compDef); synthetic != nil {
+			periodSeconds = synthetic.PeriodSeconds
+		} else if compDef.Spec.LifecycleActions != nil && compDef.Spec.LifecycleActions.AvailableProbe != nil {
 			periodSeconds = compDef.Spec.LifecycleActions.AvailableProbe.PeriodSeconds
 		}
 		return pointer.Int32(probeReportPeriodSeconds(periodSeconds) * 2)
 	}
 
+	allSucceed := func() *appsv1.ComponentAvailableCondition {
+		return &appsv1.ComponentAvailableCondition{
+			ComponentAvailableExpression: appsv1.ComponentAvailableExpression{
+				All: &appsv1.ComponentAvailableProbeAssertion{
+					ActionAssertion: appsv1.ActionAssertion{
+						Succeed: pointer.Bool(true),
+					},
+					Strict: pointer.Bool(true),
+				},
+			},
+		}
+	}
+
 	// has available policy defined
 	if compDef.Spec.Available != nil {
-		policy := *compDef.Spec.Available
+		policy := *compDef.Spec.Available.DeepCopy()
 		if policy.WithProbe != nil {
 			if policy.WithProbe.TimeWindowSeconds == nil {
 				policy.WithProbe.TimeWindowSeconds = timeWindowSeconds()
 			}
+			// the synthetic probe asserts all the targets are reachable by default
+			if policy.WithProbe.Synthetic != nil && policy.WithProbe.Condition == nil {
+				policy.WithProbe.Condition = allSucceed()
+			}
 			policy.WithPhases = nil
 			policy.WithRole = nil
 		}
@@ -557,17 +576,8 @@ func GetComponentAvailablePolicy(compDef *appsv1.ComponentDefinition) appsv1.Com
 		return appsv1.ComponentAvailable{
 			WithProbe: &appsv1.ComponentAvailableWithProbe{
 				TimeWindowSeconds: timeWindowSeconds(),
-				Condition: &appsv1.ComponentAvailableCondition{
-					ComponentAvailableExpression: appsv1.ComponentAvailableExpression{
-						All: &appsv1.ComponentAvailableProbeAssertion{
-							ActionAssertion: appsv1.ActionAssertion{
-								Succeed: pointer.Bool(true),
-							},
-							Strict: pointer.Bool(true),
-						},
-					},
-				},
-				Description: "all replicas are available",
+				Condition:         allSucceed(),
+				Description:       "all replicas are available",
 			},
 		}
 	}
@@ -578,3 +588,10 @@ func GetComponentAvailablePolicy(compDef *appsv1.ComponentDefinition) appsv1.Com
 		WithPhases: pointer.String(string(appsv1.RunningComponentPhase)),
 	}
 }
+
+func syntheticProbe(compDef *appsv1.ComponentDefinition) *appsv1.ComponentAvailableSyntheticProbe {
+	if compDef.Spec.Available == nil || compDef.Spec.Available.WithProbe == nil {
+		return nil
+	}
+	return compDef.Spec.Available.WithProbe.Synthetic
+}
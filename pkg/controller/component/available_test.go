@@ -733,4 +733,38 @@ var _ = Describe("Available", func() {
 			Expect(available).Should(BeFalse())
 		})
 	})
+
+	Context("available policy", func() {
+		It("synthetic probe", func() {
+			compDef := &appsv1.ComponentDefinition{
+				Spec: appsv1.ComponentDefinitionSpec{
+					Available: &appsv1.ComponentAvailable{
+						WithProbe: &appsv1.ComponentAvailableWithProbe{
+							Synthetic: &appsv1.ComponentAvailableSyntheticProbe{
+								Targets: []appsv1.SyntheticProbeTarget{
+									{
+										Name:    "write",
+										Service: "rw",
+										Port:    "3306",
+									},
+								},
+								PeriodSeconds: 30,
+							},
+						},
+					},
+				},
+			}
+
+			policy := GetComponentAvailablePolicy(compDef)
+			Expect(policy.WithProbe).ShouldNot(BeNil())
+			Expect(*policy.WithProbe.TimeWindowSeconds).Should(Equal(int32(60)))
+			Expect(policy.WithProbe.Condition).ShouldNot(BeNil())
+			Expect(policy.WithProbe.Condition.All).ShouldNot(BeNil())
+			Expect(*policy.WithProbe.Condition.All.Succeed).Should(BeTrue())
+
+			// the definition is not modified
+			Expect(compDef.Spec.Available.WithProbe.TimeWindowSeconds).Should(BeNil())
+			Expect(compDef.Spec.Available.WithProbe.Condition).Should(BeNil())
+		})
+	})
 })
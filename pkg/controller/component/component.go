@@ -96,7 +96,8 @@ func BuildComponent(cluster *appsv1.Cluster, compSpec *appsv1.ClusterComponentSp
 		SetSystemAccounts(compSpec.SystemAccounts).
 		SetStop(compSpec.Stop).
 		SetSidecars(nil).
-		SetEnableInstanceAPI(compSpec.EnableInstanceAPI)
+		SetEnableInstanceAPI(compSpec.EnableInstanceAPI).
+		SetFailoverPolicy(compSpec.FailoverPolicy)
 	return compBuilder.GetObject(), nil
 }
 
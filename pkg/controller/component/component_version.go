@@ -285,11 +285,13 @@ func actionsToResolveImage(compDef *appsv1.ComponentDefinition) map[string]*apps
 		normalize("switchover"):       compDef.Spec.LifecycleActions.Switchover,
 		normalize("memberJoin"):       compDef.Spec.LifecycleActions.MemberJoin,
 		normalize("memberLeave"):      compDef.Spec.LifecycleActions.MemberLeave,
+		normalize("replicationLag"):   compDef.Spec.LifecycleActions.ReplicationLag,
 		normalize("readonly"):         compDef.Spec.LifecycleActions.Readonly,
 		normalize("readwrite"):        compDef.Spec.LifecycleActions.Readwrite,
 		normalize("dataDump"):         compDef.Spec.LifecycleActions.DataDump,
 		normalize("dataLoad"):         compDef.Spec.LifecycleActions.DataLoad,
 		normalize("reconfigure"):      compDef.Spec.LifecycleActions.Reconfigure,
+		normalize("parameterQuery"):   compDef.Spec.LifecycleActions.ParameterQuery,
 		normalize("accountProvision"): compDef.Spec.LifecycleActions.AccountProvision,
 	}
 	if compDef.Spec.LifecycleActions.RoleProbe != nil {
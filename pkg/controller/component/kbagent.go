@@ -22,6 +22,8 @@ package component
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
+	"slices"
 	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
@@ -42,6 +44,8 @@ const (
 	kbAgentCommand              = "/bin/kbagent"
 	kbAgentSharedMountPath      = "/kubeblocks"
 	kbAgentCommandOnSharedMount = "/kubeblocks/kbagent"
+	kbAgentAuthVolumeName       = "kbagent-auth"
+	kbAgentAuthMountPath        = "/etc/kbagent/auth"
 
 	minAvailablePort = 1025
 	maxAvailablePort = 65535
@@ -189,6 +193,11 @@ func buildKBAgentContainer(synthesizedComp *SynthesizedComponent) error {
 		return err
 	}
 
+	buildKBAgentAuth(synthesizedComp, container, workerContainer)
+
+	// the journal is shared by the server and the worker
+	buildKBAgentJournal(synthesizedComp, container, workerContainer)
+
 	// set kb-agent container ports to host network
 	if synthesizedComp.HostNetwork != nil {
 		if synthesizedComp.HostNetwork.ContainerPorts == nil {
@@ -214,6 +223,71 @@ func buildKBAgentContainer(synthesizedComp *SynthesizedComponent) error {
 	return nil
 }
 
+// IsKBAgentAuthRequired checks whether the kb-agent of the component requires the credentials to call.
+func IsKBAgentAuthRequired(synthesizedComp *SynthesizedComponent) bool {
+	if synthesizedComp.PodSpec == nil {
+		return false
+	}
+	return slices.ContainsFunc(synthesizedComp.PodSpec.Volumes, func(v corev1.Volume) bool {
+		return v.Name == kbAgentAuthVolumeName
+	})
+}
+
+func buildKBAgentAuth(synthesizedComp *SynthesizedComponent, containers ...*corev1.Container) {
+	tlsEnabled := viper.GetBool(constant.CfgKBAgentTLSEnabled)
+	tokenAuthEnabled := viper.GetBool(constant.CfgKBAgentTokenAuthEnabled)
+	if !tlsEnabled && !tokenAuthEnabled {
+		return
+	}
+
+	file := func(key string) string {
+		return filepath.Join(kbAgentAuthMountPath, key)
+	}
+	mount := corev1.VolumeMount{
+		Name:      kbAgentAuthVolumeName,
+		MountPath: kbAgentAuthMountPath,
+		ReadOnly:  true,
+	}
+	for _, c := range containers {
+		if tlsEnabled {
+			c.Args = append(c.Args,
+				"--tls-cert-file", file(proto.AuthCertKey),
+				"--tls-key-file", file(proto.AuthKeyKey),
+				"--tls-client-ca-file", file(proto.AuthCAKey))
+		}
+		if tokenAuthEnabled {
+			c.Args = append(c.Args, "--auth-token-file", file(proto.AuthTokenKey))
+		}
+		c.VolumeMounts = append(c.VolumeMounts, mount)
+	}
+
+	synthesizedComp.PodSpec.Volumes = append(synthesizedComp.PodSpec.Volumes, corev1.Volume{
+		Name: kbAgentAuthVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: constant.GenerateKBAgentAuthSecretName(synthesizedComp.ClusterName, synthesizedComp.Name),
+			},
+		},
+	})
+}
+
+// buildKBAgentJournal mounts the journal volume to the kbagent containers, the volume is an emptyDir,
+// so the journal is kept for the lifetime of the pod only.
+func buildKBAgentJournal(synthesizedComp *SynthesizedComponent, containers ...*corev1.Container) {
+	for _, c := range containers {
+		c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+			Name:      kbagent.JournalVolumeName,
+			MountPath: kbagent.JournalMountPath,
+		})
+	}
+	synthesizedComp.PodSpec.Volumes = append(synthesizedComp.PodSpec.Volumes, corev1.Volume{
+		Name: kbagent.JournalVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+}
+
 func mergedActionEnv4KBAgent(synthesizedComp *SynthesizedComponent) []corev1.EnvVar {
 	env := make([]corev1.EnvVar, 0)
 	envSet := sets.New[string]()
@@ -236,11 +310,13 @@ func mergedActionEnv4KBAgent(synthesizedComp *SynthesizedComponent) []corev1.Env
 			synthesizedComp.LifecycleActions.Switchover,
 			synthesizedComp.LifecycleActions.MemberJoin,
 			synthesizedComp.LifecycleActions.MemberLeave,
+			synthesizedComp.LifecycleActions.ReplicationLag,
 			synthesizedComp.LifecycleActions.Readonly,
 			synthesizedComp.LifecycleActions.Readwrite,
 			synthesizedComp.LifecycleActions.DataDump,
 			synthesizedComp.LifecycleActions.DataLoad,
 			synthesizedComp.LifecycleActions.Reconfigure,
+			synthesizedComp.LifecycleActions.ParameterQuery,
 			synthesizedComp.LifecycleActions.AccountProvision,
 		} {
 			checkedAppend(action)
@@ -279,6 +355,9 @@ func buildKBAgentStartupEnvs(synthesizedComp *SynthesizedComponent) ([]corev1.En
 		if a := buildAction4KBAgent(synthesizedComp.LifecycleActions.MemberLeave, "memberLeave"); a != nil {
 			actions = append(actions, *a)
 		}
+		if a := buildAction4KBAgent(synthesizedComp.LifecycleActions.ReplicationLag, "replicationLag"); a != nil {
+			actions = append(actions, *a)
+		}
 		if a := buildAction4KBAgent(synthesizedComp.LifecycleActions.Readonly, "readonly"); a != nil {
 			actions = append(actions, *a)
 		}
@@ -296,6 +375,9 @@ func buildKBAgentStartupEnvs(synthesizedComp *SynthesizedComponent) ([]corev1.En
 		if a := buildAction4KBAgent(synthesizedComp.LifecycleActions.Reconfigure, "reconfigure"); a != nil {
 			actions = append(actions, *a)
 		}
+		if a := buildAction4KBAgent(synthesizedComp.LifecycleActions.ParameterQuery, "parameterQuery"); a != nil {
+			actions = append(actions, *a)
+		}
 		if a := buildAction4KBAgent(synthesizedComp.LifecycleActions.AccountProvision, "accountProvision"); a != nil {
 			actions = append(actions, *a)
 		}
@@ -305,11 +387,22 @@ func buildKBAgentStartupEnvs(synthesizedComp *SynthesizedComponent) ([]corev1.En
 			probes = append(probes, *p)
 		}
 		// TODO: how to schedule the execution of probes?
-		if a, p := buildProbe4KBAgent(synthesizedComp.LifecycleActions.AvailableProbe, availableProbe, synthesizedComp.FullCompName); a != nil && p != nil {
-			p.ReportPeriodSeconds = probeReportPeriodSeconds(p.PeriodSeconds)
-			actions = append(actions, *a)
-			probes = append(probes, *p)
+		if synthesizedComp.SyntheticProbe == nil {
+			if a, p := buildProbe4KBAgent(synthesizedComp.LifecycleActions.AvailableProbe, availableProbe, synthesizedComp.FullCompName); a != nil && p != nil {
+				p.ReportPeriodSeconds = probeReportPeriodSeconds(p.PeriodSeconds)
+				actions = append(actions, *a)
+				probes = append(probes, *p)
+			}
+		}
+	}
+
+	// the synthetic probe takes the place of the available probe action
+	if synthesizedComp.SyntheticProbe != nil {
+		p, err := buildSyntheticProbe4KBAgent(synthesizedComp)
+		if err != nil {
+			return nil, err
 		}
+		probes = append(probes, *p)
 	}
 
 	traverseUserDefinedActions(synthesizedComp, func(name string, action *appsv1.Action) {
@@ -397,6 +490,81 @@ func buildProbe4KBAgent(probe *appsv1.Probe, name, instance string) (*proto.Acti
 	return a, p
 }
 
+func buildSyntheticProbe4KBAgent(synthesizedComp *SynthesizedComponent) (*proto.Probe, error) {
+	probe := synthesizedComp.SyntheticProbe
+	p := &proto.Probe{
+		Action:              availableProbe,
+		PeriodSeconds:       probe.PeriodSeconds,
+		SuccessThreshold:    probe.SuccessThreshold,
+		FailureThreshold:    probe.FailureThreshold,
+		ReportPeriodSeconds: probeReportPeriodSeconds(probe.PeriodSeconds),
+		Instance:            synthesizedComp.FullCompName,
+		Synthetic: &proto.SyntheticProbe{
+			TimeoutSeconds: probe.TimeoutSeconds,
+		},
+	}
+	for _, target := range probe.Targets {
+		t, err := buildSyntheticTarget4KBAgent(synthesizedComp, target)
+		if err != nil {
+			return nil, err
+		}
+		p.Synthetic.Targets = append(p.Synthetic.Targets, *t)
+	}
+	return p, nil
+}
+
+func buildSyntheticTarget4KBAgent(synthesizedComp *SynthesizedComponent, target appsv1.SyntheticProbeTarget) (*proto.SyntheticTarget, error) {
+	var svc *appsv1.ComponentService
+	for i, s := range synthesizedComp.ComponentServices {
+		if s.Name == target.Service {
+			svc = &synthesizedComp.ComponentServices[i]
+			break
+		}
+	}
+	if svc == nil {
+		return nil, fmt.Errorf("the service %s of synthetic probe target %s is not defined", target.Service, target.Name)
+	}
+	if svc.PodService != nil && *svc.PodService {
+		return nil, fmt.Errorf("the service %s of synthetic probe target %s is a pod service, which is not supported", target.Service, target.Name)
+	}
+
+	port := int32(0)
+	for _, p := range svc.Spec.Ports {
+		if p.Name == target.Port {
+			port = p.Port
+			break
+		}
+	}
+	if port == 0 {
+		num, err := strconv.ParseInt(target.Port, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("the port %s of synthetic probe target %s is not defined in the service %s", target.Port, target.Name, target.Service)
+		}
+		port = int32(num)
+	}
+
+	path := target.Path
+	if len(path) == 0 {
+		path = appsv1.SyntheticProbeReadPath
+	}
+	svcName := constant.GenerateComponentServiceName(synthesizedComp.ClusterName, synthesizedComp.Name, svc.ServiceName)
+	t := &proto.SyntheticTarget{
+		Name:  target.Name,
+		Host:  intctrlutil.ServiceFQDN(synthesizedComp.Namespace, svcName),
+		Port:  port,
+		Path:  string(path),
+		Roles: target.Roles,
+	}
+	if target.Action.Defined() {
+		t.Action = syntheticTargetActionName(target.Name)
+	}
+	return t, nil
+}
+
+func syntheticTargetActionName(target string) string {
+	return fmt.Sprintf("synthetic-%s", target)
+}
+
 func handleCustomImageNContainerDefined(synthesizedComp *SynthesizedComponent, containers ...*corev1.Container) error {
 	image, c, err := customExecActionImageNContainer(synthesizedComp)
 	if err != nil {
@@ -443,11 +611,13 @@ func customExecActionImageNContainer(synthesizedComp *SynthesizedComponent) (str
 			synthesizedComp.LifecycleActions.Switchover,
 			synthesizedComp.LifecycleActions.MemberJoin,
 			synthesizedComp.LifecycleActions.MemberLeave,
+			synthesizedComp.LifecycleActions.ReplicationLag,
 			synthesizedComp.LifecycleActions.Readonly,
 			synthesizedComp.LifecycleActions.Readwrite,
 			synthesizedComp.LifecycleActions.DataDump,
 			synthesizedComp.LifecycleActions.DataLoad,
 			synthesizedComp.LifecycleActions.Reconfigure,
+			synthesizedComp.LifecycleActions.ParameterQuery,
 			synthesizedComp.LifecycleActions.AccountProvision,
 		}...)
 		if synthesizedComp.LifecycleActions.RoleProbe != nil && synthesizedComp.LifecycleActions.RoleProbe.Defined() {
@@ -541,7 +711,7 @@ func iterAvailablePort(port int32, set map[int32]bool) (int32, error) {
 }
 
 func hasActionDefined(synthesizedComp *SynthesizedComponent) bool {
-	if synthesizedComp.LifecycleActions != nil {
+	if synthesizedComp.LifecycleActions != nil || synthesizedComp.SyntheticProbe != nil {
 		return true
 	}
 	for _, tpl := range synthesizedComp.FileTemplates {
@@ -549,6 +719,9 @@ func hasActionDefined(synthesizedComp *SynthesizedComponent) bool {
 			return true
 		}
 	}
+	if synthesizedComp.ShardingLifecycleActions != nil && synthesizedComp.ShardingLifecycleActions.Rebalance != nil {
+		return true
+	}
 	return false
 }
 
@@ -560,4 +733,16 @@ func traverseUserDefinedActions(synthesizedComp *SynthesizedComponent, f func(na
 			f(name, synthesizedComp.FileTemplates[i].Reconfigure)
 		}
 	}
+	// actions defined by the sharding definition
+	if synthesizedComp.ShardingLifecycleActions != nil && synthesizedComp.ShardingLifecycleActions.Rebalance != nil {
+		f(lifecycle.UDFActionName(UDFRebalanceActionName), synthesizedComp.ShardingLifecycleActions.Rebalance)
+	}
+	// actions to check the targets of the synthetic probe
+	if synthesizedComp.SyntheticProbe != nil {
+		for i, target := range synthesizedComp.SyntheticProbe.Targets {
+			if target.Action.Defined() {
+				f(syntheticTargetActionName(target.Name), synthesizedComp.SyntheticProbe.Targets[i].Action)
+			}
+		}
+	}
 }
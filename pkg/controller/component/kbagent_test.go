@@ -157,7 +157,7 @@ var _ = Describe("kb-agent", func() {
 
 			c := kbAgentContainer()
 			Expect(c).ShouldNot(BeNil())
-			Expect(c.Env).Should(HaveLen(6)) // 4 + 2
+			Expect(c.Env).Should(HaveLen(7)) // 4 + 3
 		})
 
 		It("action env", func() {
@@ -182,7 +182,7 @@ var _ = Describe("kb-agent", func() {
 
 			c := kbAgentContainer()
 			Expect(c).ShouldNot(BeNil())
-			Expect(c.Env).Should(HaveLen(8)) // 2 + 4 + 2
+			Expect(c.Env).Should(HaveLen(9)) // 2 + 4 + 3
 			Expect(reflect.DeepEqual(c.Env[0], env[0])).Should(BeTrue())
 			Expect(reflect.DeepEqual(c.Env[1], env[1])).Should(BeTrue())
 		})
@@ -201,7 +201,7 @@ var _ = Describe("kb-agent", func() {
 			Expect(c).ShouldNot(BeNil())
 			Expect(c.Image).Should(Equal(image))
 			Expect(c.Command[0]).Should(Equal(kbAgentCommandOnSharedMount))
-			Expect(c.VolumeMounts).Should(HaveLen(1))
+			Expect(c.VolumeMounts).Should(HaveLen(2)) // shared + journal
 			Expect(c.VolumeMounts[0]).Should(Equal(sharedVolumeMount))
 		})
 
@@ -239,7 +239,7 @@ var _ = Describe("kb-agent", func() {
 			Expect(c).ShouldNot(BeNil())
 			Expect(c.Image).Should(Equal(viperx.GetString(constant.KBToolsImage)))
 			Expect(c.Command[0]).Should(Equal(kbAgentCommand))
-			Expect(c.VolumeMounts).Should(HaveLen(0))
+			Expect(c.VolumeMounts).Should(HaveLen(1)) // journal
 		})
 
 		It("custom container - volume mounts", func() {
@@ -257,7 +257,7 @@ var _ = Describe("kb-agent", func() {
 
 			c := kbAgentContainer()
 			Expect(c).ShouldNot(BeNil())
-			Expect(c.VolumeMounts).Should(HaveLen(1))
+			Expect(c.VolumeMounts).Should(HaveLen(2)) // container + journal
 			Expect(c.VolumeMounts[0]).Should(Equal(container.VolumeMounts[0]))
 		})
 
@@ -315,7 +315,7 @@ var _ = Describe("kb-agent", func() {
 			Expect(c).ShouldNot(BeNil())
 			Expect(c.Image).Should(Equal(container.Image))
 			Expect(c.Command[0]).Should(Equal(kbAgentCommandOnSharedMount))
-			Expect(c.VolumeMounts).Should(HaveLen(2))
+			Expect(c.VolumeMounts).Should(HaveLen(3)) // shared + container + journal
 			Expect(c.VolumeMounts[0]).Should(Equal(sharedVolumeMount))
 			Expect(c.VolumeMounts[1]).Should(Equal(container.VolumeMounts[0]))
 		})
@@ -342,11 +342,25 @@ var _ = Describe("kb-agent", func() {
 			c := kbAgentContainer()
 			Expect(c.Image).Should(Equal(image))
 			Expect(c.Command[0]).Should(Equal(kbAgentCommandOnSharedMount))
-			Expect(c.VolumeMounts).Should(HaveLen(2))
+			Expect(c.VolumeMounts).Should(HaveLen(3)) // shared + container + journal
 			Expect(c.VolumeMounts[0]).Should(Equal(sharedVolumeMount))
 			Expect(c.VolumeMounts[1]).Should(Equal(container.VolumeMounts[0]))
 		})
 
+		It("journal", func() {
+			err := buildKBAgentContainer(synthesizedComp)
+			Expect(err).Should(BeNil())
+
+			c := kbAgentContainer()
+			Expect(c).ShouldNot(BeNil())
+			Expect(c.VolumeMounts).Should(HaveLen(1))
+			Expect(c.VolumeMounts[0].Name).Should(Equal(kbagent.JournalVolumeName))
+			Expect(c.VolumeMounts[0].MountPath).Should(Equal(kbagent.JournalMountPath))
+			Expect(synthesizedComp.PodSpec.Volumes).Should(HaveLen(1))
+			Expect(synthesizedComp.PodSpec.Volumes[0].Name).Should(Equal(kbagent.JournalVolumeName))
+			Expect(synthesizedComp.PodSpec.Volumes[0].EmptyDir).ShouldNot(BeNil())
+		})
+
 		// TODO: host-network
 
 		It("user-defined actions", func() {
@@ -442,5 +456,114 @@ var _ = Describe("kb-agent", func() {
 				Value: "/var/run/server.conf",
 			}))
 		})
+
+		It("synthetic probe", func() {
+			synthesizedComp.Namespace = "default"
+			synthesizedComp.ClusterName = "test-cluster"
+			synthesizedComp.Name = "mysql"
+			synthesizedComp.ComponentServices = []appsv1.ComponentService{
+				{
+					Service: appsv1.Service{
+						Name:        "rw",
+						ServiceName: "rw",
+						Spec: corev1.ServiceSpec{
+							Ports: []corev1.ServicePort{{Name: "mysql", Port: 3306}},
+						},
+						RoleSelector: "primary",
+					},
+				},
+			}
+			synthesizedComp.LifecycleActions.AvailableProbe = &appsv1.Probe{
+				Action: appsv1.Action{
+					Exec: &appsv1.ExecAction{
+						Command: []string{"echo", "available"},
+					},
+				},
+			}
+			synthesizedComp.SyntheticProbe = &appsv1.ComponentAvailableSyntheticProbe{
+				Targets: []appsv1.SyntheticProbeTarget{
+					{
+						Name:    "write",
+						Service: "rw",
+						Port:    "mysql",
+						Path:    appsv1.SyntheticProbeWritePath,
+						Action: &appsv1.Action{
+							Exec: &appsv1.ExecAction{
+								Command: []string{"mysql", "-e", "replace into kubeblocks.heartbeat values (1, now())"},
+							},
+						},
+					},
+					{
+						Name:    "read",
+						Service: "rw",
+						Port:    "3307",
+						Roles:   []string{"secondary"},
+					},
+				},
+				PeriodSeconds:  5,
+				TimeoutSeconds: 1,
+			}
+
+			err := buildKBAgentContainer(synthesizedComp)
+			Expect(err).Should(BeNil())
+
+			c := kbAgentContainer()
+			Expect(c).ShouldNot(BeNil())
+			var da, dp string
+			for _, e := range c.Env {
+				switch e.Name {
+				case "KB_AGENT_ACTION":
+					da = e.Value
+				case "KB_AGENT_PROBE":
+					dp = e.Value
+				}
+			}
+			actions := make([]proto.Action, 0)
+			Expect(json.Unmarshal([]byte(da), &actions)).Should(BeNil())
+			for _, a := range actions {
+				Expect(a.Name).ShouldNot(Equal(availableProbe))
+			}
+			Expect(actions).Should(ContainElement(HaveField("Name", "synthetic-write")))
+
+			probes := make([]proto.Probe, 0)
+			Expect(json.Unmarshal([]byte(dp), &probes)).Should(BeNil())
+			Expect(probes).Should(HaveLen(2))
+			Expect(probes[1].Action).Should(Equal(availableProbe))
+			Expect(probes[1].ReportPeriodSeconds).Should(Equal(int32(minProbeReportPeriodSeconds)))
+			Expect(probes[1].Synthetic).ShouldNot(BeNil())
+			Expect(probes[1].Synthetic.TimeoutSeconds).Should(Equal(int32(1)))
+			Expect(probes[1].Synthetic.Targets).Should(Equal([]proto.SyntheticTarget{
+				{
+					Name:   "write",
+					Host:   "test-cluster-mysql-rw.default.svc.cluster.local",
+					Port:   3306,
+					Path:   "Write",
+					Action: "synthetic-write",
+				},
+				{
+					Name:  "read",
+					Host:  "test-cluster-mysql-rw.default.svc.cluster.local",
+					Port:  3307,
+					Path:  "Read",
+					Roles: []string{"secondary"},
+				},
+			}))
+		})
+
+		It("synthetic probe - service not defined", func() {
+			synthesizedComp.SyntheticProbe = &appsv1.ComponentAvailableSyntheticProbe{
+				Targets: []appsv1.SyntheticProbeTarget{
+					{
+						Name:    "write",
+						Service: "rw",
+						Port:    "mysql",
+					},
+				},
+			}
+
+			err := buildKBAgentContainer(synthesizedComp)
+			Expect(err).ShouldNot(BeNil())
+			Expect(err.Error()).Should(ContainSubstring("is not defined"))
+		})
 	})
 })
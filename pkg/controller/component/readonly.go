@@ -0,0 +1,110 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+)
+
+const (
+	readonlyConditionReasonReadWrite = "ReadWrite"
+)
+
+// GetReadonlyReplica returns the read-only status of the replica, or nil if the replica is in the read-write state.
+func GetReadonlyReplica(comp *appsv1.Component, name string) *appsv1.ReadonlyReplicaStatus {
+	for i, replica := range comp.Status.ReadonlyReplicas {
+		if replica.Name == name {
+			return &comp.Status.ReadonlyReplicas[i]
+		}
+	}
+	return nil
+}
+
+// SetReadonlyReplica records the replica as read-only in the status of the component, and updates the Readonly condition.
+func SetReadonlyReplica(comp *appsv1.Component, name string, reason appsv1.ReadonlyReason, message string) {
+	if replica := GetReadonlyReplica(comp, name); replica != nil {
+		replica.Reason = reason
+		replica.Message = message
+	} else {
+		comp.Status.ReadonlyReplicas = append(comp.Status.ReadonlyReplicas, appsv1.ReadonlyReplicaStatus{
+			Name:               name,
+			Reason:             reason,
+			LastTransitionTime: metav1.Now(),
+			Message:            message,
+		})
+		sort.Slice(comp.Status.ReadonlyReplicas, func(i, j int) bool {
+			return comp.Status.ReadonlyReplicas[i].Name < comp.Status.ReadonlyReplicas[j].Name
+		})
+	}
+	setReadonlyCondition(comp)
+}
+
+// RemoveReadonlyReplica removes the replica from the read-only replicas of the component, and updates the Readonly condition.
+func RemoveReadonlyReplica(comp *appsv1.Component, name string) {
+	replicas := make([]appsv1.ReadonlyReplicaStatus, 0, len(comp.Status.ReadonlyReplicas))
+	for _, replica := range comp.Status.ReadonlyReplicas {
+		if replica.Name != name {
+			replicas = append(replicas, replica)
+		}
+	}
+	if len(replicas) == len(comp.Status.ReadonlyReplicas) {
+		return
+	}
+	if len(replicas) == 0 {
+		replicas = nil
+	}
+	comp.Status.ReadonlyReplicas = replicas
+	setReadonlyCondition(comp)
+}
+
+func setReadonlyCondition(comp *appsv1.Component) {
+	cond := metav1.Condition{
+		Type:               appsv1.ConditionTypeReadonly,
+		ObservedGeneration: comp.Generation,
+	}
+	if len(comp.Status.ReadonlyReplicas) == 0 {
+		// the condition is only reported for components that have ever had read-only replicas.
+		if meta.FindStatusCondition(comp.Status.Conditions, appsv1.ConditionTypeReadonly) == nil {
+			return
+		}
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = readonlyConditionReasonReadWrite
+		cond.Message = "all replicas are in the read-write state"
+	} else {
+		names := make([]string, 0, len(comp.Status.ReadonlyReplicas))
+		cond.Reason = string(appsv1.ReadonlyReasonOpsRequest)
+		for _, replica := range comp.Status.ReadonlyReplicas {
+			names = append(names, replica.Name)
+			if replica.Reason == appsv1.ReadonlyReasonVolumeHighWatermark {
+				cond.Reason = string(appsv1.ReadonlyReasonVolumeHighWatermark)
+			}
+		}
+		cond.Status = metav1.ConditionTrue
+		cond.Message = fmt.Sprintf("replicas are in the read-only state: %s", strings.Join(names, ","))
+	}
+	meta.SetStatusCondition(&comp.Status.Conditions, cond)
+}
@@ -103,6 +103,7 @@ func BuildSynthesizedComponent(ctx context.Context, cli client.Reader,
 		MinReadySeconds:                  compDefObj.Spec.MinReadySeconds,
 		PolicyRules:                      compDefObj.Spec.PolicyRules,
 		LifecycleActions:                 compDefObj.Spec.LifecycleActions,
+		SyntheticProbe:                   syntheticProbe(compDefObj),
 		SystemAccounts:                   compDefObj.Spec.SystemAccounts,
 		Replicas:                         comp.Spec.Replicas,
 		Resources:                        comp.Spec.Resources,
@@ -157,6 +158,10 @@ func BuildSynthesizedComponent(ctx context.Context, cli client.Reader,
 		return nil, err
 	}
 
+	if err = buildShardingLifecycleActions(ctx, cli, synthesizeComp, comp); err != nil {
+		return nil, err
+	}
+
 	if err = buildKBAgentContainer(synthesizeComp); err != nil {
 		return nil, errors.Wrap(err, "build kb-agent container failed")
 	}
@@ -175,6 +180,21 @@ func BuildSynthesizedComponent(ctx context.Context, cli client.Reader,
 	return synthesizeComp, nil
 }
 
+// buildShardingLifecycleActions loads the lifecycle actions defined by the sharding definition for shards,
+// the actions executed on shards need to be registered to the kb-agent.
+func buildShardingLifecycleActions(ctx context.Context, cli client.Reader, synthesizeComp *SynthesizedComponent, comp *appsv1.Component) error {
+	shardingDefName, ok := comp.Labels[constant.ShardingDefLabelKey]
+	if cli == nil || !ok || len(shardingDefName) == 0 {
+		return nil
+	}
+	shardingDef := &appsv1.ShardingDefinition{}
+	if err := cli.Get(ctx, types.NamespacedName{Name: shardingDefName}, shardingDef); err != nil {
+		return err
+	}
+	synthesizeComp.ShardingLifecycleActions = shardingDef.Spec.LifecycleActions
+	return nil
+}
+
 func BuildComp2CompDefs(ctx context.Context, cli client.Reader, namespace, clusterName string) (map[string]string, error) {
 	if cli == nil {
 		return nil, nil // for test
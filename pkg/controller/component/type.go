@@ -69,16 +69,18 @@ type SynthesizedComponent struct {
 	ParallelPodManagementConcurrency *intstr.IntOrString             `json:"parallelPodManagementConcurrency,omitempty"`
 	PodUpdatePolicy                  kbappsv1.PodUpdatePolicyType    `json:"podUpdatePolicy,omitempty"`
 	PodUpgradePolicy                 kbappsv1.PodUpdatePolicyType
-	UpdateStrategy                   *kbappsv1.UpdateStrategy            `json:"updateStrategy,omitempty"`
-	InstanceUpdateStrategy           *kbappsv1.InstanceUpdateStrategy    `json:"instanceUpdateStrategy,omitempty"`
-	PolicyRules                      []rbacv1.PolicyRule                 `json:"policyRules,omitempty"`
-	LifecycleActions                 *kbappsv1.ComponentLifecycleActions `json:"lifecycleActions,omitempty"`
-	SystemAccounts                   []kbappsv1.SystemAccount            `json:"systemAccounts,omitempty"`
-	Volumes                          []kbappsv1.ComponentVolume          `json:"volumes,omitempty"`
-	HostNetwork                      *kbappsv1.HostNetwork               `json:"hostNetwork,omitempty"`
-	ComponentServices                []kbappsv1.ComponentService         `json:"componentServices,omitempty"`
-	MinReadySeconds                  int32                               `json:"minReadySeconds,omitempty"`
-	DisableExporter                  *bool                               `json:"disableExporter,omitempty"`
+	UpdateStrategy                   *kbappsv1.UpdateStrategy                   `json:"updateStrategy,omitempty"`
+	InstanceUpdateStrategy           *kbappsv1.InstanceUpdateStrategy           `json:"instanceUpdateStrategy,omitempty"`
+	PolicyRules                      []rbacv1.PolicyRule                        `json:"policyRules,omitempty"`
+	LifecycleActions                 *kbappsv1.ComponentLifecycleActions        `json:"lifecycleActions,omitempty"`
+	SyntheticProbe                   *kbappsv1.ComponentAvailableSyntheticProbe // the built-in synthetic probe used as the available probe
+	ShardingLifecycleActions         *kbappsv1.ShardingLifecycleActions         // actions defined by the sharding definition, for shards only
+	SystemAccounts                   []kbappsv1.SystemAccount                   `json:"systemAccounts,omitempty"`
+	Volumes                          []kbappsv1.ComponentVolume                 `json:"volumes,omitempty"`
+	HostNetwork                      *kbappsv1.HostNetwork                      `json:"hostNetwork,omitempty"`
+	ComponentServices                []kbappsv1.ComponentService                `json:"componentServices,omitempty"`
+	MinReadySeconds                  int32                                      `json:"minReadySeconds,omitempty"`
+	DisableExporter                  *bool                                      `json:"disableExporter,omitempty"`
 	Stop                             *bool
 	EnableInstanceAPI                *bool
 	InstanceAssistantObjects         []corev1.ObjectReference
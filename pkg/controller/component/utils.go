@@ -192,6 +192,9 @@ var (
 
 type mockHostNetworkPortManagerKey struct{}
 
+// UDFRebalanceActionName is the name of the user-defined action to rebalance the data between shards.
+const UDFRebalanceActionName = "rebalance"
+
 func UDFReconfigureActionName(tpl SynthesizedFileTemplate) string {
 	return fmt.Sprintf("reconfigure-%s", tpl.Name)
 }
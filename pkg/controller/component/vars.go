@@ -43,6 +43,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/generics"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
 )
 
 var (
@@ -740,7 +741,11 @@ func resolveCredentialPasswordRef(ctx context.Context, cli client.Reader, synthe
 	resolvePassword := func(obj any) (*corev1.EnvVar, *corev1.EnvVar, error) {
 		secret := obj.(*corev1.Secret)
 		if secret.Data != nil {
-			if _, ok := secret.Data[constant.AccountPasswdForSecret]; ok {
+			if passwd, ok := secret.Data[constant.AccountPasswdForSecret]; ok {
+				if secretstore.IsReferred(secret) && secretstore.HasEnvRef(string(passwd)) {
+					// the password is kept in the secret store, refer to it directly, it will be resolved when the container starts
+					return nil, &corev1.EnvVar{Name: defineKey, Value: string(passwd)}, nil
+				}
 				return nil, &corev1.EnvVar{
 					Name: defineKey,
 					ValueFrom: &corev1.EnvVarSource{
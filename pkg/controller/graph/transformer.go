@@ -22,12 +22,16 @@ package graph
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	"github.com/apecloud/kubeblocks/pkg/metrics"
+	"github.com/apecloud/kubeblocks/pkg/tracing"
 )
 
 // TransformContext is used by Transformer.Transform
@@ -54,7 +58,7 @@ var ErrPrematureStop = errors.New("Premature-Stop")
 func (r TransformerChain) ApplyTo(ctx TransformContext, dag *DAG) error {
 	var delayedError error
 	for _, transformer := range r {
-		if err := transformer.Transform(ctx, dag); err != nil {
+		if err := transform(ctx, transformer, dag); err != nil {
 			if intctrlutil.IsDelayedRequeueError(err) {
 				if delayedError == nil {
 					delayedError = err
@@ -67,6 +71,16 @@ func (r TransformerChain) ApplyTo(ctx TransformContext, dag *DAG) error {
 	return delayedError
 }
 
+// transform runs the transformer in a child span of the reconciliation cycle, and records its latency.
+func transform(ctx TransformContext, transformer Transformer, dag *DAG) error {
+	start := time.Now()
+	_, span := tracing.StartStep(ctx.GetContext(), "Transformer", transformer)
+	err := transformer.Transform(ctx, dag)
+	tracing.End(span, ignoredIfPrematureStop(err))
+	metrics.ObserveReconcileStep("Transformer", generics.TypeName(transformer), start)
+	return err
+}
+
 func ignoredIfPrematureStop(err error) error {
 	if err == ErrPrematureStop {
 		return nil
@@ -247,6 +247,14 @@ func updatePodRoleLabel(cli client.Client, reqCtx intctrlutil.RequestCtx,
 	// update pod role label
 	newPod := pod.DeepCopy()
 	role, ok := roleMap[roleName]
+	probedRole := roleName
+	if ok {
+		probedRole = role.Name
+	}
+	if !constant.AcceptProbedRole(newPod.Labels, probedRole) {
+		reqCtx.Log.Info("the replica is fenced, ignore its role", "pod", pod.Name, "role", roleName)
+		ok = false
+	}
 	switch ok {
 	case true:
 		newPod.Labels[RoleLabelKey] = role.Name
@@ -170,6 +170,41 @@ var _ = Describe("pod role label event handler test", func() {
 		})
 	})
 
+	Context("updatePodRoleLabel function", func() {
+		It("should keep the fenced replica out of its role", func() {
+			reqCtx := intctrlutil.RequestCtx{
+				Ctx: ctx,
+				Log: logger,
+			}
+			its := builder.NewInstanceSetBuilder(namespace, name).
+				SetRoles([]workloads.ReplicaRole{{Name: "leader", UpdatePriority: 5}, {Name: "follower", UpdatePriority: 4}}).
+				GetObject()
+			pod := builder.NewPodBuilder(namespace, getPodName(name, 0)).
+				AddLabels(constant.FencedRoleLabelKey, "leader").
+				GetObject()
+
+			By("report the role it held")
+			k8sMock.EXPECT().
+				Update(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, pd *corev1.Pod, _ ...client.UpdateOption) error {
+					Expect(pd.Labels).ShouldNot(HaveKey(RoleLabelKey))
+					Expect(pd.Labels).Should(HaveKeyWithValue(constant.FencedRoleLabelKey, "leader"))
+					return nil
+				}).Times(1)
+			Expect(updatePodRoleLabel(k8sMock, reqCtx, *its, pod, "leader", "1")).Should(Succeed())
+
+			By("report another role")
+			k8sMock.EXPECT().
+				Update(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, pd *corev1.Pod, _ ...client.UpdateOption) error {
+					Expect(pd.Labels).Should(HaveKeyWithValue(RoleLabelKey, "follower"))
+					Expect(pd.Labels).ShouldNot(HaveKey(constant.FencedRoleLabelKey))
+					return nil
+				}).Times(1)
+			Expect(updatePodRoleLabel(k8sMock, reqCtx, *its, pod, "follower", "2")).Should(Succeed())
+		})
+	})
+
 	Context("parseProbeEventMessage function", func() {
 		It("should work well", func() {
 			reqCtx := intctrlutil.RequestCtx{
@@ -22,6 +22,7 @@ package kubebuilderx
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -31,6 +32,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	"github.com/apecloud/kubeblocks/pkg/metrics"
+	"github.com/apecloud/kubeblocks/pkg/tracing"
 )
 
 // TODO(free6om): this is a new reconciler framework in the very early stage leaving the following tasks to do:
@@ -81,6 +85,7 @@ func (c *controller) Prepare(reader TreeLoader) Controller {
 		return c
 	}
 	c.tree, c.err = c.oldTree.DeepCopy()
+	tracing.SetObject(c.ctx, c.oldTree.GetRoot())
 
 	// init placement
 	c.ctx = intoContext(c.ctx, placement(c.oldTree.GetRoot()))
@@ -111,7 +116,12 @@ func (c *controller) Do(reconcilers ...Reconciler) Controller {
 	case !result.Satisfied:
 		return c
 	}
+	start := time.Now()
+	_, span := tracing.StartStep(c.ctx, "Reconciler", reconciler)
 	c.res, c.err = reconciler.Reconcile(c.tree)
+	span.SetAttributes(tracing.AttrNext.String(string(c.res.Next)))
+	tracing.End(span, c.err)
+	metrics.ObserveReconcileStep("Reconciler", generics.TypeName(reconciler), start)
 
 	return c.Do(reconcilers[1:]...)
 }
@@ -39,6 +39,8 @@ import (
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	"github.com/apecloud/kubeblocks/pkg/tracing"
 )
 
 type transformContext struct {
@@ -247,19 +249,23 @@ func (b *PlanBuilder) defaultWalkFunc(v graph.Vertex) error {
 		return errors.New("vertex action can't be nil")
 	}
 	ctx := b.transCtx.ctx
+	var err error
 	switch *vertex.Action {
 	case model.CREATE:
-		return b.createObject(ctx, vertex)
+		err = b.createObject(ctx, vertex)
 	case model.UPDATE:
-		return b.updateObject(ctx, vertex)
+		err = b.updateObject(ctx, vertex)
 	case model.PATCH:
-		return b.patchObject(ctx, vertex)
+		err = b.patchObject(ctx, vertex)
 	case model.DELETE:
-		return b.deleteObject(ctx, vertex)
+		err = b.deleteObject(ctx, vertex)
 	case model.STATUS:
-		return b.statusObject(ctx, vertex)
+		err = b.statusObject(ctx, vertex)
 	}
-	return nil
+	if err == nil {
+		tracing.RecordChange(ctx, string(*vertex.Action), vertex.Obj)
+	}
+	return err
 }
 
 func (b *PlanBuilder) createObject(ctx context.Context, vertex *model.ObjectVertex) error {
@@ -342,15 +348,7 @@ func (b *PlanBuilder) emitEvent(obj client.Object, reason string, action model.A
 	root := b.currentTree.GetRoot()
 	b.currentTree.EventRecorder.Eventf(root, corev1.EventTypeNormal, reason,
 		"%s %s %s in %s %s successful",
-		strings.ToLower(string(action)), getTypeName(obj), obj.GetName(), getTypeName(root), root.GetName())
-}
-
-func getTypeName(i any) string {
-	t := reflect.TypeOf(i)
-	if t.Kind() == reflect.Pointer {
-		t = t.Elem()
-	}
-	return t.Name()
+		strings.ToLower(string(action)), generics.TypeName(obj), obj.GetName(), generics.TypeName(root), root.GetName())
 }
 
 // NewPlanBuilder returns a PlanBuilder
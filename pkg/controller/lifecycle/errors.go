@@ -40,3 +40,28 @@ func IgnoreNotDefined(err error) error {
 	}
 	return err
 }
+
+// actionErrorType returns the type of the error returned by an action, it's empty if there is no error.
+func actionErrorType(err error) string {
+	if err == nil {
+		return ""
+	}
+	for _, e := range []struct {
+		err error
+		typ string
+	}{
+		{ErrActionNotDefined, "NotDefined"},
+		{ErrActionNotImplemented, "NotImplemented"},
+		{ErrPreconditionFailed, "PreconditionFailed"},
+		{ErrActionInProgress, "InProgress"},
+		{ErrActionBusy, "Busy"},
+		{ErrActionTimedOut, "TimedOut"},
+		{ErrActionFailed, "Failed"},
+		{ErrActionInternalError, "InternalError"},
+	} {
+		if errors.Is(err, e.err) {
+			return e.typ
+		}
+	}
+	return "Unknown"
+}
@@ -23,6 +23,7 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -37,6 +38,8 @@ import (
 	kbagt "github.com/apecloud/kubeblocks/pkg/kbagent"
 	kbacli "github.com/apecloud/kubeblocks/pkg/kbagent/client"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	"github.com/apecloud/kubeblocks/pkg/metrics"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 type lifecycleAction interface {
@@ -90,7 +93,11 @@ func (a *kbagent) Switchover(ctx context.Context, cli client.Reader, opts *Optio
 		currentPod:   a.pod.Name,
 		candidatePod: candidate,
 	}
-	return a.ignoreOutput(a.checkedCallAction(ctx, cli, a.lifecycleActions.Switchover, lfa, opts))
+	err := a.ignoreOutput(a.checkedCallAction(ctx, cli, a.lifecycleActions.Switchover, lfa, opts))
+	if !errors.Is(err, ErrActionNotDefined) {
+		metrics.RecordSwitchover(a.namespace, a.clusterName, a.compName, err)
+	}
+	return err
 }
 
 func (a *kbagent) MemberJoin(ctx context.Context, cli client.Reader, opts *Options) error {
@@ -113,6 +120,20 @@ func (a *kbagent) MemberLeave(ctx context.Context, cli client.Reader, opts *Opti
 	return a.ignoreOutput(a.checkedCallAction(ctx, cli, a.lifecycleActions.MemberLeave, lfa, opts))
 }
 
+func (a *kbagent) ReplicationLag(ctx context.Context, cli client.Reader, opts *Options) ([]byte, error) {
+	return a.checkedCallAction(ctx, cli, a.lifecycleActions.ReplicationLag, &replicationLag{}, opts)
+}
+
+func (a *kbagent) Readonly(ctx context.Context, cli client.Reader, opts *Options) error {
+	lfa := &readonly{}
+	return a.ignoreOutput(a.checkedCallAction(ctx, cli, a.lifecycleActions.Readonly, lfa, opts))
+}
+
+func (a *kbagent) Readwrite(ctx context.Context, cli client.Reader, opts *Options) error {
+	lfa := &readwrite{}
+	return a.ignoreOutput(a.checkedCallAction(ctx, cli, a.lifecycleActions.Readwrite, lfa, opts))
+}
+
 func (a *kbagent) Reconfigure(ctx context.Context, cli client.Reader, opts *Options, args map[string]string) error {
 	lfa := &reconfigure{
 		args: args,
@@ -120,6 +141,13 @@ func (a *kbagent) Reconfigure(ctx context.Context, cli client.Reader, opts *Opti
 	return a.ignoreOutput(a.checkedCallAction(ctx, cli, a.lifecycleActions.Reconfigure, lfa, opts))
 }
 
+func (a *kbagent) ParameterQuery(ctx context.Context, cli client.Reader, opts *Options, params map[string][]string) ([]byte, error) {
+	lfa := &parameterQuery{
+		params: params,
+	}
+	return a.checkedCallAction(ctx, cli, a.lifecycleActions.ParameterQuery, lfa, opts)
+}
+
 func (a *kbagent) AccountProvision(ctx context.Context, cli client.Reader, opts *Options, statement, user, password string) error {
 	lfa := &accountProvision{
 		statement: statement,
@@ -222,7 +250,10 @@ func (a *kbagent) callAction(ctx context.Context, cli client.Reader, spec *appsv
 	if err1 != nil {
 		return nil, err1
 	}
-	return a.callActionWithSelector(ctx, spec, lfa, req)
+	start := time.Now()
+	output, err2 := a.callActionWithSelector(ctx, cli, spec, lfa, req)
+	metrics.ObserveKBAgentAction(lfa.name(), start, actionErrorType(err2))
+	return output, err2
 }
 
 func (a *kbagent) buildActionRequest(ctx context.Context, cli client.Reader, lfa lifecycleAction, opts *Options) (*proto.ActionRequest, error) {
@@ -278,7 +309,7 @@ func (a *kbagent) templateVarsParameters() (map[string]string, error) {
 	return m, nil
 }
 
-func (a *kbagent) callActionWithSelector(ctx context.Context, spec *appsv1.Action, lfa lifecycleAction, req *proto.ActionRequest) ([]byte, error) {
+func (a *kbagent) callActionWithSelector(ctx context.Context, cli client.Reader, spec *appsv1.Action, lfa lifecycleAction, req *proto.ActionRequest) ([]byte, error) {
 	pods, err := a.selectTargetPods(spec)
 	if err != nil {
 		return nil, err
@@ -287,6 +318,11 @@ func (a *kbagent) callActionWithSelector(ctx context.Context, spec *appsv1.Actio
 		return nil, fmt.Errorf("no available pod to execute action %s", lfa.name())
 	}
 
+	credential, err := a.credential(ctx, cli)
+	if err != nil {
+		return nil, err
+	}
+
 	// TODO: impl
 	//  - back-off to retry
 	//  - timeout
@@ -304,9 +340,9 @@ func (a *kbagent) callActionWithSelector(ctx context.Context, spec *appsv1.Actio
 		if err != nil {
 			// If kb is not run in a k8s cluster, using pod ip to call kb-agent would fail.
 			// So we use a client that utilizes k8s' portforward ability.
-			cli, err = kbacli.NewPortForwardClient(pod, endpoint)
+			cli, err = kbacli.NewPortForwardClient(pod, endpoint, credential)
 		} else {
-			cli, err = kbacli.NewClient(endpoint)
+			cli, err = kbacli.NewClient(endpoint, credential)
 		}
 		if err != nil {
 			return nil, err // mock client error
@@ -332,6 +368,33 @@ func (a *kbagent) callActionWithSelector(ctx context.Context, spec *appsv1.Actio
 	return output, nil
 }
 
+func (a *kbagent) credential(ctx context.Context, cli client.Reader) (*kbacli.Credential, error) {
+	tlsEnabled := viper.GetBool(constant.CfgKBAgentTLSEnabled)
+	tokenAuthEnabled := viper.GetBool(constant.CfgKBAgentTokenAuthEnabled)
+	if !tlsEnabled && !tokenAuthEnabled {
+		return nil, nil
+	}
+
+	secretKey := types.NamespacedName{
+		Namespace: a.namespace,
+		Name:      constant.GenerateKBAgentAuthSecretName(a.clusterName, a.compName),
+	}
+	secret := &corev1.Secret{}
+	if err := cli.Get(ctx, secretKey, secret); err != nil {
+		return nil, errors.Wrap(err, "failed to get the credentials of kb-agent")
+	}
+	credential := &kbacli.Credential{}
+	if tlsEnabled {
+		credential.CA = secret.Data[proto.AuthCAKey]
+		credential.Cert = secret.Data[proto.AuthCertKey]
+		credential.Key = secret.Data[proto.AuthKeyKey]
+	}
+	if tokenAuthEnabled {
+		credential.Token = string(secret.Data[proto.AuthTokenKey])
+	}
+	return credential, nil
+}
+
 func (a *kbagent) selectTargetPods(spec *appsv1.Action) ([]*corev1.Pod, error) {
 	return SelectTargetPods(a.pods, a.pod, spec)
 }
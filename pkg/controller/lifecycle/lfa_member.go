@@ -131,3 +131,15 @@ func (a *memberLeave) parameters(ctx context.Context, cli client.Reader) (map[st
 		leaveMemberPodNameVar: a.pod.Name,
 	}, nil
 }
+
+type replicationLag struct{}
+
+var _ lifecycleAction = &replicationLag{}
+
+func (a *replicationLag) name() string {
+	return "replicationLag"
+}
+
+func (a *replicationLag) parameters(ctx context.Context, cli client.Reader) (map[string]string, error) {
+	return nil, nil
+}
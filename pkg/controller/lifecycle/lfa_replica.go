@@ -21,6 +21,7 @@ package lifecycle
 
 import (
 	"context"
+	"encoding/json"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -29,6 +30,8 @@ const (
 	configFilesCreated = "KB_CONFIG_FILES_CREATED"
 	configFilesRemoved = "KB_CONFIG_FILES_REMOVED"
 	configFilesUpdated = "KB_CONFIG_FILES_UPDATED"
+
+	queryParameters = "KB_QUERY_PARAMETERS"
 )
 
 func FileTemplateChanges(created, removed, updated string) map[string]string {
@@ -39,6 +42,30 @@ func FileTemplateChanges(created, removed, updated string) map[string]string {
 	}
 }
 
+type readonly struct{}
+
+var _ lifecycleAction = &readonly{}
+
+func (a *readonly) name() string {
+	return "readonly"
+}
+
+func (a *readonly) parameters(ctx context.Context, cli client.Reader) (map[string]string, error) {
+	return nil, nil
+}
+
+type readwrite struct{}
+
+var _ lifecycleAction = &readwrite{}
+
+func (a *readwrite) name() string {
+	return "readwrite"
+}
+
+func (a *readwrite) parameters(ctx context.Context, cli client.Reader) (map[string]string, error) {
+	return nil, nil
+}
+
 type reconfigure struct {
 	args map[string]string
 }
@@ -57,3 +84,26 @@ func (a *reconfigure) parameters(ctx context.Context, cli client.Reader) (map[st
 	// - KB_CONFIG_FILES_UPDATED: file1:checksum1,file2:checksum2...
 	return a.args, nil
 }
+
+type parameterQuery struct {
+	params map[string][]string
+}
+
+var _ lifecycleAction = &parameterQuery{}
+
+func (a *parameterQuery) name() string {
+	return "parameterQuery"
+}
+
+func (a *parameterQuery) parameters(ctx context.Context, cli client.Reader) (map[string]string, error) {
+	// The container executing this action has access to following variables:
+	//
+	// - KB_QUERY_PARAMETERS: {"file1": ["param1", "param2"], "file2": ["param3"]}
+	data, err := json.Marshal(a.params)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		queryParameters: string(data),
+	}, nil
+}
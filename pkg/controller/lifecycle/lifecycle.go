@@ -48,12 +48,16 @@ type Lifecycle interface {
 
 	MemberLeave(ctx context.Context, cli client.Reader, opts *Options) error
 
-	// Readonly(ctx context.Context, cli client.Reader, opts *Options) error
+	ReplicationLag(ctx context.Context, cli client.Reader, opts *Options) ([]byte, error)
 
-	// Readwrite(ctx context.Context, cli client.Reader, opts *Options) error
+	Readonly(ctx context.Context, cli client.Reader, opts *Options) error
+
+	Readwrite(ctx context.Context, cli client.Reader, opts *Options) error
 
 	Reconfigure(ctx context.Context, cli client.Reader, opts *Options, args map[string]string) error
 
+	ParameterQuery(ctx context.Context, cli client.Reader, opts *Options, params map[string][]string) ([]byte, error)
+
 	AccountProvision(ctx context.Context, cli client.Reader, opts *Options, statement, user, password string) error
 
 	UserDefined(ctx context.Context, cli client.Reader, opts *Options, name string, action *appsv1.Action, args map[string]string) error
@@ -414,6 +414,84 @@ var _ = Describe("lifecycle", func() {
 			Expect(output).Should(Equal([]byte(val)))
 		})
 
+		It("parameter query", func() {
+			lifecycleActions.ParameterQuery = &appsv1.Action{
+				Exec: &appsv1.ExecAction{
+					Command: []string{"/bin/bash", "-c", "echo -n parameter-query"},
+				},
+			}
+			lifecycle, err := New(namespace, clusterName, compName, lifecycleActions, nil, nil, pods...)
+			Expect(err).Should(BeNil())
+			Expect(lifecycle).ShouldNot(BeNil())
+
+			output := []byte(`{"my.cnf":{"max_connections":"1000"}}`)
+			mockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+				recorder.Action(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req proto.ActionRequest) (proto.ActionResponse, error) {
+					Expect(req.Action).Should(Equal("parameterQuery"))
+					Expect(req.Parameters).Should(HaveKeyWithValue("KB_QUERY_PARAMETERS", `{"my.cnf":["max_connections"]}`))
+					return proto.ActionResponse{
+						Output: output,
+					}, nil
+				}).AnyTimes()
+			})
+
+			result, err1 := lifecycle.ParameterQuery(ctx, k8sClient, nil, map[string][]string{"my.cnf": {"max_connections"}})
+			Expect(err1).Should(BeNil())
+			Expect(result).Should(Equal(output))
+		})
+
+		It("replication lag", func() {
+			lifecycleActions.ReplicationLag = &appsv1.Action{
+				Exec: &appsv1.ExecAction{
+					Command: []string{"/bin/bash", "-c", "echo -n 1024"},
+				},
+			}
+			lifecycle, err := New(namespace, clusterName, compName, lifecycleActions, nil, nil, pods...)
+			Expect(err).Should(BeNil())
+			Expect(lifecycle).ShouldNot(BeNil())
+
+			mockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+				recorder.Action(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req proto.ActionRequest) (proto.ActionResponse, error) {
+					Expect(req.Action).Should(Equal("replicationLag"))
+					return proto.ActionResponse{
+						Output: []byte("1024"),
+					}, nil
+				}).AnyTimes()
+			})
+
+			output, err1 := lifecycle.ReplicationLag(ctx, k8sClient, nil)
+			Expect(err1).Should(BeNil())
+			Expect(output).Should(Equal([]byte("1024")))
+		})
+
+		It("readonly & readwrite", func() {
+			lifecycleActions.Readonly = &appsv1.Action{
+				Exec: &appsv1.ExecAction{
+					Command: []string{"/bin/bash", "-c", "echo -n readonly"},
+				},
+			}
+			lifecycleActions.Readwrite = &appsv1.Action{
+				Exec: &appsv1.ExecAction{
+					Command: []string{"/bin/bash", "-c", "echo -n readwrite"},
+				},
+			}
+			lifecycle, err := New(namespace, clusterName, compName, lifecycleActions, nil, pods[0], pods...)
+			Expect(err).Should(BeNil())
+			Expect(lifecycle).ShouldNot(BeNil())
+
+			actions := make([]string, 0)
+			mockKBAgentClient(func(recorder *kbacli.MockClientMockRecorder) {
+				recorder.Action(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req proto.ActionRequest) (proto.ActionResponse, error) {
+					actions = append(actions, req.Action)
+					return proto.ActionResponse{}, nil
+				}).AnyTimes()
+			})
+
+			Expect(lifecycle.Readonly(ctx, k8sClient, nil)).Should(Succeed())
+			Expect(lifecycle.Readwrite(ctx, k8sClient, nil)).Should(Succeed())
+			Expect(actions).Should(Equal([]string{"readonly", "readwrite"}))
+		})
+
 		It("precondition", func() {
 			clusterReady := appsv1.ClusterReadyPreConditionType
 			lifecycleActions.PostProvision.PreCondition = &clusterReady
@@ -21,16 +21,27 @@ package plan
 
 import (
 	"bytes"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/Masterminds/sprig/v3"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	defaultTLSCertValidityDays    = 36500
+	defaultTLSCertRenewBeforeDays = 30
 )
 
 func ComposeTLSCertsWithSecret(compDef *appsv1.ComponentDefinition,
@@ -46,15 +57,16 @@ func ComposeTLSCertsWithSecret(compDef *appsv1.ComponentDefinition,
 	// IP: 127.0.0.1 and ::1
 	// DNS: localhost and *.<clusterName>-<compName>-headless.<namespace>.svc.cluster.local
 	const spliter = "___spliter___"
+	validity := tlsCertValidityDays()
 	SignedCertTpl := fmt.Sprintf(`
-	{{- $ca := genCA "KubeBlocks" 36500 -}}
-	{{- $cert := genSignedCert "%s peer" (list "127.0.0.1" "::1") (list "localhost" "*.%s-%s-headless.%s.svc.cluster.local") 36500 $ca -}}
+	{{- $ca := genCA "KubeBlocks" %d -}}
+	{{- $cert := genSignedCert "%s peer" (list "127.0.0.1" "::1") (list "localhost" "*.%s-%s-headless.%s.svc.cluster.local") %d $ca -}}
 	{{- $ca.Cert -}}
 	{{- print "%s" -}}
 	{{- $cert.Cert -}}
 	{{- print "%s" -}}
 	{{- $cert.Key -}}
-`, compName, clusterName, compName, namespace, spliter, spliter)
+`, validity, compName, clusterName, compName, namespace, validity, spliter, spliter)
 	out, err := buildFromTemplate(SignedCertTpl, nil)
 	if err != nil {
 		return nil, err
@@ -77,6 +89,111 @@ func ComposeTLSCertsWithSecret(compDef *appsv1.ComponentDefinition,
 	return secret, nil
 }
 
+// RenewTLSCertsWithSecret issues new certificates as ComposeTLSCertsWithSecret does. The private key of the CA is not kept,
+// so the new certificates are signed by a new CA, and the CA certificates in the running secret that have not expired
+// are appended to the CA file. The instances keep trusting the peers that still present the old certificates until
+// the new ones are rolled out.
+func RenewTLSCertsWithSecret(compDef *appsv1.ComponentDefinition,
+	synthesizedComp component.SynthesizedComponent, running, secret *corev1.Secret) (*corev1.Secret, error) {
+	secret, err := ComposeTLSCertsWithSecret(compDef, synthesizedComp, secret)
+	if err != nil {
+		return nil, err
+	}
+	if compDef.Spec.TLS.CAFile != nil {
+		key := *compDef.Spec.TLS.CAFile
+		secret.Data[key] = append(secret.Data[key], unexpiredTLSCerts(running.Data[key], time.Now())...)
+	}
+	return secret, nil
+}
+
+// unexpiredTLSCerts returns the PEM-encoded certificates in the data that have not expired.
+func unexpiredTLSCerts(data []byte, now time.Time) []byte {
+	var (
+		block *pem.Block
+		certs []byte
+	)
+	for {
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certs
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil || now.After(cert.NotAfter) {
+			continue
+		}
+		certs = append(certs, pem.EncodeToMemory(block)...)
+	}
+}
+
+// ParseTLSCert parses the first PEM-encoded certificate in the data.
+func ParseTLSCert(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM-encoded certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// IsTLSCertExpiring checks whether the certificate will expire within the renewal window.
+func IsTLSCertExpiring(cert *x509.Certificate, now time.Time) bool {
+	return now.Add(TLSCertRenewBefore()).After(cert.NotAfter)
+}
+
+// TLSCertRenewBefore returns how long ahead of the expiry the certificates should be renewed.
+func TLSCertRenewBefore() time.Duration {
+	days := viper.GetInt(constant.CfgKeyTLSCertRenewBeforeDays)
+	if days <= 0 {
+		days = defaultTLSCertRenewBeforeDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+func tlsCertValidityDays() int {
+	days := viper.GetInt(constant.CfgKeyTLSCertValidityDays)
+	if days <= 0 {
+		days = defaultTLSCertValidityDays
+	}
+	return days
+}
+
+// ComposeKBAgentAuthSecret generates the bearer token and the certificates used to authenticate to the kb-agent.
+// The certificate is used as both the server and the client certificate, and it is verified with the server name
+// instead of the pod IP.
+func ComposeKBAgentAuthSecret(secret *corev1.Secret) (*corev1.Secret, error) {
+	const spliter = "___spliter___"
+	tpl := fmt.Sprintf(`
+	{{- $ca := genCA "KubeBlocks" 36500 -}}
+	{{- $cert := genSignedCert "%s" nil (list "%s") 36500 $ca -}}
+	{{- $ca.Cert -}}
+	{{- print "%s" -}}
+	{{- $cert.Cert -}}
+	{{- print "%s" -}}
+	{{- $cert.Key -}}
+	{{- print "%s" -}}
+	{{- randAlphaNum 32 -}}
+`, proto.AuthServerName, proto.AuthServerName, spliter, spliter, spliter)
+	out, err := buildFromTemplate(tpl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(out, spliter)
+	if len(parts) != 4 {
+		return nil, errors.Errorf("generate kb-agent credentials failed for secret %s/%s", secret.Namespace, secret.Name)
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[proto.AuthCAKey] = []byte(parts[0])
+	secret.Data[proto.AuthCertKey] = []byte(parts[1])
+	secret.Data[proto.AuthKeyKey] = []byte(parts[2])
+	secret.Data[proto.AuthTokenKey] = []byte(parts[3])
+	return secret, nil
+}
+
 func buildFromTemplate(tpl string, vars interface{}) (string, error) {
 	fmap := sprig.TxtFuncMap()
 	t := template.Must(template.New("tls").Funcs(fmap).Parse(tpl))
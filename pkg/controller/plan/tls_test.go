@@ -20,6 +20,10 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package plan
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -28,7 +32,10 @@ import (
 	"k8s.io/utils/ptr"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 var _ = Describe("TLS test", func() {
@@ -61,4 +68,108 @@ var _ = Describe("TLS test", func() {
 		Expect(secret.Data[*compDef.Spec.TLS.CertFile]).ShouldNot(BeZero())
 		Expect(secret.Data[*compDef.Spec.TLS.KeyFile]).ShouldNot(BeZero())
 	})
+
+	It("TLS cert expiry", func() {
+		compDef := &appsv1.ComponentDefinition{
+			Spec: appsv1.ComponentDefinitionSpec{
+				TLS: &appsv1.TLS{
+					CertFile: ptr.To("cert.pem"),
+				},
+			},
+		}
+		synthesizedComp := component.SynthesizedComponent{
+			Namespace:   testCtx.DefaultNamespace,
+			ClusterName: "foo",
+			Name:        "bar",
+		}
+		compose := func() *x509.Certificate {
+			secret := &corev1.Secret{
+				Data: map[string][]byte{},
+			}
+			_, err := ComposeTLSCertsWithSecret(compDef, synthesizedComp, secret)
+			Expect(err).Should(BeNil())
+			cert, err := ParseTLSCert(secret.Data[*compDef.Spec.TLS.CertFile])
+			Expect(err).Should(BeNil())
+			return cert
+		}
+
+		By("the certs issued with the default validity")
+		cert := compose()
+		Expect(IsTLSCertExpiring(cert, time.Now())).Should(BeFalse())
+
+		By("the certs issued with a validity shorter than the renewal window")
+		viper.Set(constant.CfgKeyTLSCertValidityDays, 10)
+		defer viper.Set(constant.CfgKeyTLSCertValidityDays, 0)
+		cert = compose()
+		Expect(cert.NotAfter).Should(BeTemporally("~", time.Now().Add(10*24*time.Hour), time.Hour))
+		Expect(IsTLSCertExpiring(cert, time.Now())).Should(BeTrue())
+
+		_, err := ParseTLSCert([]byte("not a certificate"))
+		Expect(err).ShouldNot(BeNil())
+	})
+
+	It("RenewTLSCertsWithSecret", func() {
+		compDef := &appsv1.ComponentDefinition{
+			Spec: appsv1.ComponentDefinitionSpec{
+				TLS: &appsv1.TLS{
+					CAFile:   ptr.To("ca.pem"),
+					CertFile: ptr.To("cert.pem"),
+					KeyFile:  ptr.To("key.pem"),
+				},
+			},
+		}
+		synthesizedComp := component.SynthesizedComponent{
+			Namespace:   testCtx.DefaultNamespace,
+			ClusterName: "foo",
+			Name:        "bar",
+		}
+		running, err := ComposeTLSCertsWithSecret(compDef, synthesizedComp, &corev1.Secret{Data: map[string][]byte{}})
+		Expect(err).Should(BeNil())
+		renewed, err := RenewTLSCertsWithSecret(compDef, synthesizedComp, running, &corev1.Secret{Data: map[string][]byte{}})
+		Expect(err).Should(BeNil())
+		Expect(renewed.Data[*compDef.Spec.TLS.CertFile]).ShouldNot(Equal(running.Data[*compDef.Spec.TLS.CertFile]))
+
+		By("both the old and new certs are trusted by the CA bundle")
+		pool := x509.NewCertPool()
+		Expect(pool.AppendCertsFromPEM(renewed.Data[*compDef.Spec.TLS.CAFile])).Should(BeTrue())
+		for _, secret := range []*corev1.Secret{running, renewed} {
+			cert, err := ParseTLSCert(secret.Data[*compDef.Spec.TLS.CertFile])
+			Expect(err).Should(BeNil())
+			_, err = cert.Verify(x509.VerifyOptions{
+				Roots:     pool,
+				KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+			})
+			Expect(err).Should(BeNil())
+		}
+
+		By("the expired CA certs are dropped")
+		bundle := renewed.Data[*compDef.Spec.TLS.CAFile]
+		Expect(unexpiredTLSCerts(bundle, time.Now())).Should(Equal(bundle))
+		Expect(unexpiredTLSCerts(bundle, time.Now().Add(36501*24*time.Hour))).Should(BeEmpty())
+	})
+
+	It("ComposeKBAgentAuthSecret", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      "foo-bar-kbagent-auth",
+			},
+		}
+		_, err := ComposeKBAgentAuthSecret(secret)
+		Expect(err).Should(BeNil())
+		Expect(secret.Data[proto.AuthTokenKey]).Should(HaveLen(32))
+
+		pair, err := tls.X509KeyPair(secret.Data[proto.AuthCertKey], secret.Data[proto.AuthKeyKey])
+		Expect(err).Should(BeNil())
+		cert, err := x509.ParseCertificate(pair.Certificate[0])
+		Expect(err).Should(BeNil())
+		pool := x509.NewCertPool()
+		Expect(pool.AppendCertsFromPEM(secret.Data[proto.AuthCAKey])).Should(BeTrue())
+		_, err = cert.Verify(x509.VerifyOptions{
+			DNSName:   proto.AuthServerName,
+			Roots:     pool,
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		})
+		Expect(err).Should(BeNil())
+	})
 })
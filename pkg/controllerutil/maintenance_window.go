@@ -0,0 +1,87 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"time"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+)
+
+// IsInMaintenanceWindow checks whether the time is within one of the maintenance windows.
+// If not, it returns the start time of the next window as well.
+// It always returns true if no valid windows are specified.
+func IsInMaintenanceWindow(windows []appsv1.MaintenanceWindow, now time.Time) (bool, *time.Time) {
+	if len(windows) == 0 {
+		return true, nil
+	}
+	now = now.UTC()
+	var (
+		next  *time.Time
+		valid bool
+	)
+	for _, w := range windows {
+		hour, minute, ok := parseMaintenanceWindowStartTime(w.StartTime)
+		if !ok || w.DurationMinutes <= 0 {
+			continue
+		}
+		valid = true
+		duration := time.Duration(w.DurationMinutes) * time.Minute
+		// a window lasts at most one week, so it's enough to check the windows started within
+		// the last seven days and the next seven days.
+		for days := -7; days <= 7; days++ {
+			day := now.AddDate(0, 0, days)
+			if !maintenanceWindowStartsOn(w, day.Weekday()) {
+				continue
+			}
+			start := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, time.UTC)
+			if !now.Before(start) && now.Before(start.Add(duration)) {
+				return true, nil
+			}
+			if start.After(now) && (next == nil || start.Before(*next)) {
+				next = &start
+			}
+		}
+	}
+	if !valid {
+		return true, nil
+	}
+	return false, next
+}
+
+func maintenanceWindowStartsOn(window appsv1.MaintenanceWindow, weekday time.Weekday) bool {
+	if len(window.DaysOfWeek) == 0 {
+		return true
+	}
+	for _, d := range window.DaysOfWeek {
+		if string(d) == weekday.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func parseMaintenanceWindowStartTime(startTime string) (int, int, bool) {
+	t, err := time.Parse("15:04", startTime)
+	if err != nil {
+		return 0, 0, false
+	}
+	return t.Hour(), t.Minute(), true
+}
@@ -0,0 +1,107 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"testing"
+	"time"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+)
+
+func TestIsInMaintenanceWindow(t *testing.T) {
+	// 2024-01-01 is Monday
+	monday := func(hour, minute int) time.Time {
+		return time.Date(2024, 1, 1, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		windows  []appsv1.MaintenanceWindow
+		now      time.Time
+		inWindow bool
+		next     *time.Time
+	}{
+		{
+			name:     "no windows",
+			now:      monday(10, 0),
+			inWindow: true,
+		},
+		{
+			name:     "daily window",
+			windows:  []appsv1.MaintenanceWindow{{StartTime: "09:30", DurationMinutes: 60}},
+			now:      monday(10, 0),
+			inWindow: true,
+		},
+		{
+			name:     "before the daily window",
+			windows:  []appsv1.MaintenanceWindow{{StartTime: "09:30", DurationMinutes: 60}},
+			now:      monday(9, 0),
+			inWindow: false,
+			next:     &[]time.Time{monday(9, 30)}[0],
+		},
+		{
+			name:     "after the daily window",
+			windows:  []appsv1.MaintenanceWindow{{StartTime: "09:30", DurationMinutes: 60}},
+			now:      monday(10, 30),
+			inWindow: false,
+			next:     &[]time.Time{monday(9, 30).AddDate(0, 0, 1)}[0],
+		},
+		{
+			name:     "window across midnight of the previous day",
+			windows:  []appsv1.MaintenanceWindow{{DaysOfWeek: []appsv1.Weekday{appsv1.Sunday}, StartTime: "23:00", DurationMinutes: 120}},
+			now:      monday(0, 30),
+			inWindow: true,
+		},
+		{
+			name:     "weekly window",
+			windows:  []appsv1.MaintenanceWindow{{DaysOfWeek: []appsv1.Weekday{appsv1.Wednesday, appsv1.Saturday}, StartTime: "02:00", DurationMinutes: 180}},
+			now:      monday(2, 30),
+			inWindow: false,
+			next:     &[]time.Time{monday(2, 0).AddDate(0, 0, 2)}[0],
+		},
+		{
+			name: "earliest of multiple windows",
+			windows: []appsv1.MaintenanceWindow{
+				{DaysOfWeek: []appsv1.Weekday{appsv1.Friday}, StartTime: "01:00", DurationMinutes: 60},
+				{DaysOfWeek: []appsv1.Weekday{appsv1.Tuesday}, StartTime: "22:00", DurationMinutes: 60},
+			},
+			now:      monday(12, 0),
+			inWindow: false,
+			next:     &[]time.Time{monday(22, 0).AddDate(0, 0, 1)}[0],
+		},
+		{
+			name:     "time in other timezone",
+			windows:  []appsv1.MaintenanceWindow{{StartTime: "09:30", DurationMinutes: 60}},
+			now:      monday(10, 0).In(time.FixedZone("UTC+8", 8*3600)),
+			inWindow: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inWindow, next := IsInMaintenanceWindow(tt.windows, tt.now)
+			if inWindow != tt.inWindow {
+				t.Errorf("expected in window: %v, but got %v", tt.inWindow, inWindow)
+			}
+			if tt.next == nil && next != nil || tt.next != nil && (next == nil || !next.Equal(*tt.next)) {
+				t.Errorf("expected next window: %v, but got %v", tt.next, next)
+			}
+		})
+	}
+}
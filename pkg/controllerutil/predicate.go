@@ -41,6 +41,7 @@ var (
 	//         pkg                   reconciler               resource                 sub-resources             operation
 	// experimentalv1alpha1 NodeCountScalerReconciler      NodeCountScaler          corev1.Node                      w
 	//                                                                              appsv1alpha1.Cluster             w
+	//                      ComponentAutoscalerReconciler  ComponentAutoscaler      opsv1alpha1.OpsRequest           w
 	// extensionsv1alpha1   AddonReconciler                Addon                    batchv1.Job                      w
 	// corev1               EventReconciler                Event
 	// workloadsv1alpha1    InstanceSetReconciler          InstanceSet              corev1.Pod                       w
@@ -90,7 +91,7 @@ var (
 	//    addon: ClusterDefinition, ComponentDefinition, ComponentVersion, BackupPolicyTemplate
 	//	  user：ServiceDescriptor, Cluster
 	//    controller: Component, InstanceSet
-	// unchanged：NodeCountScaler, Addon - the new operator will be responsible for these
+	// unchanged：NodeCountScaler, ComponentAutoscaler, Addon - the new operator will be responsible for these
 	// deleted：ClusterVersion, ComponentClassDefinition - nothing to do
 	// group changed：OpsRequest, OpsDefinition, ConfigConstraint, Configuration - nothing to do
 	// TODO:
@@ -50,6 +50,11 @@ type JobAction struct {
 
 	// BackOffLimit is the number of retries before considering a JobAction as failed.
 	BackOffLimit *int32
+
+	// ChecksumContainerName is the name of the container that reports the checksum
+	// of the backed up data by its termination message. If it is empty, the checksum
+	// will not be recorded.
+	ChecksumContainerName string
 }
 
 func (j *JobAction) GetName() string {
@@ -87,8 +92,13 @@ func (j *JobAction) Execute(actCtx ActionContext) (*dpv1alpha1.ActionStatus, err
 		_, finishedType, msg := utils.IsJobFinished(&original)
 		switch finishedType {
 		case batchv1.JobComplete:
+			checksum, err := j.getChecksum(actCtx)
+			if err != nil {
+				return handleErr(ctrlutil.NewErrorf(ctrlutil.ErrorTypeRequeue, "failed to get the checksum of job %s: %s", key.Name, err.Error()))
+			}
 			return sb.phase(dpv1alpha1.ActionPhaseCompleted).
 				completionTimestamp(nil).
+				checksum(checksum).
 				build(), nil
 		case batchv1.JobFailed:
 			return sb.phase(dpv1alpha1.ActionPhaseFailed).
@@ -123,6 +133,15 @@ func (j *JobAction) Execute(actCtx ActionContext) (*dpv1alpha1.ActionStatus, err
 	return handleErr(client.IgnoreAlreadyExists(actCtx.Client.Create(actCtx.Ctx, job)))
 }
 
+// getChecksum gets the checksum reported by the termination message of the checksum container.
+func (j *JobAction) getChecksum(actCtx ActionContext) (string, error) {
+	if j.ChecksumContainerName == "" {
+		return "", nil
+	}
+	return utils.GetJobContainerTerminationMessage(actCtx.Ctx, actCtx.Client,
+		j.ObjectMeta.Namespace, j.ObjectMeta.Name, j.ChecksumContainerName)
+}
+
 func (j *JobAction) validate() error {
 	if j.ObjectMeta.Name == "" {
 		return fmt.Errorf("name is required")
@@ -105,6 +105,11 @@ func (b *statusBuilder) timeRange(start, end *metav1.Time) *statusBuilder {
 	return b
 }
 
+func (b *statusBuilder) checksum(checksum string) *statusBuilder {
+	b.status.Checksum = checksum
+	return b
+}
+
 func (b *statusBuilder) build() *dpv1alpha1.ActionStatus {
 	return b.status
 }
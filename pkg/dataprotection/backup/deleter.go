@@ -70,12 +70,34 @@ type Deleter struct {
 // If the deletion job exists, it will check the job status and return the corresponding
 // deletion status.
 func (d *Deleter) DeleteBackupFiles(backup *dpv1alpha1.Backup) (DeletionStatus, error) {
+	return d.deleteBackupFiles(backup, BuildDeleteBackupFilesJobKey(backup, false), true)
+}
+
+// DeleteReplicaBackupFiles builds a job to delete the copy of the backup files in the
+// secondary backup repository, which is recorded in `backup.status.replicas[replicaIndex]`,
+// and returns the deletion status.
+func (d *Deleter) DeleteReplicaBackupFiles(backup *dpv1alpha1.Backup, replicaIndex int) (DeletionStatus, error) {
+	replica := backup.Status.Replicas[replicaIndex]
+	if replica.Path == "" {
+		return DeletionStatusSucceeded, nil
+	}
+	replicaBackup := backup.DeepCopy()
+	replicaBackup.Status.BackupRepoName = replica.BackupRepoName
+	replicaBackup.Status.Path = replica.Path
+	replicaBackup.Status.PersistentVolumeClaimName = ""
+	replicaBackup.Status.KopiaRepoPath = ""
+	// the pre-delete action only needs to be executed for the original backup files
+	return d.deleteBackupFiles(replicaBackup, BuildDeleteReplicaBackupFilesJobKey(backup, replicaIndex), false)
+}
+
+func (d *Deleter) deleteBackupFiles(backup *dpv1alpha1.Backup,
+	jobKey types.NamespacedName,
+	doPreDelete bool) (DeletionStatus, error) {
 	backupMethod := backup.Status.BackupMethod
 	if backupMethod != nil && boolptr.IsSetToTrue(backupMethod.SnapshotVolumes) {
 		// if the backup is volume snapshot, ignore to delete files
 		return DeletionStatusSucceeded, nil
 	}
-	jobKey := BuildDeleteBackupFilesJobKey(backup, false)
 	job := &batchv1.Job{}
 	exists, err := ctrlutil.CheckResourceExists(d.Ctx, d.Client, jobKey, job)
 	if err != nil {
@@ -142,9 +164,11 @@ func (d *Deleter) DeleteBackupFiles(backup *dpv1alpha1.Backup) (DeletionStatus,
 		backupFilePath = "/" + backupFilePath
 	}
 	// do pre-delete action
-	preDeleteAction, err := d.getPreDeleteAction(backup.Status.BackupMethod)
-	if err != nil {
-		return DeletionStatusUnknown, err
+	var preDeleteAction *dpv1alpha1.BaseJobActionSpec
+	if doPreDelete {
+		if preDeleteAction, err = d.getPreDeleteAction(backup.Status.BackupMethod); err != nil {
+			return DeletionStatusUnknown, err
+		}
 	}
 	if preDeleteAction != nil {
 		preJob, err := d.doPreDeleteAction(backup, backupRepo, preDeleteAction, legacyPVCName, backupFilePath)
@@ -389,3 +413,11 @@ func BuildDeleteBackupFilesJobKey(backup *dpv1alpha1.Backup, isPreDelete bool) c
 	}
 	return client.ObjectKey{Namespace: backup.Namespace, Name: jobName}
 }
+
+func BuildDeleteReplicaBackupFilesJobKey(backup *dpv1alpha1.Backup, replicaIndex int) client.ObjectKey {
+	jobName := fmt.Sprintf("%s-%s%d-%s", backup.UID[:8], deleteBackupFilesJobNamePrefix, replicaIndex, backup.Name)
+	if len(jobName) > 63 {
+		jobName = strings.TrimSuffix(jobName[:63], "-")
+	}
+	return client.ObjectKey{Namespace: backup.Namespace, Name: jobName}
+}
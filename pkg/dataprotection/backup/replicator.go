@@ -0,0 +1,275 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	replicateBackupFilesJobNamePrefix = "replicate-"
+	replicateContainerName            = "replicator"
+	replicaRepoVolumeMountPath        = "/replicadata"
+)
+
+type ReplicationStatus string
+
+const (
+	ReplicationStatusCopying   ReplicationStatus = "Copying"
+	ReplicationStatusCompleted ReplicationStatus = "Completed"
+	ReplicationStatusFailed    ReplicationStatus = "Failed"
+	// ReplicationStatusJobFailed means the replication job failed, the replication can be
+	// retried by RetryReplicationJob.
+	ReplicationStatusJobFailed ReplicationStatus = "JobFailed"
+)
+
+type Replicator struct {
+	ctrlutil.RequestCtx
+	Client               client.Client
+	Scheme               *runtime.Scheme
+	WorkerServiceAccount string
+}
+
+// ReplicateBackupFiles builds a job to copy the backup files from the backup repository of the backup
+// to the path of the secondary backup repository. The secondary backup repository must be ready
+// in the namespace of the backup. It returns the replication status and a message that describes
+// the failure reason.
+func (r *Replicator) ReplicateBackupFiles(backup *dpv1alpha1.Backup,
+	replicaRepo *dpv1alpha1.BackupRepo,
+	replicaPath string) (ReplicationStatus, string, error) {
+	if msg := checkBackupReplicable(backup); msg != "" {
+		return ReplicationStatusFailed, msg, nil
+	}
+	jobKey := BuildReplicateBackupFilesJobKey(backup)
+	job := &batchv1.Job{}
+	exists, err := ctrlutil.CheckResourceExists(r.Ctx, r.Client, jobKey, job)
+	if err != nil {
+		return ReplicationStatusCopying, "", err
+	}
+
+	// if replication job exists, check its status
+	if exists {
+		if !job.DeletionTimestamp.IsZero() {
+			// the failed job is being deleted to retry the replication.
+			return ReplicationStatusCopying, "", nil
+		}
+		_, finishedType, msg := utils.IsJobFinished(job)
+		switch finishedType {
+		case batchv1.JobComplete:
+			return ReplicationStatusCompleted, "", nil
+		case batchv1.JobFailed:
+			return ReplicationStatusJobFailed, fmt.Sprintf("replication job \"%s\" failed, %s", job.Name, msg), nil
+		default:
+			return ReplicationStatusCopying, "", nil
+		}
+	}
+
+	backupRepo := &dpv1alpha1.BackupRepo{}
+	if err = r.Client.Get(r.Ctx, client.ObjectKey{Name: backup.Status.BackupRepoName}, backupRepo); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ReplicationStatusFailed, fmt.Sprintf("backup repo %s not found", backup.Status.BackupRepoName), nil
+		}
+		return ReplicationStatusCopying, "", err
+	}
+	if replicaPath == "" {
+		return ReplicationStatusFailed, "replica path is empty", nil
+	}
+	// make sure the paths have a leading slash
+	backupFilePath := backup.Status.Path
+	if !strings.HasPrefix(backupFilePath, "/") {
+		backupFilePath = "/" + backupFilePath
+	}
+	if !strings.HasPrefix(replicaPath, "/") {
+		replicaPath = "/" + replicaPath
+	}
+	return ReplicationStatusCopying, "", r.createReplicateBackupFilesJob(jobKey, backup, backupRepo, replicaRepo,
+		backupFilePath, replicaPath)
+}
+
+// RetryReplicationJob deletes the failed replication job of the backup once the backoff has
+// elapsed since the job failed, so that the job is created again by ReplicateBackupFiles.
+// It returns the remaining time to wait if the backoff has not elapsed yet.
+func (r *Replicator) RetryReplicationJob(backup *dpv1alpha1.Backup, backoff time.Duration) (time.Duration, error) {
+	job := &batchv1.Job{}
+	exists, err := ctrlutil.CheckResourceExists(r.Ctx, r.Client, BuildReplicateBackupFilesJobKey(backup), job)
+	if err != nil || !exists {
+		return 0, err
+	}
+	for _, c := range job.Status.Conditions {
+		if c.Type != batchv1.JobFailed || c.Status != corev1.ConditionTrue {
+			continue
+		}
+		if wait := time.Until(c.LastTransitionTime.Add(backoff)); wait > 0 {
+			return wait, nil
+		}
+	}
+	return 0, client.IgnoreNotFound(ctrlutil.BackgroundDeleteObject(r.Client, r.Ctx, job))
+}
+
+// DeleteReplicationJob deletes the replication job of the backup.
+func (r *Replicator) DeleteReplicationJob(backup *dpv1alpha1.Backup) error {
+	job := &batchv1.Job{}
+	exists, err := ctrlutil.CheckResourceExists(r.Ctx, r.Client, BuildReplicateBackupFilesJobKey(backup), job)
+	if err != nil || !exists {
+		return err
+	}
+	return client.IgnoreNotFound(ctrlutil.BackgroundDeleteObject(r.Client, r.Ctx, job))
+}
+
+// checkBackupReplicable returns the reason why the backup data can not be replicated,
+// or an empty string if it can be replicated.
+func checkBackupReplicable(backup *dpv1alpha1.Backup) string {
+	backupMethod := backup.Status.BackupMethod
+	switch {
+	case backupMethod != nil && boolptr.IsSetToTrue(backupMethod.SnapshotVolumes):
+		return "the backup taken by volume snapshots can not be replicated"
+	case backup.Status.KopiaRepoPath != "":
+		return "the backup stored in a Kopia repository can not be replicated"
+	case backup.Status.BackupRepoName == "":
+		return "backup repo is not recorded in the backup status"
+	case backup.Status.Path == "":
+		return "backup path is empty"
+	}
+	return ""
+}
+
+func (r *Replicator) buildReplicateBackupFilesScript(backupPath, replicaPath string) string {
+	// this script pulls each backup file from the backup repository and pushes it to
+	// the secondary backup repository. The secondary backup repository is accessed
+	// by overriding the local backend path or the config file of datasafed.
+	return fmt.Sprintf(`
+set -o errexit
+set -o nounset
+set -o pipefail
+export PATH="$PATH:$%s"
+export DATASAFED_BACKEND_BASE_PATH="%s"
+replica_path="%s"
+
+replica_datasafed() {
+  if [ -n "${%s:-}" ]; then
+    DATASAFED_LOCAL_BACKEND_PATH="${%s}" DATASAFED_BACKEND_BASE_PATH="${replica_path}" datasafed "$@"
+  else
+    env -u DATASAFED_LOCAL_BACKEND_PATH DATASAFED_BACKEND_BASE_PATH="${replica_path}" datasafed -c "${%s}" "$@"
+  fi
+}
+
+# read the file list line by line from a file, so that the file names with spaces are kept
+# and the counter is not lost in a subshell.
+list_file=$(mktemp)
+datasafed list -r -f / > "${list_file}"
+copied=0
+while IFS= read -r file; do
+  datasafed pull "${file}" - < /dev/null | replica_datasafed push - "${file}"
+  copied=$((copied+1))
+done < "${list_file}"
+rm -f "${list_file}"
+echo "copied ${copied} backup files to ${replica_path}"
+`, dptypes.DPDatasafedBinPath, backupPath, replicaPath,
+		dptypes.DPReplicaDatasafedLocalBackendPath, dptypes.DPReplicaDatasafedLocalBackendPath,
+		dptypes.DPReplicaDatasafedConfigFile)
+}
+
+func (r *Replicator) createReplicateBackupFilesJob(
+	jobKey types.NamespacedName,
+	backup *dpv1alpha1.Backup,
+	backupRepo *dpv1alpha1.BackupRepo,
+	replicaRepo *dpv1alpha1.BackupRepo,
+	backupFilePath string,
+	replicaFilePath string) error {
+	runAsUser := int64(0)
+	container := corev1.Container{
+		Name:            replicateContainerName,
+		Command:         []string{"sh", "-c"},
+		Args:            []string{r.buildReplicateBackupFilesScript(backupFilePath, replicaFilePath)},
+		Image:           viper.GetString(constant.KBToolsImage),
+		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: boolptr.False(),
+			RunAsUser:                &runAsUser,
+		},
+	}
+	ctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
+
+	// build pod
+	podSpec := corev1.PodSpec{
+		Containers:         []corev1.Container{container},
+		RestartPolicy:      corev1.RestartPolicyNever,
+		ServiceAccountName: r.WorkerServiceAccount,
+	}
+	if err := utils.AddTolerations(&podSpec); err != nil {
+		return err
+	}
+	// the backup data is decrypted when pulled and encrypted again when pushed,
+	// so the copy is encrypted with the same encryption config.
+	utils.InjectDatasafed(&podSpec, backupRepo, RepoVolumeMountPath, backup.Status.EncryptionConfig, "")
+	utils.InjectReplicaDatasafed(&podSpec, replicaRepo, replicaRepoVolumeMountPath)
+
+	// build job
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: jobKey.Namespace,
+			Name:      jobKey.Name,
+			Labels: map[string]string{
+				constant.AppManagedByLabelKey: dptypes.AppName,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: jobKey.Namespace,
+					Name:      jobKey.Name,
+				},
+				Spec: podSpec,
+			},
+			BackoffLimit: &dptypes.DefaultBackOffLimit,
+		},
+	}
+	if err := utils.SetControllerReference(backup, job, r.Scheme); err != nil {
+		return err
+	}
+	r.Log.V(1).Info("create a job to replicate backup files", "job", job)
+	return client.IgnoreAlreadyExists(r.Client.Create(r.Ctx, job))
+}
+
+func BuildReplicateBackupFilesJobKey(backup *dpv1alpha1.Backup) client.ObjectKey {
+	jobName := fmt.Sprintf("%s-%s%s", backup.UID[:8], replicateBackupFilesJobNamePrefix, backup.Name)
+	if len(jobName) > 63 {
+		jobName = strings.TrimSuffix(jobName[:63], "-")
+	}
+	return client.ObjectKey{Namespace: backup.Namespace, Name: jobName}
+}
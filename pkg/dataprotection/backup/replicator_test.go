@@ -0,0 +1,167 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	testdp "github.com/apecloud/kubeblocks/pkg/testutil/dataprotection"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+var _ = Describe("Backup Replicator Test", func() {
+	const (
+		backupPath      = "/backup/test-backup"
+		replicaPath     = "/replica/test-backup"
+		replicaRepoName = "replica-repo"
+	)
+
+	buildReplicator := func() *Replicator {
+		return &Replicator{
+			RequestCtx: ctrlutil.RequestCtx{
+				Log:      logger,
+				Ctx:      testCtx.Ctx,
+				Recorder: recorder,
+			},
+			Scheme: testEnv.Scheme,
+			Client: testCtx.Cli,
+		}
+	}
+
+	cleanEnv := func() {
+		By("clean resources")
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupSignature, true, inNS)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.JobSignature, true, inNS)
+		testapps.ClearResources(&testCtx, generics.BackupRepoSignature, client.HasLabels{testCtx.TestObjLabelKey})
+	}
+
+	BeforeEach(func() {
+		cleanEnv()
+		viper.Set(constant.KBToolsImage, testdp.KBToolImage)
+	})
+
+	AfterEach(func() {
+		cleanEnv()
+		viper.Set(constant.KBToolsImage, "")
+	})
+
+	Context("replicate backup files", func() {
+		var (
+			backup      *dpv1alpha1.Backup
+			replicaRepo *dpv1alpha1.BackupRepo
+			replicator  *Replicator
+		)
+
+		BeforeEach(func() {
+			backup = testdp.NewFakeBackup(&testCtx, nil)
+			replicaRepo = &dpv1alpha1.BackupRepo{}
+			replicaRepo.Name = replicaRepoName
+			replicator = buildReplicator()
+		})
+
+		It("should fail when the backup is taken by volume snapshots", func() {
+			backup.Status.BackupMethod = &dpv1alpha1.BackupMethod{SnapshotVolumes: boolptr.True()}
+			status, _, err := replicator.ReplicateBackupFiles(backup, replicaRepo, replicaPath)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(status).Should(Equal(ReplicationStatusFailed))
+		})
+
+		It("should fail when the backup is stored in a Kopia repository", func() {
+			backup.Status.BackupRepoName = testdp.BackupRepoName
+			backup.Status.Path = backupPath
+			backup.Status.KopiaRepoPath = "/kopia"
+			status, _, err := replicator.ReplicateBackupFiles(backup, replicaRepo, replicaPath)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(status).Should(Equal(ReplicationStatusFailed))
+		})
+
+		It("should fail when the backup repo is not found", func() {
+			backup.Status.BackupRepoName = testdp.BackupRepoName
+			backup.Status.Path = backupPath
+			status, _, err := replicator.ReplicateBackupFiles(backup, replicaRepo, replicaPath)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(status).Should(Equal(ReplicationStatusFailed))
+		})
+
+		It("should create job to replicate backup files", func() {
+			By("mock backup repo")
+			backupRepo := testapps.CreateCustomizedObj(&testCtx, "backup/backuprepo.yaml",
+				&dpv1alpha1.BackupRepo{}, func(obj *dpv1alpha1.BackupRepo) {
+					obj.Name = testdp.BackupRepoName
+					obj.Spec.StorageProviderRef = testdp.StorageProviderName
+				})
+
+			By("replicate backup files")
+			backup.Status.BackupRepoName = backupRepo.Name
+			backup.Status.Path = backupPath
+			status, _, err := replicator.ReplicateBackupFiles(backup, replicaRepo, replicaPath)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(status).Should(Equal(ReplicationStatusCopying))
+
+			By("check job exist")
+			job := &batchv1.Job{}
+			key := BuildReplicateBackupFilesJobKey(backup)
+			Eventually(testapps.CheckObjExists(&testCtx, key, job, true)).Should(Succeed())
+
+			By("replicate backup with job succeed")
+			testdp.ReplaceK8sJobStatus(&testCtx, key, batchv1.JobComplete)
+			Eventually(func(g Gomega) {
+				status, _, err := replicator.ReplicateBackupFiles(backup, replicaRepo, replicaPath)
+				g.Expect(err).ShouldNot(HaveOccurred())
+				g.Expect(status).Should(Equal(ReplicationStatusCompleted))
+			}).Should(Succeed())
+
+			By("replicate backup with job failed")
+			testdp.ReplaceK8sJobStatus(&testCtx, key, batchv1.JobFailed)
+			Eventually(func(g Gomega) {
+				status, _, err := replicator.ReplicateBackupFiles(backup, replicaRepo, replicaPath)
+				g.Expect(err).ShouldNot(HaveOccurred())
+				g.Expect(status).Should(Equal(ReplicationStatusJobFailed))
+			}).Should(Succeed())
+
+			By("retry the failed replication job")
+			wait, err := replicator.RetryReplicationJob(backup, 0)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(wait).Should(BeZero())
+			Eventually(testapps.CheckObjExists(&testCtx, key, job, false)).Should(Succeed())
+
+			By("recreate the replication job")
+			status, _, err = replicator.ReplicateBackupFiles(backup, replicaRepo, replicaPath)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(status).Should(Equal(ReplicationStatusCopying))
+			Eventually(testapps.CheckObjExists(&testCtx, key, job, true)).Should(Succeed())
+
+			By("delete the replication job")
+			Expect(replicator.DeleteReplicationJob(backup)).Should(Succeed())
+			Eventually(testapps.CheckObjExists(&testCtx, key, job, false)).Should(Succeed())
+		})
+	})
+})
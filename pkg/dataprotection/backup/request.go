@@ -22,6 +22,7 @@ package backup
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -36,17 +37,19 @@ import (
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 const (
-	BackupDataJobNamePrefix = "dp-backup"
-	prebackupJobNamePrefix  = "dp-prebackup"
-	postbackupJobNamePrefix = "dp-postbackup"
-	BackupDataContainerName = "backupdata"
-	managerContainerName    = "manager"
-	managerSharedVolumeName = "manager-shared-volume"
-	managerSharedMountPath  = "/dp-manager"
+	BackupDataJobNamePrefix      = "dp-backup"
+	ConsolidateDataJobNamePrefix = "dp-consolidate"
+	prebackupJobNamePrefix       = "dp-prebackup"
+	postbackupJobNamePrefix      = "dp-postbackup"
+	BackupDataContainerName      = "backupdata"
+	managerContainerName         = "manager"
+	managerSharedVolumeName      = "manager-shared-volume"
+	managerSharedMountPath       = "/dp-manager"
 )
 
 // Request is a request for a backup, with all references to other objects.
@@ -67,6 +70,9 @@ type Request struct {
 	Target               *dpv1alpha1.BackupTarget
 	ParentBackup         *dpv1alpha1.Backup
 	BaseBackup           *dpv1alpha1.Backup
+	// ConsolidatedBackups is the incremental backup chain to be merged into a synthetic
+	// full backup, starting with the base full backup.
+	ConsolidatedBackups []*dpv1alpha1.Backup
 }
 
 func (r *Request) GetBackupType() string {
@@ -79,6 +85,12 @@ func (r *Request) GetBackupType() string {
 	return ""
 }
 
+// IsConsolidation returns true if the request builds a synthetic full backup
+// by consolidating an incremental backup chain.
+func (r *Request) IsConsolidation() bool {
+	return len(r.ConsolidatedBackups) > 0
+}
+
 // BuildActions builds the actions for the backup.
 func (r *Request) BuildActions() (map[string][]action.Action, error) {
 	var actions = map[string][]action.Action{}
@@ -96,6 +108,16 @@ func (r *Request) BuildActions() (map[string][]action.Action, error) {
 	for i := range r.TargetPods {
 		var podActions []action.Action
 
+		// the consolidation works on the backup repository only, it does not touch the target.
+		if r.IsConsolidation() {
+			consolidateAction, err := r.buildConsolidateDataAction(r.TargetPods[i], fmt.Sprintf("%s-%s%d", ConsolidateDataJobNamePrefix, r.getActionTargetPrefix(), i))
+			if err != nil {
+				return nil, err
+			}
+			actions[r.TargetPods[i].Name] = appendIgnoreNil(podActions, consolidateAction)
+			continue
+		}
+
 		// 1. build pre-backup actions
 		if err := r.buildPreBackupActions(&podActions, r.TargetPods[i], i); err != nil {
 			return nil, err
@@ -177,13 +199,20 @@ func (r *Request) buildBackupDataAction(targetPod *corev1.Pod, name string) (act
 		if err != nil {
 			return nil, fmt.Errorf("failed to build job action pod spec: %w", err)
 		}
-		r.InjectManagerContainer(podSpec, backupDataAct.SyncProgress, r.buildSyncProgressCommand())
+		syncProgressCommand := r.buildSyncProgressCommand()
+		var checksumContainerName string
+		if viper.GetBool(dptypes.CfgKeyBackupChecksumEnabled) {
+			syncProgressCommand += buildChecksumCommand()
+			checksumContainerName = managerContainerName
+		}
+		r.InjectManagerContainer(podSpec, backupDataAct.SyncProgress, syncProgressCommand)
 		return &action.JobAction{
-			Name:         name,
-			ObjectMeta:   *buildBackupJobObjMeta(r.Backup, name),
-			Owner:        r.Backup,
-			PodSpec:      podSpec,
-			BackOffLimit: r.BackupPolicy.Spec.BackoffLimit,
+			Name:                  name,
+			ObjectMeta:            *buildBackupJobObjMeta(r.Backup, name),
+			Owner:                 r.Backup,
+			PodSpec:               podSpec,
+			BackOffLimit:          r.BackupPolicy.Spec.BackoffLimit,
+			ChecksumContainerName: checksumContainerName,
 		}, nil
 	case dpv1alpha1.BackupTypeContinuous:
 		podSpec, err := r.BuildJobActionPodSpec(r.TargetPods[0], BackupDataContainerName, &backupDataAct.JobActionSpec)
@@ -207,6 +236,23 @@ func (r *Request) buildBackupDataAction(targetPod *corev1.Pod, name string) (act
 	return nil, fmt.Errorf("unsupported backup type %s", r.ActionSet.Spec.BackupType)
 }
 
+func (r *Request) buildConsolidateDataAction(targetPod *corev1.Pod, name string) (action.Action, error) {
+	if !r.ActionSet.HasConsolidateDataAction() {
+		return nil, fmt.Errorf("actionSet %s does not support consolidating backups", r.ActionSet.Name)
+	}
+	podSpec, err := r.BuildJobActionPodSpec(targetPod, BackupDataContainerName, r.ActionSet.Spec.Backup.ConsolidateData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build job action pod spec: %w", err)
+	}
+	return &action.JobAction{
+		Name:         name,
+		ObjectMeta:   *buildBackupJobObjMeta(r.Backup, name),
+		Owner:        r.Backup,
+		PodSpec:      podSpec,
+		BackOffLimit: r.BackupPolicy.Spec.BackoffLimit,
+	}, nil
+}
+
 func (r *Request) buildCreateVolumeSnapshotAction(targetPod *corev1.Pod, name string, index int) (action.Action, error) {
 	if r.BackupMethod == nil ||
 		!boolptr.IsSetToTrue(r.BackupMethod.SnapshotVolumes) {
@@ -310,12 +356,18 @@ func (r *Request) buildJobAction(targetPod *corev1.Pod,
 func (r *Request) BuildJobActionPodSpec(targetPod *corev1.Pod,
 	name string,
 	job *dpv1alpha1.JobActionSpec) (*corev1.PodSpec, error) {
+	// the consolidation works on the backup repository only, the target pod may be not
+	// running and only its name is known, so nothing is inherited from the target pod.
+	fromTargetPod := !r.IsConsolidation()
 
 	// build environment variables, include built-in envs, envs from backupMethod
 	// and envs from actionSet. Latter will override former for the same name.
 	// env from backupMethod has the highest priority.
 	buildEnv := func() ([]corev1.EnvVar, error) {
-		envVars := targetPod.Spec.Containers[0].Env
+		var envVars []corev1.EnvVar
+		if fromTargetPod {
+			envVars = targetPod.Spec.Containers[0].Env
+		}
 		envVars = append(envVars, []corev1.EnvVar{
 			{
 				Name:  dptypes.DPBackupName,
@@ -343,11 +395,13 @@ func (r *Request) BuildJobActionPodSpec(targetPod *corev1.Pod,
 				Value: r.Spec.RetentionPeriod.String(),
 			},
 		}...)
-		envFromTarget, err := utils.BuildEnvByTarget(targetPod, r.Target.ConnectionCredential, r.Target.ContainerPort)
-		if err != nil {
-			return nil, err
+		if fromTargetPod {
+			envFromTarget, err := utils.BuildEnvByTarget(targetPod, r.Target.ConnectionCredential, r.Target.ContainerPort)
+			if err != nil {
+				return nil, err
+			}
+			envVars = append(envVars, envFromTarget...)
 		}
-		envVars = append(envVars, envFromTarget...)
 		if r.ActionSet != nil {
 			envVars = append(envVars, r.ActionSet.Spec.Env...)
 			envVars = append(envVars, utils.BuildEnvByParameters(r.Backup.Spec.Parameters)...)
@@ -383,11 +437,36 @@ func (r *Request) BuildJobActionPodSpec(targetPod *corev1.Pod,
 				},
 			}...)
 		}
+		if r.IsConsolidation() {
+			// build envs for consolidating incremental backups
+			var incrementalBackupNames []string
+			for _, backup := range r.ConsolidatedBackups[1:] {
+				incrementalBackupNames = append(incrementalBackupNames, backup.Name)
+			}
+			envVars = append(envVars, []corev1.EnvVar{
+				{
+					Name:  dptypes.DPBaseBackupName,
+					Value: r.ConsolidatedBackups[0].Name,
+				},
+				{
+					Name:  dptypes.DPAncestorIncrementalBackupNames,
+					Value: strings.Join(incrementalBackupNames, ","),
+				},
+				{
+					Name:  dptypes.DPTargetRelativePath,
+					Value: BuildTargetRelativePath(r.Target, targetPod.Name),
+				},
+				{
+					Name:  dptypes.DPBackupRootPath,
+					Value: BuildBackupRootPath(r.Backup, r.BackupRepo.Spec.PathPrefix, r.BackupPolicy.Spec.PathPrefix),
+				},
+			}...)
+		}
 		return utils.MergeEnv(envVars, r.BackupMethod.Env), nil
 	}
 
 	runOnTargetPodNode := func() bool {
-		return boolptr.IsSetToTrue(job.RunOnTargetPodNode)
+		return fromTargetPod && boolptr.IsSetToTrue(job.RunOnTargetPodNode)
 	}
 
 	buildVolumes := func() []corev1.Volume {
@@ -432,7 +511,6 @@ func (r *Request) BuildJobActionPodSpec(targetPod *corev1.Pod,
 		Image:           intctrlutil.ReplaceImageRegistry(image),
 		Command:         job.Command,
 		Env:             env,
-		EnvFrom:         targetPod.Spec.Containers[0].EnvFrom,
 		VolumeMounts:    buildVolumeMounts(),
 		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
 		SecurityContext: &corev1.SecurityContext{
@@ -441,6 +519,10 @@ func (r *Request) BuildJobActionPodSpec(targetPod *corev1.Pod,
 		},
 	}
 
+	if fromTargetPod {
+		container.EnvFrom = targetPod.Spec.Containers[0].EnvFrom
+	}
+
 	if r.BackupMethod.RuntimeSettings != nil {
 		container.Resources = r.BackupMethod.RuntimeSettings.Resources
 	}
@@ -474,6 +556,15 @@ func (r *Request) BuildJobActionPodSpec(targetPod *corev1.Pod,
 
 	utils.InjectDatasafed(podSpec, r.BackupRepo, RepoVolumeMountPath,
 		r.Status.EncryptionConfig, r.Status.KopiaRepoPath)
+
+	// the password of the connection credential may be kept in the secret store
+	var credentialSecrets []string
+	if fromTargetPod && r.Target.ConnectionCredential != nil {
+		credentialSecrets = append(credentialSecrets, r.Target.ConnectionCredential.SecretName)
+	}
+	if err = secretstore.InjectEnvResolver(podSpec, credentialSecrets...); err != nil {
+		return nil, err
+	}
 	return podSpec, nil
 }
 
@@ -523,6 +614,31 @@ kubectl -n "$namespace" get backups.dataprotection.kubeblocks.io "$backup_name"
 `, dptypes.DPBackupInfoFile, dptypes.DPCheckInterval, r.Backup.Namespace, r.Backup.Name)
 }
 
+// buildChecksumCommand builds the script that computes the SHA-256 checksum of each
+// backup file in the backup path, and pushes the checksum manifest to the backup repo.
+// The digest of the manifest is written to the termination message of the container,
+// so that it can be recorded in the action status.
+func buildChecksumCommand() string {
+	return fmt.Sprintf(`
+# compute the checksums of the backup files, fail if any file can't be listed or read
+set -eo pipefail
+manifest_name="%s"
+manifest_file=$(mktemp)
+datasafed list -r -f / | while IFS= read -r file; do
+  case "$(basename "$file")" in
+    "$manifest_name"|kubeblocks-backup.json)
+      continue
+      ;;
+  esac
+  checksum=$(datasafed pull "$file" - | sha256sum | awk '{print $1}')
+  echo "${checksum}  ${file}" >> "$manifest_file"
+done
+datasafed push - "/${manifest_name}" < "$manifest_file"
+echo "sha256:$(sha256sum "$manifest_file" | awk '{print $1}')" > /dev/termination-log
+rm -f "$manifest_file"
+`, ChecksumManifestFileName)
+}
+
 func (r *Request) buildContinuousSyncProgressCommand() string {
 	// sync progress script will wait for the backup info file to be created,
 	// if the file is created, it will update the backup status and exit.
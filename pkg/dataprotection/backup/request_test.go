@@ -24,15 +24,20 @@ import (
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/action"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
 	"github.com/apecloud/kubeblocks/pkg/generics"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
 	testdp "github.com/apecloud/kubeblocks/pkg/testutil/dataprotection"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 var _ = Describe("Request Test", func() {
@@ -146,3 +151,136 @@ var _ = Describe("Request Test", func() {
 		})
 	})
 })
+
+var _ = Describe("Request with secret store", func() {
+	BeforeEach(func() {
+		viper.Set(constant.CfgKeySecretStoreProvider, secretstore.VaultProvider)
+		viper.Set(constant.CfgKeySecretStoreAddress, "http://vault:8200")
+	})
+
+	AfterEach(func() {
+		viper.Set(constant.CfgKeySecretStoreProvider, "")
+		viper.Set(constant.CfgKeySecretStoreAddress, "")
+	})
+
+	It("resolves the connection credential kept in the secret store when the backup job starts", func() {
+		accountSecretName := constant.GenerateAccountSecretName(testdp.ClusterName, testdp.ComponentName, "root")
+		passwordRef := secretstore.EnvRef(secretstore.SecretPath(testCtx.DefaultNamespace, accountSecretName), constant.AccountPasswdForSecret)
+		// the target and credential are built the same way as the backup policy generated for the cluster
+		target := &dpv1alpha1.BackupTarget{
+			PodSelector: &dpv1alpha1.PodSelector{
+				Strategy: dpv1alpha1.PodSelectionStrategyAny,
+			},
+			ConnectionCredential: &dpv1alpha1.ConnectionCredential{
+				SecretName:  accountSecretName,
+				PasswordKey: constant.AccountPasswdForSecret,
+				UsernameKey: constant.AccountNameForSecret,
+			},
+		}
+		request := &Request{
+			Backup: &dpv1alpha1.Backup{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testCtx.DefaultNamespace,
+					Name:      testdp.BackupName,
+				},
+			},
+			BackupPolicy: &dpv1alpha1.BackupPolicy{},
+			BackupMethod: &dpv1alpha1.BackupMethod{Name: testdp.BackupMethodName},
+			BackupRepo:   &dpv1alpha1.BackupRepo{},
+			Target:       target,
+		}
+		targetPod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      "target",
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "mysql",
+						Env:  []corev1.EnvVar{{Name: "MYSQL_ROOT_PASSWORD", Value: passwordRef}},
+					},
+				},
+			},
+		}
+
+		podSpec, err := request.BuildJobActionPodSpec(targetPod, "backup", &dpv1alpha1.JobActionSpec{
+			BaseJobActionSpec: dpv1alpha1.BaseJobActionSpec{
+				Image:   "backup-tool",
+				Command: []string{"sh", "-c", "backup.sh"},
+			},
+		})
+		Expect(err).Should(Succeed())
+		Expect(podSpec.InitContainers).ShouldNot(BeEmpty())
+		Expect(podSpec.InitContainers[0].Name).Should(Equal("init-secret-store"))
+		c := podSpec.Containers[0]
+		Expect(c.Command).Should(Equal([]string{"/kb-secret-store/kbagent", secretstore.EnvCommand, "--", "sh", "-c", "backup.sh"}))
+		Expect(c.Env).Should(ContainElement(corev1.EnvVar{
+			Name: dptypes.DPDBPassword,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: accountSecretName},
+					Key:                  constant.AccountPasswdForSecret,
+				},
+			},
+		}))
+		Expect(c.Env).Should(ContainElement(corev1.EnvVar{Name: "MYSQL_ROOT_PASSWORD", Value: passwordRef}))
+		Expect(c.Env).Should(ContainElement(corev1.EnvVar{Name: constant.CfgKeySecretStoreAddress, Value: "http://vault:8200"}))
+	})
+})
+
+var _ = Describe("Request of consolidation", func() {
+	It("should build the consolidate action without the live target pod", func() {
+		fullBackup := &dpv1alpha1.Backup{ObjectMeta: metav1.ObjectMeta{Name: "full", Namespace: testCtx.DefaultNamespace}}
+		incBackup := &dpv1alpha1.Backup{ObjectMeta: metav1.ObjectMeta{Name: "inc", Namespace: testCtx.DefaultNamespace}}
+		job := &dpv1alpha1.JobActionSpec{
+			BaseJobActionSpec: dpv1alpha1.BaseJobActionSpec{
+				Image:   "consolidate",
+				Command: []string{"sh", "-c", "consolidate"},
+			},
+			RunOnTargetPodNode: boolptr.True(),
+		}
+		request := &Request{
+			Backup: &dpv1alpha1.Backup{
+				ObjectMeta: metav1.ObjectMeta{Name: "synthetic", Namespace: testCtx.DefaultNamespace, UID: "synthetic-uid"},
+				Spec:       dpv1alpha1.BackupSpec{ConsolidateBackupName: incBackup.Name},
+			},
+			ActionSet: &dpv1alpha1.ActionSet{
+				ObjectMeta: metav1.ObjectMeta{Name: testdp.ActionSetName},
+				Spec: dpv1alpha1.ActionSetSpec{
+					BackupType: dpv1alpha1.BackupTypeFull,
+					Backup:     &dpv1alpha1.BackupActionSpec{ConsolidateData: job},
+				},
+			},
+			BackupPolicy: &dpv1alpha1.BackupPolicy{},
+			BackupMethod: &dpv1alpha1.BackupMethod{Name: testdp.BackupMethodName},
+			BackupRepo:   &dpv1alpha1.BackupRepo{ObjectMeta: metav1.ObjectMeta{Name: testdp.BackupRepoName}},
+			Target: &dpv1alpha1.BackupTarget{
+				PodSelector: &dpv1alpha1.PodSelector{Strategy: dpv1alpha1.PodSelectionStrategyAll},
+				ConnectionCredential: &dpv1alpha1.ConnectionCredential{
+					SecretName:  "credential",
+					PasswordKey: "password",
+				},
+			},
+			// only the name of the target pod is known
+			TargetPods: []*corev1.Pod{{
+				ObjectMeta: metav1.ObjectMeta{Name: "target-pod", Namespace: testCtx.DefaultNamespace},
+			}},
+			ConsolidatedBackups: []*dpv1alpha1.Backup{fullBackup, incBackup},
+		}
+
+		actions, err := request.BuildActions()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actions).To(HaveKey("target-pod"))
+		Expect(actions["target-pod"]).To(HaveLen(1))
+		podSpec := actions["target-pod"][0].(*action.JobAction).PodSpec
+		Expect(podSpec.NodeSelector).To(BeEmpty())
+		env := podSpec.Containers[0].Env
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: dptypes.DPTargetPodName, Value: "target-pod"}))
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: dptypes.DPBaseBackupName, Value: fullBackup.Name}))
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: dptypes.DPAncestorIncrementalBackupNames, Value: incBackup.Name}))
+		for _, e := range env {
+			Expect(e.Name).NotTo(BeElementOf(dptypes.DPDBHost, dptypes.DPDBPort, dptypes.DPDBPassword))
+		}
+	})
+})
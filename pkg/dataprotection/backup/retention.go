@@ -0,0 +1,131 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+)
+
+// GetBackupsRetainedByGFS returns the names of the backups retained by the GFS retention policy.
+// The backups should be all the backups created by the same schedule policy, only the completed
+// ones are taken into account. The completed backups are grouped by the day, the ISO week and
+// the month of their completion time in UTC, and the latest backup of each of the most recent
+// groups is retained, as well as the latest `minCount` backups.
+func GetBackupsRetainedByGFS(backups []*dpv1alpha1.Backup, policy *dpv1alpha1.GFSRetentionPolicy) sets.Set[string] {
+	retained := sets.New[string]()
+	if policy == nil {
+		return retained
+	}
+	var completedBackups []*dpv1alpha1.Backup
+	for _, b := range backups {
+		if b.Status.Phase == dpv1alpha1.BackupPhaseCompleted && b.GetEndTime() != nil {
+			completedBackups = append(completedBackups, b)
+		}
+	}
+	// sort by stop time in descending order
+	sort.Slice(completedBackups, func(i, j int) bool {
+		i, j = j, i
+		return dputils.CompareWithBackupStopTime(*completedBackups[i], *completedBackups[j])
+	})
+
+	type rule struct {
+		limit  int32
+		period func(b *dpv1alpha1.Backup) string
+		kept   sets.Set[string]
+	}
+	rules := []*rule{
+		{
+			limit: policy.Daily,
+			kept:  sets.New[string](),
+			period: func(b *dpv1alpha1.Backup) string {
+				return b.GetEndTime().UTC().Format("2006-01-02")
+			},
+		},
+		{
+			limit: policy.Weekly,
+			kept:  sets.New[string](),
+			period: func(b *dpv1alpha1.Backup) string {
+				year, week := b.GetEndTime().UTC().ISOWeek()
+				return fmt.Sprintf("%d-W%02d", year, week)
+			},
+		},
+		{
+			limit: policy.Monthly,
+			kept:  sets.New[string](),
+			period: func(b *dpv1alpha1.Backup) string {
+				return b.GetEndTime().UTC().Format("2006-01")
+			},
+		},
+	}
+	for i, b := range completedBackups {
+		if int32(i) < policy.MinCount {
+			retained.Insert(b.Name)
+		}
+		for _, r := range rules {
+			period := r.period(b)
+			// the backups are sorted in descending order, so the first backup of
+			// a period is the latest one.
+			if r.kept.Has(period) || int32(r.kept.Len()) >= r.limit {
+				continue
+			}
+			r.kept.Insert(period)
+			retained.Insert(b.Name)
+		}
+	}
+	return retained
+}
+
+// GetAncestorsOfBackups returns the names of the ancestors of the named backups, that is, the parent
+// and the base backups of the incremental backups, recursively. The ancestors must be retained as long
+// as the named backups are retained, since an incremental backup is deleted with its parent.
+// Only the ancestors in the given backups are returned.
+func GetAncestorsOfBackups(names sets.Set[string], backups []*dpv1alpha1.Backup) sets.Set[string] {
+	backupMap := make(map[string]*dpv1alpha1.Backup, len(backups))
+	for _, b := range backups {
+		backupMap[b.Name] = b
+	}
+	ancestors := sets.New[string]()
+	queue := sets.List(names)
+	for len(queue) > 0 {
+		b, ok := backupMap[queue[0]]
+		queue = queue[1:]
+		if !ok {
+			continue
+		}
+		parent := b.Status.ParentBackupName
+		if len(parent) == 0 {
+			parent = b.Spec.ParentBackupName
+		}
+		for _, name := range []string{parent, b.Status.BaseBackupName} {
+			if _, ok = backupMap[name]; !ok || ancestors.Has(name) {
+				continue
+			}
+			ancestors.Insert(name)
+			queue = append(queue, name)
+		}
+	}
+	return ancestors
+}
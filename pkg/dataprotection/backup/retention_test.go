@@ -0,0 +1,158 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+)
+
+func TestGetBackupsRetainedByGFS(t *testing.T) {
+	// one completed backup per day from 2024-01-01 (Monday) to 2024-03-31
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	var backups []*dpv1alpha1.Backup
+	for i := 0; i < 91; i++ {
+		completionTime := metav1.NewTime(start.AddDate(0, 0, i))
+		backups = append(backups, &dpv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("backup-%s", completionTime.Format("0102"))},
+			Status: dpv1alpha1.BackupStatus{
+				Phase:               dpv1alpha1.BackupPhaseCompleted,
+				CompletionTimestamp: &completionTime,
+			},
+		})
+	}
+	// a failed backup is never retained
+	backups = append(backups, &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup-failed"},
+		Status:     dpv1alpha1.BackupStatus{Phase: dpv1alpha1.BackupPhaseFailed},
+	})
+
+	tests := []struct {
+		name     string
+		policy   *dpv1alpha1.GFSRetentionPolicy
+		expected sets.Set[string]
+	}{
+		{
+			name:     "nil policy",
+			policy:   nil,
+			expected: sets.New[string](),
+		},
+		{
+			name:     "daily",
+			policy:   &dpv1alpha1.GFSRetentionPolicy{Daily: 3},
+			expected: sets.New("backup-0331", "backup-0330", "backup-0329"),
+		},
+		{
+			name:     "weekly",
+			policy:   &dpv1alpha1.GFSRetentionPolicy{Weekly: 2},
+			expected: sets.New("backup-0331", "backup-0324"),
+		},
+		{
+			name:     "monthly",
+			policy:   &dpv1alpha1.GFSRetentionPolicy{Monthly: 3},
+			expected: sets.New("backup-0331", "backup-0229", "backup-0131"),
+		},
+		{
+			name:     "daily, weekly and monthly",
+			policy:   &dpv1alpha1.GFSRetentionPolicy{Daily: 2, Weekly: 2, Monthly: 2},
+			expected: sets.New("backup-0331", "backup-0330", "backup-0324", "backup-0229"),
+		},
+		{
+			name:     "min count",
+			policy:   &dpv1alpha1.GFSRetentionPolicy{Monthly: 1, MinCount: 2},
+			expected: sets.New("backup-0331", "backup-0330"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GetBackupsRetainedByGFS(backups, tt.policy))
+		})
+	}
+}
+
+func TestGetAncestorsOfBackups(t *testing.T) {
+	newBackup := func(name, parent, base string) *dpv1alpha1.Backup {
+		return &dpv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: dpv1alpha1.BackupStatus{
+				ParentBackupName: parent,
+				BaseBackupName:   base,
+			},
+		}
+	}
+	backups := []*dpv1alpha1.Backup{
+		newBackup("full-1", "", ""),
+		newBackup("inc-1", "full-1", "full-1"),
+		newBackup("inc-2", "inc-1", "full-1"),
+		newBackup("inc-3", "inc-2", "full-1"),
+		newBackup("full-2", "", ""),
+		newBackup("inc-4", "full-2", "full-2"),
+		// the parent is not in the backups
+		newBackup("inc-5", "full-0", "full-0"),
+	}
+	// the parent recorded in the spec is used if it is not recorded in the status yet
+	specOnly := newBackup("inc-6", "", "")
+	specOnly.Spec.ParentBackupName = "full-2"
+	backups = append(backups, specOnly)
+
+	tests := []struct {
+		name     string
+		names    sets.Set[string]
+		expected sets.Set[string]
+	}{
+		{
+			name:     "full backup",
+			names:    sets.New("full-1"),
+			expected: sets.New[string](),
+		},
+		{
+			name:     "incremental backup",
+			names:    sets.New("inc-2"),
+			expected: sets.New("inc-1", "full-1"),
+		},
+		{
+			name:     "incremental backups of different chains",
+			names:    sets.New("inc-3", "inc-4"),
+			expected: sets.New("inc-2", "inc-1", "full-1", "full-2"),
+		},
+		{
+			name:     "parent not found",
+			names:    sets.New("inc-5"),
+			expected: sets.New[string](),
+		},
+		{
+			name:     "parent in spec",
+			names:    sets.New("inc-6"),
+			expected: sets.New("full-2"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GetAncestorsOfBackups(tt.names, backups))
+		})
+	}
+}
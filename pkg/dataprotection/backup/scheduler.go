@@ -203,11 +203,17 @@ func (s *Scheduler) buildPodSpec(schedulePolicy *dpv1alpha1.SchedulePolicy) (*co
 	if err != nil {
 		return nil, err
 	}
+	var annotations string
+	if boolptr.IsSetToTrue(schedulePolicy.VerifyBackup) {
+		annotations = fmt.Sprintf(`
+  annotations:
+    %s: "true"`, dptypes.VerifyBackupAnnotationKey)
+	}
 	createBackupCmd := fmt.Sprintf(`%s
 kubectl create -f - <<EOF
 apiVersion: dataprotection.kubeblocks.io/v1alpha1
 kind: Backup
-metadata:
+metadata:%s
   labels:
     dataprotection.kubeblocks.io/autobackup: "true"
     dataprotection.kubeblocks.io/backup-schedule: "%s"
@@ -218,7 +224,7 @@ spec:
   backupMethod: %s
   retentionPeriod: %s%s
 EOF
-`, checkCommand, s.BackupSchedule.Name, s.generateBackupName(schedulePolicy), s.BackupSchedule.Namespace,
+`, checkCommand, annotations, s.BackupSchedule.Name, s.generateBackupName(schedulePolicy), s.BackupSchedule.Namespace,
 		s.BackupPolicy.Name, schedulePolicy.BackupMethod,
 		schedulePolicy.RetentionPeriod, parameters)
 
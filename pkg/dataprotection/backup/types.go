@@ -28,4 +28,8 @@ const (
 
 	// BackupInfoFileName is the backup info file name in the backup path.
 	BackupInfoFileName = "backup.info"
+
+	// ChecksumManifestFileName is the file name of the checksum manifest in the backup path,
+	// which records the SHA-256 checksum of each backup file.
+	ChecksumManifestFileName = "kubeblocks-checksums.sha256"
 )
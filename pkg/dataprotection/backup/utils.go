@@ -160,6 +160,17 @@ func GenerateBackupJobName(backup *dpv1alpha1.Backup, prefix string) string {
 	return name
 }
 
+// GenerateSyntheticBackupName generates the name of the synthetic full backup which
+// consolidates the backup chain of the incremental backup.
+func GenerateSyntheticBackupName(backup *dpv1alpha1.Backup) string {
+	name := fmt.Sprintf("%s-synthetic", backup.Name)
+	// backup name cannot exceed 63 characters for label value limit.
+	if len(name) > 63 {
+		return fmt.Sprintf("%s-%s-synthetic", strings.TrimSuffix(backup.Name[:44], "-"), backup.UID[:8])
+	}
+	return name
+}
+
 func GenerateBackupStatefulSetName(backup *dpv1alpha1.Backup, targetName, prefix string) string {
 	name := backup.Name
 	// for cluster mode with multiple targets, the statefulSet name should include the target name.
@@ -20,6 +20,12 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package backup
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -230,3 +236,159 @@ func TestSetExpirationTime(t *testing.T) {
 		})
 	}
 }
+
+// fakeDatasafed mimics the datasafed commands used by the backup scripts on top of a local directory.
+const fakeDatasafed = `#!/bin/bash
+set -e
+case "$1" in
+  list)
+    cd "$FAKE_REPO" && find . -type f | sed 's|^\./||' | sort
+    ;;
+  pull)
+    if [ "$(basename "$2")" = "$FAKE_BROKEN_FILE" ]; then
+      exit 1
+    fi
+    cat "$FAKE_REPO/$2"
+    ;;
+  push)
+    repo="${DATASAFED_LOCAL_BACKEND_PATH:-$FAKE_REPO}"
+    mkdir -p "$(dirname "$repo/$3")"
+    cat > "$repo/$3"
+    ;;
+esac
+`
+
+// runBackupScript runs the script with bash against a fake datasafed serving the files,
+// and returns the repo directory and the termination message.
+func runBackupScript(t *testing.T, script string, files map[string]string, brokenFile string, env ...string) (string, string, error) {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash is not available")
+	}
+	dir := t.TempDir()
+	repo := filepath.Join(dir, "repo")
+	for name, content := range files {
+		path := filepath.Join(repo, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	binDir := filepath.Join(dir, "bin")
+	assert.NoError(t, os.MkdirAll(binDir, 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(binDir, "datasafed"), []byte(fakeDatasafed), 0o755))
+	terminationLog := filepath.Join(dir, "termination-log")
+	script = strings.ReplaceAll(script, "/dev/termination-log", terminationLog)
+
+	cmd := exec.Command(bash, "-c", script)
+	cmd.Env = append(os.Environ(),
+		"PATH="+binDir+string(os.PathListSeparator)+os.Getenv("PATH"),
+		"FAKE_REPO="+repo,
+		"FAKE_BROKEN_FILE="+brokenFile)
+	cmd.Env = append(cmd.Env, env...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Logf("script output: %s", out)
+	}
+	msg, _ := os.ReadFile(terminationLog)
+	return repo, string(msg), err
+}
+
+func TestBuildChecksumCommand(t *testing.T) {
+	files := map[string]string{
+		"data/file with spaces.dat": "foo",
+		"data/plain.dat":            "bar",
+		"kubeblocks-backup.json":    "{}",
+	}
+
+	t.Run("compute the checksums of all files", func(t *testing.T) {
+		repo, msg, err := runBackupScript(t, buildChecksumCommand(), files, "")
+		assert.NoError(t, err)
+		manifest, err := os.ReadFile(filepath.Join(repo, ChecksumManifestFileName))
+		assert.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(manifest)), "\n")
+		assert.Len(t, lines, 2)
+		assert.Contains(t, string(manifest), "  data/file with spaces.dat")
+		assert.Contains(t, string(manifest), "  data/plain.dat")
+		assert.NotContains(t, string(manifest), "kubeblocks-backup.json")
+		assert.True(t, strings.HasPrefix(msg, "sha256:"))
+	})
+
+	t.Run("fail if a file can't be read", func(t *testing.T) {
+		repo, msg, err := runBackupScript(t, buildChecksumCommand(), files, "plain.dat")
+		assert.Error(t, err)
+		assert.NoFileExists(t, filepath.Join(repo, ChecksumManifestFileName))
+		assert.Empty(t, msg)
+	})
+}
+
+func TestBuildReplicateBackupFilesScript(t *testing.T) {
+	files := map[string]string{
+		"data/file with spaces.dat": "foo",
+		"data/plain.dat":            "bar",
+	}
+	r := &Replicator{}
+
+	t.Run("copy all files to the replica repo", func(t *testing.T) {
+		replicaRepo := t.TempDir()
+		_, _, err := runBackupScript(t, r.buildReplicateBackupFilesScript("/backup", "/replica"), files, "",
+			dptypes.DPDatasafedBinPath+"=", dptypes.DPReplicaDatasafedLocalBackendPath+"="+replicaRepo)
+		assert.NoError(t, err)
+		for name, content := range files {
+			data, err := os.ReadFile(filepath.Join(replicaRepo, name))
+			assert.NoError(t, err)
+			assert.Equal(t, content, string(data))
+		}
+	})
+
+	t.Run("fail if a file can't be copied", func(t *testing.T) {
+		replicaRepo := t.TempDir()
+		_, _, err := runBackupScript(t, r.buildReplicateBackupFilesScript("/backup", "/replica"), files, "plain.dat",
+			dptypes.DPDatasafedBinPath+"=", dptypes.DPReplicaDatasafedLocalBackendPath+"="+replicaRepo)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuildVerifyBackupFilesScript(t *testing.T) {
+	sha256sum := func(content string) string {
+		sum := sha256.Sum256([]byte(content))
+		return hex.EncodeToString(sum[:])
+	}
+	files := map[string]string{
+		"data/file with spaces.dat": "foo",
+		"data/*":                    "bar",
+	}
+	manifest := sha256sum("foo") + "  data/file with spaces.dat\n" + sha256sum("bar") + "  data/*\n"
+	script := (&Verifier{}).buildVerifyBackupFilesScript("/backup")
+
+	t.Run("verify the checksums of all files", func(t *testing.T) {
+		filesWithManifest := map[string]string{ChecksumManifestFileName: manifest}
+		for name, content := range files {
+			filesWithManifest[name] = content
+		}
+		_, msg, err := runBackupScript(t, script, filesWithManifest, "", dptypes.DPDatasafedBinPath+"=")
+		assert.NoError(t, err)
+		assert.Equal(t, verifiedMessagePrefix+"checksums of 2 backup files matched", strings.TrimSpace(msg))
+	})
+
+	t.Run("report the corrupted files", func(t *testing.T) {
+		corrupted := map[string]string{
+			ChecksumManifestFileName:    manifest,
+			"data/file with spaces.dat": "corrupted",
+			"data/*":                    "bar",
+		}
+		_, msg, err := runBackupScript(t, script, corrupted, "", dptypes.DPDatasafedBinPath+"=")
+		assert.Error(t, err)
+		assert.Equal(t, corruptedMessagePrefix+"corrupted backup files: ./data/file with spaces.dat", strings.TrimSpace(msg))
+	})
+
+	t.Run("report the files without checksum manifest as unverifiable", func(t *testing.T) {
+		_, msg, err := runBackupScript(t, script, files, "", dptypes.DPDatasafedBinPath+"=")
+		assert.NoError(t, err)
+		assert.Equal(t, unverifiableMessagePrefix+"no checksum manifest found, 2 backup files are readable", strings.TrimSpace(msg))
+	})
+
+	t.Run("report the unreadable files without checksum manifest as corrupted", func(t *testing.T) {
+		_, msg, err := runBackupScript(t, script, files, "*", dptypes.DPDatasafedBinPath+"=")
+		assert.Error(t, err)
+		assert.Equal(t, corruptedMessagePrefix+"corrupted backup files: data/*", strings.TrimSpace(msg))
+	})
+}
@@ -0,0 +1,283 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	verifyBackupFilesJobNamePrefix = "verify-"
+	verifyContainerName            = "verifier"
+
+	// the prefixes of the termination message of the verifier container
+	verifiedMessagePrefix     = "Verified: "
+	unverifiableMessagePrefix = "Unverifiable: "
+	corruptedMessagePrefix    = "Corrupted: "
+)
+
+type VerificationStatus string
+
+const (
+	VerificationStatusVerifying VerificationStatus = "Verifying"
+	VerificationStatusVerified  VerificationStatus = "Verified"
+	// VerificationStatusUnverifiable means the backup has no checksum manifest to verify its integrity,
+	// only the readability of the backup files is checked.
+	VerificationStatusUnverifiable VerificationStatus = "Unverifiable"
+	VerificationStatusCorrupted    VerificationStatus = "Corrupted"
+	VerificationStatusFailed       VerificationStatus = "Failed"
+)
+
+type Verifier struct {
+	ctrlutil.RequestCtx
+	Client               client.Client
+	Scheme               *runtime.Scheme
+	WorkerServiceAccount string
+}
+
+// VerifyBackupFiles builds a job to re-read the backup files from the backup repository
+// and check them against the checksum manifests recorded during the backup. If no checksum
+// manifest exists, the job only checks whether the backup files are readable, and the backup
+// is reported as unverifiable.
+// It returns the verification status and a message that describes the result.
+func (v *Verifier) VerifyBackupFiles(backup *dpv1alpha1.Backup) (VerificationStatus, string, error) {
+	backupMethod := backup.Status.BackupMethod
+	if backupMethod != nil && boolptr.IsSetToTrue(backupMethod.SnapshotVolumes) {
+		return VerificationStatusFailed, "the backup taken by volume snapshots can not be verified", nil
+	}
+	jobKey := BuildVerifyBackupFilesJobKey(backup)
+	job := &batchv1.Job{}
+	exists, err := ctrlutil.CheckResourceExists(v.Ctx, v.Client, jobKey, job)
+	if err != nil {
+		return VerificationStatusVerifying, "", err
+	}
+
+	// if verification job exists, check its status
+	if exists {
+		_, finishedType, msg := utils.IsJobFinished(job)
+		if finishedType != batchv1.JobComplete && finishedType != batchv1.JobFailed {
+			return VerificationStatusVerifying, "", nil
+		}
+		result, err := utils.GetJobContainerTerminationMessage(v.Ctx, v.Client, job.Namespace, job.Name, verifyContainerName)
+		if err != nil {
+			return VerificationStatusVerifying, "", err
+		}
+		switch {
+		case finishedType == batchv1.JobComplete && strings.HasPrefix(result, unverifiableMessagePrefix):
+			return VerificationStatusUnverifiable, strings.TrimPrefix(result, unverifiableMessagePrefix), nil
+		case finishedType == batchv1.JobComplete:
+			return VerificationStatusVerified, strings.TrimPrefix(result, verifiedMessagePrefix), nil
+		case strings.HasPrefix(result, corruptedMessagePrefix):
+			return VerificationStatusCorrupted, strings.TrimPrefix(result, corruptedMessagePrefix), nil
+		default:
+			return VerificationStatusFailed, fmt.Sprintf("verification job \"%s\" failed, %s", job.Name, msg), nil
+		}
+	}
+
+	var backupRepo *dpv1alpha1.BackupRepo
+	if backup.Status.BackupRepoName != "" {
+		backupRepo = &dpv1alpha1.BackupRepo{}
+		if err = v.Client.Get(v.Ctx, client.ObjectKey{Name: backup.Status.BackupRepoName}, backupRepo); err != nil {
+			if apierrors.IsNotFound(err) {
+				return VerificationStatusFailed, fmt.Sprintf("backup repo %s not found", backup.Status.BackupRepoName), nil
+			}
+			return VerificationStatusVerifying, "", err
+		}
+	}
+
+	// if backupRepo is nil (likely because it's a legacy backup object), use the backup PVC
+	var legacyPVCName string
+	if backupRepo == nil {
+		legacyPVCName = backup.Status.PersistentVolumeClaimName
+		if legacyPVCName == "" {
+			return VerificationStatusFailed, "neither backup repo nor persistent volume claim is recorded in the backup status", nil
+		}
+	}
+
+	backupFilePath := backup.Status.Path
+	if backupFilePath == "" {
+		return VerificationStatusFailed, "backup path is empty", nil
+	}
+	// make sure the path has a leading slash
+	if !strings.HasPrefix(backupFilePath, "/") {
+		backupFilePath = "/" + backupFilePath
+	}
+	return VerificationStatusVerifying, "", v.createVerifyBackupFilesJob(jobKey, backup, backupRepo, legacyPVCName, backupFilePath)
+}
+
+// DeleteVerificationJob deletes the verification job of the backup, so that the backup
+// can be verified again.
+func (v *Verifier) DeleteVerificationJob(backup *dpv1alpha1.Backup) error {
+	job := &batchv1.Job{}
+	exists, err := ctrlutil.CheckResourceExists(v.Ctx, v.Client, BuildVerifyBackupFilesJobKey(backup), job)
+	if err != nil || !exists {
+		return err
+	}
+	return client.IgnoreNotFound(ctrlutil.BackgroundDeleteObject(v.Client, v.Ctx, job))
+}
+
+func (v *Verifier) buildVerifyBackupFilesScript(backupPath string) string {
+	// this script traverses the checksum manifests in the backup path, and compares the
+	// checksum of each backup file with the recorded one. If no checksum manifest exists,
+	// it only checks whether the backup files are readable, and reports the backup as
+	// unverifiable. The result is written to the termination message of the container.
+	return fmt.Sprintf(`
+set -o nounset
+set -o pipefail
+export PATH="$PATH:$%s"
+export DATASAFED_BACKEND_BASE_PATH="%s"
+manifest_name="%s"
+result_file="/dev/termination-log"
+
+all_files=$(mktemp)
+manifests=$(mktemp)
+datasafed list -r -f / > "${all_files}" || exit 1
+while IFS= read -r file; do
+  if [ "$(basename "${file}")" = "${manifest_name}" ]; then
+    echo "${file}" >> "${manifests}"
+  fi
+done < "${all_files}"
+
+checked=0
+corrupted_files=""
+if [ ! -s "${manifests}" ]; then
+  echo "no checksum manifest found, check whether the backup files are readable"
+  while IFS= read -r file; do
+    if ! datasafed pull "${file}" - > /dev/null < /dev/null; then
+      corrupted_files="${corrupted_files} ${file}"
+    fi
+    checked=$((checked+1))
+  done < "${all_files}"
+  result="%sno checksum manifest found, ${checked} backup files are readable"
+else
+  while IFS= read -r manifest; do
+    manifest_dir=$(dirname "${manifest}")
+    manifest_file=$(mktemp)
+    datasafed pull "${manifest}" - > "${manifest_file}" < /dev/null || exit 1
+    while read -r expected file; do
+      actual=$(DATASAFED_BACKEND_BASE_PATH="${DATASAFED_BACKEND_BASE_PATH}/${manifest_dir}" \
+        datasafed pull "${file}" - < /dev/null | sha256sum | awk '{print $1}')
+      if [ "${actual}" != "${expected}" ]; then
+        echo "checksum of ${manifest_dir}/${file} mismatched, expected ${expected}, actual ${actual}"
+        corrupted_files="${corrupted_files} ${manifest_dir}/${file}"
+      fi
+      checked=$((checked+1))
+    done < "${manifest_file}"
+    rm -f "${manifest_file}"
+  done < "${manifests}"
+  result="%schecksums of ${checked} backup files matched"
+fi
+rm -f "${all_files}" "${manifests}"
+
+if [ -n "${corrupted_files}" ]; then
+  echo "%scorrupted backup files:${corrupted_files}" | tee "${result_file}"
+  exit 1
+fi
+echo "${result}" | tee "${result_file}"
+`, dptypes.DPDatasafedBinPath, backupPath, ChecksumManifestFileName, unverifiableMessagePrefix, verifiedMessagePrefix, corruptedMessagePrefix)
+}
+
+func (v *Verifier) createVerifyBackupFilesJob(
+	jobKey types.NamespacedName,
+	backup *dpv1alpha1.Backup,
+	backupRepo *dpv1alpha1.BackupRepo,
+	legacyPVCName string,
+	backupFilePath string) error {
+	runAsUser := int64(0)
+	container := corev1.Container{
+		Name:            verifyContainerName,
+		Command:         []string{"sh", "-c"},
+		Args:            []string{v.buildVerifyBackupFilesScript(backupFilePath)},
+		Image:           viper.GetString(constant.KBToolsImage),
+		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: boolptr.False(),
+			RunAsUser:                &runAsUser,
+		},
+	}
+	ctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
+
+	// build pod
+	podSpec := corev1.PodSpec{
+		Containers:         []corev1.Container{container},
+		RestartPolicy:      corev1.RestartPolicyNever,
+		ServiceAccountName: v.WorkerServiceAccount,
+	}
+	if err := utils.AddTolerations(&podSpec); err != nil {
+		return err
+	}
+	if backupRepo != nil {
+		utils.InjectDatasafed(&podSpec, backupRepo, RepoVolumeMountPath, backup.Status.EncryptionConfig, backup.Status.KopiaRepoPath)
+	} else {
+		utils.InjectDatasafedWithPVC(&podSpec, legacyPVCName, RepoVolumeMountPath, backup.Status.KopiaRepoPath)
+	}
+
+	// build job, the verification result is deterministic, so do not retry it.
+	backoffLimit := int32(0)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: jobKey.Namespace,
+			Name:      jobKey.Name,
+			Labels: map[string]string{
+				constant.AppManagedByLabelKey: dptypes.AppName,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: jobKey.Namespace,
+					Name:      jobKey.Name,
+				},
+				Spec: podSpec,
+			},
+			BackoffLimit: &backoffLimit,
+		},
+	}
+	if err := utils.SetControllerReference(backup, job, v.Scheme); err != nil {
+		return err
+	}
+	v.Log.V(1).Info("create a job to verify backup files", "job", job)
+	return client.IgnoreAlreadyExists(v.Client.Create(v.Ctx, job))
+}
+
+func BuildVerifyBackupFilesJobKey(backup *dpv1alpha1.Backup) client.ObjectKey {
+	jobName := fmt.Sprintf("%s-%s%s", backup.UID[:8], verifyBackupFilesJobNamePrefix, backup.Name)
+	if len(jobName) > 63 {
+		jobName = strings.TrimSuffix(jobName[:63], "-")
+	}
+	return client.ObjectKey{Namespace: backup.Namespace, Name: jobName}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	testdp "github.com/apecloud/kubeblocks/pkg/testutil/dataprotection"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+var _ = Describe("Backup Verifier Test", func() {
+	const (
+		backupRepoPVCName = "backup-repo-pvc"
+		backupPath        = "/backup/test-backup"
+	)
+
+	buildVerifier := func() *Verifier {
+		return &Verifier{
+			RequestCtx: ctrlutil.RequestCtx{
+				Log:      logger,
+				Ctx:      testCtx.Ctx,
+				Recorder: recorder,
+			},
+			Scheme: testEnv.Scheme,
+			Client: testCtx.Cli,
+		}
+	}
+
+	cleanEnv := func() {
+		By("clean resources")
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupSignature, true, inNS)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.JobSignature, true, inNS)
+	}
+
+	BeforeEach(func() {
+		cleanEnv()
+		viper.Set(constant.KBToolsImage, testdp.KBToolImage)
+	})
+
+	AfterEach(func() {
+		cleanEnv()
+		viper.Set(constant.KBToolsImage, "")
+	})
+
+	Context("verify backup files", func() {
+		var (
+			backup   *dpv1alpha1.Backup
+			verifier *Verifier
+		)
+
+		BeforeEach(func() {
+			backup = testdp.NewFakeBackup(&testCtx, nil)
+			verifier = buildVerifier()
+		})
+
+		It("should fail when the backup is taken by volume snapshots", func() {
+			backup.Status.BackupMethod = &dpv1alpha1.BackupMethod{SnapshotVolumes: boolptr.True()}
+			status, _, err := verifier.VerifyBackupFiles(backup)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(status).Should(Equal(VerificationStatusFailed))
+		})
+
+		It("should fail when backup status path is empty", func() {
+			backup.Status.PersistentVolumeClaimName = backupRepoPVCName
+			status, _, err := verifier.VerifyBackupFiles(backup)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(status).Should(Equal(VerificationStatusFailed))
+		})
+
+		It("should create job to verify backup files", func() {
+			By("mock backup repo PVC")
+			backupRepoPVC := testdp.NewFakePVC(&testCtx, backupRepoPVCName)
+
+			By("verify backup files")
+			backup.Status.PersistentVolumeClaimName = backupRepoPVC.Name
+			backup.Status.Path = backupPath
+			status, _, err := verifier.VerifyBackupFiles(backup)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(status).Should(Equal(VerificationStatusVerifying))
+
+			By("check job exist")
+			job := &batchv1.Job{}
+			key := BuildVerifyBackupFilesJobKey(backup)
+			Eventually(testapps.CheckObjExists(&testCtx, key, job, true)).Should(Succeed())
+
+			By("verify backup with job succeed")
+			testdp.ReplaceK8sJobStatus(&testCtx, key, batchv1.JobComplete)
+			backupKey := client.ObjectKeyFromObject(backup)
+			Eventually(testapps.CheckObj(&testCtx, backupKey, func(g Gomega, fetched *dpv1alpha1.Backup) {
+				status, _, err := verifier.VerifyBackupFiles(fetched)
+				g.Expect(err).ShouldNot(HaveOccurred())
+				g.Expect(status).Should(Equal(VerificationStatusVerified))
+			})).Should(Succeed())
+
+			By("verify backup with job failed")
+			testdp.ReplaceK8sJobStatus(&testCtx, key, batchv1.JobFailed)
+			Eventually(testapps.CheckObj(&testCtx, backupKey, func(g Gomega, fetched *dpv1alpha1.Backup) {
+				status, _, err := verifier.VerifyBackupFiles(fetched)
+				g.Expect(err).ShouldNot(HaveOccurred())
+				g.Expect(status).Should(Equal(VerificationStatusFailed))
+			})).Should(Succeed())
+
+			By("delete the verification job")
+			Expect(verifier.DeleteVerificationJob(backup)).Should(Succeed())
+			Eventually(testapps.CheckObjExists(&testCtx, key, job, false)).Should(Succeed())
+		})
+	})
+})
@@ -38,6 +38,7 @@ import (
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
@@ -63,6 +64,7 @@ type restoreJobBuilder struct {
 	jobName              string
 	labels               map[string]string
 	serviceAccount       string
+	credentialSecret     string
 }
 
 func newRestoreJobBuilder(restore *dpv1alpha1.Restore, backupSet BackupActionSet, backupRepo *dpv1alpha1.BackupRepo, stage dpv1alpha1.RestoreStage) *restoreJobBuilder {
@@ -279,6 +281,7 @@ func (r *restoreJobBuilder) addTargetPodAndCredentialEnv(pod *corev1.Pod,
 		addDBHostEnv()
 		addDBPortEnv()
 	} else {
+		r.credentialSecret = connectionCredential.SecretName
 		appendEnvFromSecret := func(envName, keyName string) {
 			if keyName == "" {
 				return
@@ -316,7 +319,7 @@ func (r *restoreJobBuilder) builderRestoreJobName(jobIndex int) string {
 }
 
 // build the restore job by this builder.
-func (r *restoreJobBuilder) build() *batchv1.Job {
+func (r *restoreJobBuilder) build() (*batchv1.Job, error) {
 	if r.jobName == "" {
 		r.jobName = r.builderRestoreJobName(0)
 	}
@@ -440,7 +443,16 @@ func (r *restoreJobBuilder) build() *batchv1.Job {
 			utils.InjectDatasafedWithPVC(&job.Spec.Template.Spec, pvcName, mountPath, kopiaRepoPath)
 		}
 	}
-	return job
+
+	// 5. resolve the password of the connection credential if it's kept in the secret store
+	var credentialSecrets []string
+	if r.credentialSecret != "" {
+		credentialSecrets = append(credentialSecrets, r.credentialSecret)
+	}
+	if err := secretstore.InjectEnvResolver(&job.Spec.Template.Spec, credentialSecrets...); err != nil {
+		return nil, err
+	}
+	return job, nil
 }
 
 func (r *restoreJobBuilder) InjectManagerContainer(podSpec *corev1.PodSpec) {
@@ -95,6 +95,11 @@ func (r *RestoreManager) GetBackupActionSetByNamespaced(reqCtx intctrlutil.Reque
 		}
 		return nil, err
 	}
+	// fall back to the copy in the secondary backup repo if the backup repo is unavailable.
+	backup, err := utils.ResolveBackupReplica(reqCtx.Ctx, cli, backup)
+	if err != nil {
+		return nil, err
+	}
 	backupMethod := backup.Status.BackupMethod
 	if backupMethod == nil {
 		return nil, intctrlutil.NewFatalError(fmt.Sprintf(`status.backupMethod of backup "%s" is empty`, backupName))
@@ -109,7 +114,12 @@ func (r *RestoreManager) GetBackupActionSetByNamespaced(reqCtx intctrlutil.Reque
 
 // BuildDifferentialBackupActionSets builds the backupActionSets for specified incremental backup.
 func (r *RestoreManager) BuildDifferentialBackupActionSets(reqCtx intctrlutil.RequestCtx, cli client.Client, sourceBackupSet BackupActionSet) error {
-	parentBackupSet, err := r.GetBackupActionSetByNamespaced(reqCtx, cli, sourceBackupSet.Backup.Spec.ParentBackupName, sourceBackupSet.Backup.Namespace)
+	// the parent backup in the status is the actual one the backup is based on.
+	parentBackupName := sourceBackupSet.Backup.Status.ParentBackupName
+	if len(parentBackupName) == 0 {
+		parentBackupName = sourceBackupSet.Backup.Spec.ParentBackupName
+	}
+	parentBackupSet, err := r.GetBackupActionSetByNamespaced(reqCtx, cli, parentBackupName, sourceBackupSet.Backup.Namespace)
 	if err != nil || parentBackupSet == nil {
 		return err
 	}
@@ -558,7 +568,10 @@ func (r *RestoreManager) BuildPrepareDataJobs(reqCtx intctrlutil.RequestCtx, cli
 			continue
 		}
 		// build job and append
-		job := jobBuilder.setJobName(jobBuilder.builderRestoreJobName(i)).addCommonEnv(sourceTargetPodName).build()
+		job, err := jobBuilder.setJobName(jobBuilder.builderRestoreJobName(i)).addCommonEnv(sourceTargetPodName).build()
+		if err != nil {
+			return nil, err
+		}
 		if prepareDataConfig.IsSerialPolicy() &&
 			restoreJobHasCompleted(r.Restore.Status.Actions.PrepareData, job.Name) {
 			// if the job has completed and the restore policy is Serial, continue
@@ -603,8 +616,7 @@ func (r *RestoreManager) BuildVolumePopulateJob(
 	if err != nil {
 		return nil, err
 	}
-	job := jobBuilder.addToSpecificVolumesAndMounts(volume, volumeMount).build()
-	return job, nil
+	return jobBuilder.addToSpecificVolumesAndMounts(volume, volumeMount).build()
 }
 
 // BuildPostReadyActionJobs builds the post ready jobs.
@@ -651,7 +663,7 @@ func (r *RestoreManager) BuildPostReadyActionJobs(reqCtx intctrlutil.RequestCtx,
 			return nil, err
 		}
 		sort.Sort(intctrlutil.ByPodName(targetPodList.Items))
-		buildJob := func(targetPod *corev1.Pod, sourceTargetPodName string, index int) *batchv1.Job {
+		buildJob := func(targetPod *corev1.Pod, sourceTargetPodName string, index int) (*batchv1.Job, error) {
 			if boolptr.IsSetToTrue(actionSpec.Job.RunOnTargetPodNode) {
 				jobBuilder.resetSpecificVolumesAndMounts()
 				jobBuilder.setNodeNameToNodeSelector(targetPod.Spec.NodeName)
@@ -693,7 +705,11 @@ func (r *RestoreManager) BuildPostReadyActionJobs(reqCtx intctrlutil.RequestCtx,
 				// no need to recover the volume when the pod selection policy is 'All' and sourceTargetPodName is not found.
 				continue
 			}
-			jobs = append(jobs, buildJob(&targetPodList.Items[i], sourceTargetPodName, i))
+			job, err := buildJob(&targetPodList.Items[i], sourceTargetPodName, i)
+			if err != nil {
+				return nil, err
+			}
+			jobs = append(jobs, job)
 		}
 		return jobs, nil
 	}
@@ -717,7 +733,10 @@ func (r *RestoreManager) BuildPostReadyActionJobs(reqCtx intctrlutil.RequestCtx,
 			jobBuilder.setImage(viper.GetString(constant.KBToolsImage)).setCommand([]string{"kubectl"}).setArgs(args).
 				setJobName(buildJobName(i)).
 				setToleration(targetPodList.Items[i].Spec.Tolerations)
-			job := jobBuilder.build()
+			job, err := jobBuilder.build()
+			if err != nil {
+				return nil, err
+			}
 			// create exec job in kubeblocks namespace for security
 			kbInstalledNamespace := viper.GetString(constant.CfgKeyCtrlrMgrNS)
 			if kbInstalledNamespace != "" {
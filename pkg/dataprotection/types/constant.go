@@ -35,6 +35,8 @@ const (
 	CfgKeyWorkerClusterRoleName = "WORKER_CLUSTER_ROLE_NAME"
 	// CfgDataProtectionReconcileWorkers the max reconcile workers for MaxConcurrentReconciles
 	CfgDataProtectionReconcileWorkers = "DATAPROTECTION_RECONCILE_WORKERS"
+	// CfgKeyBackupChecksumEnabled is the key of whether to record the checksums of the backup files
+	CfgKeyBackupChecksumEnabled = "BACKUP_CHECKSUM_ENABLED"
 )
 
 // config default values
@@ -68,6 +70,8 @@ const (
 	SkipReconciliationAnnotationKey = "dataprotection.kubeblocks.io/skip-reconciliation"
 	// SkipRestorationCheckAnnotationKey specifies whether to skip restoration check.
 	SkipRestorationCheckAnnotationKey = "dataprotection.kubeblocks.io/skip-restoration-check"
+	// VerifyBackupAnnotationKey specifies whether to verify the integrity of the backup data.
+	VerifyBackupAnnotationKey = "dataprotection.kubeblocks.io/verify-backup"
 )
 
 // label keys
@@ -125,6 +129,7 @@ const (
 	// DPAncestorIncrementalBackupNames backup CR names
 	// Used to restore incremental backups, recording the incremental ancestor backup names in order of end time
 	// For example: ${DP_ANCESTOR_INCREMENTAL_BACKUP_NAMES}=incrementalBackupName1,incrementalBackupName2,incrementalBackupName3
+	// Also used to consolidate incremental backups, recording the incremental backup names to be merged into the base backup.
 	DPAncestorIncrementalBackupNames = "DP_ANCESTOR_INCREMENTAL_BACKUP_NAMES"
 	// DPTTL backup time to live, reference the backup.spec.retentionPeriod
 	DPTTL = "DP_TTL"
@@ -140,6 +145,10 @@ const (
 	DPBackupStopTime = "DP_BACKUP_STOP_TIME" // backup stop time
 	// DPDatasafedBinPath the path containing the datasafed binary
 	DPDatasafedBinPath = "DP_DATASAFED_BIN_PATH"
+	// DPReplicaDatasafedLocalBackendPath the local backend path of the secondary backup repository for replication
+	DPReplicaDatasafedLocalBackendPath = "DP_REPLICA_DATASAFED_LOCAL_BACKEND_PATH"
+	// DPReplicaDatasafedConfigFile the datasafed config file of the secondary backup repository for replication
+	DPReplicaDatasafedConfigFile = "DP_REPLICA_DATASAFED_CONFIG_FILE"
 
 	// NOTE: do not add 'DP_' prefix to the value of the following constants, they are the datasafed built-in environment.
 
@@ -20,23 +20,32 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package utils
 
 import (
+	"context"
 	"fmt"
+	"path"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 const (
-	datasafedImageEnv        = "DATASAFED_IMAGE"
-	defaultDatasafedImage    = "apecloud/datasafed:latest"
-	datasafedBinMountPath    = "/bin/datasafed"
-	datasafedConfigMountPath = "/etc/datasafed"
+	datasafedImageEnv               = "DATASAFED_IMAGE"
+	defaultDatasafedImage           = "apecloud/datasafed:latest"
+	datasafedBinMountPath           = "/bin/datasafed"
+	datasafedConfigMountPath        = "/etc/datasafed"
+	datasafedConfigFileName         = "datasafed.conf"
+	replicaDatasafedConfigMountPath = "/etc/datasafed-replica"
+	datasafedConfigVolumeName       = "dp-datasafed-config"
+	replicaBackupRepoVolumeName     = "dp-replica-backup-repo"
 )
 
 func InjectDatasafed(podSpec *corev1.PodSpec, repo *dpv1alpha1.BackupRepo, repoVolumeMountPath string,
@@ -45,6 +54,7 @@ func InjectDatasafed(podSpec *corev1.PodSpec, repo *dpv1alpha1.BackupRepo, repoV
 		InjectDatasafedWithPVC(podSpec, repo.Status.BackupPVCName, repoVolumeMountPath, kopiaRepoPath)
 	} else if repo.AccessByTool() {
 		InjectDatasafedWithConfig(podSpec, repo.Status.ToolConfigSecretName, kopiaRepoPath)
+		InjectDatasafedConfigResolver(podSpec, repo)
 	}
 	injectEncryptionEnvs(podSpec, encryptionConfig)
 }
@@ -100,7 +110,7 @@ func InjectDatasafedWithPVC(podSpec *corev1.PodSpec, pvcName string, mountPath s
 }
 
 func InjectDatasafedWithConfig(podSpec *corev1.PodSpec, configSecretName string, kopiaRepoPath string) {
-	volumeName := "dp-datasafed-config"
+	volumeName := datasafedConfigVolumeName
 	volume := corev1.Volume{
 		Name: volumeName,
 		VolumeSource: corev1.VolumeSource{
@@ -125,6 +135,67 @@ func InjectDatasafedWithConfig(podSpec *corev1.PodSpec, configSecretName string,
 	injectDatasafedInstaller(podSpec)
 }
 
+// InjectReplicaDatasafed mounts the secondary backup repository used by the replication
+// to the pod. Unlike InjectDatasafed, it does not change the default backend of datasafed,
+// the location of the secondary backup repository is exposed by the environment variables
+// DP_REPLICA_DATASAFED_LOCAL_BACKEND_PATH or DP_REPLICA_DATASAFED_CONFIG_FILE instead.
+func InjectReplicaDatasafed(podSpec *corev1.PodSpec, repo *dpv1alpha1.BackupRepo, repoVolumeMountPath string) {
+	volumeName := replicaBackupRepoVolumeName
+	var (
+		volume      corev1.Volume
+		volumeMount corev1.VolumeMount
+		env         corev1.EnvVar
+	)
+	if repo.AccessByMount() {
+		volume = corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: repo.Status.BackupPVCName,
+				},
+			},
+		}
+		volumeMount = corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: repoVolumeMountPath,
+		}
+		env = corev1.EnvVar{
+			Name:  dptypes.DPReplicaDatasafedLocalBackendPath,
+			Value: repoVolumeMountPath,
+		}
+	} else {
+		volume = corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: repo.Status.ToolConfigSecretName,
+				},
+			},
+		}
+		volumeMount = corev1.VolumeMount{
+			Name:      volumeName,
+			ReadOnly:  true,
+			MountPath: replicaDatasafedConfigMountPath,
+		}
+		env = corev1.EnvVar{
+			Name:  dptypes.DPReplicaDatasafedConfigFile,
+			Value: path.Join(replicaDatasafedConfigMountPath, datasafedConfigFileName),
+		}
+	}
+	injectElements(podSpec, toSlice(volume), toSlice(volumeMount), toSlice(env))
+	if repo.AccessByTool() && len(repo.Spec.CredentialStorePath) > 0 {
+		secretstore.InjectFileResolver(podSpec, volumeName)
+	}
+}
+
+// InjectDatasafedConfigResolver makes the tool config of datasafed resolved when the pod starts, if the credentials
+// of the repo are kept in the secret store, since the tool config Secret only holds the references to them.
+func InjectDatasafedConfigResolver(podSpec *corev1.PodSpec, repo *dpv1alpha1.BackupRepo) {
+	if len(repo.Spec.CredentialStorePath) > 0 {
+		secretstore.InjectFileResolver(podSpec, datasafedConfigVolumeName)
+	}
+}
+
 func injectDatasafedInstaller(podSpec *corev1.PodSpec) {
 	sharedVolumeName := "dp-datasafed-bin"
 	sharedVolume := corev1.Volume{
@@ -174,3 +245,43 @@ func injectElements(podSpec *corev1.PodSpec, volumes []corev1.Volume, volumeMoun
 func toSlice[T any](s ...T) []T {
 	return s
 }
+
+// ResolveBackupReplica returns the backup to read the backup data from. If the backup repository
+// of the backup is unavailable, the data location of the returned backup is switched to a completed
+// copy in a secondary backup repository which is ready. Otherwise, the backup is returned as it is.
+func ResolveBackupReplica(ctx context.Context, cli client.Client, backup *dpv1alpha1.Backup) (*dpv1alpha1.Backup, error) {
+	if backup.Status.BackupRepoName == "" || len(backup.Status.Replicas) == 0 {
+		return backup, nil
+	}
+	ready, err := isBackupRepoReady(ctx, cli, backup.Status.BackupRepoName)
+	if err != nil || ready {
+		return backup, err
+	}
+	for _, replica := range backup.Status.Replicas {
+		if replica.Phase != dpv1alpha1.BackupReplicaPhaseCompleted {
+			continue
+		}
+		if ready, err = isBackupRepoReady(ctx, cli, replica.BackupRepoName); err != nil {
+			return nil, err
+		} else if !ready {
+			continue
+		}
+		replicaBackup := backup.DeepCopy()
+		replicaBackup.Status.BackupRepoName = replica.BackupRepoName
+		replicaBackup.Status.Path = replica.Path
+		replicaBackup.Status.PersistentVolumeClaimName = ""
+		return replicaBackup, nil
+	}
+	return backup, nil
+}
+
+func isBackupRepoReady(ctx context.Context, cli client.Client, repoName string) (bool, error) {
+	repo := &dpv1alpha1.BackupRepo{}
+	if err := cli.Get(ctx, client.ObjectKey{Name: repoName}, repo); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return repo.Status.Phase == dpv1alpha1.BackupRepoReady, nil
+}
@@ -26,6 +26,7 @@ import (
 	"reflect"
 	"sort"
 	"strconv"
+	"strings"
 
 	"golang.org/x/mod/semver"
 	batchv1 "k8s.io/api/batch/v1"
@@ -100,6 +101,33 @@ func GetAssociatedPodsOfJob(ctx context.Context, cli client.Client, namespace, j
 	return podList, err
 }
 
+// GetJobContainerTerminationMessage gets the termination message of the specified container
+// from the most recently created pod of the job. It returns an empty string if no container
+// has terminated.
+func GetJobContainerTerminationMessage(ctx context.Context, cli client.Client, namespace, jobName, containerName string) (string, error) {
+	podList, err := GetAssociatedPodsOfJob(ctx, cli, namespace, jobName)
+	if err != nil {
+		return "", err
+	}
+	var (
+		latestPod *corev1.Pod
+		message   string
+	)
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if latestPod != nil && pod.CreationTimestamp.Before(&latestPod.CreationTimestamp) {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name == containerName && status.State.Terminated != nil {
+				latestPod = pod
+				message = strings.TrimSpace(status.State.Terminated.Message)
+			}
+		}
+	}
+	return message, nil
+}
+
 func RemoveDataProtectionFinalizer(ctx context.Context, cli client.Client, obj client.Object) error {
 	if !controllerutil.ContainsFinalizer(obj, dptypes.DataProtectionFinalizerName) {
 		return nil
@@ -153,3 +153,15 @@ func ToGVK(object client.Object) schema.GroupVersionKind {
 	t = t.Elem()
 	return corev1.SchemeGroupVersion.WithKind(t.Name())
 }
+
+// TypeName returns the name of the type of i, or the type pointed to if i is a pointer.
+func TypeName(i any) string {
+	t := reflect.TypeOf(i)
+	if t == nil {
+		return ""
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Name()
+}
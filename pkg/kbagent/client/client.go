@@ -21,6 +21,9 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
 	"io"
 	"net"
 	"net/http"
@@ -36,6 +39,9 @@ const (
 type Client interface {
 	io.Closer
 	Action(ctx context.Context, req proto.ActionRequest) (proto.ActionResponse, error)
+
+	// Journal lists or fetches the journal entries of the action and task runs.
+	Journal(ctx context.Context, req proto.JournalRequest) (proto.JournalResponse, error)
 }
 
 // HACK: for unit test only.
@@ -56,7 +62,42 @@ func GetMockClient() Client {
 	return mockClient
 }
 
-func NewClient(endpoint func() (string, int32, error)) (Client, error) {
+// Credential is the credential presented by clients to authenticate to the kb-agent.
+type Credential struct {
+	// Token is the bearer token, it is sent with each request if it is not empty.
+	Token string
+	// CA is used to verify the server certificate, and the Cert and Key are presented as the client certificate.
+	// The TLS is enabled if all of them are not empty.
+	CA   []byte
+	Cert []byte
+	Key  []byte
+}
+
+func (c *Credential) tlsEnabled() bool {
+	return c != nil && len(c.CA) > 0 && len(c.Cert) > 0 && len(c.Key) > 0
+}
+
+func (c *Credential) tlsConfig() (*tls.Config, error) {
+	if !c.tlsEnabled() {
+		return nil, nil
+	}
+	cert, err := tls.X509KeyPair(c.Cert, c.Key)
+	if err != nil {
+		return nil, fmt.Errorf("load kb-agent client certificate error: %s", err.Error())
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(c.CA) {
+		return nil, fmt.Errorf("no valid kb-agent CA certificate found")
+	}
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		ServerName:   proto.AuthServerName,
+	}, nil
+}
+
+func NewClient(endpoint func() (string, int32, error), credential *Credential) (Client, error) {
 	if mockClient != nil || mockClientError != nil {
 		return mockClient, mockClientError
 	}
@@ -73,18 +114,33 @@ func NewClient(endpoint func() (string, int32, error)) (Client, error) {
 	dialer := &net.Dialer{
 		Timeout: defaultConnectTimeout,
 	}
+	tlsConfig, err := credential.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
 	transport := &http.Transport{
 		Dial:                dialer.Dial,
 		TLSHandshakeTimeout: defaultConnectTimeout,
+		TLSClientConfig:     tlsConfig,
 	}
 	cli := &http.Client{
 		// don't set timeout at client level
 		// Timeout:   time.Second * 30,
 		Transport: transport,
 	}
+	scheme := "http"
+	if tlsConfig != nil {
+		scheme = "https"
+	}
+	var token string
+	if credential != nil {
+		token = credential.Token
+	}
 	return &httpClient{
+		scheme: scheme,
 		host:   host,
 		port:   port,
+		token:  token,
 		client: cli,
 	}, nil
 }
@@ -75,3 +75,18 @@ func (mr *MockClientMockRecorder) Action(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Action", reflect.TypeOf((*MockClient)(nil).Action), arg0, arg1)
 }
+
+// Journal mocks base method.
+func (m *MockClient) Journal(arg0 context.Context, arg1 proto.JournalRequest) (proto.JournalResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Journal", arg0, arg1)
+	ret0, _ := ret[0].(proto.JournalResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Journal indicates an expected call of Journal.
+func (mr *MockClientMockRecorder) Journal(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Journal", reflect.TypeOf((*MockClient)(nil).Journal), arg0, arg1)
+}
@@ -32,12 +32,14 @@ import (
 )
 
 const (
-	urlTemplate = "http://%s:%d%s"
+	urlTemplate = "%s://%s:%d%s"
 )
 
 type httpClient struct {
+	scheme string
 	host   string
 	port   int32
+	token  string
 	client *http.Client
 }
 
@@ -61,7 +63,25 @@ func (c *httpClient) Action(ctx context.Context, req proto.ActionRequest) (proto
 		return rsp, err
 	}
 
-	url := fmt.Sprintf(urlTemplate, c.host, c.port, proto.ServiceAction.URI)
+	url := fmt.Sprintf(urlTemplate, c.scheme, c.host, c.port, proto.ServiceAction.URI)
+	payload, err := c.request(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return rsp, err
+	}
+
+	defer payload.Close()
+	return decode(payload, &rsp)
+}
+
+func (c *httpClient) Journal(ctx context.Context, req proto.JournalRequest) (proto.JournalResponse, error) {
+	rsp := proto.JournalResponse{}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return rsp, err
+	}
+
+	url := fmt.Sprintf(urlTemplate, c.scheme, c.host, c.port, proto.ServiceJournal.URI)
 	payload, err := c.request(ctx, http.MethodPost, url, bytes.NewReader(data))
 	if err != nil {
 		return rsp, err
@@ -76,6 +96,9 @@ func (c *httpClient) request(ctx context.Context, method, url string, body io.Re
 	if err != nil {
 		return nil, err
 	}
+	if len(c.token) > 0 {
+		req.Header.Set(proto.AuthHeader, proto.AuthBearerPrefix+c.token)
+	}
 
 	rsp, err := c.client.Do(req)
 	if err != nil {
@@ -86,6 +109,7 @@ func (c *httpClient) request(ctx context.Context, method, url string, body io.Re
 	case http.StatusOK, http.StatusInternalServerError:
 		return rsp.Body, nil
 	default:
+		_ = rsp.Body.Close()
 		return nil, fmt.Errorf("unexpected http status code: %s", rsp.Status)
 	}
 }
@@ -39,10 +39,11 @@ import (
 )
 
 type portForwardClient struct {
-	pod    *corev1.Pod
-	port   string
-	config *rest.Config
-	logger logr.Logger
+	pod        *corev1.Pod
+	port       string
+	credential *Credential
+	config     *rest.Config
+	logger     logr.Logger
 }
 
 var _ Client = &portForwardClient{}
@@ -55,7 +56,26 @@ func (pf *portForwardClient) Close() error {
 // Since we can't know httpClient's lifecycle, a portforward is bound to one request.
 // It's not efficient, but enough for debugging purposes.
 func (pf *portForwardClient) Action(ctx context.Context, req proto.ActionRequest) (proto.ActionResponse, error) {
-	emptyResp := proto.ActionResponse{}
+	rsp := proto.ActionResponse{}
+	err := pf.forward(func(client Client) error {
+		var err error
+		rsp, err = client.Action(ctx, req)
+		return err
+	})
+	return rsp, err
+}
+
+func (pf *portForwardClient) Journal(ctx context.Context, req proto.JournalRequest) (proto.JournalResponse, error) {
+	rsp := proto.JournalResponse{}
+	err := pf.forward(func(client Client) error {
+		var err error
+		rsp, err = client.Journal(ctx, req)
+		return err
+	})
+	return rsp, err
+}
+
+func (pf *portForwardClient) forward(f func(client Client) error) error {
 	stopCh := make(chan struct{})
 	defer close(stopCh) // this will stop forwarder
 	readyCh := make(chan struct{})
@@ -78,7 +98,7 @@ func (pf *portForwardClient) Action(ctx context.Context, req proto.ActionRequest
 
 	forwarder, err := pf.newPortForwarder(readyCh, stopCh, outWriter)
 	if err != nil {
-		return emptyResp, err
+		return err
 	}
 	go func() {
 		err := forwarder.ForwardPorts()
@@ -92,29 +112,29 @@ func (pf *portForwardClient) Action(ctx context.Context, req proto.ActionRequest
 		// do nothing
 	case err := <-errCh:
 		pf.logger.Error(err, "port forward failed")
-		return emptyResp, err
+		return err
 	}
 
 	ports, err := forwarder.GetPorts()
 	if err != nil {
-		return emptyResp, err
+		return err
 	}
 	if len(ports) == 0 {
-		return emptyResp, fmt.Errorf("no port was forwarded")
+		return fmt.Errorf("no port was forwarded")
 	}
 
 	endpoint := func() (string, int32, error) {
 		return "localhost", int32(ports[0].Local), nil
 	}
-	client, err := NewClient(endpoint)
+	client, err := NewClient(endpoint, pf.credential)
 	if err != nil {
-		return emptyResp, err
+		return err
 	}
 
-	rsp, err := client.Action(ctx, req)
+	err = f(client)
 	_ = client.Close()
 
-	return rsp, err
+	return err
 }
 
 func (pf *portForwardClient) createDialer(method string, url *url.URL, config *rest.Config) (httpstream.Dialer, error) {
@@ -149,7 +169,7 @@ func (pf *portForwardClient) newPortForwarder(readyCh, stopCh chan struct{}, out
 	return fw, nil
 }
 
-func NewPortForwardClient(pod *corev1.Pod, endpoint func() (string, int32, error)) (Client, error) {
+func NewPortForwardClient(pod *corev1.Pod, endpoint func() (string, int32, error), credential *Credential) (Client, error) {
 	if mockClient != nil || mockClientError != nil {
 		return mockClient, mockClientError
 	}
@@ -161,9 +181,10 @@ func NewPortForwardClient(pod *corev1.Pod, endpoint func() (string, int32, error
 
 	config := ctrl.GetConfigOrDie()
 	return &portForwardClient{
-		pod:    pod,
-		port:   fmt.Sprint(port),
-		config: config,
-		logger: ctrl.Log.WithName("portforward"),
+		pod:        pod,
+		port:       fmt.Sprint(port),
+		credential: credential,
+		config:     config,
+		logger:     ctrl.Log.WithName("portforward"),
 	}, nil
 }
@@ -0,0 +1,42 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package proto
+
+const (
+	// AuthServerName is the server name presented in the certificates of kb-agent,
+	// clients should verify the server certificate against it instead of the pod IP.
+	AuthServerName = "kbagent"
+
+	// AuthTokenKey is the key of the bearer token in the auth secret of kb-agent.
+	AuthTokenKey = "token"
+	// AuthCAKey is the key of the CA certificate in the auth secret of kb-agent.
+	AuthCAKey = "ca.crt"
+	// AuthCertKey is the key of the certificate in the auth secret of kb-agent.
+	AuthCertKey = "tls.crt"
+	// AuthKeyKey is the key of the private key in the auth secret of kb-agent.
+	AuthKeyKey = "tls.key"
+
+	AuthHeader       = "Authorization"
+	AuthBearerPrefix = "Bearer "
+
+	// AuthStreamingLineMaxSize is the max size of the auth line of the streaming server. If the token auth is enabled,
+	// the client should send the bearer token as a line ("Bearer <token>\n") ahead of the streaming handshake.
+	AuthStreamingLineMaxSize = 1024
+)
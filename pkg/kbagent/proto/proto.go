@@ -93,13 +93,40 @@ const (
 )
 
 type Probe struct {
-	Instance            string `json:"instance"`
-	Action              string `json:"action"`
-	InitialDelaySeconds int32  `json:"initialDelaySeconds,omitempty"`
-	PeriodSeconds       int32  `json:"periodSeconds,omitempty"`
-	SuccessThreshold    int32  `json:"successThreshold,omitempty"`
-	FailureThreshold    int32  `json:"failureThreshold,omitempty"`
-	ReportPeriodSeconds int32  `json:"reportPeriodSeconds,omitempty"`
+	Instance            string          `json:"instance"`
+	Action              string          `json:"action"`
+	InitialDelaySeconds int32           `json:"initialDelaySeconds,omitempty"`
+	PeriodSeconds       int32           `json:"periodSeconds,omitempty"`
+	SuccessThreshold    int32           `json:"successThreshold,omitempty"`
+	FailureThreshold    int32           `json:"failureThreshold,omitempty"`
+	ReportPeriodSeconds int32           `json:"reportPeriodSeconds,omitempty"`
+	Synthetic           *SyntheticProbe `json:"synthetic,omitempty"` // the built-in synthetic check to run instead of the action
+}
+
+type SyntheticProbe struct {
+	TimeoutSeconds int32             `json:"timeoutSeconds,omitempty"`
+	Targets        []SyntheticTarget `json:"targets"`
+}
+
+type SyntheticTarget struct {
+	Name   string   `json:"name"`
+	Host   string   `json:"host"`
+	Port   int32    `json:"port"`
+	Path   string   `json:"path"`             // Read or Write path of the service
+	Roles  []string `json:"roles,omitempty"`  // roles of the replica that check the target, all roles if empty
+	Action string   `json:"action,omitempty"` // the action to check the target, only dial the target if empty
+}
+
+type SyntheticProbeOutput struct {
+	Role    string                  `json:"role,omitempty"`
+	Targets []SyntheticTargetResult `json:"targets"`
+}
+
+type SyntheticTargetResult struct {
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Succeed bool   `json:"succeed"`
+	Message string `json:"message,omitempty"` // the error of the check on failure
 }
 
 type ProbeEvent struct {
@@ -139,3 +166,36 @@ type NewReplicaTask struct {
 	Parameters     map[string]string `json:"parameters,omitempty"` // parameters for data dump and load
 	TimeoutSeconds *int32            `json:"timeoutSeconds,omitempty"`
 }
+
+const (
+	JournalKindAction    = "action"
+	JournalKindStreaming = "streaming"
+	JournalKindTask      = "task"
+)
+
+// JournalEntry records a run of an action or task.
+type JournalEntry struct {
+	ID              string            `json:"id"`
+	Kind            string            `json:"kind"`
+	Name            string            `json:"name"`                 // the name of the action or task
+	Replica         string            `json:"replica"`              // the replica that the action or task ran on
+	Parameters      map[string]string `json:"parameters,omitempty"` // the values of sensitive parameters are masked
+	StartTime       time.Time         `json:"startTime"`
+	EndTime         time.Time         `json:"endTime"`
+	Error           string            `json:"error,omitempty"`
+	Message         string            `json:"message,omitempty"`
+	Output          []byte            `json:"output,omitempty"`
+	OutputTruncated bool              `json:"outputTruncated,omitempty"`
+}
+
+type JournalRequest struct {
+	ID    string `json:"id,omitempty"`    // fetch the entry with the ID, all other fields are ignored if it is set
+	Name  string `json:"name,omitempty"`  // list the entries of the action or task only
+	Limit int    `json:"limit,omitempty"` // the maximum number of entries to list, the latest entries are returned first
+}
+
+type JournalResponse struct {
+	Error   string         `json:"error,omitempty"`
+	Message string         `json:"message,omitempty"`
+	Entries []JournalEntry `json:"entries,omitempty"`
+}
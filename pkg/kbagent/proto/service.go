@@ -41,4 +41,11 @@ var (
 		Version: "v1.0",
 		URI:     "/v1.0/streaming",
 	}
+	// ServiceJournal lists and fetches the pod-local journal of the action and task runs,
+	// the journal does not outlive the pod.
+	ServiceJournal = &Service{
+		Kind:    "Journal",
+		Version: "v1.0",
+		URI:     "/v1.0/journal",
+	}
 )
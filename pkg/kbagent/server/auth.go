@@ -0,0 +1,147 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+// ServerTLSConfig returns the TLS config used by the HTTP and streaming servers, it returns nil if the TLS is not enabled.
+func (c *Config) ServerTLSConfig() (*tls.Config, error) {
+	if len(c.TLSCertFile) == 0 && len(c.TLSKeyFile) == 0 {
+		return nil, nil
+	}
+	cert, err := c.loadKeyPair()
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+	if len(c.TLSClientCAFile) > 0 {
+		pool, err := c.loadCA()
+		if err != nil {
+			return nil, err
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
+
+// ClientTLSConfig returns the TLS config used to connect to the streaming server of other replicas,
+// it returns nil if the TLS is not enabled.
+func (c *Config) ClientTLSConfig() (*tls.Config, error) {
+	if len(c.TLSCertFile) == 0 && len(c.TLSKeyFile) == 0 {
+		return nil, nil
+	}
+	cert, err := c.loadKeyPair()
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		ServerName:   proto.AuthServerName,
+	}
+	if len(c.TLSClientCAFile) > 0 {
+		pool, err := c.loadCA()
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
+
+func (c *Config) loadKeyPair() (tls.Certificate, error) {
+	if len(c.TLSCertFile) == 0 || len(c.TLSKeyFile) == 0 {
+		return tls.Certificate{}, fmt.Errorf("both the TLS cert file and key file are required")
+	}
+	cert, err := tls.LoadX509KeyPair(c.TLSCertFile, c.TLSKeyFile)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("load TLS key pair error: %s", err.Error())
+	}
+	return cert, nil
+}
+
+func (c *Config) loadCA() (*x509.CertPool, error) {
+	data, err := os.ReadFile(c.TLSClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("read TLS CA file error: %s", err.Error())
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no valid certificate found in the TLS CA file %s", c.TLSClientCAFile)
+	}
+	return pool, nil
+}
+
+// AuthToken returns the bearer token required to call the servers, it returns empty if the token auth is not enabled.
+func (c *Config) AuthToken() (string, error) {
+	if len(c.AuthTokenFile) == 0 {
+		return "", nil
+	}
+	data, err := os.ReadFile(c.AuthTokenFile)
+	if err != nil {
+		return "", fmt.Errorf("read auth token file error: %s", err.Error())
+	}
+	token := strings.TrimSpace(string(data))
+	if len(token) == 0 {
+		return "", fmt.Errorf("the auth token file %s is empty", c.AuthTokenFile)
+	}
+	return token, nil
+}
+
+func authenticated(token, header string) bool {
+	if len(token) == 0 {
+		return true
+	}
+	if !strings.HasPrefix(header, proto.AuthBearerPrefix) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(strings.TrimPrefix(header, proto.AuthBearerPrefix))) == 1
+}
+
+// readAuthLine reads the auth line sent ahead of the streaming handshake, it reads byte by byte to leave the
+// handshake packet untouched.
+func readAuthLine(r io.Reader) (string, error) {
+	line := make([]byte, 0, len(proto.AuthBearerPrefix)+64)
+	b := make([]byte, 1)
+	for len(line) < proto.AuthStreamingLineMaxSize {
+		if _, err := io.ReadFull(r, b); err != nil {
+			return "", fmt.Errorf("read auth line error: %s", err.Error())
+		}
+		if b[0] == '\n' {
+			return string(line), nil
+		}
+		line = append(line, b[0])
+	}
+	return "", fmt.Errorf("the auth line exceeds %d bytes", proto.AuthStreamingLineMaxSize)
+}
@@ -0,0 +1,215 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/valyala/fasthttp"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+const testToken = "test-token"
+
+type fakeService struct {
+	requests chan string
+}
+
+func (s *fakeService) Kind() string { return "fake" }
+
+func (s *fakeService) URI() string { return "/v1.0/fake" }
+
+func (s *fakeService) Start() error { return nil }
+
+func (s *fakeService) HandleConn(ctx context.Context, conn net.Conn) error {
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return err
+	}
+	s.requests <- strings.TrimSpace(line)
+	return nil
+}
+
+func (s *fakeService) HandleRequest(ctx context.Context, payload []byte) ([]byte, error) {
+	return payload, nil
+}
+
+func TestAuthenticated(t *testing.T) {
+	cases := []struct {
+		token  string
+		header string
+		expect bool
+	}{
+		{"", "", true},
+		{"", "Bearer any", true},
+		{testToken, "", false},
+		{testToken, testToken, false},
+		{testToken, "Basic " + testToken, false},
+		{testToken, "Bearer wrong-token", false},
+		{testToken, "Bearer " + testToken + "x", false},
+		{testToken, "Bearer " + testToken, true},
+	}
+	for _, c := range cases {
+		if ret := authenticated(c.token, c.header); ret != c.expect {
+			t.Errorf("authenticated(%q, %q) = %v, expected %v", c.token, c.header, ret, c.expect)
+		}
+	}
+}
+
+func TestReadAuthLine(t *testing.T) {
+	r := strings.NewReader("Bearer " + testToken + "\n{\"action\":\"dataDump\"}")
+	line, err := readAuthLine(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if line != "Bearer "+testToken {
+		t.Errorf("unexpected auth line: %q", line)
+	}
+	if rest := r.Len(); rest != len("{\"action\":\"dataDump\"}") {
+		t.Errorf("the handshake packet should be left untouched, %d bytes left", rest)
+	}
+
+	if _, err = readAuthLine(strings.NewReader("Bearer " + testToken)); err == nil {
+		t.Errorf("expect an error for the incomplete auth line")
+	}
+	if _, err = readAuthLine(strings.NewReader(strings.Repeat("x", proto.AuthStreamingLineMaxSize+1) + "\n")); err == nil {
+		t.Errorf("expect an error for the oversized auth line")
+	}
+}
+
+func TestDispatcher(t *testing.T) {
+	s := &httpServer{logger: logr.Discard(), token: testToken}
+	handler := s.dispatcher(&fakeService{})
+
+	call := func(header string) *fasthttp.RequestCtx {
+		reqCtx := &fasthttp.RequestCtx{}
+		reqCtx.Request.Header.SetMethod(fasthttp.MethodPost)
+		reqCtx.Request.SetBodyString("payload")
+		if len(header) > 0 {
+			reqCtx.Request.Header.Set(proto.AuthHeader, header)
+		}
+		handler(reqCtx)
+		return reqCtx
+	}
+
+	for _, header := range []string{"", "Bearer wrong-token", testToken} {
+		reqCtx := call(header)
+		if code := reqCtx.Response.StatusCode(); code != fasthttp.StatusUnauthorized {
+			t.Errorf("header %q: unexpected status code %d", header, code)
+		}
+		if body := string(reqCtx.Response.Body()); body != "unauthorized" {
+			t.Errorf("header %q: unexpected body %q", header, body)
+		}
+	}
+
+	reqCtx := call(proto.AuthBearerPrefix + testToken)
+	if code := reqCtx.Response.StatusCode(); code != fasthttp.StatusOK {
+		t.Errorf("unexpected status code %d", code)
+	}
+	if body := string(reqCtx.Response.Body()); body != "payload" {
+		t.Errorf("unexpected body %q", body)
+	}
+}
+
+func TestMetricsAuth(t *testing.T) {
+	s := &httpServer{logger: logr.Discard(), token: testToken}
+	handler := s.router()
+
+	call := func(header string) *fasthttp.RequestCtx {
+		reqCtx := &fasthttp.RequestCtx{}
+		reqCtx.Request.Header.SetMethod(fasthttp.MethodGet)
+		reqCtx.Request.SetRequestURI(metricsURI)
+		if len(header) > 0 {
+			reqCtx.Request.Header.Set(proto.AuthHeader, header)
+		}
+		handler(reqCtx)
+		return reqCtx
+	}
+
+	for _, header := range []string{"", "Bearer wrong-token"} {
+		if code := call(header).Response.StatusCode(); code != fasthttp.StatusUnauthorized {
+			t.Errorf("header %q: unexpected status code %d", header, code)
+		}
+	}
+	reqCtx := call(proto.AuthBearerPrefix + testToken)
+	if code := reqCtx.Response.StatusCode(); code != fasthttp.StatusOK {
+		t.Errorf("unexpected status code %d", code)
+	}
+	if body := string(reqCtx.Response.Body()); body == "unauthorized" {
+		t.Errorf("unexpected body %q", body)
+	}
+}
+
+func TestStreamingServerAuth(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), proto.AuthTokenKey)
+	if err := os.WriteFile(tokenFile, []byte(testToken+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	svc := &fakeService{requests: make(chan string, 1)}
+	s := NewStreamingServer(logr.Discard(), Config{Address: "127.0.0.1", AuthTokenFile: tokenFile}, svc).(*streamingServer)
+	if err := s.StartNonBlocking(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	// send returns the request received by the service, or empty if the connection is rejected
+	send := func(authLine string) string {
+		conn, err := net.Dial("tcp", s.listener.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		if _, err = fmt.Fprintf(conn, "%s\n%s\n", authLine, "handshake"); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case req := <-svc.requests:
+			return req
+		case <-time.After(time.Second):
+			// the connection should be closed by the server
+			_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+			if _, err = conn.Read(make([]byte, 1)); err == nil || errors.Is(err, os.ErrDeadlineExceeded) {
+				t.Errorf("the connection is expected to be closed by the server, error: %v", err)
+			}
+			return ""
+		}
+	}
+
+	if req := send("Bearer wrong-token"); len(req) > 0 {
+		t.Errorf("the connection with a wrong token should be rejected, request: %s", req)
+	}
+	if req := send("handshake without token"); len(req) > 0 {
+		t.Errorf("the connection without token should be rejected, request: %s", req)
+	}
+	if req := send(proto.AuthBearerPrefix + testToken); req != "handshake" {
+		t.Errorf("unexpected request: %q", req)
+	}
+}
@@ -21,6 +21,7 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
@@ -28,14 +29,18 @@ import (
 
 	fasthttprouter "github.com/fasthttp/router"
 	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
 
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
 	"github.com/apecloud/kubeblocks/pkg/kbagent/service"
 )
 
 const (
 	defaultMaxConcurrency = 8
 	jsonContentTypeHeader = "application/json"
+	metricsURI            = "/metrics"
 )
 
 type httpServer struct {
@@ -43,6 +48,7 @@ type httpServer struct {
 	config   Config
 	services []service.Service
 	servers  []*fasthttp.Server
+	token    string
 }
 
 var _ Server = &httpServer{}
@@ -51,6 +57,15 @@ var _ Server = &httpServer{}
 func (s *httpServer) StartNonBlocking() error {
 	s.logger.Info("starting the HTTP server")
 
+	tlsConfig, err := s.config.ServerTLSConfig()
+	if err != nil {
+		return err
+	}
+	s.token, err = s.config.AuthToken()
+	if err != nil {
+		return err
+	}
+
 	handler := s.router()
 
 	var listeners []net.Listener
@@ -66,6 +81,9 @@ func (s *httpServer) StartNonBlocking() error {
 		if err != nil {
 			s.logger.Error(err, "listen HTTP server error", "address", s.config.Address, "port", s.config.Port)
 		} else {
+			if tlsConfig != nil {
+				l = tls.NewListener(l, tlsConfig)
+			}
 			listeners = append(listeners, l)
 		}
 	}
@@ -117,9 +135,24 @@ func (s *httpServer) router() fasthttp.RequestHandler {
 	for i := range s.services {
 		s.registerService(router, s.services[i])
 	}
+	s.registerMetrics(router)
 	return router.Handler
 }
 
+// registerMetrics registers the metrics behind the same auth as the services, the scraper is required to
+// send the auth token as a bearer token if the auth is enabled.
+func (s *httpServer) registerMetrics(router *fasthttprouter.Router) {
+	handler := fasthttpadaptor.NewFastHTTPHandler(promhttp.HandlerFor(service.MetricsRegistry, promhttp.HandlerOpts{}))
+	router.Handle(fasthttp.MethodGet, metricsURI, func(reqCtx *fasthttp.RequestCtx) {
+		if !authenticated(s.token, string(reqCtx.Request.Header.Peek(proto.AuthHeader))) {
+			httpRespond(reqCtx, fasthttp.StatusUnauthorized, nil, errors.New("unauthorized"))
+			return
+		}
+		handler(reqCtx)
+	})
+	s.logger.Info("register metrics to server", "method", fasthttp.MethodGet, "uri", metricsURI)
+}
+
 func (s *httpServer) registerService(router *fasthttprouter.Router, svc service.Service) {
 	router.Handle(fasthttp.MethodPost, svc.URI(), s.dispatcher(svc))
 	s.logger.Info("register service to server", "service", svc.Kind(), "method", fasthttp.MethodPost, "uri", svc.URI())
@@ -127,13 +160,19 @@ func (s *httpServer) registerService(router *fasthttprouter.Router, svc service.
 
 func (s *httpServer) dispatcher(svc service.Service) func(*fasthttp.RequestCtx) {
 	return func(reqCtx *fasthttp.RequestCtx) {
-		ctx := context.Background()
-		body := reqCtx.PostBody()
-
-		output, err := svc.HandleRequest(ctx, body)
-		statusCode := fasthttp.StatusOK
-		if err != nil {
-			statusCode = fasthttp.StatusInternalServerError
+		var (
+			output     []byte
+			err        error
+			statusCode = fasthttp.StatusOK
+		)
+		if authenticated(s.token, string(reqCtx.Request.Header.Peek(proto.AuthHeader))) {
+			output, err = svc.HandleRequest(context.Background(), reqCtx.PostBody())
+			if err != nil {
+				statusCode = fasthttp.StatusInternalServerError
+			}
+		} else {
+			err = errors.New("unauthorized")
+			statusCode = fasthttp.StatusUnauthorized
 		}
 		httpRespond(reqCtx, statusCode, output, err)
 		if s.config.Logging {
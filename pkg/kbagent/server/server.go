@@ -41,6 +41,14 @@ type Config struct {
 	StreamingPort    int
 	Concurrency      int
 	Logging          bool
+
+	// TLSCertFile and TLSKeyFile are the certificate and key used to serve the HTTP and streaming servers over TLS.
+	TLSCertFile string
+	TLSKeyFile  string
+	// TLSClientCAFile is the CA used to verify the client certificates, the client certificate is required if it is set.
+	TLSClientCAFile string
+	// AuthTokenFile is the file that contains the bearer token required to call the HTTP and streaming servers.
+	AuthTokenFile string
 }
 
 // NewHTTPServer returns a new HTTP server.
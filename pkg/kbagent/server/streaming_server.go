@@ -21,6 +21,7 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
@@ -32,11 +33,16 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/kbagent/service"
 )
 
+const (
+	streamingAuthTimeout = 10 * time.Second
+)
+
 type streamingServer struct {
 	logger   logr.Logger
 	config   Config
 	service  service.Service
 	listener net.Listener
+	token    string
 }
 
 var _ Server = &streamingServer{}
@@ -50,18 +56,33 @@ func (s *streamingServer) StartNonBlocking() error {
 		return nil
 	}
 
-	var err1 error
+	tlsConfig, err1 := s.config.ServerTLSConfig()
+	if err1 != nil {
+		return err1
+	}
+	s.token, err1 = s.config.AuthToken()
+	if err1 != nil {
+		return err1
+	}
+
 	s.listener, err1 = net.Listen("tcp", fmt.Sprintf("%s:%v", s.config.Address, s.config.StreamingPort))
 	if err1 != nil {
 		s.logger.Error(err1, "listen failed", "listen address", s.config.Address, "port", s.config.StreamingPort)
 		return err1
 	}
+	if tlsConfig != nil {
+		// the client certificate is checked if the client CA is set
+		s.listener = tls.NewListener(s.listener, tlsConfig)
+	}
 
 	go func() {
 		var tempErr error
 		for {
 			conn, err2 := s.listener.Accept()
 			if err2 != nil {
+				if errors.Is(err2, net.ErrClosed) {
+					return // the server is closed
+				}
 				var netErr net.Error
 				if errors.As(errors.Unwrap(err2), &netErr) && netErr.Temporary() {
 					if tempErr == nil || !errors.Is(err2, tempErr) {
@@ -99,12 +120,35 @@ func (s *streamingServer) silentClose(c io.Closer) {
 	_ = s.close(c)
 }
 
+// authenticate checks the bearer token sent by the client ahead of the streaming handshake, if the token auth is enabled.
+func (s *streamingServer) authenticate(conn net.Conn) error {
+	if len(s.token) == 0 {
+		return nil
+	}
+	if err := conn.SetReadDeadline(time.Now().Add(streamingAuthTimeout)); err != nil {
+		return err
+	}
+	line, err := readAuthLine(conn)
+	if err != nil {
+		return err
+	}
+	if !authenticated(s.token, line) {
+		return errors.New("unauthorized")
+	}
+	return conn.SetReadDeadline(time.Time{})
+}
+
 func (s *streamingServer) handleConn(conn net.Conn) {
 	defer s.silentClose(conn)
 
 	logger := s.logger.WithValues("remote", conn.RemoteAddr())
 	logger.Info("accepted a new streaming connection")
 
+	if err := s.authenticate(conn); err != nil {
+		logger.Error(err, "authenticate streaming connection error")
+		return
+	}
+
 	now := time.Now()
 	err := s.service.HandleConn(context.Background(), conn)
 	if err != nil {
@@ -26,6 +26,7 @@ import (
 	"net"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -34,10 +35,11 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
 )
 
-func newActionService(logger logr.Logger, actions []proto.Action) (*actionService, error) {
+func newActionService(logger logr.Logger, actions []proto.Action, journal *journal) (*actionService, error) {
 	sa := &actionService{
 		logger:         logger,
 		actions:        make(map[string]*proto.Action),
+		journal:        journal,
 		mutex:          sync.Mutex{},
 		runningActions: map[string]*runningAction{},
 	}
@@ -51,12 +53,14 @@ func newActionService(logger logr.Logger, actions []proto.Action) (*actionServic
 type actionService struct {
 	logger  logr.Logger
 	actions map[string]*proto.Action
+	journal *journal
 
 	mutex          sync.Mutex
 	runningActions map[string]*runningAction
 }
 
 type runningAction struct {
+	startTime  time.Time
 	resultChan chan *asyncResult
 }
 
@@ -83,7 +87,11 @@ func (s *actionService) HandleRequest(ctx context.Context, payload []byte) ([]by
 	if err != nil {
 		return s.encode(nil, err), nil
 	}
+	start := s.startTime(req)
 	resp, err := s.handleRequest(ctx, req)
+	if !errors.Is(err, proto.ErrInProgress) {
+		s.journal.record(proto.JournalKindAction, req.Action, req.Parameters, start, resp, err)
+	}
 	result := string(resp)
 	if err != nil {
 		result = err.Error()
@@ -112,6 +120,16 @@ func (s *actionService) encode(out []byte, err error) []byte {
 	return data
 }
 
+// startTime returns the start time of the running non-blocking action, or now if it is not running.
+func (s *actionService) startTime(req *proto.ActionRequest) time.Time {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if running, ok := s.runningActions[req.Action]; ok {
+		return running.startTime
+	}
+	return time.Now()
+}
+
 func (s *actionService) handleRequest(ctx context.Context, req *proto.ActionRequest) ([]byte, error) {
 	action, ok := s.actions[req.Action]
 	if !ok {
@@ -141,6 +159,7 @@ func (s *actionService) handleRequestNonBlocking(ctx context.Context, req *proto
 			return nil, err
 		}
 		running = &runningAction{
+			startTime:  time.Now(),
 			resultChan: resultChan,
 		}
 		s.runningActions[req.Action] = running
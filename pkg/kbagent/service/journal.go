@@ -0,0 +1,295 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/util"
+)
+
+const (
+	defaultJournalMaxEntries    = 256
+	defaultJournalMaxOutputSize = 4 * 1024
+	journalFileSuffix           = ".json"
+	journalMaskedValue          = "******"
+)
+
+var (
+	journalSensitiveKeywords = []string{"PASSWORD", "SECRET", "TOKEN"}
+)
+
+// journal is a bounded on-disk journal of the action and task runs, each entry is stored as a single file,
+// so that the server and the worker can share the same directory.
+//
+// The journal is pod-local rather than persistent: the directory is an emptyDir volume of the pod, so the entries
+// survive the restarts of the kbagent containers, but are lost once the pod is deleted, evicted or rescheduled.
+// The entries should be fetched before the pod is recreated if they need to be kept longer.
+type journal struct {
+	logger     logr.Logger
+	dir        string
+	maxEntries int
+	mutex      sync.Mutex
+}
+
+func newJournal(logger logr.Logger, dir string) (*journal, error) {
+	if len(dir) == 0 {
+		return nil, nil
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, errors.Wrapf(err, "create the journal dir %s error", dir)
+	}
+	return &journal{
+		logger:     logger,
+		dir:        dir,
+		maxEntries: defaultJournalMaxEntries,
+	}, nil
+}
+
+// record writes the entry into the journal, the errors are logged only to not fail the action.
+func (j *journal) record(kind, name string, parameters map[string]string, start time.Time, output []byte, err error) {
+	if j == nil {
+		return
+	}
+	entry := proto.JournalEntry{
+		ID:         j.newID(start),
+		Kind:       kind,
+		Name:       name,
+		Replica:    util.PodName(),
+		Parameters: maskSensitiveParameters(parameters),
+		StartTime:  start,
+		EndTime:    time.Now(),
+	}
+	if err != nil {
+		entry.Error = proto.Error2Type(err)
+		entry.Message = err.Error()
+	}
+	if len(output) > defaultJournalMaxOutputSize {
+		entry.Output = output[:defaultJournalMaxOutputSize]
+		entry.OutputTruncated = true
+	} else {
+		entry.Output = output
+	}
+	if err1 := j.write(entry); err1 != nil {
+		j.logger.Error(err1, "failed to write the journal entry", "kind", kind, "name", name)
+	}
+}
+
+func (j *journal) newID(start time.Time) string {
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	// the fixed-width timestamp keeps the IDs sorted in time order
+	return fmt.Sprintf("%019d-%s", start.UnixNano(), hex.EncodeToString(suffix))
+}
+
+func (j *journal) write(entry proto.JournalEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	// write to a temporary file first to avoid reading a partial entry
+	tmp := filepath.Join(j.dir, "."+entry.ID)
+	if err = os.WriteFile(tmp, data, 0640); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, j.file(entry.ID)); err != nil {
+		return err
+	}
+	return j.prune()
+}
+
+func (j *journal) prune() error {
+	ids, err := j.ids()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(ids)-j.maxEntries; i++ {
+		if err = os.Remove(j.file(ids[i])); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// ids returns the IDs of all entries in the journal, in time order.
+func (j *journal) ids() ([]string, error) {
+	files, err := os.ReadDir(j.dir)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(files))
+	for _, f := range files {
+		if f.IsDir() || strings.HasPrefix(f.Name(), ".") || !strings.HasSuffix(f.Name(), journalFileSuffix) {
+			continue
+		}
+		ids = append(ids, strings.TrimSuffix(f.Name(), journalFileSuffix))
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
+func (j *journal) file(id string) string {
+	return filepath.Join(j.dir, id+journalFileSuffix)
+}
+
+func (j *journal) get(id string) (*proto.JournalEntry, error) {
+	if strings.ContainsAny(id, `/\`) {
+		return nil, errors.Wrapf(proto.ErrBadRequest, "invalid journal entry id %s", id)
+	}
+	data, err := os.ReadFile(j.file(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Wrapf(proto.ErrNotDefined, "journal entry %s is not found", id)
+		}
+		return nil, err
+	}
+	entry := &proto.JournalEntry{}
+	if err = json.Unmarshal(data, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (j *journal) list(name string, limit int) ([]proto.JournalEntry, error) {
+	ids, err := j.ids()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]proto.JournalEntry, 0)
+	for i := len(ids) - 1; i >= 0; i-- {
+		if limit > 0 && len(entries) >= limit {
+			break
+		}
+		entry, err := j.get(ids[i])
+		if err != nil {
+			if errors.Is(err, proto.ErrNotDefined) {
+				continue // pruned
+			}
+			return nil, err
+		}
+		if len(name) == 0 || entry.Name == name {
+			entries = append(entries, *entry)
+		}
+	}
+	return entries, nil
+}
+
+func maskSensitiveParameters(parameters map[string]string) map[string]string {
+	if len(parameters) == 0 {
+		return nil
+	}
+	masked := make(map[string]string, len(parameters))
+	for k, v := range parameters {
+		upper := strings.ToUpper(k)
+		if slices.ContainsFunc(journalSensitiveKeywords, func(keyword string) bool {
+			return strings.Contains(upper, keyword)
+		}) {
+			v = journalMaskedValue
+		}
+		masked[k] = v
+	}
+	return masked
+}
+
+func newJournalService(logger logr.Logger, journal *journal) (*journalService, error) {
+	sj := &journalService{
+		logger:  logger,
+		journal: journal,
+	}
+	logger.Info(fmt.Sprintf("create service %s", sj.Kind()), "enabled", journal != nil)
+	return sj, nil
+}
+
+type journalService struct {
+	logger  logr.Logger
+	journal *journal
+}
+
+var _ Service = &journalService{}
+
+func (s *journalService) Kind() string {
+	return proto.ServiceJournal.Kind
+}
+
+func (s *journalService) URI() string {
+	return proto.ServiceJournal.URI
+}
+
+func (s *journalService) Start() error {
+	return nil
+}
+
+func (s *journalService) HandleConn(ctx context.Context, conn net.Conn) error {
+	return nil
+}
+
+func (s *journalService) HandleRequest(ctx context.Context, payload []byte) ([]byte, error) {
+	req := &proto.JournalRequest{}
+	if err := json.Unmarshal(payload, req); err != nil {
+		return s.encode(nil, errors.Wrapf(proto.ErrBadRequest, "unmarshal journal request error: %s", err.Error())), nil
+	}
+	entries, err := s.handleRequest(req)
+	return s.encode(entries, err), nil
+}
+
+func (s *journalService) handleRequest(req *proto.JournalRequest) ([]proto.JournalEntry, error) {
+	if s.journal == nil {
+		return nil, errors.Wrap(proto.ErrNotDefined, "the journal is not enabled")
+	}
+	if len(req.ID) > 0 {
+		entry, err := s.journal.get(req.ID)
+		if err != nil {
+			return nil, err
+		}
+		return []proto.JournalEntry{*entry}, nil
+	}
+	return s.journal.list(req.Name, req.Limit)
+}
+
+func (s *journalService) encode(entries []proto.JournalEntry, err error) []byte {
+	rsp := &proto.JournalResponse{}
+	if err == nil {
+		rsp.Entries = entries
+	} else {
+		rsp.Error = proto.Error2Type(err)
+		rsp.Message = err.Error()
+	}
+	data, _ := json.Marshal(rsp)
+	return data
+}
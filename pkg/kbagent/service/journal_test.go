@@ -0,0 +1,202 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+var _ = Describe("journal", func() {
+	var (
+		dir string
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "kbagent-journal-")
+		Expect(err).Should(BeNil())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).Should(Succeed())
+	})
+
+	Context("journal", func() {
+		It("disabled", func() {
+			j, err := newJournal(logr.New(nil), "")
+			Expect(err).Should(BeNil())
+			Expect(j).Should(BeNil())
+
+			// should be safe to record with a nil journal
+			j.record(proto.JournalKindAction, "action", nil, time.Now(), nil, nil)
+		})
+
+		It("record and get", func() {
+			j, err := newJournal(logr.New(nil), dir)
+			Expect(err).Should(BeNil())
+
+			parameters := map[string]string{
+				"KB_ACCOUNT_NAME":     "root",
+				"KB_ACCOUNT_PASSWORD": "password",
+			}
+			j.record(proto.JournalKindAction, "accountProvision", parameters, time.Now(), []byte("output"), proto.ErrFailed)
+
+			entries, err := j.list("", 0)
+			Expect(err).Should(BeNil())
+			Expect(entries).Should(HaveLen(1))
+
+			entry, err := j.get(entries[0].ID)
+			Expect(err).Should(BeNil())
+			Expect(entry.Kind).Should(Equal(proto.JournalKindAction))
+			Expect(entry.Name).Should(Equal("accountProvision"))
+			Expect(entry.Parameters["KB_ACCOUNT_NAME"]).Should(Equal("root"))
+			Expect(entry.Parameters["KB_ACCOUNT_PASSWORD"]).Should(Equal(journalMaskedValue))
+			Expect(entry.Output).Should(Equal([]byte("output")))
+			Expect(entry.Error).Should(Equal(proto.Error2Type(proto.ErrFailed)))
+			Expect(entry.EndTime.Before(entry.StartTime)).Should(BeFalse())
+		})
+
+		It("get not found", func() {
+			j, err := newJournal(logr.New(nil), dir)
+			Expect(err).Should(BeNil())
+
+			_, err = j.get("not-exist")
+			Expect(err).ShouldNot(BeNil())
+			Expect(err).Should(MatchError(proto.ErrNotDefined))
+
+			_, err = j.get("../not-exist")
+			Expect(err).Should(MatchError(proto.ErrBadRequest))
+		})
+
+		It("truncate output", func() {
+			j, err := newJournal(logr.New(nil), dir)
+			Expect(err).Should(BeNil())
+
+			j.record(proto.JournalKindAction, "action", nil, time.Now(), []byte(strings.Repeat("x", defaultJournalMaxOutputSize+1)), nil)
+
+			entries, err := j.list("", 0)
+			Expect(err).Should(BeNil())
+			Expect(entries).Should(HaveLen(1))
+			Expect(entries[0].Output).Should(HaveLen(defaultJournalMaxOutputSize))
+			Expect(entries[0].OutputTruncated).Should(BeTrue())
+		})
+
+		It("bounded", func() {
+			j, err := newJournal(logr.New(nil), dir)
+			Expect(err).Should(BeNil())
+			j.maxEntries = 3
+
+			start := time.Now()
+			for i := 0; i < 5; i++ {
+				j.record(proto.JournalKindAction, fmt.Sprintf("action-%d", i), nil, start.Add(time.Duration(i)*time.Second), nil, nil)
+			}
+
+			entries, err := j.list("", 0)
+			Expect(err).Should(BeNil())
+			Expect(entries).Should(HaveLen(3))
+			// the latest entries first
+			Expect(entries[0].Name).Should(Equal("action-4"))
+			Expect(entries[1].Name).Should(Equal("action-3"))
+			Expect(entries[2].Name).Should(Equal("action-2"))
+		})
+
+		It("list with filter and limit", func() {
+			j, err := newJournal(logr.New(nil), dir)
+			Expect(err).Should(BeNil())
+
+			start := time.Now()
+			for i := 0; i < 4; i++ {
+				j.record(proto.JournalKindAction, fmt.Sprintf("action-%d", i%2), nil, start.Add(time.Duration(i)*time.Second), nil, nil)
+			}
+
+			entries, err := j.list("action-1", 0)
+			Expect(err).Should(BeNil())
+			Expect(entries).Should(HaveLen(2))
+
+			entries, err = j.list("", 1)
+			Expect(err).Should(BeNil())
+			Expect(entries).Should(HaveLen(1))
+			Expect(entries[0].Name).Should(Equal("action-1"))
+		})
+	})
+
+	Context("journal service", func() {
+		It("not enabled", func() {
+			service, err := newJournalService(logr.New(nil), nil)
+			Expect(err).Should(BeNil())
+			Expect(service.Kind()).Should(Equal(proto.ServiceJournal.Kind))
+
+			output, err := service.HandleRequest(context.Background(), []byte("{}"))
+			Expect(err).Should(BeNil())
+			rsp := &proto.JournalResponse{}
+			Expect(json.Unmarshal(output, rsp)).Should(Succeed())
+			Expect(rsp.Error).Should(Equal(proto.Error2Type(proto.ErrNotDefined)))
+		})
+
+		It("action", func() {
+			j, err := newJournal(logr.New(nil), dir)
+			Expect(err).Should(BeNil())
+			actionSvc, err := newActionService(logr.New(nil), []proto.Action{
+				{
+					Name: "echo",
+					Exec: &proto.ExecAction{
+						Commands: []string{"echo", "-n", "hello"},
+					},
+				},
+			}, j)
+			Expect(err).Should(BeNil())
+			journalSvc, err := newJournalService(logr.New(nil), j)
+			Expect(err).Should(BeNil())
+
+			req, _ := json.Marshal(proto.ActionRequest{Action: "echo"})
+			_, err = actionSvc.HandleRequest(context.Background(), req)
+			Expect(err).Should(BeNil())
+
+			req, _ = json.Marshal(proto.JournalRequest{Name: "echo"})
+			output, err := journalSvc.HandleRequest(context.Background(), req)
+			Expect(err).Should(BeNil())
+			rsp := &proto.JournalResponse{}
+			Expect(json.Unmarshal(output, rsp)).Should(Succeed())
+			Expect(rsp.Error).Should(BeEmpty())
+			Expect(rsp.Entries).Should(HaveLen(1))
+			Expect(rsp.Entries[0].Output).Should(Equal([]byte("hello")))
+
+			req, _ = json.Marshal(proto.JournalRequest{ID: rsp.Entries[0].ID})
+			output, err = journalSvc.HandleRequest(context.Background(), req)
+			Expect(err).Should(BeNil())
+			rsp = &proto.JournalResponse{}
+			Expect(json.Unmarshal(output, rsp)).Should(Succeed())
+			Expect(rsp.Entries).Should(HaveLen(1))
+			Expect(rsp.Entries[0].Name).Should(Equal("echo"))
+		})
+	})
+})
@@ -26,6 +26,7 @@ import (
 	"net"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -38,6 +39,7 @@ import (
 
 const (
 	defaultProbePeriodSeconds = 60
+	roleProbeAction           = "roleProbe"
 )
 
 func newProbeService(logger logr.Logger, actionService *actionService, probes []proto.Probe) (*probeService, error) {
@@ -48,9 +50,16 @@ func newProbeService(logger logr.Logger, actionService *actionService, probes []
 		runners:       make(map[string]*probeRunner),
 	}
 	for i, p := range probes {
-		if _, ok := actionService.actions[p.Action]; !ok {
+		if _, ok := actionService.actions[p.Action]; !ok && p.Synthetic == nil {
 			return nil, fmt.Errorf("probe %s has no action defined", p.Action)
 		}
+		if p.Synthetic != nil {
+			for _, target := range p.Synthetic.Targets {
+				if _, ok := actionService.actions[target.Action]; len(target.Action) > 0 && !ok {
+					return nil, fmt.Errorf("synthetic target %s of probe %s has no action defined", target.Name, p.Action)
+				}
+			}
+		}
 		sp.probes[p.Action] = &probes[i]
 	}
 	logger.Info(fmt.Sprintf("create service %s", sp.Kind()), "probes", strings.Join(maps.Keys(sp.probes), ","))
@@ -81,12 +90,27 @@ func (s *probeService) Start() error {
 			actionService: s.actionService,
 			latestEvent:   make(chan proto.ProbeEvent, 1),
 		}
-		go runner.run(s.probes[name])
+		if s.probes[name].Synthetic != nil {
+			runner.synthetic = newSyntheticChecker(s.probes[name].Synthetic, s.role, s.actionService)
+		}
 		s.runners[name] = runner
 	}
+	// launch the runners after all of them are created, the synthetic checker looks up the role probe runner
+	for name, runner := range s.runners {
+		go runner.run(s.probes[name])
+	}
 	return nil
 }
 
+// role returns the latest succeed output of the role probe, which is the current role of the replica.
+func (s *probeService) role() string {
+	runner, ok := s.runners[roleProbeAction]
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(string(runner.output()))
+}
+
 func (s *probeService) HandleConn(ctx context.Context, conn net.Conn) error {
 	return nil
 }
@@ -98,9 +122,11 @@ func (s *probeService) HandleRequest(ctx context.Context, payload []byte) ([]byt
 type probeRunner struct {
 	logger        logr.Logger
 	actionService *actionService
+	synthetic     *syntheticChecker
 	ticker        *time.Ticker
 	succeedCount  int64
 	failedCount   int64
+	outputLock    sync.RWMutex
 	latestOutput  []byte
 	latestEvent   chan proto.ProbeEvent
 }
@@ -130,7 +156,7 @@ func (r *probeRunner) launchRunLoop(probe *proto.Probe) {
 
 func (r *probeRunner) runLoop(probe *proto.Probe) {
 	once := func() {
-		output, err := r.actionService.handleRequest(context.Background(), &proto.ActionRequest{Action: probe.Action})
+		output, err := r.runProbe(probe)
 		if err == nil {
 			r.succeedCount++
 			r.failedCount = 0
@@ -142,7 +168,9 @@ func (r *probeRunner) runLoop(probe *proto.Probe) {
 		r.report(probe, output, err)
 
 		if succeed, _ := r.succeed(probe); succeed && !reflect.DeepEqual(output, r.latestOutput) {
+			r.outputLock.Lock()
 			r.latestOutput = output
+			r.outputLock.Unlock()
 		}
 	}
 
@@ -154,6 +182,19 @@ func (r *probeRunner) runLoop(probe *proto.Probe) {
 	}
 }
 
+func (r *probeRunner) runProbe(probe *proto.Probe) ([]byte, error) {
+	if r.synthetic != nil {
+		return r.synthetic.check(context.Background())
+	}
+	return r.actionService.handleRequest(context.Background(), &proto.ActionRequest{Action: probe.Action})
+}
+
+func (r *probeRunner) output() []byte {
+	r.outputLock.RLock()
+	defer r.outputLock.RUnlock()
+	return r.latestOutput
+}
+
 func (r *probeRunner) launchReportLoop(probe *proto.Probe) {
 	if probe.ReportPeriodSeconds <= 0 {
 		return
@@ -58,7 +58,7 @@ var _ = Describe("probe", func() {
 
 		BeforeEach(func() {
 			var err error
-			actionSvc, err = newActionService(logr.New(nil), actions)
+			actionSvc, err = newActionService(logr.New(nil), actions, nil)
 			Expect(err).Should(BeNil())
 		})
 
@@ -21,6 +21,7 @@ package service
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
 
 	"github.com/go-logr/logr"
@@ -39,8 +40,12 @@ type Service interface {
 	HandleRequest(ctx context.Context, payload []byte) ([]byte, error)
 }
 
-func New(logger logr.Logger, actions []proto.Action, probes []proto.Probe, streaming []string) ([]Service, error) {
-	sa, err := newActionService(logger, actions)
+func New(logger logr.Logger, actions []proto.Action, probes []proto.Probe, streaming []string, journalDir string) ([]Service, error) {
+	j, err := newJournal(logger, journalDir)
+	if err != nil {
+		return nil, err
+	}
+	sa, err := newActionService(logger, actions, j)
 	if err != nil {
 		return nil, err
 	}
@@ -52,14 +57,20 @@ func New(logger logr.Logger, actions []proto.Action, probes []proto.Probe, strea
 	if err != nil {
 		return nil, err
 	}
-	return []Service{sa, sp, ss}, nil
+	sj, err := newJournalService(logger, j)
+	if err != nil {
+		return nil, err
+	}
+	return []Service{sa, sp, ss, sj}, nil
 }
 
-func RunTasks(logger logr.Logger, service Service, tasks []proto.Task) error {
+func RunTasks(logger logr.Logger, service Service, tasks []proto.Task, tlsConfig *tls.Config, token string) error {
 	st := &taskService{
 		logger:        logger,
 		actionService: service.(*actionService),
 		tasks:         tasks,
+		tlsConfig:     tlsConfig,
+		token:         token,
 	}
 	return st.runTasks(context.Background())
 }
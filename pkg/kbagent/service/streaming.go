@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"net"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -40,6 +41,7 @@ const (
 func newStreamingService(logger logr.Logger, actionService *actionService, streamingActions []string) (*streamingService, error) {
 	ss := &streamingService{
 		logger:           logger,
+		journal:          actionService.journal,
 		streamingActions: make(map[string]*proto.Action),
 	}
 	for _, a := range streamingActions {
@@ -55,6 +57,7 @@ func newStreamingService(logger logr.Logger, actionService *actionService, strea
 
 type streamingService struct {
 	logger           logr.Logger
+	journal          *journal
 	streamingActions map[string]*proto.Action
 }
 
@@ -83,7 +86,10 @@ func (s *streamingService) HandleConn(ctx context.Context, conn net.Conn) error
 		return fmt.Errorf("%s is not supported", req.Action)
 	}
 
-	return s.streaming(ctx, conn, action, req)
+	start := time.Now()
+	err = s.streaming(ctx, conn, action, req)
+	s.journal.record(proto.JournalKindStreaming, req.Action, req.Parameters, start, nil, err)
+	return err
 }
 
 func (s *streamingService) HandleRequest(ctx context.Context, payload []byte) ([]byte, error) {
@@ -0,0 +1,185 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+const (
+	defaultSyntheticTimeoutSeconds = 5
+
+	// the environment variables provided to the action of a synthetic target
+	syntheticTargetNameVar  = "KB_SYNTHETIC_TARGET_NAME"
+	syntheticTargetHostVar  = "KB_SYNTHETIC_TARGET_HOST"
+	syntheticTargetPortVar  = "KB_SYNTHETIC_TARGET_PORT"
+	syntheticTargetPathVar  = "KB_SYNTHETIC_TARGET_PATH"
+	syntheticReplicaRoleVar = "KB_SYNTHETIC_REPLICA_ROLE"
+)
+
+var (
+	// MetricsRegistry is the registry of the metrics exposed by kbagent.
+	MetricsRegistry = prometheus.NewRegistry()
+
+	syntheticCheckDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "kbagent",
+			Name:      "synthetic_check_duration_seconds",
+			Help:      "Latency of the synthetic checks to the service endpoints.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+		},
+		[]string{"target", "path", "role"},
+	)
+	syntheticChecksTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "kbagent",
+			Name:      "synthetic_checks_total",
+			Help:      "Number of synthetic checks to the service endpoints, partitioned by result.",
+		},
+		[]string{"target", "path", "role", "result"},
+	)
+	syntheticCheckUp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "kbagent",
+			Name:      "synthetic_check_up",
+			Help:      "Whether the latest synthetic check to the service endpoint succeeded (1) or not (0).",
+		},
+		[]string{"target", "path"},
+	)
+)
+
+func init() {
+	MetricsRegistry.MustRegister(syntheticCheckDuration, syntheticChecksTotal, syntheticCheckUp)
+}
+
+// syntheticChecker checks the service endpoints that apply to the current role of the replica, by running
+// the action of the target or connecting to it, and reports the result as the output of a probe.
+type syntheticChecker struct {
+	probe  *proto.SyntheticProbe
+	role   func() string
+	dial   func(ctx context.Context, network, address string) (net.Conn, error)
+	action func(ctx context.Context, req *proto.ActionRequest) ([]byte, error)
+}
+
+func newSyntheticChecker(probe *proto.SyntheticProbe, role func() string, actionService *actionService) *syntheticChecker {
+	dialer := &net.Dialer{}
+	return &syntheticChecker{
+		probe:  probe,
+		role:   role,
+		dial:   dialer.DialContext,
+		action: actionService.handleRequest,
+	}
+}
+
+func (c *syntheticChecker) check(ctx context.Context) ([]byte, error) {
+	role := c.role()
+	output := proto.SyntheticProbeOutput{
+		Role:    role,
+		Targets: make([]proto.SyntheticTargetResult, 0),
+	}
+	failed := make([]string, 0)
+	for _, target := range c.probe.Targets {
+		if len(target.Roles) > 0 && !slices.Contains(target.Roles, role) {
+			continue
+		}
+		err := c.checkTarget(ctx, target, role)
+		result := proto.SyntheticTargetResult{
+			Name:    target.Name,
+			Path:    target.Path,
+			Succeed: err == nil,
+		}
+		if err != nil {
+			result.Message = err.Error()
+			failed = append(failed, fmt.Sprintf("%s: %s", target.Name, err.Error()))
+		}
+		output.Targets = append(output.Targets, result)
+	}
+
+	out, err := json.Marshal(&output)
+	if err != nil {
+		return nil, err
+	}
+	if len(failed) > 0 {
+		return out, fmt.Errorf("synthetic check failed, %s", strings.Join(failed, "; "))
+	}
+	return out, nil
+}
+
+func (c *syntheticChecker) checkTarget(ctx context.Context, target proto.SyntheticTarget, role string) error {
+	timeout := c.probe.TimeoutSeconds
+	if timeout <= 0 {
+		timeout = defaultSyntheticTimeoutSeconds
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	var err error
+	if len(target.Action) > 0 {
+		err = c.runAction(ctx, target, role, timeout)
+	} else {
+		err = c.dialTarget(ctx, target)
+	}
+	syntheticCheckDuration.WithLabelValues(target.Name, target.Path, role).Observe(time.Since(start).Seconds())
+
+	result, up := "succeed", 1.0
+	if err != nil {
+		result, up = "failed", 0.0
+	}
+	syntheticChecksTotal.WithLabelValues(target.Name, target.Path, role, result).Inc()
+	syntheticCheckUp.WithLabelValues(target.Name, target.Path).Set(up)
+	return err
+}
+
+// runAction runs the action of the target to check its read or write path.
+func (c *syntheticChecker) runAction(ctx context.Context, target proto.SyntheticTarget, role string, timeout int32) error {
+	_, err := c.action(ctx, &proto.ActionRequest{
+		Action: target.Action,
+		Parameters: map[string]string{
+			syntheticTargetNameVar:  target.Name,
+			syntheticTargetHostVar:  target.Host,
+			syntheticTargetPortVar:  strconv.Itoa(int(target.Port)),
+			syntheticTargetPathVar:  target.Path,
+			syntheticReplicaRoleVar: role,
+		},
+		TimeoutSeconds: &timeout,
+	})
+	return err
+}
+
+// dialTarget checks that a TCP connection can be established to the target.
+func (c *syntheticChecker) dialTarget(ctx context.Context, target proto.SyntheticTarget) error {
+	conn, err := c.dial(ctx, "tcp", net.JoinHostPort(target.Host, strconv.Itoa(int(target.Port))))
+	if err == nil {
+		_ = conn.Close()
+	}
+	return err
+}
@@ -0,0 +1,222 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+var _ = Describe("synthetic", func() {
+	Context("synthetic", func() {
+		var (
+			listener   net.Listener
+			activePort int32
+			closedPort int32
+		)
+
+		BeforeEach(func() {
+			var err error
+			listener, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).Should(BeNil())
+			activePort = int32(listener.Addr().(*net.TCPAddr).Port)
+			go func() {
+				for {
+					conn, err := listener.Accept()
+					if err != nil {
+						return
+					}
+					_ = conn.Close()
+				}
+			}()
+
+			closed, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).Should(BeNil())
+			closedPort = int32(closed.Addr().(*net.TCPAddr).Port)
+			Expect(closed.Close()).Should(Succeed())
+		})
+
+		AfterEach(func() {
+			_ = listener.Close()
+		})
+
+		newProbe := func() *proto.SyntheticProbe {
+			return &proto.SyntheticProbe{
+				TimeoutSeconds: 1,
+				Targets: []proto.SyntheticTarget{
+					{
+						Name: "write",
+						Host: "127.0.0.1",
+						Port: activePort,
+						Path: "Write",
+					},
+					{
+						Name:  "read",
+						Host:  "127.0.0.1",
+						Port:  closedPort,
+						Path:  "Read",
+						Roles: []string{"follower"},
+					},
+				},
+			}
+		}
+
+		It("check", func() {
+			checker := newSyntheticChecker(newProbe(), func() string { return "leader" }, nil)
+
+			out, err := checker.check(ctx)
+			Expect(err).Should(BeNil())
+
+			output := proto.SyntheticProbeOutput{}
+			Expect(json.Unmarshal(out, &output)).Should(Succeed())
+			Expect(output.Role).Should(Equal("leader"))
+			Expect(output.Targets).Should(Equal([]proto.SyntheticTargetResult{
+				{Name: "write", Path: "Write", Succeed: true},
+			}))
+			Expect(testutil.ToFloat64(syntheticCheckUp.WithLabelValues("write", "Write"))).Should(Equal(float64(1)))
+		})
+
+		It("check - failed", func() {
+			checker := newSyntheticChecker(newProbe(), func() string { return "follower" }, nil)
+
+			out, err := checker.check(ctx)
+			Expect(err).ShouldNot(BeNil())
+			Expect(err.Error()).Should(ContainSubstring("read"))
+
+			output := proto.SyntheticProbeOutput{}
+			Expect(json.Unmarshal(out, &output)).Should(Succeed())
+			Expect(output.Targets).Should(Equal([]proto.SyntheticTargetResult{
+				{Name: "write", Path: "Write", Succeed: true},
+				{Name: "read", Path: "Read", Succeed: false, Message: output.Targets[1].Message},
+			}))
+			Expect(output.Targets[1].Message).ShouldNot(BeEmpty())
+			Expect(testutil.ToFloat64(syntheticCheckUp.WithLabelValues("read", "Read"))).Should(Equal(float64(0)))
+		})
+
+		It("check - action", func() {
+			actions := []proto.Action{
+				{
+					Name: "synthetic-write",
+					Exec: &proto.ExecAction{
+						Commands: []string{"/bin/bash", "-c", "echo -n $KB_SYNTHETIC_TARGET_NAME $KB_SYNTHETIC_TARGET_PORT $KB_SYNTHETIC_TARGET_PATH $KB_SYNTHETIC_REPLICA_ROLE"},
+					},
+				},
+				{
+					Name: "synthetic-read",
+					Exec: &proto.ExecAction{
+						Commands: []string{"/bin/bash", "-c", "echo -n read-only >&2; exit 1"},
+					},
+				},
+			}
+			actionSvc, err := newActionService(logr.New(nil), actions, nil)
+			Expect(err).Should(BeNil())
+
+			probe := newProbe()
+			// the action takes the place of the connection, the closed port is checked by the action only
+			probe.Targets[0].Port = closedPort
+			probe.Targets[0].Action = "synthetic-write"
+			probe.Targets[1].Action = "synthetic-read"
+			probe.Targets[1].Roles = nil
+			var params map[string]string
+			checker := newSyntheticChecker(probe, func() string { return "leader" }, actionSvc)
+			checker.action = func(ctx context.Context, req *proto.ActionRequest) ([]byte, error) {
+				if req.Action == "synthetic-write" {
+					params = req.Parameters
+				}
+				return actionSvc.handleRequest(ctx, req)
+			}
+
+			out, err := checker.check(ctx)
+			Expect(err).ShouldNot(BeNil())
+			Expect(err.Error()).Should(ContainSubstring("read-only"))
+			Expect(params).Should(HaveKeyWithValue(syntheticTargetNameVar, "write"))
+			Expect(params).Should(HaveKeyWithValue(syntheticTargetHostVar, "127.0.0.1"))
+			Expect(params).Should(HaveKeyWithValue(syntheticTargetPathVar, "Write"))
+			Expect(params).Should(HaveKeyWithValue(syntheticReplicaRoleVar, "leader"))
+
+			output := proto.SyntheticProbeOutput{}
+			Expect(json.Unmarshal(out, &output)).Should(Succeed())
+			Expect(output.Targets).Should(HaveLen(2))
+			Expect(output.Targets[0].Succeed).Should(BeTrue())
+			Expect(output.Targets[1].Succeed).Should(BeFalse())
+			Expect(output.Targets[1].Message).Should(ContainSubstring("read-only"))
+		})
+
+		It("probe service - action not defined", func() {
+			probe := newProbe()
+			probe.Targets[0].Action = "synthetic-write"
+			probes := []proto.Probe{
+				{
+					Action:    "availableProbe",
+					Synthetic: probe,
+				},
+			}
+			actionSvc, err := newActionService(logr.New(nil), nil, nil)
+			Expect(err).Should(BeNil())
+
+			_, err = newProbeService(logr.New(nil), actionSvc, probes)
+			Expect(err).ShouldNot(BeNil())
+			Expect(err.Error()).Should(ContainSubstring("no action defined"))
+		})
+
+		It("probe service", func() {
+			actions := []proto.Action{
+				{
+					Name: "roleProbe",
+					Exec: &proto.ExecAction{
+						Commands: []string{"/bin/bash", "-c", "echo -n leader"},
+					},
+				},
+			}
+			probes := []proto.Probe{
+				{
+					Action:        "roleProbe",
+					PeriodSeconds: 1,
+				},
+				{
+					Action:        "availableProbe",
+					PeriodSeconds: 1,
+					Synthetic:     newProbe(),
+				},
+			}
+			actionSvc, err := newActionService(logr.New(nil), actions, nil)
+			Expect(err).Should(BeNil())
+
+			service, err := newProbeService(logr.New(nil), actionSvc, probes)
+			Expect(err).Should(BeNil())
+			Expect(service.Start()).Should(Succeed())
+
+			r := service.runners["availableProbe"]
+			Expect(r).ShouldNot(BeNil())
+			Expect(r.synthetic).ShouldNot(BeNil())
+			Eventually(service.role).WithTimeout(5 * time.Second).Should(Equal("leader"))
+		})
+	})
+})
@@ -21,6 +21,7 @@ package service
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -39,6 +40,8 @@ type taskService struct {
 	logger        logr.Logger
 	actionService *actionService
 	tasks         []proto.Task
+	tlsConfig     *tls.Config
+	token         string
 }
 
 type task interface {
@@ -98,12 +101,23 @@ func (s *taskService) runTask(ctx context.Context, task proto.Task) error {
 
 	ch, err1 := t.run(ctx)
 	if err1 != nil {
+		s.record(task, event, err1)
 		return notify(err1, nil, nil)
 	}
 
 	exit, exited := s.report(ctx, task, t, event)
 
-	return notify(s.wait(ch), exit, exited)
+	err2 := s.wait(ch)
+	s.record(task, event, err2)
+	return notify(err2, exit, exited)
+}
+
+func (s *taskService) record(task proto.Task, event proto.TaskEvent, err error) {
+	var parameters map[string]string
+	if task.NewReplica != nil {
+		parameters = task.NewReplica.Parameters
+	}
+	s.actionService.journal.record(proto.JournalKindTask, task.Task, parameters, event.StartTime, nil, err)
 }
 
 func (s *taskService) newTask(task proto.Task) task {
@@ -112,6 +126,8 @@ func (s *taskService) newTask(task proto.Task) task {
 			logger:        s.logger,
 			actionService: s.actionService,
 			task:          task.NewReplica,
+			tlsConfig:     s.tlsConfig,
+			token:         s.token,
 		}
 	}
 	return nil
@@ -21,9 +21,11 @@ package service
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"net"
+	"strconv"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -44,6 +46,8 @@ type newReplicaTask struct {
 	logger        logr.Logger
 	actionService *actionService
 	task          *proto.NewReplicaTask
+	tlsConfig     *tls.Config
+	token         string
 }
 
 var _ task = &newReplicaTask{}
@@ -75,6 +79,12 @@ func (s *newReplicaTask) handshake(ctx context.Context) (net.Conn, error) {
 		return nil, err
 	}
 
+	if len(s.token) > 0 {
+		if _, err = conn.Write([]byte(proto.AuthBearerPrefix + s.token + "\n")); err != nil {
+			return nil, err
+		}
+	}
+
 	// reuse the action request as the handshake packet, define a new one when needed
 	req := proto.ActionRequest{
 		Action:         newReplicaDataDump,
@@ -114,5 +124,9 @@ func (s *newReplicaTask) connectToRemote(ctx context.Context) (net.Conn, error)
 	dialer := &net.Dialer{
 		Timeout: newReplicaConnectTimeoutSeconds * time.Second,
 	}
-	return dialer.Dial("tcp", fmt.Sprintf("%s:%d", s.task.Remote, s.task.Port))
+	address := net.JoinHostPort(s.task.Remote, strconv.Itoa(int(s.task.Port)))
+	if s.tlsConfig != nil {
+		return tls.DialWithDialer(dialer, "tcp", address, s.tlsConfig)
+	}
+	return dialer.Dial("tcp", address)
 }
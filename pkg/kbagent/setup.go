@@ -46,10 +46,17 @@ const (
 	DefaultHTTPPort      = 3501
 	DefaultStreamingPort = 3502
 
+	// the journal of the action and task runs is pod-local, it is kept in an emptyDir volume shared by the
+	// server and the worker, survives the restarts of the containers, but is lost once the pod is deleted.
+	JournalVolumeName = "kbagent-journal"
+	JournalMountPath  = "/var/lib/kbagent"
+	JournalDir        = "/var/lib/kbagent/journal"
+
 	actionEnvName    = "KB_AGENT_ACTION"
 	probeEnvName     = "KB_AGENT_PROBE"
 	streamingEnvName = "KB_AGENT_STREAMING"
 	taskEnvName      = "KB_AGENT_TASK"
+	journalEnvName   = "KB_AGENT_JOURNAL_DIR"
 )
 
 func BuildEnv4Server(actions []proto.Action, probes []proto.Probe, streaming []string) ([]corev1.EnvVar, error) {
@@ -76,6 +83,10 @@ func BuildEnv4Server(actions []proto.Action, probes []proto.Probe, streaming []s
 			Value: strings.Join(streaming, ","),
 		})
 	}
+	envVars = append(envVars, corev1.EnvVar{
+		Name:  journalEnvName,
+		Value: JournalDir,
+	})
 	return append(util.DefaultEnvVars(), envVars...), nil
 }
 
@@ -137,7 +148,7 @@ func Launch(logger logr.Logger, config server.Config) (bool, error) {
 	if config.Server {
 		return true, runAsServer(logger, config, services)
 	}
-	return false, runAsWorker(logger, services, envVars)
+	return false, runAsWorker(logger, config, services, envVars)
 }
 
 func initialize(logger logr.Logger, envVars map[string]string) ([]service.Service, error) {
@@ -155,7 +166,7 @@ func initialize(logger logr.Logger, envVars map[string]string) ([]service.Servic
 	if len(ds) > 0 {
 		streaming = strings.Split(ds, ",")
 	}
-	return service.New(logger, actions, probes, streaming)
+	return service.New(logger, actions, probes, streaming, envVars[journalEnvName])
 }
 
 func getActionProbeNStreamingEnvValues(envVars map[string]string) (string, string, string) {
@@ -230,7 +241,7 @@ func runAsServer(logger logr.Logger, config server.Config, services []service.Se
 	return nil
 }
 
-func runAsWorker(logger logr.Logger, services []service.Service, envVars map[string]string) error {
+func runAsWorker(logger logr.Logger, config server.Config, services []service.Service, envVars map[string]string) error {
 	dt, ok := envVars[taskEnvName]
 	if !ok || len(dt) == 0 {
 		return nil // has no task
@@ -243,7 +254,16 @@ func runAsWorker(logger logr.Logger, services []service.Service, envVars map[str
 		return err
 	}
 
-	if err := service.RunTasks(logger, actionService(services), tasks); err != nil {
+	tlsConfig, err := config.ClientTLSConfig()
+	if err != nil {
+		return err
+	}
+	token, err := config.AuthToken()
+	if err != nil {
+		return err
+	}
+
+	if err := service.RunTasks(logger, actionService(services), tasks, tlsConfig, token); err != nil {
 		return errors.Wrap(err, "failed to run as worker")
 	}
 	return nil
@@ -0,0 +1,105 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+)
+
+var (
+	backupsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "backups_total",
+			Help:      "Number of the finished backups by the phase, Completed or Failed.",
+		},
+		[]string{"namespace", "policy", "method", "phase"},
+	)
+	backupDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "backup_duration_seconds",
+			Help:      "Duration of the completed backups.",
+			Buckets:   prometheus.ExponentialBuckets(30, 2, 12),
+		},
+		[]string{"namespace", "policy", "method"},
+	)
+	backupSize = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "backup_last_size_bytes",
+			Help:      "Total size of the last completed backup.",
+		},
+		[]string{"namespace", "policy", "method"},
+	)
+	restoresTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "restores_total",
+			Help:      "Number of the finished restores by the phase, Completed or Failed.",
+		},
+		[]string{"namespace", "phase"},
+	)
+	restoreDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "restore_duration_seconds",
+			Help:      "Duration of the finished restores.",
+			Buckets:   prometheus.ExponentialBuckets(30, 2, 12),
+		},
+		[]string{"namespace", "phase"},
+	)
+)
+
+// RecordBackupFinished records a backup that has just been Completed or Failed.
+func RecordBackupFinished(backup *dpv1alpha1.Backup) {
+	ns, policy, method := backup.Namespace, backup.Spec.BackupPolicyName, backup.Spec.BackupMethod
+	backupsTotal.WithLabelValues(ns, policy, method, string(backup.Status.Phase)).Inc()
+	if backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted {
+		return
+	}
+	if backup.Status.StartTimestamp != nil && backup.Status.CompletionTimestamp != nil {
+		duration := backup.Status.CompletionTimestamp.Sub(backup.Status.StartTimestamp.Time)
+		backupDuration.WithLabelValues(ns, policy, method).Observe(duration.Seconds())
+	}
+	if size, err := resource.ParseQuantity(backup.Status.TotalSize); err == nil {
+		backupSize.WithLabelValues(ns, policy, method).Set(float64(size.Value()))
+	}
+}
+
+// RecordRestoreFinished records a restore that has just been Completed or Failed.
+func RecordRestoreFinished(restore *dpv1alpha1.Restore) {
+	ns, phase := restore.Namespace, string(restore.Status.Phase)
+	restoresTotal.WithLabelValues(ns, phase).Inc()
+	start := restore.CreationTimestamp.Time
+	if restore.Status.StartTimestamp != nil {
+		start = restore.Status.StartTimestamp.Time
+	}
+	end := time.Now()
+	if restore.Status.CompletionTimestamp != nil {
+		end = restore.Status.CompletionTimestamp.Time
+	}
+	restoreDuration.WithLabelValues(ns, phase).Observe(end.Sub(start).Seconds())
+}
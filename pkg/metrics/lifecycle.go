@@ -0,0 +1,71 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	kbagentActionDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "kbagent_action_duration_seconds",
+			Help:      "Latency of the lifecycle actions called through the kb-agent.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 14),
+		},
+		[]string{"action"},
+	)
+	kbagentActionErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "kbagent_action_errors_total",
+			Help:      "Number of the lifecycle actions called through the kb-agent that returned an error, by the error type.",
+		},
+		[]string{"action", "error"},
+	)
+	switchoversTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "switchovers_total",
+			Help:      "Number of the switchover actions performed, by the result.",
+		},
+		[]string{"namespace", "cluster", "component", "result"},
+	)
+)
+
+// ObserveKBAgentAction records the latency of a lifecycle action, and the error type if the action failed.
+func ObserveKBAgentAction(action string, start time.Time, errType string) {
+	kbagentActionDuration.WithLabelValues(action).Observe(time.Since(start).Seconds())
+	if errType != "" {
+		kbagentActionErrors.WithLabelValues(action, errType).Inc()
+	}
+}
+
+// RecordSwitchover records a switchover of the component.
+func RecordSwitchover(namespace, cluster, component string, err error) {
+	result := "Succeeded"
+	if err != nil {
+		result = "Failed"
+	}
+	switchoversTotal.WithLabelValues(namespace, cluster, component, result).Inc()
+}
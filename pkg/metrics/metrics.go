@@ -0,0 +1,50 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// metricsNamespace is the namespace of all the domain metrics, they are served with the controller-runtime
+// metrics at the metrics endpoint of the manager.
+const metricsNamespace = "kubeblocks"
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		reconcileStepDuration,
+		opsRequestsTotal,
+		opsRequestDuration,
+		backupsTotal,
+		backupDuration,
+		backupSize,
+		restoresTotal,
+		restoreDuration,
+		kbagentActionDuration,
+		kbagentActionErrors,
+		switchoversTotal,
+	)
+}
+
+// RegisterCollector registers the collector to the metrics registry of the manager.
+func RegisterCollector(c prometheus.Collector) error {
+	return ctrlmetrics.Registry.Register(c)
+}
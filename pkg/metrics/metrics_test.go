@@ -0,0 +1,141 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestPhaseCollector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := appsv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	cluster := &appsv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mycluster"},
+		Status:     appsv1.ClusterStatus{Phase: appsv1.RunningClusterPhase},
+	}
+	comp := &appsv1.Component{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "mycluster-mysql",
+			Labels:    map[string]string{constant.AppInstanceLabelKey: "mycluster"},
+		},
+		Status: appsv1.ComponentStatus{Phase: appsv1.FailedComponentPhase},
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, comp).Build()
+
+	expected := `
+# HELP kubeblocks_cluster_phase The current phase of the cluster, the value is always 1.
+# TYPE kubeblocks_cluster_phase gauge
+kubeblocks_cluster_phase{cluster="mycluster",namespace="default",phase="Running"} 1
+# HELP kubeblocks_component_phase The current phase of the component, the value is always 1.
+# TYPE kubeblocks_component_phase gauge
+kubeblocks_component_phase{cluster="mycluster",component="mycluster-mysql",namespace="default",phase="Failed"} 1
+`
+	if err := testutil.CollectAndCompare(NewPhaseCollector(cli), strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRecordOpsRequestPhase(t *testing.T) {
+	opsRequestsTotal.Reset()
+	opsRequestDuration.Reset()
+
+	now := time.Now()
+	ops := &opsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "restart"},
+		Spec:       opsv1alpha1.OpsRequestSpec{Type: opsv1alpha1.RestartType},
+		Status: opsv1alpha1.OpsRequestStatus{
+			Phase:          opsv1alpha1.OpsRunningPhase,
+			StartTimestamp: metav1.Time{Time: now.Add(-time.Minute)},
+		},
+	}
+	RecordOpsRequestPhase(ops, opsv1alpha1.OpsCreatingPhase)
+	// no transition
+	RecordOpsRequestPhase(ops, opsv1alpha1.OpsRunningPhase)
+
+	ops.Status.Phase = opsv1alpha1.OpsSucceedPhase
+	ops.Status.CompletionTimestamp = metav1.Time{Time: now}
+	RecordOpsRequestPhase(ops, opsv1alpha1.OpsRunningPhase)
+
+	if v := testutil.ToFloat64(opsRequestsTotal.WithLabelValues("default", "Restart", "Running")); v != 1 {
+		t.Errorf("expect 1 Running OpsRequest, got %v", v)
+	}
+	if v := testutil.ToFloat64(opsRequestsTotal.WithLabelValues("default", "Restart", "Succeed")); v != 1 {
+		t.Errorf("expect 1 Succeed OpsRequest, got %v", v)
+	}
+	if n := testutil.CollectAndCount(opsRequestDuration); n != 1 {
+		t.Errorf("expect the duration of the completed OpsRequest observed once, got %d series", n)
+	}
+}
+
+func TestRecordBackupFinished(t *testing.T) {
+	backupsTotal.Reset()
+	backupSize.Reset()
+
+	now := time.Now()
+	backup := &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "backup"},
+		Spec:       dpv1alpha1.BackupSpec{BackupPolicyName: "policy", BackupMethod: "xtrabackup"},
+		Status: dpv1alpha1.BackupStatus{
+			Phase:               dpv1alpha1.BackupPhaseCompleted,
+			TotalSize:           "1Ki",
+			StartTimestamp:      &metav1.Time{Time: now.Add(-time.Minute)},
+			CompletionTimestamp: &metav1.Time{Time: now},
+		},
+	}
+	RecordBackupFinished(backup)
+	backup.Status.Phase = dpv1alpha1.BackupPhaseFailed
+	RecordBackupFinished(backup)
+
+	if v := testutil.ToFloat64(backupSize.WithLabelValues("default", "policy", "xtrabackup")); v != 1024 {
+		t.Errorf("expect backup size 1024, got %v", v)
+	}
+	if v := testutil.ToFloat64(backupsTotal.WithLabelValues("default", "policy", "xtrabackup", "Failed")); v != 1 {
+		t.Errorf("expect 1 failed backup, got %v", v)
+	}
+}
+
+func TestRecordSwitchover(t *testing.T) {
+	switchoversTotal.Reset()
+
+	RecordSwitchover("default", "mycluster", "mysql", nil)
+	RecordSwitchover("default", "mycluster", "mysql", errors.New("switchover failed"))
+
+	for _, result := range []string{"Succeeded", "Failed"} {
+		if v := testutil.ToFloat64(switchoversTotal.WithLabelValues("default", "mycluster", "mysql", result)); v != 1 {
+			t.Errorf("expect 1 %s switchover, got %v", result, v)
+		}
+	}
+}
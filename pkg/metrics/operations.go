@@ -0,0 +1,71 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+)
+
+var (
+	opsRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "opsrequests_total",
+			Help:      "Number of the OpsRequests entered the phase.",
+		},
+		[]string{"namespace", "type", "phase"},
+	)
+	opsRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "opsrequest_duration_seconds",
+			Help:      "Duration of the completed OpsRequests, from the start to the completion.",
+			Buckets:   prometheus.ExponentialBuckets(10, 2, 14),
+		},
+		[]string{"namespace", "type", "phase"},
+	)
+)
+
+// RecordOpsRequestPhase records the phase transition of the OpsRequest, it should be called after the new phase
+// is persisted. The duration is observed once the OpsRequest is completed.
+func RecordOpsRequestPhase(ops *opsv1alpha1.OpsRequest, prevPhase opsv1alpha1.OpsPhase) {
+	phase := ops.Status.Phase
+	if phase == prevPhase || phase == "" {
+		return
+	}
+	opsType, ns := string(ops.Spec.Type), ops.Namespace
+	opsRequestsTotal.WithLabelValues(ns, opsType, string(phase)).Inc()
+	if !ops.IsComplete(phase) || ops.IsComplete(prevPhase) {
+		return
+	}
+	start := ops.Status.StartTimestamp.Time
+	if start.IsZero() {
+		start = ops.CreationTimestamp.Time
+	}
+	end := ops.Status.CompletionTimestamp.Time
+	if end.IsZero() {
+		end = time.Now()
+	}
+	opsRequestDuration.WithLabelValues(ns, opsType, string(phase)).Observe(end.Sub(start).Seconds())
+}
@@ -0,0 +1,83 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metrics
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+var (
+	clusterPhaseDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "cluster", "phase"),
+		"The current phase of the cluster, the value is always 1.",
+		[]string{"namespace", "cluster", "phase"}, nil,
+	)
+	componentPhaseDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "component", "phase"),
+		"The current phase of the component, the value is always 1.",
+		[]string{"namespace", "cluster", "component", "phase"}, nil,
+	)
+)
+
+type phaseCollector struct {
+	cli client.Reader
+}
+
+var _ prometheus.Collector = &phaseCollector{}
+
+// NewPhaseCollector returns a collector that reports the phases of the clusters and components at scrape time,
+// the objects are read from the cache of the manager.
+func NewPhaseCollector(cli client.Reader) prometheus.Collector {
+	return &phaseCollector{cli: cli}
+}
+
+func (c *phaseCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- clusterPhaseDesc
+	ch <- componentPhaseDesc
+}
+
+func (c *phaseCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx := context.Background()
+	clusters := &appsv1.ClusterList{}
+	if err := c.cli.List(ctx, clusters); err != nil {
+		ch <- prometheus.NewInvalidMetric(clusterPhaseDesc, err)
+	} else {
+		for _, cluster := range clusters.Items {
+			ch <- prometheus.MustNewConstMetric(clusterPhaseDesc, prometheus.GaugeValue, 1,
+				cluster.Namespace, cluster.Name, string(cluster.Status.Phase))
+		}
+	}
+
+	comps := &appsv1.ComponentList{}
+	if err := c.cli.List(ctx, comps); err != nil {
+		ch <- prometheus.NewInvalidMetric(componentPhaseDesc, err)
+	} else {
+		for _, comp := range comps.Items {
+			ch <- prometheus.MustNewConstMetric(componentPhaseDesc, prometheus.GaugeValue, 1,
+				comp.Namespace, comp.Labels[constant.AppInstanceLabelKey], comp.Name, string(comp.Status.Phase))
+		}
+	}
+}
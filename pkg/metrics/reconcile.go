@@ -0,0 +1,41 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var reconcileStepDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "reconcile_step_duration_seconds",
+		Help:      "Latency of the steps, transformers or reconcilers, of the reconciliation cycles.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 15),
+	},
+	[]string{"kind", "step"},
+)
+
+// ObserveReconcileStep records the latency of a step of a reconciliation cycle, such as a transformer or a reconciler.
+func ObserveReconcileStep(kind, step string, start time.Time) {
+	reconcileStepDuration.WithLabelValues(kind, step).Observe(time.Since(start).Seconds())
+}
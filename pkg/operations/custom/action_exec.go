@@ -173,10 +173,14 @@ func (e *ExecAction) buildExecPodSpec(actionCtx ActionContext,
 	if err != nil {
 		return nil, err
 	}
-	return &corev1.PodSpec{
+	podSpec := &corev1.PodSpec{
 		Containers: []corev1.Container{*container},
 		// tolerate all taints
 		Tolerations:      toleration,
 		ImagePullSecrets: intctrlutil.BuildImagePullSecrets(),
-	}, nil
+	}
+	if err = injectSecretStoreEnvResolver(podSpec, env); err != nil {
+		return nil, err
+	}
+	return podSpec, nil
 }
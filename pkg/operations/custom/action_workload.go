@@ -207,6 +207,9 @@ func (w *WorkloadAction) buildPodSpec(actionCtx ActionContext,
 			podSpec.ServiceAccountName = saKey.Name
 		}
 	}
+	if err = injectSecretStoreEnvResolver(podSpec, env); err != nil {
+		return nil, err
+	}
 	return podSpec, nil
 }
 
@@ -38,6 +38,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/scheduling"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/secretstore"
 )
 
 const (
@@ -131,6 +132,17 @@ func buildComponentEnvs(reqCtx intctrlutil.RequestCtx,
 	return nil
 }
 
+// injectSecretStoreEnvResolver makes the containers resolve the account password kept in the secret store when they start.
+func injectSecretStoreEnvResolver(podSpec *corev1.PodSpec, env []corev1.EnvVar) error {
+	var secretNames []string
+	for _, v := range env {
+		if v.Name == kbEnvAccountPassword && v.ValueFrom != nil && v.ValueFrom.SecretKeyRef != nil {
+			secretNames = append(secretNames, v.ValueFrom.SecretKeyRef.Name)
+		}
+	}
+	return secretstore.InjectEnvResolver(podSpec, secretNames...)
+}
+
 // BuildEnvVars builds the env vars by the vars of the targetPodTemplate.
 func buildEnvVars(reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
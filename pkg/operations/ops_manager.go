@@ -20,12 +20,14 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"fmt"
 	"slices"
 	"strings"
 	"sync"
 	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -81,6 +83,9 @@ func (opsMgr *OpsManager) Do(reqCtx intctrlutil.RequestCtx, cli client.Client, o
 			if _, ok := err.(*WaitForClusterPhaseErr); ok {
 				return intctrlutil.ResultToP(intctrlutil.RequeueAfter(time.Second, reqCtx.Log, "wait cluster to a right phase"))
 			}
+			if requeueErr, ok := err.(intctrlutil.RequeueError); ok {
+				return intctrlutil.ResultToP(intctrlutil.RequeueAfter(requeueErr.RequeueAfter(), reqCtx.Log, requeueErr.Reason()))
+			}
 			return nil, err
 		}
 		return intctrlutil.ResultToP(intctrlutil.Reconciled())
@@ -106,6 +111,11 @@ func (opsMgr *OpsManager) doPreConditionAndTransPhaseToCreating(reqCtx intctrlut
 	cli client.Client,
 	opsRes *OpsResource,
 	opsBehaviour OpsBehaviour) error {
+	// disruptive operations wait for the maintenance window of the cluster, before being enqueued,
+	// so they don't block the operations queued after them.
+	if err := validateOpsMaintenanceWindow(reqCtx, cli, opsRes, opsBehaviour); err != nil {
+		return err
+	}
 	if opsBehaviour.QueueByCluster || opsBehaviour.QueueBySelf {
 		// if ToClusterPhase is not empty, enqueue OpsRequest to the cluster Annotation.
 		opsRecorde, err := enqueueOpsRequestToClusterAnnotation(reqCtx.Ctx, cli, opsRes, opsBehaviour)
@@ -142,6 +152,9 @@ func (opsMgr *OpsManager) doPreConditionAndTransPhaseToCreating(reqCtx intctrlut
 		}
 	}
 	opsDeepCopy := opsRes.OpsRequest.DeepCopy()
+	if meta.IsStatusConditionTrue(opsRes.OpsRequest.Status.Conditions, opsv1alpha1.ConditionTypeMaintenanceWindow) {
+		opsRes.OpsRequest.SetStatusCondition(*opsv1alpha1.NewInMaintenanceWindowCondition(opsRes.OpsRequest))
+	}
 	// save last configuration into status.lastConfiguration
 	if err = opsBehaviour.OpsHandler.SaveLastConfiguration(reqCtx, cli, opsRes); err != nil {
 		return err
@@ -178,22 +191,95 @@ func (opsMgr *OpsManager) Reconcile(reqCtx intctrlutil.RequestCtx, cli client.Cl
 	if opsRequestPhase, requeueAfter, err = opsBehaviour.OpsHandler.ReconcileAction(reqCtx, cli, opsRes); err != nil &&
 		!isOpsRequestFailedPhase(opsRequestPhase) {
 		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
-			return requeueAfter, opsMgr.handleOpsCompleted(reqCtx, cli, opsRes, opsv1alpha1.OpsFailedPhase,
-				opsv1alpha1.NewCancelFailedCondition(opsRequest, err), opsv1alpha1.NewFailedCondition(opsRequest, err))
+			return requeueAfter, opsMgr.handleOpsFailed(reqCtx, cli, opsRes, opsBehaviour, err)
 		}
 		// if the opsRequest phase is not failed, skipped
 		return requeueAfter, err
 	}
 	switch opsRequestPhase {
 	case opsv1alpha1.OpsSucceedPhase:
+		if isOpsRollingBack(opsRequest) {
+			return 0, opsMgr.handleRollbackCompleted(reqCtx, cli, opsRes, opsv1alpha1.RollbackSucceedPhase,
+				"the OpsRequest has been rolled back successfully")
+		}
 		return 0, opsMgr.handleOpsCompleted(reqCtx, cli, opsRes, opsRequestPhase,
 			opsv1alpha1.NewCancelSucceedCondition(opsRequest.Name), opsv1alpha1.NewSucceedCondition(opsRequest))
 	case opsv1alpha1.OpsFailedPhase:
-		return 0, opsMgr.handleOpsCompleted(reqCtx, cli, opsRes, opsRequestPhase,
-			opsv1alpha1.NewCancelFailedCondition(opsRequest, err), opsv1alpha1.NewFailedCondition(opsRequest, err))
+		return 0, opsMgr.handleOpsFailed(reqCtx, cli, opsRes, opsBehaviour, err)
 	default:
-		return opsMgr.checkAndHandleOpsTimeout(reqCtx, cli, opsRes, requeueAfter)
+		return opsMgr.checkAndHandleOpsTimeout(reqCtx, cli, opsRes, opsBehaviour, requeueAfter)
+	}
+}
+
+// handleOpsFailed handles the failed opsRequest, it triggers the automatic rollback if it's enabled.
+func (opsMgr *OpsManager) handleOpsFailed(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	opsBehaviour OpsBehaviour,
+	err error) error {
+	opsRequest := opsRes.OpsRequest
+	if isOpsRollingBack(opsRequest) {
+		message := "failed to roll back the OpsRequest"
+		if err != nil {
+			message = fmt.Sprintf("%s: %s", message, err.Error())
+		}
+		return opsMgr.handleRollbackCompleted(reqCtx, cli, opsRes, opsv1alpha1.RollbackFailedPhase, message)
+	}
+	if needAutoRollback(opsRequest, opsBehaviour) {
+		message := "the OpsRequest failed"
+		if err != nil {
+			message = err.Error()
+		}
+		return opsMgr.startRollback(reqCtx, cli, opsRes, opsBehaviour, opsv1alpha1.OpsFailedPhase, message)
+	}
+	return opsMgr.handleOpsCompleted(reqCtx, cli, opsRes, opsv1alpha1.OpsFailedPhase,
+		opsv1alpha1.NewCancelFailedCondition(opsRequest, err), opsv1alpha1.NewFailedCondition(opsRequest, err))
+}
+
+// startRollback reverts the changes of the opsRequest and resets the progress to track the rollback.
+// the opsRequest stays in the Running phase until the rollback is completed.
+func (opsMgr *OpsManager) startRollback(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	opsBehaviour OpsBehaviour,
+	triggeredPhase opsv1alpha1.OpsPhase,
+	message string) error {
+	if err := opsBehaviour.RollbackFunc(reqCtx, cli, opsRes); err != nil {
+		return err
+	}
+	opsRequest := opsRes.OpsRequest
+	opsDeepCopy := opsRequest.DeepCopy()
+	opsRequest.Status.Rollback = &opsv1alpha1.OpsRollbackStatus{
+		TriggeredPhase: triggeredPhase,
+		Phase:          opsv1alpha1.RollbackRunningPhase,
+		StartTimestamp: metav1.Now(),
+		Message:        message,
+	}
+	for name, compStatus := range opsRequest.Status.Components {
+		compStatus.ProgressDetails = nil
+		opsRequest.Status.Components[name] = compStatus
+	}
+	return PatchOpsStatusWithOpsDeepCopy(reqCtx.Ctx, cli, opsRes, opsDeepCopy, opsv1alpha1.OpsRunningPhase,
+		opsv1alpha1.NewRollingBackCondition(opsRequest, message))
+}
+
+// handleRollbackCompleted records the outcome of the rollback, and completes the opsRequest with the phase
+// which triggered the rollback.
+func (opsMgr *OpsManager) handleRollbackCompleted(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	phase opsv1alpha1.RollbackPhase,
+	message string) error {
+	if err := updateHAConfigIfNecessary(reqCtx, cli, opsRes.OpsRequest, "true"); err != nil {
+		return err
 	}
+	rollback := opsRes.OpsRequest.Status.Rollback
+	opsDeepCopy := opsRes.OpsRequest.DeepCopy()
+	rollback.Phase = phase
+	rollback.CompletionTimestamp = metav1.Now()
+	rollback.Message = message
+	return PatchOpsStatusWithOpsDeepCopy(reqCtx.Ctx, cli, opsRes, opsDeepCopy, rollback.TriggeredPhase,
+		opsv1alpha1.NewRollbackCompletedCondition(phase, message))
 }
 
 func (opsMgr *OpsManager) handleOpsCompleted(reqCtx intctrlutil.RequestCtx,
@@ -258,13 +344,27 @@ func (opsMgr *OpsManager) validateDependOnSuccessfulOps(reqCtx intctrlutil.Reque
 func (opsMgr *OpsManager) checkAndHandleOpsTimeout(reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
 	opsRes *OpsResource,
+	opsBehaviour OpsBehaviour,
 	requeueAfter time.Duration) (time.Duration, error) {
 	timeoutSeconds := opsRes.OpsRequest.Spec.TimeoutSeconds
 	if timeoutSeconds == nil || *timeoutSeconds == 0 {
 		return requeueAfter, nil
 	}
-	timeoutPoint := opsRes.OpsRequest.Status.StartTimestamp.Add(time.Duration(*timeoutSeconds) * time.Second)
+	startTimestamp := opsRes.OpsRequest.Status.StartTimestamp
+	if isOpsRollingBack(opsRes.OpsRequest) {
+		// the rollback has the same timeout period as the operation.
+		startTimestamp = opsRes.OpsRequest.Status.Rollback.StartTimestamp
+	}
+	timeoutPoint := startTimestamp.Add(time.Duration(*timeoutSeconds) * time.Second)
 	if !time.Now().Before(timeoutPoint) {
+		if isOpsRollingBack(opsRes.OpsRequest) {
+			return 0, opsMgr.handleRollbackCompleted(reqCtx, cli, opsRes, opsv1alpha1.RollbackFailedPhase,
+				"failed to roll back the OpsRequest due to exceeding the specified timeout period (timeoutSeconds)")
+		}
+		if needAutoRollback(opsRes.OpsRequest, opsBehaviour) {
+			return 0, opsMgr.startRollback(reqCtx, cli, opsRes, opsBehaviour, opsv1alpha1.OpsAbortedPhase,
+				"exceeding the specified timeout period (timeoutSeconds)")
+		}
 		return 0, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, opsv1alpha1.OpsAbortedPhase,
 			opsv1alpha1.NewAbortedCondition("Aborted due to exceeding the specified timeout period (timeoutSeconds)"))
 	}
@@ -0,0 +1,311 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+var _ = Describe("OpsManager rollback", func() {
+	const (
+		namespace       = "default"
+		clusterName     = "test-cluster"
+		opsName         = "test-upgrade"
+		compDef1        = "test-compdef-1"
+		compDef2        = "test-compdef-2"
+		serviceVersion1 = "1.0.1"
+		serviceVersion2 = "1.0.2"
+	)
+
+	var (
+		cli          client.Client
+		reqCtx       intctrlutil.RequestCtx
+		opsRes       *OpsResource
+		opsBehaviour OpsBehaviour
+	)
+
+	// the cluster has been upgraded to the new ComponentDefinition and ServiceVersion by the Upgrade OpsRequest
+	newOpsRes := func(rollbackPolicy opsv1alpha1.RollbackPolicy) {
+		cluster := &appsv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      clusterName,
+			},
+			Spec: appsv1.ClusterSpec{
+				ComponentSpecs: []appsv1.ClusterComponentSpec{
+					{
+						Name:           defaultCompName,
+						ComponentDef:   compDef2,
+						ServiceVersion: serviceVersion2,
+						Replicas:       3,
+					},
+				},
+			},
+		}
+		ops := &opsv1alpha1.OpsRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      opsName,
+			},
+			Spec: opsv1alpha1.OpsRequestSpec{
+				ClusterName:    clusterName,
+				Type:           opsv1alpha1.UpgradeType,
+				TimeoutSeconds: ptr.To[int32](60),
+				SpecificOpsRequest: opsv1alpha1.SpecificOpsRequest{
+					Upgrade: &opsv1alpha1.Upgrade{
+						Components: []opsv1alpha1.UpgradeComponent{
+							{
+								ComponentOps:            opsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+								ComponentDefinitionName: ptr.To(compDef2),
+								ServiceVersion:          ptr.To(serviceVersion2),
+							},
+						},
+						RollbackPolicy: rollbackPolicy,
+					},
+				},
+			},
+			Status: opsv1alpha1.OpsRequestStatus{
+				Phase:          opsv1alpha1.OpsRunningPhase,
+				StartTimestamp: metav1.Now(),
+				LastConfiguration: opsv1alpha1.LastConfiguration{
+					Components: map[string]opsv1alpha1.LastComponentConfiguration{
+						defaultCompName: {
+							ComponentDefinitionName: compDef1,
+							ServiceVersion:          serviceVersion1,
+						},
+					},
+				},
+				Components: map[string]opsv1alpha1.OpsRequestComponentStatus{
+					defaultCompName: {
+						ProgressDetails: []opsv1alpha1.ProgressStatusDetail{
+							{
+								ObjectKey: fmt.Sprintf("Pod/%s-%s-0", clusterName, defaultCompName),
+								Status:    opsv1alpha1.FailedProgressStatus,
+							},
+						},
+					},
+				},
+			},
+		}
+
+		scheme := runtime.NewScheme()
+		Expect(appsv1.AddToScheme(scheme)).Should(Succeed())
+		Expect(opsv1alpha1.AddToScheme(scheme)).Should(Succeed())
+		cli = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(cluster, ops).
+			WithStatusSubresource(ops).
+			Build()
+
+		reqCtx = intctrlutil.RequestCtx{Ctx: ctx}
+		opsRes = &OpsResource{
+			OpsRequest: ops,
+			Cluster:    cluster,
+			Recorder:   record.NewFakeRecorder(10),
+		}
+		opsBehaviour = GetOpsManager().OpsMap[opsv1alpha1.UpgradeType]
+	}
+
+	checkClusterRolledBack := func(rolledBack bool) {
+		cluster := &appsv1.Cluster{}
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(opsRes.Cluster), cluster)).Should(Succeed())
+		if rolledBack {
+			Expect(cluster.Spec.ComponentSpecs[0].ComponentDef).Should(Equal(compDef1))
+			Expect(cluster.Spec.ComponentSpecs[0].ServiceVersion).Should(Equal(serviceVersion1))
+		} else {
+			Expect(cluster.Spec.ComponentSpecs[0].ComponentDef).Should(Equal(compDef2))
+			Expect(cluster.Spec.ComponentSpecs[0].ServiceVersion).Should(Equal(serviceVersion2))
+		}
+	}
+
+	getOpsRequest := func() *opsv1alpha1.OpsRequest {
+		ops := &opsv1alpha1.OpsRequest{}
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(opsRes.OpsRequest), ops)).Should(Succeed())
+		return ops
+	}
+
+	It("fails the OpsRequest w/o the auto rollback policy", func() {
+		newOpsRes(opsv1alpha1.NoneRollbackPolicy)
+
+		Expect(GetOpsManager().handleOpsFailed(reqCtx, cli, opsRes, opsBehaviour, fmt.Errorf("mock error"))).Should(Succeed())
+		ops := getOpsRequest()
+		Expect(ops.Status.Phase).Should(Equal(opsv1alpha1.OpsFailedPhase))
+		Expect(ops.Status.Rollback).Should(BeNil())
+		checkClusterRolledBack(false)
+	})
+
+	It("rolls back the OpsRequest automatically after it fails", func() {
+		newOpsRes(opsv1alpha1.AutoRollbackPolicy)
+
+		Expect(GetOpsManager().handleOpsFailed(reqCtx, cli, opsRes, opsBehaviour, fmt.Errorf("mock error"))).Should(Succeed())
+		checkClusterRolledBack(true)
+
+		ops := getOpsRequest()
+		Expect(ops.Status.Phase).Should(Equal(opsv1alpha1.OpsRunningPhase))
+		Expect(ops.Status.Rollback).ShouldNot(BeNil())
+		Expect(ops.Status.Rollback.TriggeredPhase).Should(Equal(opsv1alpha1.OpsFailedPhase))
+		Expect(ops.Status.Rollback.Phase).Should(Equal(opsv1alpha1.RollbackRunningPhase))
+		Expect(ops.Status.Rollback.Message).Should(Equal("mock error"))
+		Expect(ops.Status.Rollback.StartTimestamp.IsZero()).Should(BeFalse())
+		Expect(ops.Status.Components[defaultCompName].ProgressDetails).Should(BeEmpty())
+		cond := meta.FindStatusCondition(ops.Status.Conditions, opsv1alpha1.ConditionTypeRollingBack)
+		Expect(cond).ShouldNot(BeNil())
+		Expect(cond.Status).Should(Equal(metav1.ConditionTrue))
+
+		By("completing the OpsRequest with the triggered phase after the rollback succeeds")
+		Expect(GetOpsManager().handleRollbackCompleted(reqCtx, cli, opsRes, opsv1alpha1.RollbackSucceedPhase,
+			"the OpsRequest has been rolled back successfully")).Should(Succeed())
+		ops = getOpsRequest()
+		Expect(ops.Status.Phase).Should(Equal(opsv1alpha1.OpsFailedPhase))
+		Expect(ops.Status.Rollback.Phase).Should(Equal(opsv1alpha1.RollbackSucceedPhase))
+		Expect(ops.Status.Rollback.CompletionTimestamp.IsZero()).Should(BeFalse())
+		cond = meta.FindStatusCondition(ops.Status.Conditions, opsv1alpha1.ConditionTypeRollingBack)
+		Expect(cond.Status).Should(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).Should(Equal(opsv1alpha1.ReasonRollbackSucceed))
+	})
+
+	It("rolls back the OpsRequest automatically after it times out", func() {
+		newOpsRes(opsv1alpha1.AutoRollbackPolicy)
+		opsRes.OpsRequest.Status.StartTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+
+		_, err := GetOpsManager().checkAndHandleOpsTimeout(reqCtx, cli, opsRes, opsBehaviour, 0)
+		Expect(err).ShouldNot(HaveOccurred())
+		checkClusterRolledBack(true)
+
+		ops := getOpsRequest()
+		Expect(ops.Status.Phase).Should(Equal(opsv1alpha1.OpsRunningPhase))
+		Expect(ops.Status.Rollback).ShouldNot(BeNil())
+		Expect(ops.Status.Rollback.TriggeredPhase).Should(Equal(opsv1alpha1.OpsAbortedPhase))
+		Expect(ops.Status.Rollback.Phase).Should(Equal(opsv1alpha1.RollbackRunningPhase))
+		Expect(ops.Status.Rollback.Message).Should(ContainSubstring("timeoutSeconds"))
+
+		By("not timing out the rollback within the timeout period")
+		_, err = GetOpsManager().checkAndHandleOpsTimeout(reqCtx, cli, opsRes, opsBehaviour, 0)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(getOpsRequest().Status.Rollback.Phase).Should(Equal(opsv1alpha1.RollbackRunningPhase))
+	})
+
+	It("fails the rollback after it times out", func() {
+		newOpsRes(opsv1alpha1.AutoRollbackPolicy)
+		Expect(GetOpsManager().startRollback(reqCtx, cli, opsRes, opsBehaviour, opsv1alpha1.OpsFailedPhase, "mock error")).Should(Succeed())
+
+		opsRes.OpsRequest.Status.Rollback.StartTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+		_, err := GetOpsManager().checkAndHandleOpsTimeout(reqCtx, cli, opsRes, opsBehaviour, 0)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		ops := getOpsRequest()
+		Expect(ops.Status.Phase).Should(Equal(opsv1alpha1.OpsFailedPhase))
+		Expect(ops.Status.Rollback.Phase).Should(Equal(opsv1alpha1.RollbackFailedPhase))
+		Expect(ops.Status.Rollback.Message).Should(ContainSubstring("timeoutSeconds"))
+		cond := meta.FindStatusCondition(ops.Status.Conditions, opsv1alpha1.ConditionTypeRollingBack)
+		Expect(cond.Reason).Should(Equal(opsv1alpha1.ReasonRollbackFailed))
+	})
+
+	It("fails the rollback without rolling back again", func() {
+		newOpsRes(opsv1alpha1.AutoRollbackPolicy)
+		Expect(GetOpsManager().startRollback(reqCtx, cli, opsRes, opsBehaviour, opsv1alpha1.OpsFailedPhase, "mock error")).Should(Succeed())
+
+		Expect(GetOpsManager().handleOpsFailed(reqCtx, cli, opsRes, opsBehaviour, fmt.Errorf("rollback error"))).Should(Succeed())
+		ops := getOpsRequest()
+		Expect(ops.Status.Phase).Should(Equal(opsv1alpha1.OpsFailedPhase))
+		Expect(ops.Status.Rollback.Phase).Should(Equal(opsv1alpha1.RollbackFailedPhase))
+		Expect(ops.Status.Rollback.Message).Should(ContainSubstring("rollback error"))
+	})
+})
+
+var _ = Describe("OpsManager maintenance window", func() {
+	It("doesn't enqueue the disruptive OpsRequest outside the maintenance windows", func() {
+		cluster := &appsv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-cluster",
+			},
+			Spec: appsv1.ClusterSpec{
+				MaintenanceWindows: []appsv1.MaintenanceWindow{
+					{
+						StartTime:       time.Now().UTC().Add(12 * time.Hour).Format("15:04"),
+						DurationMinutes: 60,
+					},
+				},
+			},
+			Status: appsv1.ClusterStatus{
+				Phase: appsv1.RunningClusterPhase,
+			},
+		}
+		ops := &opsv1alpha1.OpsRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-upgrade",
+			},
+			Spec: opsv1alpha1.OpsRequestSpec{
+				ClusterName: cluster.Name,
+				Type:        opsv1alpha1.UpgradeType,
+				SpecificOpsRequest: opsv1alpha1.SpecificOpsRequest{
+					Upgrade: &opsv1alpha1.Upgrade{},
+				},
+			},
+			Status: opsv1alpha1.OpsRequestStatus{
+				Phase: opsv1alpha1.OpsPendingPhase,
+			},
+		}
+
+		scheme := runtime.NewScheme()
+		Expect(appsv1.AddToScheme(scheme)).Should(Succeed())
+		Expect(opsv1alpha1.AddToScheme(scheme)).Should(Succeed())
+		cli := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(cluster, ops).
+			WithStatusSubresource(ops).
+			Build()
+		reqCtx := intctrlutil.RequestCtx{Ctx: ctx}
+		opsRes := &OpsResource{
+			OpsRequest: ops,
+			Cluster:    cluster,
+			Recorder:   record.NewFakeRecorder(10),
+		}
+
+		err := GetOpsManager().doPreConditionAndTransPhaseToCreating(reqCtx, cli, opsRes, GetOpsManager().OpsMap[opsv1alpha1.UpgradeType])
+		Expect(intctrlutil.IsRequeueError(err)).Should(BeTrue())
+
+		By("checking the OpsRequest is not in the queue of the cluster")
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(cluster), cluster)).Should(Succeed())
+		Expect(cluster.Annotations).ShouldNot(HaveKey(constant.OpsRequestAnnotationKey))
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(ops), ops)).Should(Succeed())
+		Expect(ops.Status.Phase).Should(Equal(opsv1alpha1.OpsPendingPhase))
+		Expect(meta.FindStatusCondition(ops.Status.Conditions, opsv1alpha1.ConditionTypeMaintenanceWindow)).ShouldNot(BeNil())
+	})
+})
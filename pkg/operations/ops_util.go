@@ -27,6 +27,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -34,9 +35,14 @@ import (
 	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/metrics"
 	opsutil "github.com/apecloud/kubeblocks/pkg/operations/util"
 )
 
+// maintenanceWindowRequeueInterval is the maximum interval to recheck the maintenance windows of the cluster,
+// so that the changes of the windows can be noticed by the waiting opsRequests.
+const maintenanceWindowRequeueInterval = 5 * time.Minute
+
 var _ error = &WaitForClusterPhaseErr{}
 
 type WaitForClusterPhaseErr struct {
@@ -73,6 +79,7 @@ func PatchOpsStatusWithOpsDeepCopy(ctx context.Context,
 	condition ...*metav1.Condition) error {
 
 	opsRequest := opsRes.OpsRequest
+	prevPhase := opsRequestDeepCopy.Status.Phase
 	patch := client.MergeFrom(opsRequestDeepCopy)
 	for _, v := range condition {
 		if v == nil {
@@ -97,7 +104,11 @@ func PatchOpsStatusWithOpsDeepCopy(ctx context.Context,
 	if phase == opsv1alpha1.OpsCreatingPhase && opsRequest.Status.StartTimestamp.IsZero() {
 		opsRequest.Status.StartTimestamp = metav1.Time{Time: time.Now()}
 	}
-	return cli.Status().Patch(ctx, opsRequest, patch)
+	if err := cli.Status().Patch(ctx, opsRequest, patch); err != nil {
+		return err
+	}
+	metrics.RecordOpsRequestPhase(opsRequest, prevPhase)
+	return nil
 }
 
 // PatchOpsStatus patches OpsRequest.status
@@ -211,6 +222,48 @@ func validateOpsNeedWaitingClusterPhase(cluster *appsv1.Cluster, ops *opsv1alpha
 	}
 }
 
+// validateOpsMaintenanceWindow validates whether the disruptive operation can be started in the maintenance windows of the cluster.
+// If not, the opsRequest is kept in the Pending phase with a condition, and it will be requeued when the next window starts.
+func validateOpsMaintenanceWindow(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource, opsBehaviour OpsBehaviour) error {
+	ops := opsRes.OpsRequest
+	if !opsBehaviour.Disruptive || ops.Spec.BypassMaintenanceWindow || opsRes.Cluster == nil {
+		return nil
+	}
+	inWindow, nextWindowStart := intctrlutil.IsInMaintenanceWindow(opsRes.Cluster.Spec.MaintenanceWindows, time.Now())
+	if inWindow {
+		return nil
+	}
+	var (
+		requeueAfter = maintenanceWindowRequeueInterval
+		condition    = opsv1alpha1.NewWaitForMaintenanceWindowCondition(ops, nil)
+	)
+	if nextWindowStart != nil {
+		if d := time.Until(*nextWindowStart); d < requeueAfter {
+			requeueAfter = d
+		}
+		condition = opsv1alpha1.NewWaitForMaintenanceWindowCondition(ops, &metav1.Time{Time: *nextWindowStart})
+	}
+	if c := meta.FindStatusCondition(ops.Status.Conditions, condition.Type); c == nil || c.Message != condition.Message {
+		if err := PatchOpsStatus(reqCtx.Ctx, cli, opsRes, opsv1alpha1.OpsPendingPhase, condition); err != nil {
+			return err
+		}
+	}
+	return intctrlutil.NewRequeueError(requeueAfter, condition.Message)
+}
+
+// needAutoRollback checks whether the opsRequest should be rolled back automatically when it fails or times out.
+func needAutoRollback(ops *opsv1alpha1.OpsRequest, opsBehaviour OpsBehaviour) bool {
+	return opsBehaviour.RollbackFunc != nil &&
+		ops.Spec.GetRollbackPolicy() == opsv1alpha1.AutoRollbackPolicy &&
+		ops.Status.Phase == opsv1alpha1.OpsRunningPhase &&
+		ops.Status.Rollback == nil
+}
+
+// isOpsRollingBack checks whether the opsRequest is being rolled back automatically.
+func isOpsRollingBack(ops *opsv1alpha1.OpsRequest) bool {
+	return ops.Status.Rollback != nil && ops.Status.Rollback.Phase == opsv1alpha1.RollbackRunningPhase
+}
+
 func preConditionDeadlineSecondsIsSet(ops *opsv1alpha1.OpsRequest) bool {
 	return ops.Spec.PreConditionDeadlineSeconds != nil && *ops.Spec.PreConditionDeadlineSeconds != 0
 }
@@ -272,6 +325,7 @@ func abortEarlierOpsRequestWithSameKind(reqCtx intctrlutil.RequestCtx,
 		if needAborted {
 			// abort the opsRequest that matches the abort condition.
 			patch := client.MergeFrom(earlierOps.DeepCopy())
+			prevPhase := earlierOps.Status.Phase
 			earlierOps.Status.Phase = opsv1alpha1.OpsAbortedPhase
 			abortedCondition := opsv1alpha1.NewAbortedCondition(fmt.Sprintf(`Aborted as a result of the latest opsRequest "%s" being overridden`, earlierOps.Name))
 			earlierOps.SetStatusCondition(*abortedCondition)
@@ -279,6 +333,7 @@ func abortEarlierOpsRequestWithSameKind(reqCtx intctrlutil.RequestCtx,
 			if err = cli.Status().Patch(reqCtx.Ctx, earlierOps, patch); err != nil {
 				return err
 			}
+			metrics.RecordOpsRequestPhase(earlierOps, prevPhase)
 			opsRes.Recorder.Event(earlierOps, corev1.EventTypeNormal, abortedCondition.Type, abortedCondition.Message)
 			index, _ := GetOpsRecorderFromSlice(opsRequestSlice, earlierOps.Name)
 			if index != -1 {
@@ -0,0 +1,408 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// pipelinePreConditionRequeueDuration is the interval to check the precondition of a waiting step again,
+// the changes of the cluster phase will not trigger the reconciliation of the pipeline.
+const pipelinePreConditionRequeueDuration = 5 * time.Second
+
+type PipelineOpsHandler struct{}
+
+var _ OpsHandler = PipelineOpsHandler{}
+
+func init() {
+	// FromClusterPhases and ToClusterPhase are not defined, because the pipeline does not change the cluster by itself.
+	// each step is executed by a child OpsRequest, which checks the cluster phase and enqueues itself as usual.
+	pipelineBehaviour := OpsBehaviour{
+		CancelFunc: PipelineOpsHandler{}.Cancel,
+		OpsHandler: PipelineOpsHandler{},
+	}
+
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(opsv1alpha1.PipelineType, pipelineBehaviour)
+}
+
+// ActionStartedCondition the started condition when handling the pipeline request.
+func (p PipelineOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return opsv1alpha1.NewPipelineCondition(opsRes.OpsRequest), nil
+}
+
+// Action initializes the status of the pipeline steps, the steps will be started in ReconcileAction.
+func (p PipelineOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	p.initStepStatuses(opsRes.OpsRequest)
+	return nil
+}
+
+// ReconcileAction starts the steps whose dependencies have finished and aggregates the status of the steps.
+// If a step fails with the failure policy "Abort" or "Rollback", no new steps will be started,
+// and the succeeded steps will be rolled back one by one in reverse order of completion for "Rollback".
+func (p PipelineOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (opsv1alpha1.OpsPhase, time.Duration, error) {
+	var (
+		oldOpsRequest   = opsRes.OpsRequest.DeepCopy()
+		opsRequest      = opsRes.OpsRequest
+		opsRequestPhase = opsRequest.Status.Phase
+		steps           = opsRequest.Spec.Pipeline.Steps
+		requeueAfter    time.Duration
+	)
+	p.initStepStatuses(opsRequest)
+
+	// 1. sync the status of the running steps from the child OpsRequests.
+	for i := range opsRequest.Status.PipelineSteps {
+		if err := p.syncStepStatus(reqCtx, cli, opsRequest, &opsRequest.Status.PipelineSteps[i]); err != nil {
+			return opsRequestPhase, 0, err
+		}
+	}
+
+	// 2. start the steps which are ready, or skip the pending steps if the pipeline is interrupted.
+	interrupted := opsRequestPhase == opsv1alpha1.OpsCancellingPhase || len(p.getInterruptingSteps(opsRequest)) > 0
+	for _, step := range steps {
+		stepStatus := getPipelineStepStatus(opsRequest, step.Name)
+		if stepStatus.Phase != opsv1alpha1.PipelineStepPendingPhase {
+			continue
+		}
+		if interrupted {
+			completePipelineStep(stepStatus, opsv1alpha1.PipelineStepSkippedPhase, "skipped because the pipeline is interrupted")
+			continue
+		}
+		if !p.dependenciesFinished(opsRequest, step) {
+			continue
+		}
+		stepRequeueAfter, err := p.startStep(reqCtx, cli, opsRes, step, stepStatus)
+		if err != nil {
+			return opsRequestPhase, 0, err
+		}
+		if stepRequeueAfter != 0 && (requeueAfter == 0 || stepRequeueAfter < requeueAfter) {
+			requeueAfter = stepRequeueAfter
+		}
+		if stepStatus.Phase == opsv1alpha1.PipelineStepFailedPhase && isInterruptingFailurePolicy(step.FailurePolicy) {
+			interrupted = true
+		}
+	}
+
+	// 3. roll back the succeeded steps after all the running steps have finished.
+	needRollback := p.needRollback(opsRequest)
+	if needRollback && !p.hasStepInPhase(opsRequest, opsv1alpha1.PipelineStepRunningPhase) {
+		if err := p.rollbackNextStep(reqCtx, cli, opsRequest); err != nil {
+			return opsRequestPhase, 0, err
+		}
+	}
+
+	// 4. aggregate the progress and the result of the pipeline.
+	completedCount := 0
+	for _, stepStatus := range opsRequest.Status.PipelineSteps {
+		if slices.Contains([]opsv1alpha1.PipelineStepPhase{opsv1alpha1.PipelineStepSucceedPhase, opsv1alpha1.PipelineStepFailedPhase,
+			opsv1alpha1.PipelineStepSkippedPhase, opsv1alpha1.PipelineStepRolledBackPhase}, stepStatus.Phase) {
+			completedCount++
+		}
+	}
+	if err := syncProgressToOpsRequest(reqCtx, cli, opsRes, oldOpsRequest, completedCount, len(steps)); err != nil {
+		return opsRequestPhase, 0, err
+	}
+	if completedCount != len(steps) || (needRollback && p.getNextRollbackStep(opsRequest) != nil) {
+		return opsRequestPhase, requeueAfter, nil
+	}
+	if failedSteps := p.getInterruptingSteps(opsRequest); len(failedSteps) > 0 {
+		return opsv1alpha1.OpsFailedPhase, 0, fmt.Errorf("pipeline steps failed: %s", strings.Join(failedSteps, ", "))
+	}
+	return opsv1alpha1.OpsSucceedPhase, 0, nil
+}
+
+// SaveLastConfiguration records last configuration to the OpsRequest.status.lastConfiguration
+func (p PipelineOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return nil
+}
+
+// Cancel cancels the running steps which support the cancel action,
+// and the pending steps will be skipped in ReconcileAction.
+func (p PipelineOpsHandler) Cancel(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	for _, stepStatus := range opsRes.OpsRequest.Status.PipelineSteps {
+		if stepStatus.Phase != opsv1alpha1.PipelineStepRunningPhase {
+			continue
+		}
+		childOps := &opsv1alpha1.OpsRequest{}
+		if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: stepStatus.OpsRequestName, Namespace: opsRes.OpsRequest.Namespace}, childOps); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if childOps.IsComplete() || childOps.Spec.Cancel || GetOpsManager().OpsMap[childOps.Spec.Type].CancelFunc == nil {
+			continue
+		}
+		patch := client.MergeFrom(childOps.DeepCopy())
+		childOps.Spec.Cancel = true
+		if err := cli.Patch(reqCtx.Ctx, childOps, patch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p PipelineOpsHandler) initStepStatuses(opsRequest *opsv1alpha1.OpsRequest) {
+	for _, step := range opsRequest.Spec.Pipeline.Steps {
+		if getPipelineStepStatus(opsRequest, step.Name) != nil {
+			continue
+		}
+		opsRequest.Status.PipelineSteps = append(opsRequest.Status.PipelineSteps, opsv1alpha1.PipelineStepStatus{
+			Name:  step.Name,
+			Phase: opsv1alpha1.PipelineStepPendingPhase,
+		})
+	}
+}
+
+// syncStepStatus syncs the status of the running or rolling back step from the child OpsRequest.
+func (p PipelineOpsHandler) syncStepStatus(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRequest *opsv1alpha1.OpsRequest,
+	stepStatus *opsv1alpha1.PipelineStepStatus) error {
+	var childOpsName string
+	switch stepStatus.Phase {
+	case opsv1alpha1.PipelineStepRunningPhase:
+		childOpsName = stepStatus.OpsRequestName
+	case opsv1alpha1.PipelineStepRollingBackPhase:
+		childOpsName = stepStatus.RollbackOpsRequestName
+	default:
+		return nil
+	}
+	childOps := &opsv1alpha1.OpsRequest{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: childOpsName, Namespace: opsRequest.Namespace}, childOps); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		completePipelineStep(stepStatus, opsv1alpha1.PipelineStepFailedPhase, fmt.Sprintf(`OpsRequest "%s" is not found`, childOpsName))
+		return nil
+	}
+	if !childOps.IsComplete() {
+		return nil
+	}
+	switch {
+	case childOps.Status.Phase == opsv1alpha1.OpsSucceedPhase && stepStatus.Phase == opsv1alpha1.PipelineStepRunningPhase:
+		completePipelineStep(stepStatus, opsv1alpha1.PipelineStepSucceedPhase, "")
+	case childOps.Status.Phase == opsv1alpha1.OpsSucceedPhase:
+		completePipelineStep(stepStatus, opsv1alpha1.PipelineStepRolledBackPhase, "")
+	case stepStatus.Phase == opsv1alpha1.PipelineStepRollingBackPhase:
+		completePipelineStep(stepStatus, opsv1alpha1.PipelineStepFailedPhase,
+			fmt.Sprintf(`failed to roll back the step, OpsRequest "%s" is %s`, childOpsName, childOps.Status.Phase))
+	default:
+		completePipelineStep(stepStatus, opsv1alpha1.PipelineStepFailedPhase,
+			fmt.Sprintf(`OpsRequest "%s" is %s`, childOpsName, childOps.Status.Phase))
+	}
+	return nil
+}
+
+// startStep creates the child OpsRequest of the step if its precondition is met.
+func (p PipelineOpsHandler) startStep(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	step opsv1alpha1.PipelineStep,
+	stepStatus *opsv1alpha1.PipelineStepStatus) (time.Duration, error) {
+	if stepStatus.StartTimestamp.IsZero() {
+		stepStatus.StartTimestamp = metav1.Now()
+	}
+	preCondition := step.Precondition
+	if preCondition != nil && len(preCondition.ClusterPhases) > 0 &&
+		!slices.Contains(preCondition.ClusterPhases, opsRes.Cluster.Status.Phase) {
+		if preCondition.DeadlineSeconds != nil && *preCondition.DeadlineSeconds > 0 &&
+			!time.Now().Before(stepStatus.StartTimestamp.Add(time.Duration(*preCondition.DeadlineSeconds)*time.Second)) {
+			completePipelineStep(stepStatus, opsv1alpha1.PipelineStepFailedPhase,
+				fmt.Sprintf("the precondition is not met before the deadline, the phase of the cluster is %s", opsRes.Cluster.Status.Phase))
+			return 0, nil
+		}
+		stepStatus.Message = fmt.Sprintf("wait for the phase of the cluster to be one of %v", preCondition.ClusterPhases)
+		return pipelinePreConditionRequeueDuration, nil
+	}
+	childOps := buildPipelineStepOpsRequest(opsRes.OpsRequest, step.Name,
+		fmt.Sprintf("%s-%s", opsRes.OpsRequest.Name, step.Name), step.PipelineStepOps, step.TimeoutSeconds)
+	if err := cli.Create(reqCtx.Ctx, childOps); err != nil && !apierrors.IsAlreadyExists(err) {
+		return 0, err
+	}
+	stepStatus.Phase = opsv1alpha1.PipelineStepRunningPhase
+	stepStatus.OpsRequestName = childOps.Name
+	stepStatus.Message = ""
+	return 0, nil
+}
+
+// rollbackNextStep rolls back the latest completed step which has not been rolled back,
+// the steps are rolled back one at a time.
+func (p PipelineOpsHandler) rollbackNextStep(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRequest *opsv1alpha1.OpsRequest) error {
+	if p.hasStepInPhase(opsRequest, opsv1alpha1.PipelineStepRollingBackPhase) {
+		return nil
+	}
+	step := p.getNextRollbackStep(opsRequest)
+	if step == nil {
+		return nil
+	}
+	stepStatus := getPipelineStepStatus(opsRequest, step.Name)
+	childOps := buildPipelineStepOpsRequest(opsRequest, step.Name,
+		fmt.Sprintf("%s-%s-rollback", opsRequest.Name, step.Name), *step.Rollback, step.TimeoutSeconds)
+	if err := cli.Create(reqCtx.Ctx, childOps); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	stepStatus.Phase = opsv1alpha1.PipelineStepRollingBackPhase
+	stepStatus.RollbackOpsRequestName = childOps.Name
+	stepStatus.Message = ""
+	return nil
+}
+
+// getNextRollbackStep gets the succeeded step with a rollback operation which was completed last.
+func (p PipelineOpsHandler) getNextRollbackStep(opsRequest *opsv1alpha1.OpsRequest) *opsv1alpha1.PipelineStep {
+	var (
+		nextStep       *opsv1alpha1.PipelineStep
+		nextStepStatus *opsv1alpha1.PipelineStepStatus
+	)
+	for i := range opsRequest.Spec.Pipeline.Steps {
+		step := &opsRequest.Spec.Pipeline.Steps[i]
+		stepStatus := getPipelineStepStatus(opsRequest, step.Name)
+		if step.Rollback == nil || stepStatus == nil || stepStatus.Phase != opsv1alpha1.PipelineStepSucceedPhase {
+			continue
+		}
+		// the later step takes precedence if the steps were completed at the same time.
+		if nextStepStatus == nil || !stepStatus.CompletionTimestamp.Before(&nextStepStatus.CompletionTimestamp) {
+			nextStep = step
+			nextStepStatus = stepStatus
+		}
+	}
+	return nextStep
+}
+
+// needRollback checks if a step has failed with the failure policy "Rollback".
+func (p PipelineOpsHandler) needRollback(opsRequest *opsv1alpha1.OpsRequest) bool {
+	for _, step := range opsRequest.Spec.Pipeline.Steps {
+		stepStatus := getPipelineStepStatus(opsRequest, step.Name)
+		if step.FailurePolicy == opsv1alpha1.PipelineStepFailurePolicyRollback && stepStatus != nil &&
+			stepStatus.Phase == opsv1alpha1.PipelineStepFailedPhase && stepStatus.RollbackOpsRequestName == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// getInterruptingSteps gets the names of the failed steps whose failure policy is not "Continue".
+func (p PipelineOpsHandler) getInterruptingSteps(opsRequest *opsv1alpha1.OpsRequest) []string {
+	var stepNames []string
+	for _, step := range opsRequest.Spec.Pipeline.Steps {
+		stepStatus := getPipelineStepStatus(opsRequest, step.Name)
+		if stepStatus != nil && stepStatus.Phase == opsv1alpha1.PipelineStepFailedPhase && isInterruptingFailurePolicy(step.FailurePolicy) {
+			stepNames = append(stepNames, step.Name)
+		}
+	}
+	return stepNames
+}
+
+// dependenciesFinished checks if all the dependencies of the step have succeeded or failed with the failure policy "Continue".
+func (p PipelineOpsHandler) dependenciesFinished(opsRequest *opsv1alpha1.OpsRequest, step opsv1alpha1.PipelineStep) bool {
+	for _, dep := range step.DependsOn {
+		depStatus := getPipelineStepStatus(opsRequest, dep)
+		if depStatus == nil {
+			return false
+		}
+		switch depStatus.Phase {
+		case opsv1alpha1.PipelineStepSucceedPhase:
+			continue
+		case opsv1alpha1.PipelineStepFailedPhase:
+			if depStep := getPipelineStep(opsRequest, dep); depStep != nil && !isInterruptingFailurePolicy(depStep.FailurePolicy) {
+				continue
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func (p PipelineOpsHandler) hasStepInPhase(opsRequest *opsv1alpha1.OpsRequest, phase opsv1alpha1.PipelineStepPhase) bool {
+	for _, stepStatus := range opsRequest.Status.PipelineSteps {
+		if stepStatus.Phase == phase {
+			return true
+		}
+	}
+	return false
+}
+
+func buildPipelineStepOpsRequest(pipelineOps *opsv1alpha1.OpsRequest,
+	stepName, opsName string,
+	stepOps opsv1alpha1.PipelineStepOps,
+	timeoutSeconds *int32) *opsv1alpha1.OpsRequest {
+	ops := &opsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      opsName,
+			Namespace: pipelineOps.Namespace,
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey:     pipelineOps.Spec.GetClusterName(),
+				constant.OpsRequestTypeLabelKey:  string(stepOps.Type),
+				constant.OpsPipelineNameLabelKey: pipelineOps.Name,
+				constant.OpsPipelineStepLabelKey: stepName,
+			},
+		},
+		Spec: opsv1alpha1.OpsRequestSpec{
+			ClusterName:             pipelineOps.Spec.GetClusterName(),
+			Type:                    stepOps.Type,
+			TimeoutSeconds:          timeoutSeconds,
+			BypassMaintenanceWindow: pipelineOps.Spec.BypassMaintenanceWindow,
+		},
+	}
+	if stepOps.Ops != nil {
+		ops.Spec.SpecificOpsRequest = *stepOps.Ops.DeepCopy()
+	}
+	_ = intctrlutil.SetControllerReference(pipelineOps, ops)
+	return ops
+}
+
+func completePipelineStep(stepStatus *opsv1alpha1.PipelineStepStatus, phase opsv1alpha1.PipelineStepPhase, message string) {
+	stepStatus.Phase = phase
+	stepStatus.Message = message
+	stepStatus.CompletionTimestamp = metav1.Now()
+}
+
+func isInterruptingFailurePolicy(policy opsv1alpha1.PipelineStepFailurePolicy) bool {
+	return policy != opsv1alpha1.PipelineStepFailurePolicyContinue
+}
+
+func getPipelineStep(opsRequest *opsv1alpha1.OpsRequest, stepName string) *opsv1alpha1.PipelineStep {
+	for i := range opsRequest.Spec.Pipeline.Steps {
+		if opsRequest.Spec.Pipeline.Steps[i].Name == stepName {
+			return &opsRequest.Spec.Pipeline.Steps[i]
+		}
+	}
+	return nil
+}
+
+func getPipelineStepStatus(opsRequest *opsv1alpha1.OpsRequest, stepName string) *opsv1alpha1.PipelineStepStatus {
+	for i := range opsRequest.Status.PipelineSteps {
+		if opsRequest.Status.PipelineSteps[i].Name == stepName {
+			return &opsRequest.Status.PipelineSteps[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	testops "github.com/apecloud/kubeblocks/pkg/testutil/operations"
+)
+
+var _ = Describe("Pipeline OpsRequest", func() {
+
+	var (
+		randomStr   = testCtx.GetRandomStr()
+		compDefName = "test-compdef-" + randomStr
+		clusterName = "test-cluster-" + randomStr
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		// delete cluster(and all dependent sub-resources), cluster definition
+		testapps.ClearClusterResourcesWithRemoveFinalizerOption(&testCtx)
+
+		// delete rest resources
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		// namespaced
+		testapps.ClearResources(&testCtx, generics.OpsRequestSignature, inNS, ml)
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(cleanEnv)
+
+	Context("Test OpsRequest for pipeline", func() {
+		var (
+			opsRes *OpsResource
+			reqCtx intctrlutil.RequestCtx
+		)
+
+		BeforeEach(func() {
+			By("init operations resources ")
+			opsRes, _, _ = initOperationsResources(compDefName, clusterName)
+			reqCtx = intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+		})
+
+		restartStep := func(name string, failurePolicy opsv1alpha1.PipelineStepFailurePolicy, dependsOn ...string) opsv1alpha1.PipelineStep {
+			stepOps := opsv1alpha1.PipelineStepOps{
+				Type: opsv1alpha1.RestartType,
+				Ops: &opsv1alpha1.SpecificOpsRequest{
+					RestartList: []opsv1alpha1.ComponentOps{{ComponentName: defaultCompName}},
+				},
+			}
+			return opsv1alpha1.PipelineStep{
+				Name:            name,
+				DependsOn:       dependsOn,
+				FailurePolicy:   failurePolicy,
+				PipelineStepOps: stepOps,
+				Rollback:        stepOps.DeepCopy(),
+			}
+		}
+
+		createPipelineOps := func(steps ...opsv1alpha1.PipelineStep) {
+			By("create Pipeline OpsRequest")
+			ops := testops.NewOpsRequestObj("pipeline-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, opsv1alpha1.PipelineType)
+			ops.Spec.Pipeline = &opsv1alpha1.Pipeline{Steps: steps}
+			opsRes.OpsRequest = testops.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = opsv1alpha1.OpsPendingPhase
+			runAction(reqCtx, opsRes, opsv1alpha1.OpsCreatingPhase)
+
+			By("mock the pipeline OpsRequest is Running")
+			Expect(PipelineOpsHandler{}.Action(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			Expect(testapps.ChangeObjStatus(&testCtx, opsRes.OpsRequest, func() {
+				opsRes.OpsRequest.Status.Phase = opsv1alpha1.OpsRunningPhase
+			})).Should(Succeed())
+		}
+
+		mockStepOpsPhase := func(opsName string, phase opsv1alpha1.OpsPhase) {
+			childOps := &opsv1alpha1.OpsRequest{}
+			Expect(k8sClient.Get(testCtx.Ctx, client.ObjectKey{Name: opsName, Namespace: testCtx.DefaultNamespace}, childOps)).Should(Succeed())
+			Expect(testapps.ChangeObjStatus(&testCtx, childOps, func() {
+				childOps.Status.Phase = phase
+			})).Should(Succeed())
+		}
+
+		reconcilePipeline := func() {
+			_, err := GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+		}
+
+		It("should run the steps in order of dependencies", func() {
+			createPipelineOps(restartStep("first", opsv1alpha1.PipelineStepFailurePolicyAbort),
+				restartStep("second", opsv1alpha1.PipelineStepFailurePolicyAbort, "first"))
+
+			By("expect only the first step is started")
+			reconcilePipeline()
+			Expect(getPipelineStepStatus(opsRes.OpsRequest, "first").Phase).Should(Equal(opsv1alpha1.PipelineStepRunningPhase))
+			Expect(getPipelineStepStatus(opsRes.OpsRequest, "second").Phase).Should(Equal(opsv1alpha1.PipelineStepPendingPhase))
+
+			By("expect the second step is started after the first step succeeded")
+			mockStepOpsPhase(getPipelineStepStatus(opsRes.OpsRequest, "first").OpsRequestName, opsv1alpha1.OpsSucceedPhase)
+			reconcilePipeline()
+			Expect(getPipelineStepStatus(opsRes.OpsRequest, "first").Phase).Should(Equal(opsv1alpha1.PipelineStepSucceedPhase))
+			Expect(getPipelineStepStatus(opsRes.OpsRequest, "second").Phase).Should(Equal(opsv1alpha1.PipelineStepRunningPhase))
+
+			By("expect the pipeline succeeded after all the steps succeeded")
+			mockStepOpsPhase(getPipelineStepStatus(opsRes.OpsRequest, "second").OpsRequestName, opsv1alpha1.OpsSucceedPhase)
+			reconcilePipeline()
+			Eventually(testops.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(opsv1alpha1.OpsSucceedPhase))
+		})
+
+		It("should roll back the succeeded steps when a step failed with the Rollback policy", func() {
+			createPipelineOps(restartStep("first", opsv1alpha1.PipelineStepFailurePolicyAbort),
+				restartStep("second", opsv1alpha1.PipelineStepFailurePolicyRollback, "first"),
+				restartStep("third", opsv1alpha1.PipelineStepFailurePolicyAbort, "second"))
+
+			reconcilePipeline()
+			mockStepOpsPhase(getPipelineStepStatus(opsRes.OpsRequest, "first").OpsRequestName, opsv1alpha1.OpsSucceedPhase)
+			reconcilePipeline()
+
+			By("expect the first step is rolled back after the second step failed")
+			mockStepOpsPhase(getPipelineStepStatus(opsRes.OpsRequest, "second").OpsRequestName, opsv1alpha1.OpsFailedPhase)
+			reconcilePipeline()
+			Expect(getPipelineStepStatus(opsRes.OpsRequest, "second").Phase).Should(Equal(opsv1alpha1.PipelineStepFailedPhase))
+			Expect(getPipelineStepStatus(opsRes.OpsRequest, "third").Phase).Should(Equal(opsv1alpha1.PipelineStepSkippedPhase))
+			firstStepStatus := getPipelineStepStatus(opsRes.OpsRequest, "first")
+			Expect(firstStepStatus.Phase).Should(Equal(opsv1alpha1.PipelineStepRollingBackPhase))
+
+			By("expect the pipeline failed after the rollback is completed")
+			mockStepOpsPhase(firstStepStatus.RollbackOpsRequestName, opsv1alpha1.OpsSucceedPhase)
+			reconcilePipeline()
+			Expect(getPipelineStepStatus(opsRes.OpsRequest, "first").Phase).Should(Equal(opsv1alpha1.PipelineStepRolledBackPhase))
+			Eventually(testops.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(opsv1alpha1.OpsFailedPhase))
+		})
+	})
+})
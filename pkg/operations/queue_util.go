@@ -31,6 +31,7 @@ import (
 
 	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/metrics"
 	opsutil "github.com/apecloud/kubeblocks/pkg/operations/util"
 )
 
@@ -67,6 +68,7 @@ func DequeueOpsRequestInClusterAnnotation(ctx context.Context, cli client.Client
 				return err
 			}
 			patch := client.MergeFrom(ops.DeepCopy())
+			prevPhase := ops.Status.Phase
 			ops.Status.Phase = opsv1alpha1.OpsCancelledPhase
 			ops.Status.CompletionTimestamp = metav1.Time{Time: time.Now()}
 			ops.SetStatusCondition(metav1.Condition{
@@ -78,6 +80,9 @@ func DequeueOpsRequestInClusterAnnotation(ctx context.Context, cli client.Client
 			if err = cli.Status().Patch(ctx, ops, patch); err != nil && apierrors.IsNotFound(err) {
 				return err
 			}
+			if err == nil {
+				metrics.RecordOpsRequestPhase(ops, prevPhase)
+			}
 		}
 		// 2. cleanup opsRequest queue
 		opsRequestSlice = newOpsRequestSlice
@@ -0,0 +1,245 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	opsv1alpha1 "github.com/apecloud/kubeblocks/apis/operations/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/lifecycle"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+type readonlySwitchOpsHandler struct{}
+
+var _ OpsHandler = readonlySwitchOpsHandler{}
+
+func init() {
+	readonlySwitchBehaviour := OpsBehaviour{
+		FromClusterPhases: appsv1.GetClusterUpRunningPhases(),
+		QueueBySelf:       true,
+		OpsHandler:        readonlySwitchOpsHandler{},
+	}
+
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(opsv1alpha1.ReadonlySwitchType, readonlySwitchBehaviour)
+}
+
+// ActionStartedCondition the started condition when handle the readonly switch request.
+func (r readonlySwitchOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return opsv1alpha1.NewReadonlySwitchCondition(opsRes.OpsRequest), nil
+}
+
+// Action checks whether the components define the lifecycle actions and own the instances to switch.
+func (r readonlySwitchOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	for _, readonlySwitch := range opsRes.OpsRequest.Spec.ReadonlySwitchList {
+		comps, err := CustomOpsHandler{}.listComponents(reqCtx, cli, opsRes.Cluster, readonlySwitch.ComponentName)
+		if err != nil {
+			return err
+		}
+		found := map[string]bool{}
+		for i := range comps {
+			synthesizedComp, err := r.buildSynthesizedComp(reqCtx, cli, &comps[i])
+			if err != nil {
+				return err
+			}
+			actions := synthesizedComp.LifecycleActions
+			if actions == nil || (readonlySwitch.Readonly && actions.Readonly == nil) || (!readonlySwitch.Readonly && actions.Readwrite == nil) {
+				return intctrlutil.NewFatalError(fmt.Sprintf(`the component "%s" does not define the %s lifecycle action`,
+					readonlySwitch.ComponentName, r.actionName(readonlySwitch.Readonly)))
+			}
+			pods, err := component.ListOwnedPods(reqCtx.Ctx, cli, synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name)
+			if err != nil {
+				return err
+			}
+			for _, pod := range pods {
+				found[pod.Name] = true
+			}
+		}
+		for _, name := range readonlySwitch.InstanceNames {
+			if !found[name] {
+				return intctrlutil.NewFatalError(fmt.Sprintf(`the pod "%s" not belongs to the component "%s"`, name, readonlySwitch.ComponentName))
+			}
+		}
+	}
+	return nil
+}
+
+// ReconcileAction switches the replicas one by one, and loops till all of them are switched.
+func (r readonlySwitchOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (opsv1alpha1.OpsPhase, time.Duration, error) {
+	opsRequest := opsRes.OpsRequest
+	oldOpsRequestStatus := opsRequest.Status.DeepCopy()
+	patch := client.MergeFrom(opsRequest.DeepCopy())
+	if opsRequest.Status.Components == nil {
+		opsRequest.Status.Components = make(map[string]opsv1alpha1.OpsRequestComponentStatus)
+	}
+
+	var expectCount, completedCount, failedCount int32
+	for _, readonlySwitch := range opsRequest.Spec.ReadonlySwitchList {
+		expect, completed, failed, err := r.switchComponent(reqCtx, cli, opsRes, readonlySwitch)
+		if err != nil {
+			return "", 0, err
+		}
+		expectCount += expect
+		completedCount += completed
+		failedCount += failed
+	}
+
+	opsRequest.Status.Progress = fmt.Sprintf("%d/%d", completedCount, expectCount)
+	if !reflect.DeepEqual(*oldOpsRequestStatus, opsRequest.Status) {
+		if err := cli.Status().Patch(reqCtx.Ctx, opsRequest, patch); err != nil {
+			return "", 0, err
+		}
+	}
+	if completedCount < expectCount {
+		return opsv1alpha1.OpsRunningPhase, 5 * time.Second, nil
+	}
+	if failedCount > 0 {
+		return opsv1alpha1.OpsFailedPhase, 0, nil
+	}
+	return opsv1alpha1.OpsSucceedPhase, 0, nil
+}
+
+// SaveLastConfiguration this operation doesn't change the Cluster.spec.
+// empty implementation here.
+func (r readonlySwitchOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return nil
+}
+
+func (r readonlySwitchOpsHandler) switchComponent(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	opsRes *OpsResource, readonlySwitch opsv1alpha1.ReadonlySwitch) (int32, int32, int32, error) {
+	var expectCount, completedCount, failedCount int32
+	opsRequest := opsRes.OpsRequest
+	compName := readonlySwitch.ComponentName
+	progressDetails := opsRequest.Status.Components[compName].ProgressDetails
+
+	comps, err := CustomOpsHandler{}.listComponents(reqCtx, cli, opsRes.Cluster, compName)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	for i := range comps {
+		comp := &comps[i]
+		synthesizedComp, err := r.buildSynthesizedComp(reqCtx, cli, comp)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		pods, err := component.ListOwnedPods(reqCtx.Ctx, cli, synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+
+		compCopy := comp.DeepCopy()
+		for _, pod := range pods {
+			if len(readonlySwitch.InstanceNames) > 0 && !slices.Contains(readonlySwitch.InstanceNames, pod.Name) {
+				continue
+			}
+			expectCount++
+			objectKey := getProgressObjectKey(constant.PodKind, pod.Name)
+			if progressDetail := findStatusProgressDetail(progressDetails, objectKey); progressDetail != nil && isCompletedProgressStatus(progressDetail.Status) {
+				completedCount++
+				if progressDetail.Status == opsv1alpha1.FailedProgressStatus {
+					failedCount++
+				}
+				continue
+			}
+
+			progressDetail := opsv1alpha1.ProgressStatusDetail{
+				ObjectKey: objectKey,
+				Status:    opsv1alpha1.SucceedProgressStatus,
+			}
+			if err = r.switchReplica(reqCtx, cli, synthesizedComp, pod, pods, readonlySwitch.Readonly); err != nil {
+				progressDetail.Status = opsv1alpha1.FailedProgressStatus
+				progressDetail.Message = fmt.Sprintf("failed to call the %s action: %s", r.actionName(readonlySwitch.Readonly), err.Error())
+				failedCount++
+			} else if readonlySwitch.Readonly {
+				progressDetail.Message = "switched into the read-only state"
+				component.SetReadonlyReplica(comp, pod.Name, appsv1.ReadonlyReasonOpsRequest,
+					fmt.Sprintf("switched by the OpsRequest %s", opsRequest.Name))
+			} else {
+				progressDetail.Message = "switched back to the read-write state"
+				component.RemoveReadonlyReplica(comp, pod.Name)
+			}
+			completedCount++
+			setComponentStatusProgressDetail(opsRes.Recorder, opsRequest, &progressDetails, progressDetail)
+		}
+		if !reflect.DeepEqual(compCopy.Status, comp.Status) {
+			if err = cli.Status().Patch(reqCtx.Ctx, comp, client.MergeFrom(compCopy)); err != nil {
+				return 0, 0, 0, err
+			}
+		}
+	}
+
+	phase := appsv1.RunningComponentPhase
+	if completedCount < expectCount {
+		phase = appsv1.UpdatingComponentPhase
+	}
+	opsRequest.Status.Components[compName] = opsv1alpha1.OpsRequestComponentStatus{
+		Phase:           phase,
+		ProgressDetails: progressDetails,
+	}
+	return expectCount, completedCount, failedCount, nil
+}
+
+func (r readonlySwitchOpsHandler) switchReplica(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	synthesizedComp *component.SynthesizedComponent, pod *corev1.Pod, pods []*corev1.Pod, readonly bool) error {
+	lfa, err := lifecycle.New(synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name,
+		synthesizedComp.LifecycleActions, synthesizedComp.TemplateVars, pod, pods...)
+	if err != nil {
+		return err
+	}
+	if readonly {
+		return lfa.Readonly(reqCtx.Ctx, cli, nil)
+	}
+	return lfa.Readwrite(reqCtx.Ctx, cli, nil)
+}
+
+func (r readonlySwitchOpsHandler) buildSynthesizedComp(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	comp *appsv1.Component) (*component.SynthesizedComponent, error) {
+	compDef, err := component.GetCompDefByName(reqCtx.Ctx, cli, comp.Spec.CompDef)
+	if err != nil {
+		return nil, err
+	}
+	synthesizedComp, err := component.BuildSynthesizedComponent(reqCtx.Ctx, cli, compDef, comp)
+	if err != nil {
+		return nil, err
+	}
+	synthesizedComp.TemplateVars, _, err = component.ResolveTemplateNEnvVars(reqCtx.Ctx, cli, synthesizedComp, compDef.Spec.Vars)
+	if err != nil {
+		return nil, err
+	}
+	return synthesizedComp, nil
+}
+
+func (r readonlySwitchOpsHandler) actionName(readonly bool) string {
+	if readonly {
+		return "readonly"
+	}
+	return "readwrite"
+}
@@ -117,7 +117,8 @@ func buildReconfigureParameter(ops *opsv1alpha1.OpsRequest) *parametersv1alpha1.
 	paramBuilder := builder.NewParameterBuilder(ops.Namespace, ops.GetName()).
 		AddLabels(constant.AppInstanceLabelKey, ops.Spec.ClusterName).
 		AddLabels(constant.OpsRequestNameLabelKey, ops.Name).
-		ClusterRef(ops.Spec.ClusterName)
+		ClusterRef(ops.Spec.ClusterName).
+		SetDryRun(ops.Annotations[constant.ReconfigureDryRunAnnotationKey] == "true")
 	for _, reconfigure := range ops.Spec.Reconfigures {
 		if len(reconfigure.Parameters) != 0 {
 			paramBuilder.SetComponentParameters(reconfigure.ComponentName, transformComponentParameters(reconfigure.Parameters))
@@ -45,6 +45,7 @@ func init() {
 		FromClusterPhases: appsv1.GetClusterUpRunningPhases(),
 		ToClusterPhase:    appsv1.UpdatingClusterPhase,
 		QueueByCluster:    true,
+		Disruptive:        true,
 		OpsHandler:        restartOpsHandler{},
 	}
 
@@ -69,6 +69,14 @@ type OpsBehaviour struct {
 	// QueueWithSelf indicates that the operation is queued for execution within opsType scope.
 	QueueBySelf bool
 
+	// Disruptive indicates that the operation disrupts the services of the cluster,
+	// it will only be started within the maintenance windows of the cluster.
+	Disruptive bool
+
+	// RollbackFunc reverts the changes of the operation to the cluster, it is called when the operation fails or
+	// times out and the automatic rollback is enabled. The cluster should be updated in this function.
+	RollbackFunc func(reqCtx intctrlutil.RequestCtx, cli client.Client, opsResource *OpsResource) error
+
 	OpsHandler OpsHandler
 }
 
@@ -0,0 +1,207 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	// EnvCommand is the sub-command of kb-agent to resolve the references to the secret store in the env vars,
+	// and then execute the command given after "--" with the resolved env vars.
+	EnvCommand = "secret-env"
+
+	envRefPrefix = "secretstore:"
+
+	envResolverName      = "init-secret-store"
+	envResolverVolume    = "kb-secret-store"
+	envResolverMountPath = "/kb-secret-store"
+	envResolverBinary    = "/bin/kbagent"
+	envResolverCommand   = envResolverMountPath + "/kbagent"
+)
+
+// envRefRegExp matches the references to the secret store, in the form of "secretstore:<path>#<key>".
+var envRefRegExp = regexp.MustCompile(`secretstore:([A-Za-z0-9._/-]+)#([A-Za-z0-9._-]+)`)
+
+// EnvRef returns the reference to the value of the key kept in the secret store at the path.
+func EnvRef(path, key string) string {
+	return fmt.Sprintf("%s%s#%s", envRefPrefix, strings.Trim(path, "/"), key)
+}
+
+// HasEnvRef tells whether the value contains any reference to the secret store.
+func HasEnvRef(value string) bool {
+	return strings.Contains(value, envRefPrefix) && envRefRegExp.MatchString(value)
+}
+
+// Resolve replaces the references to the secret store in the values with the data kept in the secret store,
+// the values can be env vars in the form of "KEY=VALUE", or the args of a command which are expanded from the env vars.
+func Resolve(ctx context.Context, provider Provider, values []string) ([]string, error) {
+	cache := map[string]map[string]string{}
+	resolve := func(path, key string) (string, error) {
+		data, ok := cache[path]
+		if !ok {
+			var err error
+			if data, err = provider.Get(ctx, path); err != nil {
+				return "", fmt.Errorf("failed to get %s from the secret store: %w", path, err)
+			}
+			cache[path] = data
+		}
+		value, ok := data[key]
+		if !ok {
+			return "", fmt.Errorf("the key %s is not found in %s of the secret store", key, path)
+		}
+		return value, nil
+	}
+
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if !HasEnvRef(value) {
+			result = append(result, value)
+			continue
+		}
+		var resolveErr error
+		value = envRefRegExp.ReplaceAllStringFunc(value, func(ref string) string {
+			match := envRefRegExp.FindStringSubmatch(ref)
+			value, err := resolve(match[1], match[2])
+			if err != nil && resolveErr == nil {
+				resolveErr = err
+			}
+			return value
+		})
+		if resolveErr != nil {
+			return nil, resolveErr
+		}
+		result = append(result, value)
+	}
+	return result, nil
+}
+
+// InjectEnvResolver makes the containers resolve the references to the secret store in their env vars when they start.
+//
+// The containers that have env vars referring to the secret store, or referring to the secrets given, have their
+// commands prefixed with the kb-agent "secret-env" command, which is copied to the pod by an init container.
+// The resolved values are only kept in the memory of the processes, and are never persisted to the API server.
+// It's a no-op if the secret store is not configured.
+//
+// It returns an error if any container refers to the secret store but has no command specified.
+func InjectEnvResolver(podSpec *corev1.PodSpec, secretNames ...string) error {
+	if unresolved := TryInjectEnvResolver(podSpec, secretNames...); len(unresolved) > 0 {
+		return fmt.Errorf("the containers %s refer to the secret store, but have no command specified to run with the resolved env",
+			strings.Join(unresolved, ","))
+	}
+	return nil
+}
+
+// TryInjectEnvResolver is the same as InjectEnvResolver, except that the containers which refer to the secret store
+// but have no command specified are left unchanged, since the entrypoint of their images is unknown to wrap,
+// the names of these containers are returned.
+func TryInjectEnvResolver(podSpec *corev1.PodSpec, secretNames ...string) []string {
+	if !Enabled() || podSpec == nil {
+		return nil
+	}
+	names := sets.New(secretNames...)
+	injected := false
+	var unresolved []string
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			c := &containers[i]
+			if c.Name == envResolverName || !referToSecretStore(c, names) {
+				continue
+			}
+			if len(c.Command) == 0 {
+				unresolved = append(unresolved, c.Name)
+				continue
+			}
+			if c.Command[0] != envResolverCommand {
+				c.Command = append([]string{envResolverCommand, EnvCommand, "--"}, c.Command...)
+				c.Env = append(c.Env, envResolverConfigEnv()...)
+				c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{Name: envResolverVolume, MountPath: envResolverMountPath})
+			}
+			injected = true
+		}
+	}
+	if !injected {
+		return unresolved
+	}
+	for _, c := range podSpec.InitContainers {
+		if c.Name == envResolverName {
+			return unresolved
+		}
+	}
+	initContainer := corev1.Container{
+		Name:            envResolverName,
+		Image:           viper.GetString(constant.KBToolsImage),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{"cp", envResolverBinary, envResolverCommand},
+		VolumeMounts:    []corev1.VolumeMount{{Name: envResolverVolume, MountPath: envResolverMountPath}},
+	}
+	podSpec.InitContainers = append([]corev1.Container{initContainer}, podSpec.InitContainers...)
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: envResolverVolume,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+	return unresolved
+}
+
+func referToSecretStore(c *corev1.Container, secretNames sets.Set[string]) bool {
+	for _, env := range c.Env {
+		if HasEnvRef(env.Value) {
+			return true
+		}
+		if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil && secretNames.Has(env.ValueFrom.SecretKeyRef.Name) {
+			return true
+		}
+	}
+	for _, from := range c.EnvFrom {
+		if from.SecretRef != nil && secretNames.Has(from.SecretRef.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// envResolverConfigEnv returns the config of the secret store for the containers, the token is never passed,
+// the containers log in with their service account tokens instead.
+func envResolverConfigEnv() []corev1.EnvVar {
+	var env []corev1.EnvVar
+	for _, key := range []string{
+		constant.CfgKeySecretStoreProvider,
+		constant.CfgKeySecretStoreAddress,
+		constant.CfgKeySecretStoreMountPath,
+		constant.CfgKeySecretStoreAuthRole,
+		constant.CfgKeySecretStoreAuthMountPath,
+	} {
+		if value := viper.GetString(key); len(value) > 0 {
+			env = append(env, corev1.EnvVar{Name: key, Value: value})
+		}
+	}
+	return env
+}
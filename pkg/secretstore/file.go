@@ -0,0 +1,117 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	// FileCommand is the sub-command of kb-agent to resolve the references to the secret store in the files
+	// of a directory, and then write the resolved files to another directory.
+	FileCommand = "secret-file"
+
+	fileResolverPrefix       = "resolve-"
+	fileResolverSourceSuffix = "-source"
+	fileResolverSourcePath   = "/kb-secret-store/source"
+	fileResolverTargetPath   = "/kb-secret-store/target"
+)
+
+// ResolveFiles replaces the references to the secret store in the files of the source directory with the data kept
+// in the secret store, and writes the resolved files to the target directory with the same names.
+func ResolveFiles(ctx context.Context, provider Provider, sourceDir, targetDir string) error {
+	entries, err := os.ReadDir(sourceDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		// skip the hidden files and directories, such as the "..data" of the projected volumes
+		if strings.HasPrefix(entry.Name(), ".") || entry.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(sourceDir, entry.Name()))
+		if err != nil {
+			return err
+		}
+		lines, err := Resolve(ctx, provider, strings.Split(string(data), "\n"))
+		if err != nil {
+			return fmt.Errorf("failed to resolve the file %s: %w", entry.Name(), err)
+		}
+		if err = os.WriteFile(filepath.Join(targetDir, entry.Name()), []byte(strings.Join(lines, "\n")), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InjectFileResolver makes the files of the volume which refer to the secret store resolved when the pod starts.
+//
+// The volume is renamed and mounted to an init container only, which runs the kb-agent "secret-file" command to
+// resolve the files to an in-memory emptyDir volume with the original name, so the containers read the resolved
+// files from the same mounts, and the resolved values are never persisted to the API server.
+// It's a no-op if the secret store is not configured.
+func InjectFileResolver(podSpec *corev1.PodSpec, volumeName string) {
+	if !Enabled() || podSpec == nil {
+		return
+	}
+	var volume *corev1.Volume
+	for i := range podSpec.Volumes {
+		switch podSpec.Volumes[i].Name {
+		case volumeName + fileResolverSourceSuffix:
+			return // injected already
+		case volumeName:
+			volume = &podSpec.Volumes[i]
+		}
+	}
+	if volume == nil {
+		return
+	}
+	source := volume.DeepCopy()
+	source.Name = volumeName + fileResolverSourceSuffix
+	*volume = corev1.Volume{
+		Name: volumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory},
+		},
+	}
+	podSpec.Volumes = append(podSpec.Volumes, *source)
+
+	initContainer := corev1.Container{
+		Name:            fileResolverPrefix + volumeName,
+		Image:           viper.GetString(constant.KBToolsImage),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{envResolverBinary, FileCommand, fileResolverSourcePath, fileResolverTargetPath},
+		Env:             envResolverConfigEnv(),
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: source.Name, MountPath: fileResolverSourcePath, ReadOnly: true},
+			{Name: volumeName, MountPath: fileResolverTargetPath},
+		},
+	}
+	podSpec.InitContainers = append([]corev1.Container{initContainer}, podSpec.InitContainers...)
+}
@@ -0,0 +1,140 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	testsecretstore "github.com/apecloud/kubeblocks/pkg/testutil/secretstore"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+func TestResolveFiles(t *testing.T) {
+	vault := testsecretstore.NewFakeVault()
+	defer vault.Close()
+	vault.SetSecret("kubeblocks/repo", map[string]string{"accessKeyId": "id", "secretAccessKey": "key"})
+
+	provider, err := New(Config{Provider: VaultProvider, Address: vault.URL, Token: testsecretstore.FakeVaultToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sourceDir, targetDir := t.TempDir(), t.TempDir()
+	config := "[storage]\ntype = s3\naccess_key_id = " + EnvRef("kubeblocks/repo", "accessKeyId") +
+		"\nsecret_access_key = " + EnvRef("kubeblocks/repo", "secretAccessKey") + "\n"
+	if err = os.WriteFile(filepath.Join(sourceDir, "datasafed.conf"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Mkdir(filepath.Join(sourceDir, "..data"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = ResolveFiles(context.Background(), provider, sourceDir, targetDir); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(targetDir, "datasafed.conf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "[storage]\ntype = s3\naccess_key_id = id\nsecret_access_key = key\n"
+	if string(data) != expected {
+		t.Errorf("expected %q, got %q", expected, string(data))
+	}
+	if entries, _ := os.ReadDir(targetDir); len(entries) != 1 {
+		t.Errorf("expected only the resolved file in the target directory, got %v", entries)
+	}
+
+	config = "secret_access_key = " + EnvRef("kubeblocks/repo", "unknown")
+	if err = os.WriteFile(filepath.Join(sourceDir, "datasafed.conf"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err = ResolveFiles(context.Background(), provider, sourceDir, targetDir); err == nil {
+		t.Error("expected error to resolve the unknown key")
+	}
+}
+
+func TestInjectFileResolver(t *testing.T) {
+	newPodSpec := func() *corev1.PodSpec {
+		return &corev1.PodSpec{
+			Volumes: []corev1.Volume{{
+				Name: "config",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{SecretName: "tool-config"},
+				},
+			}},
+			Containers: []corev1.Container{{
+				Name:         "backup",
+				Command:      []string{"datasafed", "list"},
+				VolumeMounts: []corev1.VolumeMount{{Name: "config", MountPath: "/etc/datasafed"}},
+			}},
+		}
+	}
+
+	podSpec := newPodSpec()
+	if InjectFileResolver(podSpec, "config"); !reflect.DeepEqual(podSpec, newPodSpec()) {
+		t.Errorf("expected no-op since the secret store is not configured, got %v", podSpec)
+	}
+
+	viper.Set(constant.CfgKeySecretStoreProvider, VaultProvider)
+	viper.Set(constant.CfgKeySecretStoreAddress, "http://vault:8200")
+	defer func() {
+		viper.Set(constant.CfgKeySecretStoreProvider, "")
+		viper.Set(constant.CfgKeySecretStoreAddress, "")
+	}()
+
+	for i := 0; i < 2; i++ { // injecting twice should be idempotent
+		InjectFileResolver(podSpec, "config")
+		InjectFileResolver(podSpec, "unknown")
+	}
+	if len(podSpec.Volumes) != 2 {
+		t.Fatalf("expected the source volume to be added, got %v", podSpec.Volumes)
+	}
+	if v := podSpec.Volumes[0]; v.Name != "config" || v.EmptyDir == nil || v.EmptyDir.Medium != corev1.StorageMediumMemory {
+		t.Errorf("expected the volume to be replaced by an in-memory emptyDir, got %v", v)
+	}
+	if v := podSpec.Volumes[1]; v.Name != "config"+fileResolverSourceSuffix || v.Secret == nil || v.Secret.SecretName != "tool-config" {
+		t.Errorf("expected the source volume to refer to the secret, got %v", v)
+	}
+	if !reflect.DeepEqual(podSpec.Containers, newPodSpec().Containers) {
+		t.Errorf("the containers should not be changed: %v", podSpec.Containers)
+	}
+	if len(podSpec.InitContainers) != 1 {
+		t.Fatalf("expected the init container to be injected, got %v", podSpec.InitContainers)
+	}
+	c := podSpec.InitContainers[0]
+	if !reflect.DeepEqual(c.Command, []string{envResolverBinary, FileCommand, fileResolverSourcePath, fileResolverTargetPath}) {
+		t.Errorf("unexpected command of the init container: %v", c.Command)
+	}
+	if len(c.VolumeMounts) != 2 || c.VolumeMounts[0].Name != "config"+fileResolverSourceSuffix || c.VolumeMounts[1].Name != "config" {
+		t.Errorf("unexpected volume mounts of the init container: %v", c.VolumeMounts)
+	}
+	for _, env := range c.Env {
+		if env.Name == constant.CfgKeySecretStoreToken {
+			t.Error("the token should never be passed to the init container")
+		}
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// Provider is the interface of an external secret store that keeps the sensitive data out of the Kubernetes Secrets.
+type Provider interface {
+	// Get returns the data of the secret at the path, or ErrNotFound if the secret does not exist.
+	Get(ctx context.Context, path string) (map[string]string, error)
+
+	// Put creates or replaces the data of the secret at the path.
+	Put(ctx context.Context, path string, data map[string]string) error
+
+	// Delete deletes the secret at the path, it's not an error if the secret does not exist.
+	Delete(ctx context.Context, path string) error
+}
+
+// Config is the configuration to access the external secret store.
+type Config struct {
+	Provider  string
+	Address   string
+	Token     string
+	MountPath string
+	// AuthRole and AuthMountPath are used to log in with the service account token if the token is not provided.
+	AuthRole      string
+	AuthMountPath string
+}
+
+// ProviderFactory creates a provider with the config.
+type ProviderFactory func(config Config) (Provider, error)
+
+var (
+	ErrNotFound = errors.New("secret not found in the secret store")
+
+	providers = map[string]ProviderFactory{}
+)
+
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// RegisterProvider registers a provider factory with the name, which is used as the value of the config SECRET_STORE_PROVIDER.
+func RegisterProvider(name string, factory ProviderFactory) {
+	providers[name] = factory
+}
+
+// New creates a provider with the config.
+func New(config Config) (Provider, error) {
+	factory, ok := providers[config.Provider]
+	if !ok {
+		return nil, fmt.Errorf("unknown secret store provider: %s", config.Provider)
+	}
+	return factory(config)
+}
+
+// Enabled tells whether the external secret store is configured.
+func Enabled() bool {
+	return len(viper.GetString(constant.CfgKeySecretStoreProvider)) > 0
+}
+
+// Default returns the provider of the external secret store configured, nil if it's not configured.
+func Default() (Provider, error) {
+	if !Enabled() {
+		return nil, nil
+	}
+	return New(Config{
+		Provider:      viper.GetString(constant.CfgKeySecretStoreProvider),
+		Address:       viper.GetString(constant.CfgKeySecretStoreAddress),
+		Token:         viper.GetString(constant.CfgKeySecretStoreToken),
+		MountPath:     viper.GetString(constant.CfgKeySecretStoreMountPath),
+		AuthRole:      viper.GetString(constant.CfgKeySecretStoreAuthRole),
+		AuthMountPath: viper.GetString(constant.CfgKeySecretStoreAuthMountPath),
+	})
+}
+
+// SecretPath returns the path in the secret store to keep the sensitive data of the secret.
+func SecretPath(namespace, name string) string {
+	return fmt.Sprintf("kubeblocks/%s/%s", namespace, name)
+}
+
+// ReferTo makes the secret refer to the data kept in the secret store at the path, the values of the keys
+// are replaced with the references to the data, which are resolved by the consumers of the secret.
+func ReferTo(secret *corev1.Secret, path string, keys ...string) {
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[constant.SecretStorePathAnnotationKey] = path
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	for _, key := range keys {
+		secret.Data[key] = []byte(EnvRef(path, key))
+	}
+}
+
+// IsReferred tells whether the secret refers to the data kept in the secret store.
+func IsReferred(secret *corev1.Secret) bool {
+	_, ok := secret.Annotations[constant.SecretStorePathAnnotationKey]
+	return ok
+}
+
+// ResolveSecretData returns the data of the secret, along with the data kept in the external secret store
+// if the secret refers to it.
+func ResolveSecretData(ctx context.Context, secret *corev1.Secret) (map[string][]byte, error) {
+	path, ok := secret.Annotations[constant.SecretStorePathAnnotationKey]
+	if !ok {
+		return secret.Data, nil
+	}
+	provider, err := Default()
+	if err != nil {
+		return nil, err
+	}
+	if provider == nil {
+		return nil, fmt.Errorf("the secret %s refers to the secret store, but the secret store is not configured", secret.Name)
+	}
+	data, err := provider.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string][]byte)
+	for k, v := range secret.Data {
+		result[k] = v
+	}
+	for k, v := range data {
+		result[k] = []byte(v)
+	}
+	return result, nil
+}
+
+// DeleteSecretData deletes the data kept in the secret store if the secret refers to it.
+func DeleteSecretData(ctx context.Context, secret *corev1.Secret) error {
+	path, ok := secret.Annotations[constant.SecretStorePathAnnotationKey]
+	if !ok {
+		return nil
+	}
+	provider, err := Default()
+	if err != nil || provider == nil {
+		return err
+	}
+	return provider.Delete(ctx, path)
+}
@@ -0,0 +1,201 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package secretstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	VaultProvider = "vault"
+
+	defaultVaultMountPath     = "secret"
+	defaultVaultAuthMountPath = "kubernetes"
+	vaultRequestTimeout       = 10 * time.Second
+)
+
+// serviceAccountTokenFile is the file of the service account token used to log in with the Kubernetes auth method.
+var serviceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+func init() {
+	RegisterProvider(VaultProvider, newVaultProvider)
+}
+
+// vaultProvider accesses the secrets through the HTTP API of the KV secrets engine (version 2) of Vault,
+// or any secret store compatible with it.
+type vaultProvider struct {
+	address       string
+	mountPath     string
+	authRole      string
+	authMountPath string
+	client        *http.Client
+
+	mu    sync.Mutex
+	token string
+}
+
+type vaultKVData struct {
+	Data map[string]string `json:"data"`
+}
+
+type vaultKVResponse struct {
+	Data vaultKVData `json:"data"`
+}
+
+type vaultLoginRequest struct {
+	Role string `json:"role"`
+	JWT  string `json:"jwt"`
+}
+
+type vaultLoginResponse struct {
+	Auth struct {
+		ClientToken string `json:"client_token"`
+	} `json:"auth"`
+}
+
+func newVaultProvider(config Config) (Provider, error) {
+	if len(config.Address) == 0 {
+		return nil, fmt.Errorf("the address of the vault secret store is required")
+	}
+	mountPath := strings.Trim(config.MountPath, "/")
+	if len(mountPath) == 0 {
+		mountPath = defaultVaultMountPath
+	}
+	if len(config.Token) == 0 && len(config.AuthRole) == 0 {
+		return nil, fmt.Errorf("either the token or the auth role of the vault secret store is required")
+	}
+	authMountPath := strings.Trim(config.AuthMountPath, "/")
+	if len(authMountPath) == 0 {
+		authMountPath = defaultVaultAuthMountPath
+	}
+	return &vaultProvider{
+		address:       strings.TrimSuffix(config.Address, "/"),
+		token:         config.Token,
+		mountPath:     mountPath,
+		authRole:      config.AuthRole,
+		authMountPath: authMountPath,
+		client:        &http.Client{Timeout: vaultRequestTimeout},
+	}, nil
+}
+
+func (p *vaultProvider) Get(ctx context.Context, path string) (map[string]string, error) {
+	resp := &vaultKVResponse{}
+	if err := p.request(ctx, http.MethodGet, p.url("data", path), nil, resp); err != nil {
+		return nil, err
+	}
+	return resp.Data.Data, nil
+}
+
+func (p *vaultProvider) Put(ctx context.Context, path string, data map[string]string) error {
+	return p.request(ctx, http.MethodPost, p.url("data", path), &vaultKVData{Data: data}, nil)
+}
+
+func (p *vaultProvider) Delete(ctx context.Context, path string) error {
+	// delete the metadata to remove all the versions of the secret
+	err := p.request(ctx, http.MethodDelete, p.url("metadata", path), nil, nil)
+	if IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (p *vaultProvider) url(kind, path string) string {
+	return fmt.Sprintf("%s/v1/%s/%s/%s", p.address, p.mountPath, kind, strings.Trim(path, "/"))
+}
+
+// login logs in with the service account token through the Kubernetes auth method if the token is not provided.
+func (p *vaultProvider) login(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.token) > 0 {
+		return p.token, nil
+	}
+	jwt, err := os.ReadFile(serviceAccountTokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the service account token to log in the secret store: %w", err)
+	}
+	resp := &vaultLoginResponse{}
+	url := fmt.Sprintf("%s/v1/auth/%s/login", p.address, p.authMountPath)
+	body := &vaultLoginRequest{Role: p.authRole, JWT: strings.TrimSpace(string(jwt))}
+	if err = p.do(ctx, http.MethodPost, url, "", body, resp); err != nil {
+		return "", err
+	}
+	if len(resp.Auth.ClientToken) == 0 {
+		return "", fmt.Errorf("no token returned by the secret store after logging in with the role %s", p.authRole)
+	}
+	p.token = resp.Auth.ClientToken
+	return p.token, nil
+}
+
+func (p *vaultProvider) request(ctx context.Context, method, url string, body, out any) error {
+	token, err := p.login(ctx)
+	if err != nil {
+		return err
+	}
+	return p.do(ctx, method, url, token, body, out)
+}
+
+func (p *vaultProvider) do(ctx context.Context, method, url, token string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return err
+	}
+	if len(token) > 0 {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("secret store request %s %s failed: %s, %s", method, req.URL.Path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if out != nil {
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	testsecretstore "github.com/apecloud/kubeblocks/pkg/testutil/secretstore"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+func TestVaultProvider(t *testing.T) {
+	vault := testsecretstore.NewFakeVault()
+	defer vault.Close()
+
+	ctx := context.Background()
+	provider, err := New(Config{Provider: VaultProvider, Address: vault.URL + "/", Token: testsecretstore.FakeVaultToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = provider.Get(ctx, "ns/account"); !IsNotFound(err) {
+		t.Errorf("expected not found, got %v", err)
+	}
+
+	data := map[string]string{"username": "root", "password": "pwd"}
+	if err = provider.Put(ctx, "/ns/account", data); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(vault.Secret("ns/account"), data) {
+		t.Errorf("unexpected secret in the store: %v", vault.Secret("ns/account"))
+	}
+	got, err := provider.Get(ctx, "ns/account")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, data) {
+		t.Errorf("expected %v, got %v", data, got)
+	}
+
+	if err = provider.Delete(ctx, "ns/account"); err != nil {
+		t.Fatal(err)
+	}
+	if vault.Len() != 0 {
+		t.Errorf("the secret is not deleted: %v", vault.Secret("ns/account"))
+	}
+	if err = provider.Delete(ctx, "ns/account"); err != nil {
+		t.Errorf("deleting a non-existing secret should succeed, got %v", err)
+	}
+}
+
+func TestVaultProviderErrors(t *testing.T) {
+	vault := testsecretstore.NewFakeVault()
+	defer vault.Close()
+
+	if _, err := New(Config{Provider: VaultProvider}); err == nil {
+		t.Error("expected error for the empty address")
+	}
+	if _, err := New(Config{Provider: "unknown", Address: vault.URL}); err == nil {
+		t.Error("expected error for the unknown provider")
+	}
+
+	provider, err := New(Config{Provider: VaultProvider, Address: vault.URL, Token: "invalid-token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = provider.Get(context.Background(), "ns/account")
+	if err == nil || IsNotFound(err) || !strings.Contains(err.Error(), "403") {
+		t.Errorf("expected forbidden error, got %v", err)
+	}
+}
+
+func TestResolveSecretData(t *testing.T) {
+	vault := testsecretstore.NewFakeVault()
+	defer vault.Close()
+	vault.SetSecret("ns/account", map[string]string{"password": "pwd"})
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "account",
+		},
+		Data: map[string][]byte{"username": []byte("root")},
+	}
+
+	data, err := ResolveSecretData(context.Background(), secret)
+	if err != nil || !reflect.DeepEqual(data, secret.Data) {
+		t.Errorf("expected the data of the secret, got %v, %v", data, err)
+	}
+
+	secret.Annotations = map[string]string{constant.SecretStorePathAnnotationKey: "ns/account"}
+	if _, err = ResolveSecretData(context.Background(), secret); err == nil {
+		t.Error("expected error since the secret store is not configured")
+	}
+
+	viper.Set(constant.CfgKeySecretStoreProvider, VaultProvider)
+	viper.Set(constant.CfgKeySecretStoreAddress, vault.URL)
+	viper.Set(constant.CfgKeySecretStoreToken, testsecretstore.FakeVaultToken)
+	defer func() {
+		viper.Set(constant.CfgKeySecretStoreProvider, "")
+	}()
+	data, err = ResolveSecretData(context.Background(), secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string][]byte{"username": []byte("root"), "password": []byte("pwd")}
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("expected %v, got %v", expected, data)
+	}
+}
+
+func TestVaultProviderKubernetesAuth(t *testing.T) {
+	vault := testsecretstore.NewFakeVault()
+	defer vault.Close()
+	vault.SetSecret("ns/account", map[string]string{"password": "pwd"})
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	defer func(file string) { serviceAccountTokenFile = file }(serviceAccountTokenFile)
+	serviceAccountTokenFile = tokenFile
+
+	if _, err := New(Config{Provider: VaultProvider, Address: vault.URL}); err == nil {
+		t.Error("expected error for neither the token nor the auth role provided")
+	}
+
+	provider, err := New(Config{Provider: VaultProvider, Address: vault.URL, AuthRole: testsecretstore.FakeVaultRole})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = provider.Get(context.Background(), "ns/account"); err == nil {
+		t.Error("expected error since the service account token does not exist")
+	}
+
+	if err = os.WriteFile(tokenFile, []byte("invalid-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = provider.Get(context.Background(), "ns/account"); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("expected forbidden error for the invalid service account token, got %v", err)
+	}
+
+	if err = os.WriteFile(tokenFile, []byte(testsecretstore.FakeServiceAccountToken+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	data, err := provider.Get(context.Background(), "ns/account")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data["password"] != "pwd" {
+		t.Errorf("unexpected data: %v", data)
+	}
+}
@@ -54,6 +54,11 @@ func (f *MockParameterFactory) AddParameters(paramName, paramValue string) *Mock
 	return f
 }
 
+func (f *MockParameterFactory) SetDryRun(dryRun bool) *MockParameterFactory {
+	f.Get().Spec.DryRun = dryRun
+	return f
+}
+
 func (f *MockParameterFactory) AddCustomTemplate(tpl string, templateName, ns string) *MockParameterFactory {
 	param := &f.Get().Spec.ComponentParameters[0]
 	if param.CustomTemplates == nil {
@@ -0,0 +1,129 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package secretstore
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+const (
+	FakeVaultToken = "fake-vault-token"
+
+	// FakeVaultRole and FakeServiceAccountToken are accepted by the Kubernetes auth method of the fake Vault server.
+	FakeVaultRole           = "fake-vault-role"
+	FakeServiceAccountToken = "fake-service-account-token"
+)
+
+// FakeVault serves the HTTP API of the Vault KV secrets engine (version 2) mounted at "secret/", in memory.
+type FakeVault struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	secrets map[string]map[string]string
+}
+
+type kvData struct {
+	Data map[string]string `json:"data"`
+}
+
+// NewFakeVault starts a fake Vault server, it should be closed by the caller.
+func NewFakeVault() *FakeVault {
+	v := &FakeVault{secrets: map[string]map[string]string{}}
+	v.Server = httptest.NewServer(v)
+	return v
+}
+
+// Secret returns the data of the secret at the path, nil if the secret does not exist.
+func (v *FakeVault) Secret(path string) map[string]string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.secrets[path]
+}
+
+// SetSecret sets the data of the secret at the path.
+func (v *FakeVault) SetSecret(path string, data map[string]string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.secrets[path] = data
+}
+
+// Len returns the number of the secrets.
+func (v *FakeVault) Len() int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return len(v.secrets)
+}
+
+func (v *FakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if r.URL.Path == "/v1/auth/kubernetes/login" && r.Method == http.MethodPost {
+		req := map[string]string{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req["role"] != FakeVaultRole || req["jwt"] != FakeServiceAccountToken {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"auth": map[string]string{"client_token": FakeVaultToken}})
+		return
+	}
+	if r.Header.Get("X-Vault-Token") != FakeVaultToken {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	var path string
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/v1/secret/data/") && r.Method != http.MethodDelete:
+		path = strings.TrimPrefix(r.URL.Path, "/v1/secret/data/")
+	case strings.HasPrefix(r.URL.Path, "/v1/secret/metadata/") && r.Method == http.MethodDelete:
+		path = strings.TrimPrefix(r.URL.Path, "/v1/secret/metadata/")
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		data, ok := v.secrets[path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": kvData{Data: data}})
+	case http.MethodPost, http.MethodPut:
+		req := &kvData{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		v.secrets[path] = req.Data
+	case http.MethodDelete:
+		if _, ok := v.secrets[path]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(v.secrets, path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
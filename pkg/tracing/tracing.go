@@ -0,0 +1,179 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+	"github.com/apecloud/kubeblocks/version"
+)
+
+const (
+	tracerName  = "github.com/apecloud/kubeblocks"
+	serviceName = "kubeblocks"
+)
+
+const (
+	AttrController    = attribute.Key("kubeblocks.controller")
+	AttrNamespace     = attribute.Key("kubeblocks.object.namespace")
+	AttrName          = attribute.Key("kubeblocks.object.name")
+	AttrKind          = attribute.Key("kubeblocks.object.kind")
+	AttrGeneration    = attribute.Key("kubeblocks.object.generation")
+	AttrResVersion    = attribute.Key("kubeblocks.object.resource_version")
+	AttrStep          = attribute.Key("kubeblocks.step")
+	AttrNext          = attribute.Key("kubeblocks.step.next")
+	AttrAction        = attribute.Key("kubeblocks.change.action")
+	AttrRequeue       = attribute.Key("kubeblocks.requeue")
+	AttrRequeueAfter  = attribute.Key("kubeblocks.requeue_after")
+	AttrRequeueReason = attribute.Key("kubeblocks.requeue_reason")
+)
+
+const (
+	reconcileSpanName = "Reconcile"
+	changeEventName   = "change"
+	requeueEventName  = "requeue"
+)
+
+func Enabled() bool {
+	return viper.GetBool(constant.CfgKeyTracingEnabled)
+}
+
+// Setup installs a tracer provider exporting spans to the OTLP collector if the tracing is enabled.
+// The returned function flushes the pending spans and stops the exporter, it should be called before exiting.
+func Setup(ctx context.Context) (func(context.Context) error, error) {
+	if !Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(viper.GetString(constant.CfgKeyTracingEndpoint))}
+	if viper.GetBool(constant.CfgKeyTracingInsecure) {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	ratio := 1.0
+	if viper.IsSet(constant.CfgKeyTracingSampleRatio) {
+		ratio = viper.GetFloat64(constant.CfgKeyTracingSampleRatio)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", serviceName),
+			attribute.String("service.version", version.Version),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// StartReconcile starts the root span of a reconciliation cycle of the object requested.
+// All the spans and changes recorded with the returned context belong to this cycle.
+func StartReconcile(ctx context.Context, controller string, req ctrl.Request) (context.Context, trace.Span) {
+	return tracer().Start(ctx, controller+" "+reconcileSpanName,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			AttrController.String(controller),
+			AttrNamespace.String(req.Namespace),
+			AttrName.String(req.Name),
+		))
+}
+
+// EndReconcile ends the root span of a reconciliation cycle with its result.
+func EndReconcile(span trace.Span, res ctrl.Result, err error) {
+	span.SetAttributes(
+		AttrRequeue.Bool(res.Requeue || res.RequeueAfter > 0),
+		AttrRequeueAfter.String(res.RequeueAfter.String()),
+	)
+	End(span, err)
+}
+
+// SetObject records the object being reconciled on the span in ctx.
+func SetObject(ctx context.Context, obj client.Object) {
+	if obj == nil {
+		return
+	}
+	trace.SpanFromContext(ctx).SetAttributes(
+		AttrKind.String(generics.TypeName(obj)),
+		AttrGeneration.Int64(obj.GetGeneration()),
+		AttrResVersion.String(obj.GetResourceVersion()),
+	)
+}
+
+// StartStep starts a child span for a step of the reconciliation cycle, such as a transformer or a reconciler.
+// The span is named after the kind and the type of the step.
+func StartStep(ctx context.Context, kind string, step any) (context.Context, trace.Span) {
+	name := generics.TypeName(step)
+	return tracer().Start(ctx, kind+" "+name, trace.WithAttributes(AttrStep.String(name)))
+}
+
+// RecordChange records a change made to an object by the reconciliation cycle on the span in ctx.
+func RecordChange(ctx context.Context, action string, obj client.Object) {
+	if obj == nil {
+		return
+	}
+	trace.SpanFromContext(ctx).AddEvent(changeEventName, trace.WithAttributes(
+		AttrAction.String(action),
+		AttrKind.String(generics.TypeName(obj)),
+		AttrNamespace.String(obj.GetNamespace()),
+		AttrName.String(obj.GetName()),
+	))
+}
+
+// End ends the span and records the error if any.
+// The requeue errors are not failures, they are recorded as events to show what the cycle is waiting for.
+func End(span trace.Span, err error) {
+	defer span.End()
+	if err == nil {
+		return
+	}
+	var requeueErr intctrlutil.RequeueError
+	if errors.As(err, &requeueErr) {
+		span.AddEvent(requeueEventName, trace.WithAttributes(
+			AttrRequeueAfter.String(requeueErr.RequeueAfter().String()),
+			AttrRequeueReason.String(requeueErr.Reason()),
+		))
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,129 @@
+/*
+Copyright (C) 2022-2025 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+func setupRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return recorder
+}
+
+func attrValue(attrs []attribute.KeyValue, key attribute.Key) (attribute.Value, bool) {
+	for _, attr := range attrs {
+		if attr.Key == key {
+			return attr.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+type testTransformer struct{}
+
+func TestSetupDisabled(t *testing.T) {
+	shutdown, err := Setup(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = shutdown(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestReconcileSpans(t *testing.T) {
+	recorder := setupRecorder(t)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "test"}}
+	ctx, root := StartReconcile(context.Background(), "Cluster", req)
+	SetObject(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test", Generation: 2}})
+
+	_, step := StartStep(ctx, "Transformer", &testTransformer{})
+	End(step, intctrlutil.NewDelayedRequeueError(time.Second, "waiting for components"))
+
+	_, failed := StartStep(ctx, "Transformer", testTransformer{})
+	End(failed, errors.New("failed"))
+
+	RecordChange(ctx, "CREATE", &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-svc"}})
+	EndReconcile(root, ctrl.Result{RequeueAfter: time.Second}, nil)
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("expect 3 spans, got %d", len(spans))
+	}
+	requeued, failedSpan, rootSpan := spans[0], spans[1], spans[2]
+
+	if rootSpan.Name() != "Cluster Reconcile" {
+		t.Errorf("unexpected root span name: %s", rootSpan.Name())
+	}
+	if v, ok := attrValue(rootSpan.Attributes(), AttrKind); !ok || v.AsString() != "ConfigMap" {
+		t.Errorf("unexpected object kind: %v", v)
+	}
+	if v, ok := attrValue(rootSpan.Attributes(), AttrGeneration); !ok || v.AsInt64() != 2 {
+		t.Errorf("unexpected object generation: %v", v)
+	}
+	if v, ok := attrValue(rootSpan.Attributes(), AttrRequeue); !ok || !v.AsBool() {
+		t.Errorf("the cycle should be requeued")
+	}
+	if len(rootSpan.Events()) != 1 || rootSpan.Events()[0].Name != changeEventName {
+		t.Fatalf("expect a change event, got %v", rootSpan.Events())
+	}
+	if v, ok := attrValue(rootSpan.Events()[0].Attributes, AttrName); !ok || v.AsString() != "test-svc" {
+		t.Errorf("unexpected changed object: %v", v)
+	}
+
+	for _, span := range []sdktrace.ReadOnlySpan{requeued, failedSpan} {
+		if span.Parent().SpanID() != rootSpan.SpanContext().SpanID() {
+			t.Errorf("span %s should be a child of the root span", span.Name())
+		}
+		if span.Name() != "Transformer testTransformer" {
+			t.Errorf("unexpected step span name: %s", span.Name())
+		}
+	}
+	if requeued.Status().Code == codes.Error {
+		t.Errorf("requeue should not be recorded as an error")
+	}
+	if len(requeued.Events()) != 1 || requeued.Events()[0].Name != requeueEventName {
+		t.Errorf("expect a requeue event, got %v", requeued.Events())
+	}
+	if failedSpan.Status().Code != codes.Error {
+		t.Errorf("error should be recorded")
+	}
+}